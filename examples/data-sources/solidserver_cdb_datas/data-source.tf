@@ -0,0 +1,6 @@
+data "solidserver_cdb_datas" "myCustomDatas" {
+  custom_db        = "myFirstCustomDB"
+  filter           = {
+    value2 = "Europe"
+  }
+}
@@ -0,0 +1,3 @@
+data "solidserver_dhcp_failover" "myFirstDhcpFailoverData" {
+  name = "myFirstDhcpFailover"
+}
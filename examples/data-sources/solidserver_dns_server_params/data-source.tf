@@ -0,0 +1,3 @@
+data "solidserver_dns_server_params" "DnsServerParamsData" {
+  dnsserver = "ns.local"
+}
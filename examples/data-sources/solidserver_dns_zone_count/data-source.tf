@@ -0,0 +1,16 @@
+data "solidserver_dns_zone_count" "SmartZones" {
+  dnsserver = "smart.local"
+  type      = "master"
+}
+
+resource "solidserver_dns_zone" "myFirstZone" {
+  dnsserver = "smart.local"
+  name      = "fr.mycompany.priv"
+
+  lifecycle {
+    precondition {
+      condition     = data.solidserver_dns_zone_count.SmartZones.total < 2000
+      error_message = "DNS SMART smart.local already hosts ${data.solidserver_dns_zone_count.SmartZones.total} zones (maximum: 2000)."
+    }
+  }
+}
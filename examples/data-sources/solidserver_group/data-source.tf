@@ -0,0 +1,3 @@
+data "solidserver_group" "myFirstGroupData" {
+  name = "myFirstGroup"
+}
@@ -0,0 +1,7 @@
+data "solidserver_groups" "myNetworkGroups" {
+  name_regex = "^net"
+}
+
+output "myNetworkGroupsMembers" {
+  value = { for group in data.solidserver_groups.myNetworkGroups.groups : group.name => group.members }
+}
@@ -0,0 +1,5 @@
+data "solidserver_ip6_subnets" "myIPv6Subnets" {
+  space      = "mySpace"
+  block      = "myIPv6Block"
+  name_regex = "^prod-"
+}
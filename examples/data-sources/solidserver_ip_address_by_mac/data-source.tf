@@ -0,0 +1,4 @@
+data "solidserver_ip_address_by_mac" "myFirstIPAddressByMacData" {
+  space = "mySpace"
+  mac   = "00:11:22:33:44:55"
+}
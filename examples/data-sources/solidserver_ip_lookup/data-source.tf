@@ -0,0 +1,4 @@
+data "solidserver_ip_lookup" "myFirstIPLookup" {
+  space   = "mySpace"
+  address = "10.0.8.12"
+}
@@ -0,0 +1,5 @@
+data "solidserver_ip_subnet_next_available" "myFirstIPSubnetPreview" {
+  space       = "mySpace"
+  block       = "myBlock"
+  prefix_size = 24
+}
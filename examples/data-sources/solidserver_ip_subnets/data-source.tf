@@ -0,0 +1,14 @@
+data "solidserver_ip_subnets" "myTerminalSubnets" {
+  space    = "mySpace"
+  block    = "myBlock"
+  terminal = true
+}
+
+resource "solidserver_ip_pool" "myPools" {
+  for_each = { for subnet in data.solidserver_ip_subnets.myTerminalSubnets.subnets : subnet.cidr => subnet }
+  space    = "mySpace"
+  subnet   = each.value.name
+  name     = "pool-${each.value.name}"
+  start    = each.value.start_address
+  size     = 10
+}
@@ -0,0 +1,3 @@
+data "solidserver_managed_objects" "myWorkspaceObjects" {
+  workspace = terraform.workspace
+}
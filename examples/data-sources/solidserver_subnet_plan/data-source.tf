@@ -0,0 +1,14 @@
+data "solidserver_subnet_plan" "myMigration" {
+  document = file("${path.module}/legacy-subnets.json")
+}
+
+resource "solidserver_ip_subnet" "myMigratedSubnets" {
+  for_each         = { for subnet in data.solidserver_subnet_plan.myMigration.valid_subnets : "${subnet.space}/${subnet.cidr}" => subnet }
+  space            = each.value.space
+  block            = each.value.block
+  name             = each.value.name
+  request_ip       = split("/", each.value.cidr)[0]
+  prefix_size      = each.value.prefix_size
+  terminal         = each.value.terminal
+  class_parameters = each.value.class_parameters
+}
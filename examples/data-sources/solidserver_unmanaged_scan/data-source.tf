@@ -0,0 +1,14 @@
+data "solidserver_unmanaged_scan" "myUnmanagedObjects" {
+  space     = "mySpace"
+  dnsserver = "ns.priv"
+  types     = ["ip_subnet", "ip_pool", "dns_zone"]
+  limit     = 500
+}
+
+# Emit the import blocks adopting the unmanaged objects
+output "import_blocks" {
+  value = join("\n", [
+    for o in data.solidserver_unmanaged_scan.myUnmanagedObjects.objects :
+    "import {\n  to = ${o.resource_hint}.${o.type}_${o.id}\n  id = \"${o.import_id}\"\n}"
+  ])
+}
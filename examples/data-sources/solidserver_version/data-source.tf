@@ -0,0 +1,8 @@
+data "solidserver_version" "current" {
+}
+
+resource "solidserver_vlan_domain" "myVxlan" {
+  count = data.solidserver_version.current.features["vxlan_supported"] ? 1 : 0
+  name  = "myVxlan"
+  vxlan = true
+}
@@ -0,0 +1,5 @@
+# Requires Terraform 1.8 or later
+output "hex_to_ip" {
+  # Returns "10.0.8.12"
+  value = provider::solidserver::hex_to_ip("0a00080c")
+}
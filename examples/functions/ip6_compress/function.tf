@@ -0,0 +1,5 @@
+# Requires Terraform 1.8 or later
+output "ip6_compress" {
+  # Returns "2001:db8::1"
+  value = provider::solidserver::ip6_compress("2001:0db8:0000:0000:0000:0000:0000:0001")
+}
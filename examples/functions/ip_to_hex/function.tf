@@ -0,0 +1,5 @@
+# Requires Terraform 1.8 or later
+output "ip_to_hex" {
+  # Returns "0a00080c"
+  value = provider::solidserver::ip_to_hex("10.0.8.12")
+}
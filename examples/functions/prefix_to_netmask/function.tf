@@ -0,0 +1,5 @@
+# Requires Terraform 1.8 or later
+output "prefix_to_netmask" {
+  # Returns "255.255.255.0"
+  value = provider::solidserver::prefix_to_netmask(24)
+}
@@ -0,0 +1,5 @@
+# Requires Terraform 1.8 or later
+output "ptr_name" {
+  # Returns "12.8.0.10.in-addr.arpa"
+  value = provider::solidserver::ptr_name("10.0.8.12")
+}
@@ -0,0 +1,12 @@
+resource "solidserver_class" "myFirstSubnetClass" {
+  object_type = "ip_subnet"
+  name        = "datacenter"
+}
+
+resource "solidserver_ip_subnet" "myFirstIPSubnet" {
+  space       = "mySpace"
+  block       = "myBlock"
+  prefix_size = 24
+  name        = "mySubnet"
+  class       = solidserver_class.myFirstSubnetClass.class_name
+}
@@ -0,0 +1,8 @@
+resource "solidserver_dhcp_failover" "myFirstDhcpFailover" {
+  name               = "myFirstDhcpFailover"
+  primary            = "dhcp01.priv"
+  secondary          = "dhcp02.priv"
+  split              = 128
+  mclt               = 3600
+  max_response_delay = 60
+}
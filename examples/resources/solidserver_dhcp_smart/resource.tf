@@ -0,0 +1,17 @@
+resource "solidserver_dhcp_smart" "myFirstDhcpSMART" {
+  name = "myfirstdhcpsmart.priv"
+
+  members {
+    name = "dhcp01.priv"
+    role = "master"
+  }
+
+  members {
+    name = "dhcp02.priv"
+    role = "backup"
+  }
+
+  class_parameters = {
+    site = "paris"
+  }
+}
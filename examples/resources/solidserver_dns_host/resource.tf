@@ -0,0 +1,7 @@
+resource "solidserver_dns_host" "myFirstHost" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  name      = "myhost.mycompany.priv"
+  address   = "10.0.0.42"
+  ttl       = 3600
+}
@@ -20,4 +20,12 @@ resource "solidserver_dns_rr" "aaRecord" {
   name      = "${solidserver_ip_ptr.myFirstIPPTR.dname}"
   type      = "PTR"
   value     = "myapp.mycompany.priv"
-}
\ No newline at end of file
+}
+// Alternatively, the PTR name can be computed by the provider from the IP address
+resource "solidserver_dns_rr" "ptrRecord" {
+  dnsserver   = "ns.mycompany.priv"
+  dnsview     = "Internal"
+  ptr_address = "${solidserver_ip_address.myFirstIPAddress.address}"
+  type        = "PTR"
+  value       = "myapp.mycompany.priv"
+}
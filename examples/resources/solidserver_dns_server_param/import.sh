@@ -0,0 +1,2 @@
+# DNS server params can be imported using their DNS server name and key
+terraform import solidserver_dns_server_param.myFirstDnsServerParam ns.priv/blackhole
@@ -0,0 +1,5 @@
+resource "solidserver_dns_server_param" "myFirstDnsServerParam" {
+  dnsserver = "ns.priv"
+  key       = "blackhole"
+  value     = "10.0.0.0/8;192.168.0.0/16;"
+}
@@ -4,4 +4,22 @@ resource "solidserver_dns_zone" "myFirstZone" {
   type      = "master"
   space     = "${solidserver_ip_space.myFirstSpace.name}"
   createptr = false
-}
\ No newline at end of file
+}
+resource "solidserver_dns_zone" "myCustomerZone" {
+  dnsserver = "ns.priv"
+  name      = "customer.priv"
+  type      = "master"
+
+  default_records {
+    name  = "@"
+    type  = "TXT"
+    value = "v=spf1 -all"
+  }
+
+  default_records {
+    name  = "www"
+    type  = "CNAME"
+    value = "web.mycompany.priv"
+    ttl   = 300
+  }
+}
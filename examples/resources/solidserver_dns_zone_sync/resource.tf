@@ -0,0 +1,15 @@
+resource "solidserver_dns_rr" "records" {
+  for_each  = var.records
+  dnsserver = "ns.mycompany.priv"
+  dnszone   = "mycompany.priv"
+  name      = "${each.key}.mycompany.priv"
+  type      = "A"
+  value     = each.value
+}
+
+// Reload the zone each time its records change
+resource "solidserver_dns_zone_sync" "myZoneSync" {
+  dnsserver = "ns.mycompany.priv"
+  name      = "mycompany.priv"
+  triggers  = { for k, rr in solidserver_dns_rr.records : k => rr.value }
+}
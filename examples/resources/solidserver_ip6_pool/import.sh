@@ -0,0 +1,8 @@
+# IPv6 pools can be imported using their oid
+terraform import solidserver_ip6_pool.myFirstIP6Pool 12
+
+# Or using their space, subnet and pool names (the subnet name may contain slashes)
+terraform import solidserver_ip6_pool.myFirstIP6Pool mySpace/myFirstIP6Subnet/myFirstIP6Pool
+
+# Reserved characters within names can be URL-style escaped (ex: %2F for '/', %3A for ':', %25 for '%')
+terraform import solidserver_ip6_pool.myFirstIP6Pool "mySpace/2001:db8::%2F64 - Prod East/myFirstIP6Pool"
@@ -0,0 +1,8 @@
+# IP pools can be imported using their oid
+terraform import solidserver_ip_pool.myFirstIPPool 12
+
+# Or using their space, subnet and pool names (the subnet name may contain slashes)
+terraform import solidserver_ip_pool.myFirstIPPool mySpace/mySubnet/myFirstIPPool
+
+# Reserved characters within names can be URL-style escaped (ex: %2F for '/', %3A for ':', %25 for '%')
+terraform import solidserver_ip_pool.myFirstIPPool "mySpace/10.4.12.0%2F22 - Prod East/myFirstIPPool"
@@ -16,4 +16,11 @@ resource "solidserver_ip_subnet" "myFirstIPSubnet" {
   class_parameters = {
     vnid = "12666"
   }
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip_subnet" "mySecondIPSubnet" {
+  space            = "${solidserver_ip_space.myFirstSpace.name}"
+  block            = "${solidserver_ip_subnet.myFirstIPBlock.name}"
+  cidr             = "10.4.12.0/22"
+  name             = "mySecondIPSubnet"
+}
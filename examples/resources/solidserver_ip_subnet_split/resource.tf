@@ -0,0 +1,6 @@
+resource "solidserver_ip_subnet_split" "mySplit" {
+  space             = "mySpace"
+  parent_subnet     = "myBlock"
+  child_prefix_size = 24
+  names             = ["prod", "staging", "dev", "lab"]
+}
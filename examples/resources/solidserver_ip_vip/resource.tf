@@ -0,0 +1,12 @@
+resource "solidserver_ip_vip" "myFirstVIP" {
+  space   = "${solidserver_ip_space.myFirstSpace.name}"
+  address = "10.0.0.100"
+  name    = "myfirstvip"
+  members {
+    address = "${solidserver_ip_address.myFirstIPAddress.address}"
+  }
+  members {
+    space   = "myOtherSpace"
+    address = "10.1.0.10"
+  }
+}
@@ -0,0 +1,3 @@
+# Users can be imported using their oid, their password remaining unknown until changed
+# Set password_version on the imported user to manage it without resetting its password
+terraform import solidserver_user.myFirstUser 9
@@ -1,4 +1,11 @@
 resource "solidserver_vlan" "myFirstVxlan" {
   vlan_domain      = "${solidserver_vlan_domain.myFirstVxlanDomain.name}"
   name             = "myFirstVxlan"
-}
\ No newline at end of file
+}
+
+resource "solidserver_vlan" "myFirstServerVlan" {
+  vlan_domain      = "${solidserver_vlan_domain.myFirstVxlanDomain.name}"
+  name             = "myFirstServerVlan"
+  id_min           = 100
+  id_max           = 499
+}
@@ -1,12 +1,29 @@
 package main
 
 import (
+	"context"
+	"log"
+
 	"github.com/EfficientIP-Labs/terraform-provider-solidserver/solidserver"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
+// Source address of the provider, as documented in the installation instructions
+const providerAddress = "terraform.efficientip.com/efficientip/solidserver"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: solidserver.Provider,
-	})
+	providerServer, err := solidserver.ProviderServer(context.Background())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf5server.Serve(providerAddress, providerServer)
+
+	solidserver.FlushStats()
+	solidserver.LogHTTPStats()
+
+	if err != nil {
+		log.Fatal(err)
+	}
 }
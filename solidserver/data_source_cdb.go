@@ -86,7 +86,7 @@ func dataSourcecdbRead(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "name='"+d.Get("name").(string)+"'")
+	parameters.Add("WHERE", "name='"+whereescape(d.Get("name").(string))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/custom_db_name_list", &parameters)
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net/url"
+	"strconv"
 )
 
 func dataSourcecdbdata() *schema.Resource {
@@ -80,14 +81,40 @@ func dataSourcecdbdata() *schema.Resource {
 	}
 }
 
+// Return the values (value1 to value10) of a custom DB data entry
+func cdbdatavalues(info map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, 10)
+
+	for i := 1; i <= 10; i++ {
+		values["value"+strconv.Itoa(i)] = infostring(info, "value"+strconv.Itoa(i))
+	}
+
+	return values
+}
+
+// Return an error telling whether the custom DB or only the requested entry is missing
+func cdbdatanotfounderror(cdbName string, entry string, meta interface{}) error {
+	cdbnameID, cdbnameErr := cdbnameidbyname(cdbName, meta)
+
+	if cdbnameErr != nil {
+		return cdbnameErr
+	}
+
+	if cdbnameID == "" {
+		return fmt.Errorf("SOLIDServer - Unable to find custom DB: %s\n", cdbName)
+	}
+
+	return fmt.Errorf("SOLIDServer - Unable to find %s in custom DB: %s\n", entry, cdbName)
+}
+
 func dataSourcecdbdataRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 	d.SetId("")
 
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "name='" + d.Get("custom_db").(string) + "' and " +
-		"value1='" + d.Get("value1").(string) + "'"
+	whereClause := "name='" + whereescape(d.Get("custom_db").(string)) + "' and " +
+		"value1='" + whereescape(d.Get("value1").(string)) + "'"
 
 	parameters.Add("WHERE", whereClause)
 
@@ -100,19 +127,12 @@ func dataSourcecdbdataRead(ctx context.Context, d *schema.ResourceData, meta int
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			d.SetId(buf[0]["custom_db_name_id"].(string))
-
-			d.Set("custom_db", buf[0]["name"].(string))
-			d.Set("value1", buf[0]["value1"].(string))
-			d.Set("value2", buf[0]["value2"].(string))
-			d.Set("value3", buf[0]["value3"].(string))
-			d.Set("value4", buf[0]["value4"].(string))
-			d.Set("value5", buf[0]["value5"].(string))
-			d.Set("value6", buf[0]["value6"].(string))
-			d.Set("value7", buf[0]["value7"].(string))
-			d.Set("value8", buf[0]["value8"].(string))
-			d.Set("value9", buf[0]["value9"].(string))
-			d.Set("value10", buf[0]["value10"].(string))
+			d.SetId(infostring(buf[0], "custom_db_name_id"))
+			d.Set("custom_db", infostring(buf[0], "name"))
+
+			for k, v := range cdbdatavalues(buf[0]) {
+				d.Set(k, v)
+			}
 
 			return nil
 		}
@@ -128,7 +148,7 @@ func dataSourcecdbdataRead(ctx context.Context, d *schema.ResourceData, meta int
 		}
 
 		// Reporting a failure
-		return diag.Errorf("Unable to find custom DB: %s [%s]\n", d.Get("custom_db").(string), d.Get("value1").(string))
+		return diag.FromErr(cdbdatanotfounderror(d.Get("custom_db").(string), "key '"+d.Get("value1").(string)+"'", meta))
 	}
 
 	// Reporting a failure
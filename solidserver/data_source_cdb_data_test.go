@@ -0,0 +1,176 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Emulate the custom DB countries, holding more entries than a single page
+func mockCdbCountries(m *mockSOLIDserver) {
+	rows := []map[string]interface{}{
+		{"custom_db_name_id": "3", "name": "countries", "value1": "O'Brien", "value2": "Europe"},
+	}
+
+	// Entries are returned unsorted
+	for i := 1500; i > 0; i-- {
+		region := "Asia"
+		if i%3 == 0 {
+			region = "Europe"
+		}
+		rows = append(rows, map[string]interface{}{"custom_db_name_id": "3", "name": "countries", "value1": fmt.Sprintf("C%04d", i), "value2": region})
+	}
+
+	m.handle("/rest/custom_db_data_list", func(w http.ResponseWriter, r *http.Request) {
+		where := r.URL.Query().Get("WHERE")
+		if !strings.HasPrefix(where, "name='countries'") {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		matched := []map[string]interface{}{}
+		for _, row := range rows {
+			if where == "name='countries'" ||
+				where == "name='countries' and value1='"+strings.ReplaceAll(row["value1"].(string), "'", "''")+"'" ||
+				where == "name='countries' and value2='"+row["value2"].(string)+"'" {
+				matched = append(matched, row)
+			}
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			limit = len(matched)
+		}
+
+		page := []map[string]interface{}{}
+		for i := offset; i < len(matched) && i < offset+limit; i++ {
+			page = append(page, matched[i])
+		}
+		mockReply(w, http.StatusOK, page)
+	})
+
+	m.handle("/rest/custom_db_name_list", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("WHERE") {
+		case "name='countries'":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"custom_db_name_id": "3", "name": "countries"}})
+		case "name='empty'":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"custom_db_name_id": "4", "name": "empty"}})
+		default:
+			mockReply(w, http.StatusNoContent, nil)
+		}
+	})
+}
+
+func TestCdbDataDataSource(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mockCdbCountries(m)
+
+	d := schema.TestResourceDataRaw(t, dataSourcecdbdata().Schema, map[string]interface{}{
+		"custom_db": "countries",
+		"value1":    "O'Brien",
+	})
+
+	if diags := dataSourcecdbdataRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "3" || d.Get("value2").(string) != "Europe" || d.Get("value3").(string) != "" {
+		t.Errorf("unexpected custom DB data: %s (%v)", d.Id(), d.State())
+	}
+
+	// A missing entry and a missing custom DB are reported differently
+	d = schema.TestResourceDataRaw(t, dataSourcecdbdata().Schema, map[string]interface{}{
+		"custom_db": "countries",
+		"value1":    "ZZ",
+	})
+
+	if diags := dataSourcecdbdataRead(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "Unable to find key 'ZZ' in custom DB: countries") {
+		t.Errorf("expected a missing key error, got %v", diags)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourcecdbdata().Schema, map[string]interface{}{
+		"custom_db": "cities",
+		"value1":    "FR",
+	})
+
+	if diags := dataSourcecdbdataRead(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "Unable to find custom DB: cities") {
+		t.Errorf("expected a missing custom DB error, got %v", diags)
+	}
+}
+
+func TestCdbDatasDataSource(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mockCdbCountries(m)
+
+	d := schema.TestResourceDataRaw(t, dataSourcecdbdatas().Schema, map[string]interface{}{
+		"custom_db": "countries",
+	})
+
+	if diags := dataSourcecdbdatasRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("rows.#").(int) != 1501 || d.Get("rows.0.value1").(string) != "C0001" || d.Get("rows.1500.value1").(string) != "O'Brien" {
+		t.Errorf("unexpected custom DB entries: %d (%s ... %s)", d.Get("rows.#").(int), d.Get("rows.0.value1").(string), d.Get("rows.1500.value1").(string))
+	}
+
+	if m.count("/rest/custom_db_data_list") != 2 {
+		t.Errorf("expected the entries to be paginated, got %d request(s)", m.count("/rest/custom_db_data_list"))
+	}
+
+	// Filtering on a value
+	d = schema.TestResourceDataRaw(t, dataSourcecdbdatas().Schema, map[string]interface{}{
+		"custom_db": "countries",
+		"filter":    map[string]interface{}{"value2": "Europe"},
+	})
+
+	if diags := dataSourcecdbdatasRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("rows.#").(int) != 501 || d.Get("rows.0.value1").(string) != "C0003" {
+		t.Errorf("unexpected filtered custom DB entries: %d (%s)", d.Get("rows.#").(int), d.Get("rows.0.value1").(string))
+	}
+
+	// An empty custom DB returns no entry, a missing one fails
+	d = schema.TestResourceDataRaw(t, dataSourcecdbdatas().Schema, map[string]interface{}{
+		"custom_db": "empty",
+	})
+
+	if diags := dataSourcecdbdatasRead(context.Background(), d, s); diags.HasError() || d.Get("rows.#").(int) != 0 || d.Id() != "empty" {
+		t.Errorf("unexpected empty custom DB result: %v (%d)", diags, d.Get("rows.#").(int))
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourcecdbdatas().Schema, map[string]interface{}{
+		"custom_db": "cities",
+	})
+
+	if diags := dataSourcecdbdatasRead(context.Background(), d, s); !diags.HasError() {
+		t.Errorf("expected a missing custom DB error")
+	}
+}
+
+func TestCdbDatasFilterKeys(t *testing.T) {
+	r := dataSourcecdbdatas()
+
+	if diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"custom_db": "countries",
+		"filter":    map[string]interface{}{"value10": "x", "value2": "y"},
+	})); diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+
+	if diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"custom_db": "countries",
+		"filter":    map[string]interface{}{"name": "x"},
+	})); !diags.HasError() {
+		t.Errorf("expected filter key name to be refused")
+	}
+}
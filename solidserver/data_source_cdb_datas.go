@@ -0,0 +1,116 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+func dataSourcecdbdatas() *schema.Resource {
+	rowSchema := map[string]*schema.Schema{}
+
+	for i := 1; i <= 10; i++ {
+		rowSchema["value"+strconv.Itoa(i)] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "The name of the value " + strconv.Itoa(i),
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourcecdbdatasRead,
+
+		Description: heredoc.Doc(`
+			Custom DB Datas data-source allows to retrieve all the entries of a custom database stored within SOLIDserver.
+			Entries can be filtered on their values, making it possible to drive lookups within modules.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"custom_db": {
+				Type:        schema.TypeString,
+				Description: "The name of the custom DB.",
+				Required:    true,
+			},
+			"filter": {
+				Type:             schema.TypeMap,
+				Description:      "The values the entries must match, indexed by their field (Supported: value1 to value10).",
+				Optional:         true,
+				ValidateDiagFunc: validation.MapKeyMatch(regexp.MustCompile("^value([1-9]|10)$"), "Unsupported custom DB field, expected value1 to value10"),
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Description: "The entries of the custom DB, sorted by value1.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: rowSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcecdbdatasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cdbName := d.Get("custom_db").(string)
+	filter := d.Get("filter").(map[string]interface{})
+
+	// Building the WHERE clause, fields are sorted to keep it stable
+	whereClause := "name='" + whereescape(cdbName) + "'"
+	keys := make([]string, 0, len(filter))
+
+	for k := range filter {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		whereClause += " and " + k + "='" + whereescape(filter[k].(string)) + "'"
+	}
+
+	entries, err := listall("rest/custom_db_data_list", whereClause, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if len(entries) == 0 {
+		// An empty custom DB is not an error, a missing one is
+		cdbnameID, cdbnameErr := cdbnameidbyname(cdbName, meta)
+
+		if cdbnameErr != nil {
+			return diag.FromErr(cdbnameErr)
+		}
+
+		if cdbnameID == "" {
+			return diag.Errorf("SOLIDServer - Unable to find custom DB: %s\n", cdbName)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return infostring(entries[i], "value1") < infostring(entries[j], "value1")
+	})
+
+	rows := make([]interface{}, 0, len(entries))
+
+	for _, entry := range entries {
+		rows = append(rows, cdbdatavalues(entry))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d entry(ies) in custom DB: %s\n", len(rows), cdbName))
+
+	d.SetId(cdbName)
+	d.Set("rows", rows)
+
+	return nil
+}
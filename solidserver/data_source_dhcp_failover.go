@@ -0,0 +1,105 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+)
+
+func dataSourcedhcpfailover() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcedhcpfailoverRead,
+
+		Description: heredoc.Doc(`
+			DHCP failover data-source allows to retrieve information about the failover channel between a primary and a secondary DHCP server.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the DHCP failover channel.",
+				Required:    true,
+			},
+			"primary": {
+				Type:        schema.TypeString,
+				Description: "The name of the primary DHCP server of the failover channel.",
+				Computed:    true,
+			},
+			"secondary": {
+				Type:        schema.TypeString,
+				Description: "The name of the secondary DHCP server of the failover channel.",
+				Computed:    true,
+			},
+			"split": {
+				Type:        schema.TypeInt,
+				Description: "The load balancing split between the primary (256) and the secondary (0) DHCP servers.",
+				Computed:    true,
+			},
+			"mclt": {
+				Type:        schema.TypeInt,
+				Description: "The Maximum Client Lead Time of the failover channel in seconds.",
+				Computed:    true,
+			},
+			"max_response_delay": {
+				Type:        schema.TypeInt,
+				Description: "The number of seconds without message from the peer before considering it down.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcedhcpfailoverRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	d.SetId("")
+
+	if s.Version < 800 {
+		// Reporting a failure
+		return diag.Errorf("Object not supported in this SOLIDserver version")
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "dhcpfailover_name='"+whereescape(d.Get("name").(string))+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dhcp_failover_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			d.SetId(buf[0]["dhcpfailover_id"].(string))
+			dhcpfailoverset(d, buf[0])
+			return nil
+		}
+
+		// The service is not available on this appliance
+		if resp.StatusCode == 404 {
+			return diag.Errorf("Unable to find DHCP failover channel: %s, DHCP failover channels are not supported by this SOLIDserver\n", d.Get("name").(string))
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to read information from DHCP failover channel: %s (%s)\n", d.Get("name").(string), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to read information from DHCP failover channel: %s\n", d.Get("name").(string)))
+		}
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find DHCP failover channel: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
@@ -111,7 +111,7 @@ func dataSourcednsserverRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "dns_name='"+d.Get("name").(string)+"' AND dns_type!='vdns'")
+	parameters.Add("WHERE", "dns_name='"+whereescape(d.Get("name").(string))+"' AND dns_type!='vdns'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/dns_server_list", &parameters)
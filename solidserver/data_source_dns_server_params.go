@@ -0,0 +1,54 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcednsserverparams() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcednsserverparamsRead,
+
+		Description: heredoc.Doc(`
+			DNS server params data-source allows to retrieve all the global options set on a DNS server.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The name of the DNS server.",
+				Required:    true,
+			},
+			"params": {
+				Type:        schema.TypeMap,
+				Description: "The params set on the DNS server, indexed by their key.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcednsserverparamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serverName := d.Get("dnsserver").(string)
+
+	params, err := dnsserverparamlist(serverName, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d param(s) set on DNS server: %s\n", len(params), serverName))
+
+	d.SetId(serverName)
+	d.Set("params", params)
+
+	return nil
+}
@@ -119,7 +119,7 @@ func dataSourcednsviewRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "dns_name='"+d.Get("dnsserver").(string)+"' AND dnsview_name='"+d.Get("name").(string)+"'")
+	parameters.Add("WHERE", "dns_name='"+whereescape(d.Get("dnsserver").(string))+"' AND dnsview_name='"+whereescape(d.Get("name").(string))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/dns_view_list", &parameters)
@@ -146,7 +146,7 @@ func dataSourcednsviewRead(ctx context.Context, d *schema.ResourceData, meta int
 			}
 
 			// Updating forward mode
-			forward, forwardErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forward", meta)
+			forward, _, forwardErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forward", meta)
 			if forwardErr == nil {
 				if forward == "" {
 					d.Set("forward", "none")
@@ -159,7 +159,7 @@ func dataSourcednsviewRead(ctx context.Context, d *schema.ResourceData, meta int
 			}
 
 			// Updating forwarder information
-			forwarders, forwardersErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forwarders", meta)
+			forwarders, _, forwardersErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forwarders", meta)
 			if forwardersErr == nil {
 				if forwarders != "" {
 					d.Set("forwarders", toStringArrayInterface(strings.Split(strings.TrimSuffix(forwarders, ";"), ";")))
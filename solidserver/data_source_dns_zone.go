@@ -37,6 +37,11 @@ func dataSourcednszone() *schema.Resource {
 				Description: "The Domain Name served by the DNS zone.",
 				Required:    true,
 			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Description: "The Domain Name served by the DNS zone, lower-cased and without trailing dot (ex: example.com).",
+				Computed:    true,
+			},
 			"space": {
 				Type:        schema.TypeString,
 				Description: "The name of a space associated to the DNS zone.",
@@ -52,6 +57,43 @@ func dataSourcednszone() *schema.Resource {
 				Description: "Automaticaly create PTR records for the DNS zone.",
 				Computed:    true,
 			},
+			"rr_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of RRs within the DNS zone.",
+				Computed:    true,
+			},
+			"populate_statistics": {
+				Type:        schema.TypeBool,
+				Description: "Compute record_counts and delegations, listing all the RRs of the DNS zone on each read (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+			"record_counts": {
+				Type:        schema.TypeMap,
+				Description: "The number of RRs within the DNS zone by type (only computed with populate_statistics).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"delegations": {
+				Type:        schema.TypeList,
+				Description: "The names of the child zones delegated by the DNS zone, owning NS RRs not at the apex (only computed with populate_statistics).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"zone_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of DNS zones matching the data-source (always 1).",
+				Computed:    true,
+			},
+			"last_modified": {
+				Type:        schema.TypeString,
+				Description: "The date of the last modification of the DNS zone (RFC3339), if provided by SOLIDserver.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the DNS zone.",
@@ -62,21 +104,21 @@ func dataSourcednszone() *schema.Resource {
 				Description: "The class parameters associated to IP space.",
 				Computed:    true,
 			},
+			"wait_for_existence": waitforexistenceschema("DNS zone"),
 		},
 	}
 }
 
 func dataSourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s := meta.(*SOLIDserver)
 	d.SetId("")
 
 	// Building parameters
 	parameters := url.Values{}
 
-	whereClause := "dnszone_name='" + d.Get("name").(string) + "'"
+	whereClause := "dnszone_name='" + whereescape(d.Get("name").(string)) + "'"
 
 	if view, ok := d.Get("view").(string); ok && view != "" {
-		whereClause += " AND dnsview_name='" + view + "'"
+		whereClause += " AND dnsview_name='" + whereescape(view) + "'"
 	}
 
 	parameters.Add("WHERE", whereClause)
@@ -85,7 +127,7 @@ func dataSourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("type", d.Get("type").(string))
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/dns_zone_list", &parameters)
+	resp, body, err := waitforexistence(ctx, d, "rest/dns_zone_list", &parameters, meta)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -98,8 +140,43 @@ func dataSourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta int
 			d.Set("dnsserver", buf[0]["dns_name"].(string))
 			d.Set("view", buf[0]["dnsview_name"].(string))
 			d.Set("name", buf[0]["dnszone_name"].(string))
+			d.Set("fqdn", dnsnamecanonical(buf[0]["dnszone_name"].(string)))
 			d.Set("type", buf[0]["dnszone_type"].(string))
 
+			d.Set("zone_count", 1)
+
+			// Counting the RRs of the zone
+			rrCount, rrCountErr := countall("rest/dns_rr_count", "dnszone_id='"+buf[0]["dnszone_id"].(string)+"'", meta)
+
+			if rrCountErr != nil {
+				// Reporting a failure
+				return diag.Errorf("Unable to count RRs of DNS Zone: %s (%s)\n", d.Get("name").(string), rrCountErr)
+			}
+
+			d.Set("rr_count", rrCount)
+
+			// Aggregating the RRs of the zone by type
+			if d.Get("populate_statistics").(bool) {
+				recordCounts, delegations, statisticsErr := dnszonestatistics(buf[0]["dnszone_id"].(string), buf[0]["dnszone_name"].(string), meta)
+
+				if statisticsErr != nil {
+					// Reporting a failure
+					return diag.Errorf("Unable to compute the statistics of DNS Zone: %s (%s)\n", d.Get("name").(string), statisticsErr)
+				}
+
+				d.Set("record_counts", recordCounts)
+				d.Set("delegations", delegations)
+			} else {
+				d.Set("record_counts", map[string]interface{}{})
+				d.Set("delegations", []interface{}{})
+			}
+
+			if lastModified, lastModifiedExist := buf[0]["dnszone_last_modified"].(string); lastModifiedExist {
+				d.Set("last_modified", timestamptorfc3339(lastModified))
+			} else {
+				d.Set("last_modified", "")
+			}
+
 			d.Set("class", buf[0]["dnszone_class_name"].(string))
 
 			// Setting local class_parameters
@@ -0,0 +1,105 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+func dataSourcednszonecount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcednszonecountRead,
+
+		Description: heredoc.Doc(`
+			DNS Zone Count data-source allows to count the DNS zones hosted by a DNS server or DNS SMART,
+			ex: to enforce a maximum number of zones per server within a precondition.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The name of DNS server or DNS SMART hosting the DNS zones to count.",
+				Required:    true,
+			},
+			"dnsview": {
+				Type:        schema.TypeString,
+				Description: "The name of DNS view hosting the DNS zones to count (Default: all views).",
+				Optional:    true,
+				Default:     "",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The type of the DNS zones to count (ex: master, slave, forward; Default: all types).",
+				Optional:    true,
+				Default:     "",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Description: "The number of DNS zones matching the data-source, 0 when the DNS server doesn't exist.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcednszonecountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	serverName := d.Get("dnsserver").(string)
+
+	d.SetId(strings.ToLower(serverName) + "/" + d.Get("dnsview").(string) + "/" + d.Get("type").(string))
+
+	// Ensure the DNS server exists, an unknown server holds no zone
+	servers, serversErr := listall("rest/dns_server_list", "", meta)
+
+	if serversErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to count DNS zones of DNS server: %s (%s)\n", serverName, serversErr)
+	}
+
+	serverNames := []string{}
+	serverFound := false
+
+	for _, server := range servers {
+		if name, nameExist := server["dns_name"].(string); nameExist {
+			serverNames = append(serverNames, name)
+			serverFound = serverFound || strings.EqualFold(name, serverName)
+		}
+	}
+
+	if !serverFound {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to find DNS server: %s, no DNS zone counted\n", serverName))
+		d.Set("total", 0)
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("No DNS zone counted on DNS server %s", serverName),
+			Detail:   notfounderror("DNS server", serverName, s.Host, serverNames).Error(),
+		}}
+	}
+
+	// Counting the DNS zones
+	whereClause := "dns_name='" + whereescape(serverName) + "'"
+
+	if view := d.Get("dnsview").(string); view != "" {
+		whereClause += " AND dnsview_name='" + whereescape(view) + "'"
+	}
+
+	if zoneType := d.Get("type").(string); zoneType != "" {
+		whereClause += " AND dnszone_type='" + whereescape(zoneType) + "'"
+	}
+
+	total, totalErr := countall("rest/dns_zone_count", whereClause, meta)
+
+	if totalErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to count DNS zones of DNS server: %s (%s)\n", serverName, totalErr)
+	}
+
+	d.Set("total", total)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceDNSZoneCount(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	zoneWhere := ""
+
+	m.handle("/rest/dns_server_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"dns_name": "smart01.example.com"}, {"dns_name": "smart02.example.com"}})
+	})
+
+	m.handle("/rest/dns_zone_count", func(w http.ResponseWriter, r *http.Request) {
+		zoneWhere = r.URL.Query().Get("WHERE")
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "1999"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourcednszonecount().Schema, map[string]interface{}{
+		"dnsserver": "SMART01.example.com",
+		"dnsview":   "int'ernal",
+		"type":      "master",
+	})
+
+	if diags := dataSourcednszonecountRead(context.Background(), d, s); len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if zoneWhere != "dns_name='SMART01.example.com' AND dnsview_name='int''ernal' AND dnszone_type='master'" {
+		t.Errorf("unexpected zone count filter: %s", zoneWhere)
+	}
+
+	if d.Get("total").(int) != 1999 {
+		t.Errorf("unexpected total: %d", d.Get("total").(int))
+	}
+
+	// Unknown servers hold no zone
+	d = schema.TestResourceDataRaw(t, dataSourcednszonecount().Schema, map[string]interface{}{
+		"dnsserver": "smart03.example.com",
+	})
+
+	diags := dataSourcednszonecountRead(context.Background(), d, s)
+
+	if diags.HasError() || len(diags) != 1 || !strings.Contains(diags[0].Detail, "not found") {
+		t.Fatalf("expected a warning, got: %v", diags)
+	}
+
+	if d.Get("total").(int) != 0 || m.count("/rest/dns_zone_count") != 1 {
+		t.Errorf("unexpected total: %d (%d counts)", d.Get("total").(int), m.count("/rest/dns_zone_count"))
+	}
+}
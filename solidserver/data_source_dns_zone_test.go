@@ -0,0 +1,101 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceDNSZoneCounts(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	rrWhere := ""
+
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"dnszone_id":               "7",
+			"dns_name":                 "ns.example.com",
+			"dnsview_name":             "#",
+			"dnszone_name":             "example.com",
+			"dnszone_type":             "master",
+			"dnszone_class_name":       "",
+			"dnszone_class_parameters": "",
+			"dnszone_last_modified":    "1700000000",
+		}})
+	})
+
+	m.handle("/rest/dns_rr_count", func(w http.ResponseWriter, r *http.Request) {
+		rrWhere = r.URL.Query().Get("WHERE")
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "42"}})
+	})
+
+	// 1500 A RRs followed by the apex and delegation NS RRs, listed over two pages
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		rrs := []map[string]interface{}{}
+
+		for i := 0; i < 1500; i++ {
+			rrs = append(rrs, map[string]interface{}{"rr_full_name": "host" + strconv.Itoa(i) + ".example.com", "rr_type": "A"})
+		}
+
+		rrs = append(rrs,
+			map[string]interface{}{"rr_full_name": "example.com", "rr_type": "NS"},
+			map[string]interface{}{"rr_full_name": "lab.example.com", "rr_type": "NS"},
+			map[string]interface{}{"rr_full_name": "lab.example.com", "rr_type": "NS"},
+			map[string]interface{}{"rr_full_name": "Dev.example.com", "rr_type": "NS"},
+		)
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + listPageSize
+
+		if end > len(rrs) {
+			end = len(rrs)
+		}
+
+		mockReply(w, http.StatusOK, rrs[offset:end])
+	})
+
+	// The RRs are only listed when the statistics are requested
+	d := schema.TestResourceDataRaw(t, dataSourcednszone().Schema, map[string]interface{}{
+		"name": "example.com",
+	})
+
+	if diags := dataSourcednszoneRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if counts := d.Get("record_counts").(map[string]interface{}); len(counts) != 0 || m.count("/rest/dns_rr_list") != 0 {
+		t.Errorf("unexpected record counts without statistics: %v", counts)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourcednszone().Schema, map[string]interface{}{
+		"name":                "example.com",
+		"populate_statistics": true,
+	})
+
+	if diags := dataSourcednszoneRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if rrWhere != "dnszone_id='7'" {
+		t.Errorf("unexpected RR count filter: %s", rrWhere)
+	}
+
+	if d.Get("rr_count").(int) != 42 || d.Get("zone_count").(int) != 1 {
+		t.Errorf("unexpected counts: rr_count=%d zone_count=%d", d.Get("rr_count").(int), d.Get("zone_count").(int))
+	}
+
+	if counts := d.Get("record_counts").(map[string]interface{}); len(counts) != 2 || counts["A"].(int) != 1500 || counts["NS"].(int) != 4 || m.count("/rest/dns_rr_list") != 2 {
+		t.Errorf("unexpected record counts: %v", counts)
+	}
+
+	if delegations := toStringArray(d.Get("delegations").([]interface{})); strings.Join(delegations, ",") != "dev.example.com,lab.example.com" {
+		t.Errorf("unexpected delegations: %v", delegations)
+	}
+
+	if d.Get("last_modified").(string) != "2023-11-14T22:13:20Z" {
+		t.Errorf("unexpected last_modified: %s", d.Get("last_modified").(string))
+	}
+}
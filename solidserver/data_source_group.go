@@ -0,0 +1,164 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+)
+
+func dataSourcegroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcegroupRead,
+
+		Description: heredoc.Doc(`
+			Group data-source allows to retrieve the properties of a group of users, including meta-data.
+			It allows to verify that the groups referenced by users exist before applying any change.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the group.",
+				Required:    true,
+			},
+			"grp_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the group.",
+				Computed:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the group.",
+				Computed:    true,
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the group.",
+				Computed:    true,
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Description: "The logins of the members of the group, sorted.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"permissions": dataSourcegrouppermissionsschema(),
+		},
+	}
+}
+
+// Return the schema of the resources a group is granted permissions on
+func dataSourcegrouppermissionsschema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The resources the group is granted permissions on, sorted by type then name (empty before SOLIDserver 8.0).",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:        schema.TypeString,
+					Description: "The type of the resource (ex: ip_site).",
+					Computed:    true,
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "The name of the resource.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// Return the properties of a group as computed by the group data-sources, including its members and permissions
+// Or an error in case of failure
+func dataSourcegroupflatten(group map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	groupID := infostring(group, "grp_id")
+
+	members, err := groupmembers(groupID, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := grouppermissions(groupID, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Older SOLIDserver versions don't return the description nor the class parameters
+	retrievedClassParameters, _ := url.ParseQuery(infostring(group, "grp_class_parameters"))
+	computedClassParameters := map[string]interface{}{}
+
+	for ck := range retrievedClassParameters {
+		computedClassParameters[ck] = retrievedClassParameters[ck][0]
+	}
+
+	return map[string]interface{}{
+		"grp_id":           groupID,
+		"name":             infostring(group, "grp_name"),
+		"description":      infostring(group, "grp_description"),
+		"class_parameters": computedClassParameters,
+		"members":          members,
+		"permissions":      permissions,
+	}, nil
+}
+
+func dataSourcegroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	d.SetId("")
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "grp_name='"+whereescape(d.Get("name").(string))+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/group_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			group, groupErr := dataSourcegroupflatten(buf[0], meta)
+
+			if groupErr != nil {
+				// Reporting a failure
+				return diag.Errorf("Unable to read information from group: %s (%s)", d.Get("name").(string), groupErr)
+			}
+
+			d.SetId(group["grp_id"].(string))
+
+			for field, value := range group {
+				d.Set(field, value)
+			}
+
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to read information from group: %s (%s)\n", d.Get("name").(string), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to read information from group: %s\n", d.Get("name").(string)))
+		}
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find group: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
@@ -0,0 +1,137 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceGroup(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/group_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "grp_name='netops'" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"grp_id":               "5",
+			"grp_name":             "netops",
+			"grp_description":      "Network operations",
+			"grp_class_parameters": "team=network&oncall=1",
+		}})
+	})
+
+	m.handle("/rest/group_user_list", mockWhereList([]map[string]interface{}{
+		{"grp_id": "5", "usr_id": "3", "usr_login": "jdoe"},
+		{"grp_id": "5", "usr_id": "2", "usr_login": "admin2"},
+		{"grp_id": "6", "usr_id": "4", "usr_login": "guest"},
+	}))
+
+	m.handle("/rest/group_resource_list", mockWhereList([]map[string]interface{}{
+		{"grp_id": "5", "resource_type": "ip_site", "resource_name": "prod"},
+		{"grp_id": "5", "resource_type": "dns_server", "resource_name": "ns01"},
+	}))
+
+	d := schema.TestResourceDataRaw(t, dataSourcegroup().Schema, map[string]interface{}{
+		"name": "netops",
+	})
+
+	if diags := dataSourcegroupRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "5" || d.Get("grp_id").(string) != "5" || d.Get("description").(string) != "Network operations" || d.Get("class_parameters").(map[string]interface{})["team"] != "network" {
+		t.Errorf("unexpected group: %s %v", d.Id(), d.State().Attributes)
+	}
+
+	if members := d.Get("members").([]interface{}); !reflect.DeepEqual(members, []interface{}{"admin2", "jdoe"}) {
+		t.Errorf("unexpected members: %v", members)
+	}
+
+	if permissions := d.Get("permissions").([]interface{}); len(permissions) != 2 || permissions[0].(map[string]interface{})["type"] != "dns_server" {
+		t.Errorf("unexpected permissions: %v", permissions)
+	}
+
+	// Referencing a missing group fails before any change is applied
+	d = schema.TestResourceDataRaw(t, dataSourcegroup().Schema, map[string]interface{}{
+		"name": "missing",
+	})
+
+	if diags := dataSourcegroupRead(context.Background(), d, s); !diags.HasError() {
+		t.Errorf("expected an error for a missing group")
+	}
+}
+
+func TestDataSourceGroups(t *testing.T) {
+	// The groups returned by SOLIDserver 7.x have neither description nor class parameters
+	payloads := map[int][]map[string]interface{}{
+		730: {
+			{"grp_id": "6", "grp_name": "netread"},
+			{"grp_id": "5", "grp_name": "netops"},
+			{"grp_id": "7", "grp_name": "dnsops"},
+		},
+		830: {
+			{"grp_id": "6", "grp_name": "netread", "grp_description": "Read only", "grp_class_parameters": ""},
+			{"grp_id": "5", "grp_name": "netops", "grp_description": "Network operations", "grp_class_parameters": "team=network"},
+			{"grp_id": "7", "grp_name": "dnsops", "grp_description": "DNS operations", "grp_class_parameters": ""},
+		},
+	}
+
+	for version, groups := range payloads {
+		m, s := newMockSOLIDserver(t)
+		s.Version = version
+
+		m.handle("/rest/group_list", mockWhereList(groups))
+		m.handle("/rest/group_user_list", mockWhereList([]map[string]interface{}{
+			{"grp_id": "5", "usr_login": "jdoe"},
+			{"grp_id": "7", "usr_login": "dnsadmin"},
+		}))
+		m.handle("/rest/group_resource_list", mockWhereList([]map[string]interface{}{
+			{"grp_id": "5", "resource_type": "ip_site", "resource_name": "prod"},
+		}))
+
+		d := schema.TestResourceDataRaw(t, dataSourcegroups().Schema, map[string]interface{}{
+			"name_regex": "^net",
+		})
+
+		if diags := dataSourcegroupsRead(context.Background(), d, s); diags.HasError() {
+			t.Fatalf("unexpected error on version %d: %v", version, diags)
+		}
+
+		result := d.Get("groups").([]interface{})
+
+		if len(result) != 2 {
+			t.Fatalf("expected 2 groups on version %d, got: %v", version, result)
+		}
+
+		netops, netread := result[0].(map[string]interface{}), result[1].(map[string]interface{})
+
+		if netops["name"] != "netops" || netread["name"] != "netread" || len(netread["members"].([]interface{})) != 0 {
+			t.Errorf("unexpected groups on version %d: %v", version, result)
+		}
+
+		if !reflect.DeepEqual(netops["members"], []interface{}{"jdoe"}) {
+			t.Errorf("unexpected members on version %d: %v", version, netops["members"])
+		}
+
+		// Permissions are not exposed by SOLIDserver 7.x
+		permissions := netops["permissions"].([]interface{})
+
+		if version < versionGroupResources && (len(permissions) != 0 || m.count("/rest/group_resource_list") != 0) {
+			t.Errorf("unexpected permissions on version %d: %v", version, permissions)
+		}
+
+		if version >= versionGroupResources && (len(permissions) != 1 || permissions[0].(map[string]interface{})["name"] != "prod" || netops["description"] != "Network operations") {
+			t.Errorf("unexpected permissions on version %d: %v (%v)", version, permissions, netops)
+		}
+
+		if version < versionGroupResources && netops["description"] != "" {
+			t.Errorf("unexpected description on version %d: %v", version, netops["description"])
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"sort"
+)
+
+func dataSourcegroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcegroupsRead,
+
+		Description: heredoc.Doc(`
+			Groups data-source allows to retrieve the groups of users along with their members and permissions,
+			ex: to review the delegation of the administration of SOLIDserver.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "The regular expression the name of the groups must match.",
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Description: "The groups, sorted by name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"grp_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the group.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the group.",
+							Computed:    true,
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "The description of the group.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the group.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"members": {
+							Type:        schema.TypeList,
+							Description: "The logins of the members of the group, sorted.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"permissions": dataSourcegrouppermissionsschema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcegroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	nameRegex := regexp.MustCompile(d.Get("name_regex").(string))
+
+	groups, err := listall("rest/group_list", "", meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to list groups (%s)\n", err)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return infostring(groups[i], "grp_name") < infostring(groups[j], "grp_name")
+	})
+
+	result := make([]interface{}, 0, len(groups))
+
+	for _, group := range groups {
+		if !nameRegex.MatchString(infostring(group, "grp_name")) {
+			continue
+		}
+
+		// Members and permissions are only retrieved for the matching groups
+		computedGroup, groupErr := dataSourcegroupflatten(group, meta)
+
+		if groupErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to read information from group: %s (%s)", infostring(group, "grp_name"), groupErr)
+		}
+
+		result = append(result, computedGroup)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d group(s) matching: %s\n", len(result), d.Get("name_regex").(string)))
+
+	d.SetId("groups/" + d.Get("name_regex").(string))
+	d.Set("groups", result)
+
+	return nil
+}
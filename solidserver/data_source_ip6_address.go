@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net/url"
-	"regexp"
 	"strconv"
 )
 
@@ -86,7 +85,7 @@ func dataSourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_name='"+d.Get("space").(string)+"' AND ip6_addr='"+ip6tohexip6(d.Get("address").(string))+"'")
+	parameters.Add("WHERE", "site_name='"+whereescape(d.Get("space").(string))+"' AND ip6_addr='"+ip6tohexip6(d.Get("address").(string))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/ip6_address6_list", &parameters)
@@ -109,11 +108,7 @@ func dataSourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta
 			d.Set("prefix", hexip6toip6(buf[0]["subnet6_start_ip6_addr"].(string))+"/"+buf[0]["subnet6_prefix"].(string))
 			d.Set("prefix_size", prefix_size)
 
-			if macIgnore, _ := regexp.MatchString("^EIP:", buf[0]["ip6_mac_addr"].(string)); !macIgnore {
-				d.Set("mac", buf[0]["ip6_mac_addr"].(string))
-			} else {
-				d.Set("mac", "")
-			}
+			d.Set("mac", macnormalize(buf[0]["ip6_mac_addr"].(string)))
 
 			d.Set("class", buf[0]["ip6_class_name"].(string))
 
@@ -76,9 +76,9 @@ func dataSourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "pool6_name like '" + d.Get("name").(string) + "' " +
-		"and site_name like '" + d.Get("space").(string) + "' " +
-		"and subnet6_name like '" + d.Get("subnet").(string) + "'"
+	whereClause := "pool6_name like '" + whereescape(d.Get("name").(string)) + "' " +
+		"and site_name like '" + whereescape(d.Get("space").(string)) + "' " +
+		"and subnet6_name like '" + whereescape(d.Get("subnet").(string)) + "'"
 
 	parameters.Add("WHERE", whereClause)
 
@@ -101,8 +101,8 @@ func dataSourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "subnet6_name LIKE '" + d.Get("name").(string) + "'" +
-		" and site_name LIKE '" + d.Get("space").(string) + "'"
+	whereClause := "subnet6_name LIKE '" + whereescape(d.Get("name").(string)) + "'" +
+		" and site_name LIKE '" + whereescape(d.Get("space").(string)) + "'"
 
 	parameters.Add("WHERE", whereClause)
 
@@ -0,0 +1,13 @@
+package solidserver
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceip6subnets() *schema.Resource {
+	return dataSourceipsubnetsresource(ipSubnetsV6Columns, heredoc.Doc(`
+		IPv6 subnets data-source allows to retrieve the IPv6 blocks and subnets of a space along with their hierarchy,
+		ex: to iterate over the terminal subnets of a block within a module.
+	`))
+}
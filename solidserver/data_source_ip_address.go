@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net/url"
-	"regexp"
 	"strconv"
 )
 
@@ -91,7 +90,7 @@ func dataSourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_name='"+d.Get("space").(string)+"' AND ip_addr='"+iptohexip(d.Get("address").(string))+"'")
+	parameters.Add("WHERE", "site_name='"+whereescape(d.Get("space").(string))+"' AND ip_addr='"+iptohexip(d.Get("address").(string))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/ip_address_list", &parameters)
@@ -116,11 +115,7 @@ func dataSourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta i
 			d.Set("prefix_size", prefixLength)
 			d.Set("netmask", prefixlengthtohexip(prefixLength))
 
-			if macIgnore, _ := regexp.MatchString("^EIP:", buf[0]["mac_addr"].(string)); !macIgnore {
-				d.Set("mac", buf[0]["mac_addr"].(string))
-			} else {
-				d.Set("mac", "")
-			}
+			d.Set("mac", macnormalize(buf[0]["mac_addr"].(string)))
 
 			d.Set("class", buf[0]["ip_class_name"].(string))
 
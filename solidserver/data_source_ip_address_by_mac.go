@@ -0,0 +1,147 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+func dataSourceipaddressbymac() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipaddressbymacRead,
+
+		Description: heredoc.Doc(`
+			IP address by MAC data-source allows to retrieve the list of IPv4 addresses registered with a given MAC address within a space.
+			A MAC address can be registered several times across different subnets, an empty list is returned if none is found.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP addresses.",
+				Required:    true,
+			},
+			"mac": {
+				Type:         schema.TypeString,
+				Description:  "The MAC Address to look for (case insensitive).",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"), "Unsupported MAC address format."),
+				Required:     true,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The list of IP addresses registered with the MAC address.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IP address.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The short name or FQDN of the IP address.",
+							Computed:    true,
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Description: "The name of the subnet of the IP address.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the IP address.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceipaddressbymacRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// SOLIDserver stores MAC addresses in lower case using ':' as separator
+	mac := macnormalize(d.Get("mac").(string))
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "site_name='"+whereescape(strings.ToLower(d.Get("space").(string)))+"' AND mac_addr='"+mac+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_used_address_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 204) && (len(buf) == 0 || buf[0]["errmsg"] == nil) {
+			addresses := make([]interface{}, 0, len(buf))
+
+			for _, ip := range buf {
+				ipMac, _ := ip["mac_addr"].(string)
+
+				// Ignore pseudo MAC addresses
+				if macnormalize(ipMac) != mac {
+					continue
+				}
+
+				ipAddr, _ := ip["ip_addr"].(string)
+				ipName, _ := ip["name"].(string)
+				ipSubnet, _ := ip["subnet_name"].(string)
+				ipClassParameters, _ := ip["ip_class_parameters"].(string)
+
+				retrievedClassParameters, _ := url.ParseQuery(ipClassParameters)
+				computedClassParameters := map[string]interface{}{}
+
+				for ck := range retrievedClassParameters {
+					if ck != "gateway" {
+						computedClassParameters[ck] = retrievedClassParameters[ck][0]
+					}
+				}
+
+				addresses = append(addresses, map[string]interface{}{
+					"address":          hexiptoip(ipAddr),
+					"name":             ipName,
+					"subnet":           ipSubnet,
+					"class_parameters": computedClassParameters,
+				})
+			}
+
+			d.SetId(d.Get("space").(string) + ":" + mac)
+			d.Set("addresses", addresses)
+
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find IP addresses by MAC: %s (%s)\n", d.Get("mac"), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find IP addresses by MAC: %s\n", d.Get("mac")))
+		}
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP addresses by MAC: %s", d.Get("mac").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
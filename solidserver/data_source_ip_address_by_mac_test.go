@@ -0,0 +1,60 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceIPAddressByMac(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_used_address_list", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("WHERE"), "site_name='space01' AND mac_addr='00:11:22:aa:bb:cc'") {
+			mockReply(w, http.StatusOK, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"ip_addr": "0a000001", "name": "host01", "subnet_name": "subnet01", "mac_addr": "00:11:22:aa:bb:cc", "ip_class_parameters": "owner=netops&gateway=1"},
+			{"ip_addr": "0a010001", "name": "host01", "subnet_name": "subnet02", "mac_addr": "00:11:22:AA:BB:CC", "ip_class_parameters": ""},
+		})
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceipaddressbymac().Schema, map[string]interface{}{
+		"space": "Space01",
+		"mac":   "00-11-22-AA-BB-CC",
+	})
+
+	if diags := dataSourceipaddressbymacRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	addresses := d.Get("addresses").([]interface{})
+
+	if len(addresses) != 2 {
+		t.Fatalf("expected 2 addresses, got %d", len(addresses))
+	}
+
+	first := addresses[0].(map[string]interface{})
+
+	if first["address"] != "10.0.0.1" || first["subnet"] != "subnet01" || first["class_parameters"].(map[string]interface{})["owner"] != "netops" {
+		t.Errorf("unexpected first address: %v", first)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceipaddressbymac().Schema, map[string]interface{}{
+		"space": "space01",
+		"mac":   "00:00:00:00:00:01",
+	})
+
+	if diags := dataSourceipaddressbymacRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if addresses := d.Get("addresses").([]interface{}); len(addresses) != 0 {
+		t.Errorf("expected no address, got %d", len(addresses))
+	}
+}
@@ -0,0 +1,197 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/netip"
+	"net/url"
+)
+
+func dataSourceiplookup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceiplookupRead,
+
+		Description: heredoc.Doc(`
+			IP lookup data-source allows to reverse-resolve an IPv4 or IPv6 address into its IPAM registration and DNS PTR record.
+			An address not registered within the space is reported with found set to false rather than as an error.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP address.",
+				Required:    true,
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Description:  "The IPv4 or IPv6 address to look for.",
+				ValidateFunc: validation.IsIPAddress,
+				Required:     true,
+			},
+			"lookup_ptr": {
+				Type:        schema.TypeBool,
+				Description: "Look for a DNS PTR record of the IP address (Default: true).",
+				Optional:    true,
+				Default:     true,
+			},
+			"found": {
+				Type:        schema.TypeBool,
+				Description: "Whether the IP address is registered within the space.",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The short name or FQDN of the IP address.",
+				Computed:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the subnet of the IP address.",
+				Computed:    true,
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Description: "The name of the pool of the IP address.",
+				Computed:    true,
+			},
+			"mac": {
+				Type:        schema.TypeString,
+				Description: "The MAC Address of the IP address.",
+				Computed:    true,
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the IP address.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ptr_exists": {
+				Type:        schema.TypeBool,
+				Description: "Whether a DNS PTR record exists for the IP address.",
+				Computed:    true,
+			},
+			"ptr_target": {
+				Type:        schema.TypeString,
+				Description: "The target of the DNS PTR record of the IP address, if any.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceiplookupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	d.SetId("")
+
+	addr, addrErr := netip.ParseAddr(d.Get("address").(string))
+	if addrErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to lookup IP address: %s, invalid address\n", d.Get("address").(string))
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	service := "rest/ip_used_address_list"
+	ptrName := iptoptr(addr.String())
+
+	if addr.Is4() {
+		parameters.Add("WHERE", "site_name='"+whereescape(d.Get("space").(string))+"' AND ip_addr='"+iptohexip(addr.String())+"'")
+	} else {
+		service = "rest/ip6_address6_list"
+		ptrName = ip6toptr(addr.String())
+		parameters.Add("WHERE", "site_name='"+whereescape(d.Get("space").(string))+"' AND ip6_addr='"+ip6tohexip6(addr.StringExpanded())+"'")
+	}
+
+	parameters.Add("limit", "1")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", service, &parameters)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode != 200 && resp.StatusCode != 204) || (len(buf) > 0 && buf[0]["errmsg"] != nil) {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to lookup IP address: %s (%s)\n", d.Get("address"), errMsg))
+			}
+		}
+
+		// Reporting a failure
+		return diag.Errorf("Unable to lookup IP address: %s\n", d.Get("address").(string))
+	}
+
+	computedClassParameters := map[string]interface{}{}
+
+	if len(buf) > 0 {
+		ipName, _ := buf[0]["name"].(string)
+		ipMac, _ := buf[0]["mac_addr"].(string)
+		ipClassParameters, _ := buf[0]["ip_class_parameters"].(string)
+		ipSubnet, _ := buf[0]["subnet_name"].(string)
+		ipPool, _ := buf[0]["pool_name"].(string)
+
+		if addr.Is6() {
+			ipName, _ = buf[0]["ip6_name"].(string)
+			ipMac, _ = buf[0]["ip6_mac_addr"].(string)
+			ipClassParameters, _ = buf[0]["ip6_class_parameters"].(string)
+			ipSubnet, _ = buf[0]["subnet6_name"].(string)
+			ipPool, _ = buf[0]["pool6_name"].(string)
+		}
+
+		retrievedClassParameters, _ := url.ParseQuery(ipClassParameters)
+
+		for ck := range retrievedClassParameters {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
+		}
+
+		d.Set("found", true)
+		d.Set("name", ipName)
+		d.Set("subnet", ipSubnet)
+		d.Set("pool", ipPool)
+		d.Set("mac", macnormalize(ipMac))
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find IP address: %s\n", d.Get("address")))
+
+		d.Set("found", false)
+		d.Set("name", "")
+		d.Set("subnet", "")
+		d.Set("pool", "")
+		d.Set("mac", "")
+	}
+
+	d.Set("class_parameters", computedClassParameters)
+	d.Set("ptr_exists", false)
+	d.Set("ptr_target", "")
+
+	// Look for the PTR record of the address
+	if d.Get("lookup_ptr").(bool) {
+		ptrTarget, ptrExists, ptrErr := iplookupptr(ptrName, meta)
+
+		if ptrErr != nil {
+			// Reporting a failure
+			return diag.FromErr(ptrErr)
+		}
+
+		d.Set("ptr_exists", ptrExists)
+		d.Set("ptr_target", ptrTarget)
+	}
+
+	d.SetId(d.Get("space").(string) + ":" + addr.String())
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceIPLookup(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_used_address_list", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("WHERE"), "ip_addr='0a000801'") {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"ip_addr": "0a000801", "name": "host01", "subnet_name": "subnet01", "pool_name": "pool01", "mac_addr": "00:11:22:AA:BB:CC", "ip_class_parameters": "owner=netops"},
+		})
+	})
+
+	m.handle("/rest/ip6_address6_list", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("WHERE"), "ip6_addr='20010db8000000000000000000000001'") {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"ip6_name": "host06", "subnet6_name": "subnet06", "pool6_name": "", "ip6_mac_addr": "", "ip6_class_parameters": ""},
+		})
+	})
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") == "rr_full_name='1.8.0.10.in-addr.arpa' AND rr_type='PTR'" {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"rr_id": "7", "value1": "host01.example.com"}})
+			return
+		}
+
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	// A registered IPv4 address with a PTR record
+	d := schema.TestResourceDataRaw(t, dataSourceiplookup().Schema, map[string]interface{}{
+		"space":   "space01",
+		"address": "10.0.8.1",
+	})
+
+	if diags := dataSourceiplookupRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !d.Get("found").(bool) || d.Get("name").(string) != "host01" || d.Get("pool").(string) != "pool01" || d.Get("mac").(string) != "00:11:22:aa:bb:cc" ||
+		d.Get("class_parameters").(map[string]interface{})["owner"] != "netops" || !d.Get("ptr_exists").(bool) || d.Get("ptr_target").(string) != "host01.example.com" {
+		t.Errorf("unexpected lookup: %v", d.State().Attributes)
+	}
+
+	// An unknown address is not an error
+	d = schema.TestResourceDataRaw(t, dataSourceiplookup().Schema, map[string]interface{}{
+		"space":   "space01",
+		"address": "10.0.8.2",
+	})
+
+	if diags := dataSourceiplookupRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("found").(bool) || d.Get("ptr_exists").(bool) || d.Id() != "space01:10.0.8.2" {
+		t.Errorf("unexpected lookup: %v", d.State().Attributes)
+	}
+
+	// A registered IPv6 address without PTR lookup
+	d = schema.TestResourceDataRaw(t, dataSourceiplookup().Schema, map[string]interface{}{
+		"space":      "space01",
+		"address":    "2001:db8::1",
+		"lookup_ptr": false,
+	})
+
+	if diags := dataSourceiplookupRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !d.Get("found").(bool) || d.Get("name").(string) != "host06" || d.Get("subnet").(string) != "subnet06" || m.count("/rest/dns_rr_list") != 2 {
+		t.Errorf("unexpected lookup: %v", d.State().Attributes)
+	}
+}
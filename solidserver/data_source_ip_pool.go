@@ -81,9 +81,9 @@ func dataSourceippoolRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "pool_name like '" + d.Get("name").(string) + "' " +
-		"and site_name like '" + d.Get("space").(string) + "' " +
-		"and subnet_name like '" + d.Get("subnet").(string) + "'"
+	whereClause := "pool_name like '" + whereescape(d.Get("name").(string)) + "' " +
+		"and site_name like '" + whereescape(d.Get("space").(string)) + "' " +
+		"and subnet_name like '" + whereescape(d.Get("subnet").(string)) + "'"
 
 	parameters.Add("WHERE", whereClause)
 
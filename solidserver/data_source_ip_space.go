@@ -38,20 +38,20 @@ func dataSourceipspace() *schema.Resource {
 				Description: "The class parameters associated to IP space.",
 				Computed:    true,
 			},
+			"wait_for_existence": waitforexistenceschema("IP space"),
 		},
 	}
 }
 
 func dataSourceipspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s := meta.(*SOLIDserver)
 	d.SetId("")
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_name='"+d.Get("name").(string)+"'")
+	parameters.Add("WHERE", "site_name='"+whereescape(d.Get("name").(string))+"'")
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_site_list", &parameters)
+	resp, body, err := waitforexistence(ctx, d, "rest/ip_site_list", &parameters, meta)
 
 	if err == nil {
 		var buf [](map[string]interface{})
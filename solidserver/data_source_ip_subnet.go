@@ -96,23 +96,23 @@ func dataSourceipsubnet() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"wait_for_existence": waitforexistenceschema("IP subnet"),
 		},
 	}
 }
 
 func dataSourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s := meta.(*SOLIDserver)
 	d.SetId("")
 
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "subnet_name LIKE '" + d.Get("name").(string) + "'" +
-		" and site_name LIKE '" + d.Get("space").(string) + "'"
+	whereClause := "subnet_name LIKE '" + whereescape(d.Get("name").(string)) + "'" +
+		" and site_name LIKE '" + whereescape(d.Get("space").(string)) + "'"
 
 	parameters.Add("WHERE", whereClause)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_block_subnet_list", &parameters)
+	resp, body, err := waitforexistence(ctx, d, "rest/ip_block_subnet_list", &parameters, meta)
 
 	if err == nil {
 		var buf [](map[string]interface{})
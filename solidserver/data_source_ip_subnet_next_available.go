@@ -0,0 +1,106 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func dataSourceipsubnetnextavailable() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetnextavailableRead,
+
+		Description: heredoc.Doc(`
+			IP subnet next available data-source allows to preview the prefix that would be allocated for a new IPv4 subnet of a given size within a block.
+			Nothing is reserved, the prefix may be allocated to another subnet before being used.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP block.",
+				Required:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP block to look into.",
+				Required:    true,
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The prefix length of the IP subnet to look for (ex: 24 for a '/24').",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Required:     true,
+			},
+			"next_available_cidr": {
+				Type:        schema.TypeString,
+				Description: "The first available prefix of the requested size within the IP block (ex: 10.1.2.0/24).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceipsubnetnextavailableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	d.SetId("")
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+	if blockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blockErr)
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("block_id", blockInfo["id"].(string))
+	parameters.Add("prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+	parameters.Add("max_find", "1")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rpc/ip_find_free_subnet", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if hexaddr, hexaddrExist := buf[0]["start_ip_addr"].(string); hexaddrExist {
+				cidr := hexiptoip(hexaddr) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+
+				d.SetId(blockInfo["id"].(string) + ":" + cidr)
+				d.Set("next_available_cidr", cidr)
+				return nil
+			}
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find a free IP subnet within block: %s (%s)\n", d.Get("block").(string), errMsg))
+			}
+		}
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find a free /%d IP subnet within block: %s\n", d.Get("prefix_size").(int), d.Get("block").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
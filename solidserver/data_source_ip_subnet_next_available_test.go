@@ -0,0 +1,60 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceIPSubnetNextAvailable(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	free := true
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"subnet_id": "5", "subnet_name": "block01", "subnet_level": "0"}})
+	})
+
+	m.handle("/rpc/ip_find_free_subnet", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("block_id") != "5" || r.URL.Query().Get("prefix") != "24" || r.URL.Query().Get("max_find") != "1" {
+			t.Errorf("unexpected search parameters: %v", r.URL.Query())
+		}
+
+		if !free {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"start_ip_addr": "0a010200"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceipsubnetnextavailable().Schema, map[string]interface{}{
+		"space":       "space01",
+		"block":       "block01",
+		"prefix_size": 24,
+	})
+
+	if diags := dataSourceipsubnetnextavailableRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("next_available_cidr").(string) != "10.1.2.0/24" {
+		t.Errorf("unexpected next available cidr: %s", d.Get("next_available_cidr").(string))
+	}
+
+	// A full block is reported
+	free = false
+
+	if diags := dataSourceipsubnetnextavailableRead(context.Background(), d, s); !diags.HasError() {
+		t.Errorf("expected an error within a full block")
+	}
+
+	if m.count("/rest/ip_subnet_add") != 0 {
+		t.Errorf("expected no IP subnet to be created")
+	}
+}
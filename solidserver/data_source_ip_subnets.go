@@ -0,0 +1,310 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Columns of the subnet lists assembled by the IP subnets and IPv6 subnets data-sources
+type ipSubnetsColumns struct {
+	objectType      string
+	service         string
+	idName          string
+	nameName        string
+	parentIDName    string
+	parentNameName  string
+	levelName       string
+	startName       string
+	endName         string
+	classParamsName string
+	hexToIP         func(string) string
+	prefixSize      func(map[string]interface{}) int
+}
+
+var ipSubnetsV4Columns = ipSubnetsColumns{
+	objectType:      "IP",
+	service:         "rest/ip_block_subnet_list",
+	idName:          "subnet_id",
+	nameName:        "subnet_name",
+	parentIDName:    "parent_subnet_id",
+	parentNameName:  "parent_subnet_name",
+	levelName:       "subnet_level",
+	startName:       "start_ip_addr",
+	endName:         "end_ip_addr",
+	classParamsName: "subnet_class_parameters",
+	hexToIP:         hexiptoip,
+	prefixSize: func(subnet map[string]interface{}) int {
+		size, _ := strconv.Atoi(infostring(subnet, "subnet_size"))
+		return sizetoprefixlength(size)
+	},
+}
+
+var ipSubnetsV6Columns = ipSubnetsColumns{
+	objectType:      "IPv6",
+	service:         "rest/ip6_block6_subnet6_list",
+	idName:          "subnet6_id",
+	nameName:        "subnet6_name",
+	parentIDName:    "parent_subnet6_id",
+	parentNameName:  "parent_subnet6_name",
+	levelName:       "subnet_level",
+	startName:       "start_ip6_addr",
+	endName:         "end_ip6_addr",
+	classParamsName: "subnet6_class_parameters",
+	hexToIP:         hexip6toip6,
+	prefixSize: func(subnet map[string]interface{}) int {
+		size, _ := strconv.Atoi(infostring(subnet, "subnet6_prefix"))
+		return size
+	},
+}
+
+func dataSourceipsubnets() *schema.Resource {
+	return dataSourceipsubnetsresource(ipSubnetsV4Columns, heredoc.Doc(`
+		IP subnets data-source allows to retrieve the IPv4 blocks and subnets of a space along with their hierarchy,
+		ex: to iterate over the terminal subnets of a block within a module.
+	`))
+}
+
+// Return the schema of the IP subnets and IPv6 subnets data-sources
+func dataSourceipsubnetsresource(columns ipSubnetsColumns, description string) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return dataSourceipsubnetsRead(ctx, d, meta, columns)
+		},
+
+		Description: description,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space hosting the " + columns.objectType + " subnets.",
+				Required:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the block or subnet the " + columns.objectType + " subnets must be nested in, at any level (Default: all the subnets of the space).",
+				Optional:    true,
+				Default:     "",
+			},
+			"terminal": {
+				Type:        schema.TypeBool,
+				Description: "Only retrieve the terminal (true) or non-terminal (false) " + columns.objectType + " subnets (Default: both).",
+				Optional:    true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "The regular expression the name of the " + columns.objectType + " subnets must match.",
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The " + columns.objectType + " subnets, sorted by start address then level.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the " + columns.objectType + " subnet.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the " + columns.objectType + " subnet.",
+							Computed:    true,
+						},
+						"cidr": {
+							Type:        schema.TypeString,
+							Description: "The " + columns.objectType + " subnet prefix (ex: 10.0.0.0/24).",
+							Computed:    true,
+						},
+						"start_address": {
+							Type:        schema.TypeString,
+							Description: "The first address of the " + columns.objectType + " subnet.",
+							Computed:    true,
+						},
+						"end_address": {
+							Type:        schema.TypeString,
+							Description: "The last address of the " + columns.objectType + " subnet.",
+							Computed:    true,
+						},
+						"prefix_size": {
+							Type:        schema.TypeInt,
+							Description: "The " + columns.objectType + " subnet's prefix length (ex: 24 for a '/24').",
+							Computed:    true,
+						},
+						"terminal": {
+							Type:        schema.TypeBool,
+							Description: "The terminal property of the " + columns.objectType + " subnet.",
+							Computed:    true,
+						},
+						"level": {
+							Type:        schema.TypeInt,
+							Description: "The depth of the " + columns.objectType + " subnet within the space (0 for the top level blocks).",
+							Computed:    true,
+						},
+						"parent_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the parent block or subnet, empty for the top level blocks.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the " + columns.objectType + " subnet.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceipsubnetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}, columns ipSubnetsColumns) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	spaceName := d.Get("space").(string)
+	blockName := d.Get("block").(string)
+	nameRegex := regexp.MustCompile(d.Get("name_regex").(string))
+
+	// Listing the whole space, the hierarchy is resolved locally
+	subnets, err := listall(columns.service, "site_name='"+whereescape(spaceName)+"'", meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to list %s subnets of space: %s (%s)\n", columns.objectType, spaceName, err)
+	}
+
+	if len(subnets) == 0 {
+		// An empty space is not an error, a missing one is
+		siteID, siteErr := ipsiteidbyname(spaceName, meta)
+
+		if siteErr != nil {
+			return diag.FromErr(siteErr)
+		}
+
+		if siteID == "" {
+			return diag.Errorf("Unable to find IP space: %s\n", spaceName)
+		}
+	}
+
+	subnetsByID := map[string]map[string]interface{}{}
+	blockFound := blockName == ""
+
+	for _, subnet := range subnets {
+		subnetsByID[infostring(subnet, columns.idName)] = subnet
+		blockFound = blockFound || infostring(subnet, columns.nameName) == blockName
+	}
+
+	if !blockFound {
+		names := make([]string, 0, len(subnets))
+
+		for _, subnet := range subnets {
+			names = append(names, infostring(subnet, columns.nameName))
+		}
+
+		return diag.FromErr(notfounderror(columns.objectType+" block", blockName, "space '"+spaceName+"'", names))
+	}
+
+	// Return true if one of the ancestors of a subnet is named after the block
+	nestedinblock := func(subnet map[string]interface{}) bool {
+		for depth := 0; depth < len(subnets); depth++ {
+			parent, parentExist := subnetsByID[infostring(subnet, columns.parentIDName)]
+
+			if !parentExist {
+				return false
+			}
+
+			if infostring(parent, columns.nameName) == blockName {
+				return true
+			}
+
+			subnet = parent
+		}
+
+		return false
+	}
+
+	// An explicit false filters the non-terminal subnets, an unset value does not filter
+	terminal, filterTerminal := d.GetOkExists("terminal")
+	matched := []map[string]interface{}{}
+
+	for _, subnet := range subnets {
+		if filterTerminal && (infostring(subnet, "is_terminal") == "1") != terminal.(bool) {
+			continue
+		}
+
+		if !nameRegex.MatchString(infostring(subnet, columns.nameName)) {
+			continue
+		}
+
+		if blockName != "" && !nestedinblock(subnet) {
+			continue
+		}
+
+		matched = append(matched, subnet)
+	}
+
+	// Hexadecimal addresses have a fixed length, their order is the one of the addresses
+	sort.SliceStable(matched, func(i, j int) bool {
+		if start1, start2 := infostring(matched[i], columns.startName), infostring(matched[j], columns.startName); start1 != start2 {
+			return start1 < start2
+		}
+
+		level1, _ := strconv.Atoi(infostring(matched[i], columns.levelName))
+		level2, _ := strconv.Atoi(infostring(matched[j], columns.levelName))
+
+		return level1 < level2
+	})
+
+	result := make([]interface{}, 0, len(matched))
+
+	for _, subnet := range matched {
+		startAddress := columns.hexToIP(infostring(subnet, columns.startName))
+		prefixSize := columns.prefixSize(subnet)
+		level, _ := strconv.Atoi(infostring(subnet, columns.levelName))
+		parentName := infostring(subnet, columns.parentNameName)
+
+		if parentName == "#" {
+			parentName = ""
+		}
+
+		retrievedClassParameters, _ := url.ParseQuery(infostring(subnet, columns.classParamsName))
+		computedClassParameters := map[string]interface{}{}
+
+		for ck := range retrievedClassParameters {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":               infostring(subnet, columns.idName),
+			"name":             infostring(subnet, columns.nameName),
+			"cidr":             startAddress + "/" + strconv.Itoa(prefixSize),
+			"start_address":    startAddress,
+			"end_address":      columns.hexToIP(infostring(subnet, columns.endName)),
+			"prefix_size":      prefixSize,
+			"terminal":         infostring(subnet, "is_terminal") == "1",
+			"level":            level,
+			"parent_name":      parentName,
+			"class_parameters": computedClassParameters,
+		})
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Found %d %s subnet(s) in space: %s\n", len(result), columns.objectType, spaceName))
+
+	d.SetId(spaceName + "/" + blockName)
+	d.Set("subnets", result)
+
+	return nil
+}
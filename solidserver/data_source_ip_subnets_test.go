@@ -0,0 +1,204 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Emulate a paginated subnet list service holding the subnets of a single space
+func mockSubnetsList(space string, rows []map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "site_name='"+space+"'" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		page := []map[string]interface{}{}
+		for i := offset; i < len(rows) && i < offset+limit; i++ {
+			page = append(page, rows[i])
+		}
+		mockReply(w, http.StatusOK, page)
+	}
+}
+
+func TestDataSourceIPSubnets(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	subnet := func(id string, name string, parentID string, parentName string, level int, start uint32, size uint32, terminal string) map[string]interface{} {
+		return map[string]interface{}{
+			"subnet_id":               id,
+			"subnet_name":             name,
+			"parent_subnet_id":        parentID,
+			"parent_subnet_name":      parentName,
+			"subnet_level":            strconv.Itoa(level),
+			"start_ip_addr":           fmt.Sprintf("%08x", start),
+			"end_ip_addr":             fmt.Sprintf("%08x", start+size-1),
+			"subnet_size":             strconv.Itoa(int(size)),
+			"is_terminal":             terminal,
+			"subnet_class_parameters": "vnid=12&gateway=",
+		}
+	}
+
+	// 5000 terminal subnets nested within a block, returned unsorted over several pages
+	rows := []map[string]interface{}{
+		subnet("1", "dc1", "0", "#", 0, 0x0a000000, 1<<24, "0"),
+		subnet("3", "lab", "0", "#", 0, 0xc0a80000, 1<<16, "0"),
+		subnet("4", "lab-01", "3", "lab", 1, 0xc0a80000, 256, "1"),
+	}
+
+	for i := 5000; i > 0; i-- {
+		rows = append(rows, subnet(strconv.Itoa(i+10), fmt.Sprintf("app-%04d", i), "2", "apps", 2, 0x0a000000+uint32(i-1)*16, 16, "1"))
+	}
+
+	rows = append(rows, subnet("2", "apps", "1", "dc1", 1, 0x0a000000, 1<<16, "0"))
+
+	m.handle("/rest/ip_block_subnet_list", mockSubnetsList("prod", rows))
+	m.handle("/rest/ip_site_list", mockWhereList([]map[string]interface{}{{"site_id": "2", "site_name": "prod"}, {"site_id": "3", "site_name": "empty"}}))
+
+	// Reading the whole list of a large space from the state is slow, read the count and the first subnets only
+	read := func(config map[string]interface{}) (int, []interface{}) {
+		d := schema.TestResourceDataRaw(t, dataSourceipsubnets().Schema, config)
+
+		if diags := dataSourceipsubnetsRead(context.Background(), d, s, ipSubnetsV4Columns); diags.HasError() {
+			t.Fatalf("unexpected error for %v: %v", config, diags)
+		}
+
+		count := d.Get("subnets.#").(int)
+		subnets := []interface{}{}
+
+		for i := 0; i < count && i < 4; i++ {
+			subnets = append(subnets, d.Get("subnets."+strconv.Itoa(i)))
+		}
+
+		if count > 0 {
+			subnets = append(subnets, d.Get("subnets."+strconv.Itoa(count-1)))
+		}
+
+		return count, subnets
+	}
+
+	// All the subnets of the space, sorted by start address then level
+	count, subnets := read(map[string]interface{}{"space": "prod"})
+
+	if count != len(rows) {
+		t.Fatalf("expected %d subnets, got %d", len(rows), count)
+	}
+
+	for i, name := range []string{"dc1", "apps", "app-0001", "app-0002", "lab-01"} {
+		if subnets[i].(map[string]interface{})["name"] != name {
+			t.Errorf("unexpected subnet #%d: %v", i, subnets[i])
+		}
+	}
+
+	// Terminal subnets nested at any level within a block
+	count, subnets = read(map[string]interface{}{"space": "prod", "block": "dc1", "terminal": true})
+
+	if count != 5000 {
+		t.Fatalf("expected 5000 subnets, got %d", count)
+	}
+
+	first := subnets[0].(map[string]interface{})
+	expected := map[string]interface{}{
+		"id":            "11",
+		"name":          "app-0001",
+		"cidr":          "10.0.0.0/28",
+		"start_address": "10.0.0.0",
+		"end_address":   "10.0.0.15",
+		"prefix_size":   28,
+		"terminal":      true,
+		"level":         2,
+		"parent_name":   "apps",
+	}
+
+	for k, v := range expected {
+		if first[k] != v {
+			t.Errorf("unexpected %s: %v, expected %v", k, first[k], v)
+		}
+	}
+
+	if classParameters := first["class_parameters"].(map[string]interface{}); classParameters["vnid"] != "12" {
+		t.Errorf("unexpected class parameters: %v", classParameters)
+	}
+
+	if last := subnets[4].(map[string]interface{}); last["cidr"] != "10.1.56.112/28" {
+		t.Errorf("unexpected last subnet: %v", last)
+	}
+
+	// Non-terminal subnets and name filter
+	count, subnets = read(map[string]interface{}{"space": "prod", "terminal": false})
+
+	if count != 3 || subnets[2].(map[string]interface{})["parent_name"] != "" {
+		t.Errorf("unexpected non-terminal subnets: %v", subnets)
+	}
+
+	if count, subnets = read(map[string]interface{}{"space": "prod", "name_regex": "^lab"}); count != 2 {
+		t.Errorf("unexpected subnets matching the name: %v", subnets)
+	}
+
+	// An empty space is not an error
+	if count, subnets = read(map[string]interface{}{"space": "empty"}); count != 0 {
+		t.Errorf("unexpected subnets in an empty space: %v", subnets)
+	}
+
+	// Unknown spaces and blocks are reported
+	for _, config := range []map[string]interface{}{
+		{"space": "missing"},
+		{"space": "prod", "block": "dc2"},
+	} {
+		d := schema.TestResourceDataRaw(t, dataSourceipsubnets().Schema, config)
+
+		if diags := dataSourceipsubnetsRead(context.Background(), d, s, ipSubnetsV4Columns); !diags.HasError() {
+			t.Errorf("expected an error for %v", config)
+		}
+	}
+}
+
+func TestDataSourceIP6Subnets(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip6_block6_subnet6_list", mockSubnetsList("prod", []map[string]interface{}{
+		{
+			"subnet6_id": "5", "subnet6_name": "prod-02", "parent_subnet6_id": "1", "parent_subnet6_name": "blk",
+			"subnet_level": "1", "subnet6_prefix": "64", "is_terminal": "1", "subnet6_class_parameters": "",
+			"start_ip6_addr": "20010db8000000020000000000000000", "end_ip6_addr": "20010db800000002ffffffffffffffff",
+		},
+		{
+			"subnet6_id": "4", "subnet6_name": "prod-01", "parent_subnet6_id": "1", "parent_subnet6_name": "blk",
+			"subnet_level": "1", "subnet6_prefix": "64", "is_terminal": "1", "subnet6_class_parameters": "",
+			"start_ip6_addr": "20010db8000000010000000000000000", "end_ip6_addr": "20010db800000001ffffffffffffffff",
+		},
+		{
+			"subnet6_id": "1", "subnet6_name": "blk", "parent_subnet6_id": "0", "parent_subnet6_name": "#",
+			"subnet_level": "0", "subnet6_prefix": "48", "is_terminal": "0", "subnet6_class_parameters": "",
+			"start_ip6_addr": "20010db8000000000000000000000000", "end_ip6_addr": "20010db80000ffffffffffffffffffff",
+		},
+	}))
+
+	d := schema.TestResourceDataRaw(t, dataSourceip6subnets().Schema, map[string]interface{}{
+		"space":      "prod",
+		"block":      "blk",
+		"name_regex": "^prod-",
+	})
+
+	if diags := dataSourceipsubnetsRead(context.Background(), d, s, ipSubnetsV6Columns); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	subnets := d.Get("subnets").([]interface{})
+
+	if len(subnets) != 2 {
+		t.Fatalf("expected 2 subnets, got %v", subnets)
+	}
+
+	if first := subnets[0].(map[string]interface{}); first["cidr"] != "2001:0db8:0000:0001:0000:0000:0000:0000/64" || first["end_address"] != "2001:0db8:0000:0001:ffff:ffff:ffff:ffff" || first["prefix_size"] != 64 || first["level"] != 1 {
+		t.Errorf("unexpected first subnet: %v", first)
+	}
+}
@@ -0,0 +1,125 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+)
+
+// Class parameter holding the name of the Terraform workspace managing an object
+const managedObjectsWorkspaceParameter = "terraform_workspace"
+
+// Objects types looked up by the managed objects data-source along with the columns describing them
+var managedObjectsTypes = []struct {
+	objectType      string
+	service         string
+	classParamsName string
+	idName          string
+	nameName        string
+	resourceHint    string
+}{
+	{"ip_space", "rest/ip_site_list", "site_class_parameters", "site_id", "site_name", "solidserver_ip_space"},
+	{"ip_subnet", "rest/ip_block_subnet_list", "subnet_class_parameters", "subnet_id", "subnet_name", "solidserver_ip_subnet"},
+	{"ip_address", "rest/ip_address_list", "ip_class_parameters", "ip_id", "name", "solidserver_ip_address"},
+	{"dns_zone", "rest/dns_zone_list", "dnszone_class_parameters", "dnszone_id", "dnszone_name", "solidserver_dns_zone"},
+	{"dns_rr", "rest/dns_rr_list", "rr_class_parameters", "rr_id", "rr_full_name", "solidserver_dns_rr"},
+	{"vlan", "rest/vlmvlan_list", "vlmvlan_class_parameters", "vlmvlan_id", "vlmvlan_name", "solidserver_vlan"},
+}
+
+func dataSourcemanagedobjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcemanagedobjectsRead,
+
+		Description: heredoc.Doc(`
+			Managed objects data-source allows to retrieve the list of spaces, subnets, addresses, zones, RRs and VLANs
+			tagged with the 'terraform_workspace' class parameter set to a given Terraform workspace name.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Description: "The name of the Terraform workspace stored in the 'terraform_workspace' class parameter of the objects.",
+				Required:    true,
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Description: "The list of objects managed by the Terraform workspace.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The type of the object (ip_space, ip_subnet, ip_address, dns_zone, dns_rr or vlan).",
+							Computed:    true,
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the object.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the object.",
+							Computed:    true,
+						},
+						"resource_hint": {
+							Type:        schema.TypeString,
+							Description: "The Terraform resource type used to manage the object.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcemanagedobjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	workspace := d.Get("workspace").(string)
+	objects := []interface{}{}
+
+	// Class parameters are stored URL encoded, look for the encoded key/value pair
+	encodedParameter := url.Values{managedObjectsWorkspaceParameter: []string{workspace}}.Encode()
+
+	for _, objectType := range managedObjectsTypes {
+		whereClause := objectType.classParamsName + " LIKE '%" + encodedParameter + "%'"
+
+		buf, err := listall(objectType.service, whereClause, meta)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to list managed objects of type: %s (%s)", objectType.objectType, err)
+		}
+
+		for _, object := range buf {
+			classParameters, _ := object[objectType.classParamsName].(string)
+			retrievedClassParameters, _ := url.ParseQuery(classParameters)
+
+			// Discard partial matches (ex: workspace names sharing the same prefix)
+			if retrievedClassParameters.Get(managedObjectsWorkspaceParameter) != workspace {
+				continue
+			}
+
+			objectID, _ := object[objectType.idName].(string)
+			objectName, _ := object[objectType.nameName].(string)
+
+			objects = append(objects, map[string]interface{}{
+				"type":          objectType.objectType,
+				"id":            objectID,
+				"name":          objectName,
+				"resource_hint": objectType.resourceHint,
+			})
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d object(s) managed by workspace: %s\n", len(objects), workspace))
+
+	d.SetId(workspace)
+	d.Set("objects", objects)
+
+	return nil
+}
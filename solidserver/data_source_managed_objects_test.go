@@ -0,0 +1,74 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestListAllPagination(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		rows := []map[string]interface{}{}
+
+		for i := offset; i < 2500 && i < offset+listPageSize; i++ {
+			rows = append(rows, map[string]interface{}{"site_id": strconv.Itoa(i)})
+		}
+
+		mockReply(w, http.StatusOK, rows)
+	})
+
+	buf, err := listall("rest/ip_site_list", "", s)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(buf) != 2500 || m.count("/rest/ip_site_list") != 3 {
+		t.Errorf("expected 2500 objects in 3 pages, got %d in %d", len(buf), m.count("/rest/ip_site_list"))
+	}
+}
+
+func TestDataSourceManagedObjects(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	for _, service := range []string{"ip_site_list", "ip_address_list", "dns_zone_list", "dns_rr_list", "vlmvlan_list"} {
+		m.handle("/rest/"+service, func(w http.ResponseWriter, r *http.Request) {
+			mockReply(w, http.StatusOK, nil)
+		})
+	}
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"subnet_id": "12", "subnet_name": "subnet01", "subnet_class_parameters": "terraform_workspace=prod"},
+			{"subnet_id": "13", "subnet_name": "subnet02", "subnet_class_parameters": "terraform_workspace=prod2"},
+		})
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourcemanagedobjects().Schema, map[string]interface{}{
+		"workspace": "prod",
+	})
+
+	if diags := dataSourcemanagedobjectsRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	objects := d.Get("objects").([]interface{})
+
+	if len(objects) != 1 {
+		t.Fatalf("expected 1 object, got %d", len(objects))
+	}
+
+	if object := objects[0].(map[string]interface{}); object["id"] != "12" || object["resource_hint"] != "solidserver_ip_subnet" {
+		t.Errorf("unexpected object: %v", object)
+	}
+
+	if m.count("/rest/vlmvlan_list") != 1 {
+		t.Errorf("expected the VLANs to be listed once, got %d", m.count("/rest/vlmvlan_list"))
+	}
+}
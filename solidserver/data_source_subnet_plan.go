@@ -0,0 +1,424 @@
+package solidserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// An entry of the document planned by the subnet plan data-source
+type subnetPlanEntry struct {
+	Space           string            `json:"space"`
+	Block           string            `json:"block"`
+	Name            string            `json:"name"`
+	CIDR            string            `json:"cidr"`
+	Terminal        *bool             `json:"terminal"`
+	ClassParameters map[string]string `json:"class_parameters"`
+}
+
+// An IPv4 range, as the integer value of its first and last addresses
+type ipRange struct {
+	start    uint32
+	end      uint32
+	name     string
+	cidr     string
+	terminal bool
+}
+
+// Return true if the range is a non-terminal subnet containing the range from start to end
+// Subnets can be created within it, unlike within a terminal one
+func (r ipRange) encloses(start uint32, end uint32) bool {
+	return !r.terminal && r.start <= start && end <= r.end
+}
+
+// IPv4 ranges sorted by start address, along with the highest end address of each prefix of the list
+// Looking up the ranges overlapping a range is then a binary search
+type ipRangeIndex struct {
+	ranges []ipRange
+	maxEnd []uint32
+}
+
+func newiprangeindex(ranges []ipRange) *ipRangeIndex {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+
+	index := &ipRangeIndex{ranges: ranges, maxEnd: make([]uint32, len(ranges))}
+
+	for i, r := range ranges {
+		index.maxEnd[i] = r.end
+
+		if i > 0 && index.maxEnd[i-1] > r.end {
+			index.maxEnd[i] = index.maxEnd[i-1]
+		}
+	}
+
+	return index
+}
+
+// Return a range overlapping the range from start to end, preferring the one starting last
+// and skipping the ones ignore returns true for, if set
+// Or false if none overlaps it
+func (index *ipRangeIndex) overlapping(start uint32, end uint32, ignore func(ipRange) bool) (ipRange, bool) {
+	// Ranges starting after end can't overlap
+	i := sort.Search(len(index.ranges), func(i int) bool {
+		return index.ranges[i].start > end
+	})
+
+	if i == 0 || index.maxEnd[i-1] < start {
+		return ipRange{}, false
+	}
+
+	for i--; i >= 0; i-- {
+		if index.ranges[i].end >= start && (ignore == nil || !ignore(index.ranges[i])) {
+			return index.ranges[i], true
+		}
+	}
+
+	return ipRange{}, false
+}
+
+// Return the range spanning exactly from start to end
+// Or false if there is none
+func (index *ipRangeIndex) exact(start uint32, end uint32) (ipRange, bool) {
+	i := sort.Search(len(index.ranges), func(i int) bool {
+		return index.ranges[i].start >= start
+	})
+
+	for ; i < len(index.ranges) && index.ranges[i].start == start; i++ {
+		if index.ranges[i].end == end {
+			return index.ranges[i], true
+		}
+	}
+
+	return ipRange{}, false
+}
+
+// Return the range of an IPv4 subnet, from its hexadecimal start and end addresses
+// Or an error if they are not valid
+func iprangefromhex(startHexIP string, endHexIP string) (uint32, uint32, error) {
+	start, startErr := strconv.ParseUint(startHexIP, 16, 32)
+	end, endErr := strconv.ParseUint(endHexIP, 16, 32)
+
+	if startErr != nil || endErr != nil || start > end {
+		return 0, 0, fmt.Errorf("SOLIDServer - Invalid IP range: %s-%s\n", startHexIP, endHexIP)
+	}
+
+	return uint32(start), uint32(end), nil
+}
+
+// Return the range of an IPv4 CIDR, which must be a network address
+// Or an error if it is not valid
+func iprangefromcidr(cidr string) (uint32, uint32, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+
+	if err != nil || ip.To4() == nil {
+		return 0, 0, fmt.Errorf("invalid IPv4 CIDR: %s", cidr)
+	}
+
+	if !ip.Equal(network.IP) {
+		return 0, 0, fmt.Errorf("%s is not a network address (ex: %s)", cidr, network.String())
+	}
+
+	prefixLength, _ := network.Mask.Size()
+	start := iptolong(network.IP.String())
+
+	return start, start + uint32(prefixlengthtosize(prefixLength)-1), nil
+}
+
+func dataSourcesubnetplan() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesubnetplanRead,
+
+		Description: heredoc.Doc(`
+			Subnet plan data-source allows to validate a list of IPv4 subnets against SOLIDserver before creating them,
+			ex: to migrate the export of another IPAM. Nothing is created, the subnets safe to create are meant to be
+			iterated over into solidserver_ip_subnet resources. IPv6 subnets are not supported and reported as invalid.
+			Subnets may be planned within existing or planned non-terminal subnets, the parent subnets of the document
+			must then be created before their children.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"document": {
+				Type:         schema.TypeString,
+				Description:  "The JSON list of IPv4 subnets to validate, each of them with its space (case insensitive), block, name, cidr, terminal (Default: true) and class_parameters.",
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"entries": {
+				Type:        schema.TypeList,
+				Description: "The IP subnets of the document, in their order, along with the result of their validation.",
+				Computed:    true,
+				Elem:        dataSourcesubnetplanentry(true),
+			},
+			"valid_subnets": {
+				Type:        schema.TypeList,
+				Description: "The IP subnets of the document safe to create, in their order.",
+				Computed:    true,
+				Elem:        dataSourcesubnetplanentry(false),
+			},
+		},
+	}
+}
+
+// Return the schema of the IP subnets of the subnet plan data-source, with their status or not
+func dataSourcesubnetplanentry(status bool) *schema.Resource {
+	entry := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP subnet.",
+				Computed:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the block of the IP subnet.",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP subnet.",
+				Computed:    true,
+			},
+			"cidr": {
+				Type:        schema.TypeString,
+				Description: "The IP subnet prefix (ex: 10.0.0.0/24).",
+				Computed:    true,
+			},
+			"prefix_size": {
+				Type:        schema.TypeInt,
+				Description: "The IP subnet's prefix length (ex: 24 for a '/24').",
+				Computed:    true,
+			},
+			"terminal": {
+				Type:        schema.TypeBool,
+				Description: "The terminal property of the IP subnet.",
+				Computed:    true,
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters of the IP subnet.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+
+	if status {
+		entry.Schema["status"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "The result of the validation of the IP subnet: valid (safe to create), exists (already created with the same name) or invalid.",
+			Computed:    true,
+		}
+		entry.Schema["reason"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "The reason why the IP subnet is not valid, empty otherwise.",
+			Computed:    true,
+		}
+	}
+
+	return entry
+}
+
+func dataSourcesubnetplanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	document := d.Get("document").(string)
+	entries := []subnetPlanEntry{}
+
+	if err := json.Unmarshal([]byte(document), &entries); err != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to parse the document of the subnet plan, a list of IP subnets is expected (%s)\n", err)
+	}
+
+	// Listing the spaces once
+	spaces, spacesErr := listall("rest/ip_site_list", "", meta)
+
+	if spacesErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to list IP spaces (%s)\n", spacesErr)
+	}
+
+	// Space names are matched case insensitively, as SOLIDserver does
+	spaceNames := []string{}
+	spacesByKey := map[string]string{}
+
+	for _, space := range spaces {
+		spaceNames = append(spaceNames, infostring(space, "site_name"))
+		spacesByKey[strings.ToLower(infostring(space, "site_name"))] = infostring(space, "site_name")
+	}
+
+	// Listing the blocks and subnets of each space once, the validation is then performed locally
+	// Several blocks of a space may share the same name
+	blocksBySpace := map[string][]ipRange{}
+	subnetsBySpace := map[string]*ipRangeIndex{}
+
+	for _, entry := range entries {
+		space, spaceExist := spacesByKey[strings.ToLower(entry.Space)]
+
+		if _, listed := subnetsBySpace[space]; listed || !spaceExist {
+			continue
+		}
+
+		subnets, subnetsErr := listall("rest/ip_block_subnet_list", "site_name='"+whereescape(space)+"'", meta)
+
+		if subnetsErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to list IP subnets of space: %s (%s)\n", space, subnetsErr)
+		}
+
+		blocks := []ipRange{}
+		ranges := []ipRange{}
+
+		for _, subnet := range subnets {
+			start, end, rangeErr := iprangefromhex(infostring(subnet, "start_ip_addr"), infostring(subnet, "end_ip_addr"))
+
+			if rangeErr != nil {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Ignoring IP subnet: %s (%s)\n", infostring(subnet, "subnet_name"), rangeErr))
+				continue
+			}
+
+			r := ipRange{
+				start:    start,
+				end:      end,
+				name:     infostring(subnet, "subnet_name"),
+				cidr:     hexiptoip(infostring(subnet, "start_ip_addr")) + "/" + strconv.Itoa(sizetoprefixlength(int(end-start+1))),
+				terminal: infostring(subnet, "is_terminal") == "1",
+			}
+
+			if infostring(subnet, "subnet_level") == "0" {
+				blocks = append(blocks, r)
+			} else {
+				ranges = append(ranges, r)
+			}
+		}
+
+		blocksBySpace[space] = blocks
+		subnetsBySpace[space] = newiprangeindex(ranges)
+	}
+
+	result := make([]interface{}, 0, len(entries))
+	valid := []interface{}{}
+	planned := map[string][]ipRange{}
+
+	for i, entry := range entries {
+		terminal := entry.Terminal == nil || *entry.Terminal
+		classParameters := map[string]interface{}{}
+
+		for ck, cv := range entry.ClassParameters {
+			classParameters[ck] = cv
+		}
+
+		status, reason, prefixSize := "invalid", "", 0
+		start, end, rangeErr := iprangefromcidr(entry.CIDR)
+
+		if rangeErr == nil {
+			_, network, _ := net.ParseCIDR(entry.CIDR)
+			prefixSize, _ = network.Mask.Size()
+		}
+
+		// Looking for the block of the IP subnet among the ones sharing its name
+		space, spaceExist := spacesByKey[strings.ToLower(entry.Space)]
+		blockNames := []string{}
+		blockCIDRs := []string{}
+		blockExist := false
+
+		if !spaceExist {
+			space = entry.Space
+		}
+
+		for _, block := range blocksBySpace[space] {
+			if stringOffsetInSlice(block.name, blockNames) < 0 {
+				blockNames = append(blockNames, block.name)
+			}
+
+			if block.name == entry.Block {
+				blockCIDRs = append(blockCIDRs, block.cidr)
+				blockExist = blockExist || (block.start <= start && end <= block.end)
+			}
+		}
+
+		// Subnets within a non-terminal subnet are not overlapping it
+		enclosed := func(r ipRange) bool {
+			return r.encloses(start, end)
+		}
+
+		switch {
+		case entry.Space == "" || entry.Block == "" || entry.Name == "" || entry.CIDR == "":
+			reason = "space, block, name and cidr are required"
+		case rangeErr != nil:
+			reason = rangeErr.Error()
+		case !spaceExist:
+			reason = notfounderror("IP space", entry.Space, "SOLIDserver", spaceNames).Error()
+		case len(blockCIDRs) == 0:
+			sort.Strings(blockNames)
+			reason = notfounderror("IP block", entry.Block, "space '"+space+"'", blockNames).Error()
+		case !blockExist:
+			reason = fmt.Sprintf("%s is not within block %s (%s)", entry.CIDR, entry.Block, strings.Join(blockCIDRs, ", "))
+		default:
+			if existing, exist := subnetsBySpace[space].exact(start, end); exist && strings.EqualFold(existing.name, entry.Name) {
+				status = "exists"
+				break
+			}
+
+			if existing, overlap := subnetsBySpace[space].overlapping(start, end, enclosed); overlap {
+				reason = fmt.Sprintf("%s overlaps the existing IP subnet %s (%s)", entry.CIDR, existing.name, existing.cidr)
+				break
+			}
+
+			// The subnets of the document must not overlap each other either
+			for _, other := range planned[space] {
+				if start <= other.end && other.start <= end && !enclosed(other) {
+					reason = fmt.Sprintf("%s overlaps the IP subnet %s (%s) of the document", entry.CIDR, other.name, other.cidr)
+					break
+				}
+			}
+
+			if reason == "" {
+				status = "valid"
+				planned[space] = append(planned[space], ipRange{start: start, end: end, name: entry.Name, cidr: entry.CIDR, terminal: terminal})
+			}
+		}
+
+		subnet := map[string]interface{}{
+			"space":            space,
+			"block":            entry.Block,
+			"name":             entry.Name,
+			"cidr":             entry.CIDR,
+			"prefix_size":      prefixSize,
+			"terminal":         terminal,
+			"class_parameters": classParameters,
+		}
+
+		if status == "valid" {
+			valid = append(valid, subnet)
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("IP subnet #%d of the subnet plan is %s: %s %s\n", i, status, entry.Name, reason))
+		}
+
+		planEntry := map[string]interface{}{"status": status, "reason": reason}
+
+		for k, v := range subnet {
+			planEntry[k] = v
+		}
+
+		result = append(result, planEntry)
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(document))))
+	d.Set("entries", result)
+	d.Set("valid_subnets", valid)
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIPRangeIndex(t *testing.T) {
+	// A large range followed by small ones, the large one must still be found past them
+	index := newiprangeindex([]ipRange{
+		{start: 300, end: 310, name: "c"},
+		{start: 100, end: 1000, name: "a"},
+		{start: 200, end: 210, name: "b"},
+		{start: 2000, end: 2100, name: "d"},
+	})
+
+	cases := []struct {
+		start   uint32
+		end     uint32
+		overlap string
+	}{
+		{start: 0, end: 99},
+		{start: 0, end: 100, overlap: "a"},
+		{start: 205, end: 205, overlap: "b"},
+		{start: 400, end: 500, overlap: "a"},
+		{start: 1001, end: 1999},
+		{start: 1500, end: 2000, overlap: "d"},
+		{start: 2101, end: 4000},
+	}
+
+	for _, c := range cases {
+		r, overlap := index.overlapping(c.start, c.end, nil)
+
+		if overlap != (c.overlap != "") || r.name != c.overlap {
+			t.Errorf("%d-%d: expected overlap %q, got %q", c.start, c.end, c.overlap, r.name)
+		}
+	}
+
+	if _, exist := index.exact(200, 210); !exist {
+		t.Errorf("expected 200-210 to be found")
+	}
+
+	if _, exist := index.exact(200, 211); exist {
+		t.Errorf("unexpected range 200-211")
+	}
+}
+
+func TestDataSourceSubnetPlan(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	subnet := func(name string, level int, start uint32, size uint32, terminal string) map[string]interface{} {
+		return map[string]interface{}{
+			"subnet_name":   name,
+			"subnet_level":  strconv.Itoa(level),
+			"start_ip_addr": fmt.Sprintf("%08x", start),
+			"end_ip_addr":   fmt.Sprintf("%08x", start+size-1),
+			"is_terminal":   terminal,
+		}
+	}
+
+	// 2000 existing subnets of a block, over several pages
+	// Along with a non-terminal subnet and a second block with the same name
+	rows := []map[string]interface{}{
+		subnet("dc1", 0, 0x0a000000, 1<<16, "0"),
+		subnet("dc1", 0, 0x0a020000, 1<<16, "0"),
+		subnet("pool", 1, 0x0a00c800, 1<<10, "0"),
+	}
+
+	for i := 0; i < 2000; i++ {
+		rows = append(rows, subnet(fmt.Sprintf("app-%04d", i), 1, 0x0a000000+uint32(i)*16, 16, "1"))
+	}
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "0" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_name": "mySpace"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", mockSubnetsList("mySpace", rows))
+
+	document := `[
+		{"space": "mySpace", "block": "dc1", "name": "new-01", "cidr": "10.0.128.0/24", "class_parameters": {"vnid": "12"}},
+		{"space": "mySpace", "block": "dc1", "name": "app-0001", "cidr": "10.0.0.16/28"},
+		{"space": "mySpace", "block": "dc1", "name": "new-02", "cidr": "10.0.124.0/22", "terminal": false},
+		{"space": "mySpace", "block": "dc1", "name": "new-03", "cidr": "10.0.128.128/25"},
+		{"space": "mySpace", "block": "dc1", "name": "new-04", "cidr": "10.1.0.0/24"},
+		{"space": "mySpace", "block": "dc2", "name": "new-05", "cidr": "10.0.129.0/24"},
+		{"space": "mySpaces", "block": "dc1", "name": "new-06", "cidr": "10.0.129.0/24"},
+		{"space": "mySpace", "block": "dc1", "name": "new-07", "cidr": "10.0.129.1/24"},
+		{"space": "mySpace", "block": "dc1", "name": "new-08", "cidr": "10.0.130.0/23"},
+		{"space": "mySpace", "block": "dc1", "name": "new-09", "cidr": "10.2.1.0/24"},
+		{"space": "mySpace", "block": "dc1", "name": "new-10", "cidr": "10.0.201.0/24"},
+		{"space": "mySpace", "block": "dc1", "name": "new-11", "cidr": "10.0.0.32/29"},
+		{"space": "MYSPACE", "block": "dc1", "name": "new-12", "cidr": "10.0.140.0/24"},
+		{"space": "mySpace", "block": "dc1", "name": "new-13", "cidr": "10.0.132.0/22", "terminal": false},
+		{"space": "mySpace", "block": "dc1", "name": "new-14", "cidr": "10.0.133.0/24"},
+		{"space": "mySpace", "block": "dc1", "name": "new-15", "cidr": "10.0.133.0/25"},
+		{"space": "mySpace", "block": "dc1", "name": "new-16", "cidr": "10.0.134.0/24"}
+	]`
+
+	d := schema.TestResourceDataRaw(t, dataSourcesubnetplan().Schema, map[string]interface{}{
+		"document": document,
+	})
+
+	if diags := dataSourcesubnetplanRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []struct {
+		status string
+		reason string
+	}{
+		{status: "valid"},
+		{status: "exists"},
+		{status: "invalid", reason: "overlaps the existing IP subnet app-1999 (10.0.124.240/28)"},
+		{status: "invalid", reason: "overlaps the IP subnet new-01 (10.0.128.0/24) of the document"},
+		{status: "invalid", reason: "is not within block dc1 (10.0.0.0/16, 10.2.0.0/16)"},
+		{status: "invalid", reason: "IP block 'dc2' not found on space 'mySpace' (did you mean 'dc1'?)"},
+		{status: "invalid", reason: "IP space 'mySpaces' not found on SOLIDserver (did you mean 'mySpace'?)"},
+		{status: "invalid", reason: "is not a network address (ex: 10.0.129.0/24)"},
+		{status: "valid"},
+		{status: "valid"},
+		{status: "valid"},
+		{status: "invalid", reason: "overlaps the existing IP subnet app-0002 (10.0.0.32/28)"},
+		{status: "valid"},
+		{status: "valid"},
+		{status: "valid"},
+		{status: "invalid", reason: "overlaps the IP subnet new-14 (10.0.133.0/24) of the document"},
+		{status: "valid"},
+	}
+
+	entries := d.Get("entries").([]interface{})
+
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+
+	for i, e := range expected {
+		entry := entries[i].(map[string]interface{})
+
+		if entry["status"].(string) != e.status || !strings.Contains(entry["reason"].(string), e.reason) {
+			t.Errorf("entry #%d: expected %s (%s), got %s (%s)", i, e.status, e.reason, entry["status"], entry["reason"])
+		}
+	}
+
+	valid := d.Get("valid_subnets").([]interface{})
+
+	if len(valid) != 8 {
+		t.Fatalf("expected 8 valid subnets, got %d", len(valid))
+	}
+
+	first := valid[0].(map[string]interface{})
+
+	if first["name"] != "new-01" || first["prefix_size"] != 24 || first["terminal"] != true || first["class_parameters"].(map[string]interface{})["vnid"] != "12" {
+		t.Errorf("unexpected valid subnet: %v", first)
+	}
+
+	// The space is reported with its name on SOLIDserver
+	if space := valid[4].(map[string]interface{})["space"]; space != "mySpace" {
+		t.Errorf("unexpected space of new-12: %v", space)
+	}
+
+	// The subnets of the space are listed once
+	if m.count("/rest/ip_site_list") != 1 || m.count("/rest/ip_block_subnet_list") != 3 {
+		t.Errorf("unexpected number of requests: %d spaces, %d subnets", m.count("/rest/ip_site_list"), m.count("/rest/ip_block_subnet_list"))
+	}
+}
@@ -0,0 +1,224 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+)
+
+// Objects types looked up by the unmanaged scan data-source along with the columns describing them
+var unmanagedScanTypes = []struct {
+	objectType      string
+	service         string
+	classParamsName string
+	idName          string
+	nameName        string
+	resourceHint    string
+	dns             bool
+}{
+	{"ip_subnet", "rest/ip_block_subnet_list", "subnet_class_parameters", "subnet_id", "subnet_name", "solidserver_ip_subnet", false},
+	{"ip_pool", "rest/ip_pool_list", "pool_class_parameters", "pool_id", "pool_name", "solidserver_ip_pool", false},
+	{"ip_address", "rest/ip_address_list", "ip_class_parameters", "ip_id", "name", "solidserver_ip_address", false},
+	{"dns_zone", "rest/dns_zone_list", "dnszone_class_parameters", "dnszone_id", "dnszone_name", "solidserver_dns_zone", true},
+	{"dns_rr", "rest/dns_rr_list", "rr_class_parameters", "rr_id", "rr_full_name", "solidserver_dns_rr", true},
+}
+
+func dataSourceunmanagedscan() *schema.Resource {
+	objectTypes := []string{}
+	for _, objectType := range unmanagedScanTypes {
+		objectTypes = append(objectTypes, objectType.objectType)
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceunmanagedscanRead,
+
+		Description: heredoc.Doc(`
+			Unmanaged scan data-source allows to list the subnets, pools, addresses, zones and RRs
+			that are not tagged with the 'terraform_workspace' class parameter, along with the ID to import them.
+			Combined with import blocks, it allows to adopt the existing objects of a SOLIDserver.
+			Large spaces can be scanned in several passes using the limit and offset arguments.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space to scan.",
+				Required:    true,
+			},
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The name of the DNS server to scan, DNS zones and RRs are only scanned when set.",
+				Optional:    true,
+				Default:     "",
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Description: "The types of objects to scan (Supported: ip_subnet, ip_pool, ip_address, dns_zone, dns_rr; Default: all).",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(objectTypes, false),
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of objects to return (Default: 1000).",
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"offset": {
+				Type:         schema.TypeInt,
+				Description:  "The number of unmanaged objects to skip before returning any, to scan large spaces in several passes (Default: 0).",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"truncated": {
+				Type:        schema.TypeBool,
+				Description: "Whether more unmanaged objects remain beyond the limit.",
+				Computed:    true,
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Description: "The list of unmanaged objects.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The type of the object (ip_subnet, ip_pool, ip_address, dns_zone or dns_rr).",
+							Computed:    true,
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the object.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the object.",
+							Computed:    true,
+						},
+						"resource_hint": {
+							Type:        schema.TypeString,
+							Description: "The Terraform resource type used to manage the object.",
+							Computed:    true,
+						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Description: "The ID to use to import the object.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Return the WHERE clause listing the objects of a given type within the scanned space or DNS server
+func unmanagedscanwhere(objectType string, spaceName string, serverName string) string {
+	switch objectType {
+	case "dns_zone", "dns_rr":
+		return "dns_name='" + whereescape(serverName) + "'"
+	default:
+		return "site_name='" + whereescape(spaceName) + "'"
+	}
+}
+
+// Return the ID to use to import an object, using the composite formats supported by the importers when available
+func unmanagedscanimportid(objectType string, objectID string, object map[string]interface{}) string {
+	switch objectType {
+	case "ip_pool":
+		return importidescape(infostring(object, "site_name")) + "/" + importidescape(infostring(object, "subnet_name")) + "/" + importidescape(infostring(object, "pool_name"))
+	case "dns_zone":
+		view := infostring(object, "dnsview_name")
+		if view == "" {
+			view = "#"
+		}
+		return importidescape(infostring(object, "dns_name")) + "/" + importidescape(view) + "/" + importidescape(infostring(object, "dnszone_name"))
+	default:
+		return objectID
+	}
+}
+
+func dataSourceunmanagedscanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	spaceName := d.Get("space").(string)
+	serverName := d.Get("dnsserver").(string)
+	limit := d.Get("limit").(int)
+	skip := d.Get("offset").(int)
+
+	scannedTypes := map[string]bool{}
+	for _, objectType := range toStringArray(d.Get("types").([]interface{})) {
+		scannedTypes[objectType] = true
+	}
+
+	objects := []interface{}{}
+	truncated := false
+
+	for _, objectType := range unmanagedScanTypes {
+		if (len(scannedTypes) > 0 && !scannedTypes[objectType.objectType]) || (objectType.dns && serverName == "") || truncated {
+			continue
+		}
+
+		whereClause := unmanagedscanwhere(objectType.objectType, spaceName, serverName)
+		classParamsSupported := objectType.objectType != "dns_rr" || s.Version >= versionRRClassParameters
+
+		// Class parameters are stored URL encoded, discard the objects holding the key
+		if classParamsSupported {
+			whereClause += " AND " + objectType.classParamsName + " NOT LIKE '%" + managedObjectsWorkspaceParameter + "=%'"
+		}
+
+		err := listeach(objectType.service, whereClause, meta, func(object map[string]interface{}) bool {
+			if classParamsSupported {
+				retrievedClassParameters, _ := url.ParseQuery(infostring(object, objectType.classParamsName))
+
+				if retrievedClassParameters.Has(managedObjectsWorkspaceParameter) {
+					return true
+				}
+			}
+
+			if skip > 0 {
+				skip--
+				return true
+			}
+
+			if len(objects) >= limit {
+				truncated = true
+				return false
+			}
+
+			objectID := infostring(object, objectType.idName)
+
+			objects = append(objects, map[string]interface{}{
+				"type":          objectType.objectType,
+				"id":            objectID,
+				"name":          infostring(object, objectType.nameName),
+				"resource_hint": objectType.resourceHint,
+				"import_id":     unmanagedscanimportid(objectType.objectType, objectID, object),
+			})
+
+			return true
+		})
+
+		if err != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to list unmanaged objects of type: %s (%s)", objectType.objectType, err)
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d unmanaged object(s) within space: %s\n", len(objects), spaceName))
+
+	d.SetId(spaceName)
+	d.Set("objects", objects)
+	d.Set("truncated", truncated)
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceUnmanagedScan(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// 2500 addresses, every other one being managed by a workspace
+	m.handle("/rest/ip_address_list", func(w http.ResponseWriter, r *http.Request) {
+		if where := r.URL.Query().Get("WHERE"); where != "site_name='O''Brien' AND ip_class_parameters NOT LIKE '%terraform_workspace=%'" {
+			t.Errorf("unexpected WHERE clause: %s", where)
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		rows := []map[string]interface{}{}
+
+		for i := offset; i < 2500 && i < offset+listPageSize; i++ {
+			classParameters := ""
+			if i%2 == 1 {
+				classParameters = "terraform_workspace=prod"
+			}
+			rows = append(rows, map[string]interface{}{"ip_id": strconv.Itoa(i), "name": "host" + strconv.Itoa(i), "ip_class_parameters": classParameters})
+		}
+
+		mockReply(w, http.StatusOK, rows)
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"subnet_id": "12", "subnet_name": "10.4.12.0/22 - Prod East", "subnet_class_parameters": "owner=netops"},
+		})
+	})
+
+	m.handle("/rest/ip_pool_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"pool_id": "7", "pool_name": "dhcp", "site_name": "O'Brien", "subnet_name": "10.4.12.0/22 - Prod East", "pool_class_parameters": ""},
+		})
+	})
+
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "dns_name='ns.example.com' AND dnszone_class_parameters NOT LIKE '%terraform_workspace=%'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"dnszone_id": "3", "dnszone_name": "0/25.1.168.192.in-addr.arpa", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_class_parameters": ""},
+		})
+	})
+
+	// Space objects only
+	d := schema.TestResourceDataRaw(t, dataSourceunmanagedscan().Schema, map[string]interface{}{
+		"space": "O'Brien",
+		"types": []interface{}{"ip_subnet", "ip_pool", "dns_zone"},
+	})
+
+	if diags := dataSourceunmanagedscanRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	objects := d.Get("objects").([]interface{})
+	if len(objects) != 2 || d.Get("truncated").(bool) {
+		t.Fatalf("unexpected unmanaged objects: %v", objects)
+	}
+
+	if object := objects[0].(map[string]interface{}); object["type"] != "ip_subnet" || object["import_id"] != "12" || object["resource_hint"] != "solidserver_ip_subnet" {
+		t.Errorf("unexpected unmanaged subnet: %v", object)
+	}
+
+	// Composite import IDs must be accepted by the importers
+	importID := objects[1].(map[string]interface{})["import_id"].(string)
+	if space, subnet, pool, composite := poolimportidsplit(importID); !composite || space != "O'Brien" || subnet != "10.4.12.0/22 - Prod East" || pool != "dhcp" {
+		t.Errorf("unexpected pool import ID: %s", importID)
+	}
+
+	if m.count("/rest/dns_zone_list") != 0 {
+		t.Errorf("expected DNS zones to be scanned only along with a DNS server")
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceunmanagedscan().Schema, map[string]interface{}{
+		"space":     "O'Brien",
+		"dnsserver": "ns.example.com",
+		"types":     []interface{}{"dns_zone"},
+	})
+
+	if diags := dataSourceunmanagedscanRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	importID = d.Get("objects.0.import_id").(string)
+	if parts := importidparse(importID, 3); len(parts) != 3 || parts[0] != "ns.example.com" || parts[1] != "#" || parts[2] != "0/25.1.168.192.in-addr.arpa" {
+		t.Errorf("unexpected zone import ID: %s", importID)
+	}
+
+	// Scanning the addresses in several passes
+	d = schema.TestResourceDataRaw(t, dataSourceunmanagedscan().Schema, map[string]interface{}{
+		"space":  "O'Brien",
+		"types":  []interface{}{"ip_address"},
+		"limit":  100,
+		"offset": 200,
+	})
+
+	if diags := dataSourceunmanagedscanRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("objects.#").(int) != 100 || d.Get("objects.0.id").(string) != "400" || !d.Get("truncated").(bool) {
+		t.Errorf("unexpected address scan: %d objects from %s (truncated: %t)", d.Get("objects.#").(int), d.Get("objects.0.id").(string), d.Get("truncated").(bool))
+	}
+
+	// Listing stops as soon as the limit is reached
+	if m.count("/rest/ip_address_list") != 1 {
+		t.Errorf("expected a single page to be fetched, got %d", m.count("/rest/ip_address_list"))
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceunmanagedscan().Schema, map[string]interface{}{
+		"space":  "O'Brien",
+		"types":  []interface{}{"ip_address"},
+		"limit":  1000,
+		"offset": 1000,
+	})
+
+	if diags := dataSourceunmanagedscanRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("objects.#").(int) != 250 || d.Get("truncated").(bool) || d.Get("objects.0.name").(string) != "host2000" {
+		t.Errorf("unexpected last address scan: %d objects (truncated: %t)", d.Get("objects.#").(int), d.Get("truncated").(bool))
+	}
+}
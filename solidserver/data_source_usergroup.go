@@ -36,7 +36,7 @@ func dataSourceusergroupRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "grp_name='"+d.Get("name").(string)+"'")
+	parameters.Add("WHERE", "grp_name='"+whereescape(d.Get("name").(string))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/group_admin_list", &parameters)
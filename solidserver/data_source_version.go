@@ -0,0 +1,57 @@
+package solidserver
+
+import (
+	"context"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+)
+
+func dataSourceversion() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceversionRead,
+
+		Description: heredoc.Doc(`
+			Version data-source allows to retrieve the version of the SOLIDserver detected by the provider,
+			along with the version dependent features it supports. It allows modules to branch on capabilities
+			instead of failing at apply time.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Description: "The version string of the SOLIDserver (ex: 8.3.1).",
+				Computed:    true,
+			},
+			"version_number": {
+				Type:        schema.TypeInt,
+				Description: "The numeric version of the SOLIDserver used by the provider (ex: 831).",
+				Computed:    true,
+			},
+			"features": {
+				Type:        schema.TypeMap,
+				Description: "The version dependent features supported by the SOLIDserver (vxlan_supported, gslb_supported, rr_class_parameters_supported).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeBool,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceversionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	d.SetId(strconv.Itoa(s.Version))
+	d.Set("version", s.VersionString)
+	d.Set("version_number", s.Version)
+	d.Set("features", map[string]bool{
+		"vxlan_supported":               s.Version >= versionVXLAN,
+		"gslb_supported":                s.Version >= versionGSLB,
+		"rr_class_parameters_supported": s.Version >= versionRRClassParameters,
+	})
+
+	return nil
+}
@@ -64,10 +64,10 @@ func dataSourcevlanRead(ctx context.Context, d *schema.ResourceData, meta interf
 	// Building parameters
 	parameters := url.Values{}
 
-	whereClause := "vlmdomain_name='" + d.Get("vlan_domain").(string) + "' AND vlmvlan_name='" + d.Get("name").(string) + "'"
+	whereClause := "vlmdomain_name='" + whereescape(d.Get("vlan_domain").(string)) + "' AND vlmvlan_name='" + whereescape(d.Get("name").(string)) + "'"
 
 	if vlanRange, ok := d.Get("vlan_range").(string); ok && vlanRange != "" {
-		whereClause += " AND vlmrange_name='" + vlanRange + "'"
+		whereClause += " AND vlmrange_name='" + whereescape(vlanRange) + "'"
 	}
 
 	parameters.Add("WHERE", whereClause)
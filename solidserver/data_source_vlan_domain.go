@@ -32,6 +32,21 @@ func dataSourcevlandomain() *schema.Resource {
 				Description: "Specify if the VLAN Domain is a VXLAN Domain.",
 				Computed:    true,
 			},
+			"vlan_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of VLANs used within the VLAN Domain.",
+				Computed:    true,
+			},
+			"free_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of free VLANs within the VLAN Domain.",
+				Computed:    true,
+			},
+			"total_capacity": {
+				Type:        schema.TypeInt,
+				Description: "The number of VLAN IDs within the range of the VLAN Domain.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the VLAN Domain.",
@@ -42,20 +57,20 @@ func dataSourcevlandomain() *schema.Resource {
 				Description: "The class parameters associated to VLAN Domain.",
 				Computed:    true,
 			},
+			"wait_for_existence": waitforexistenceschema("VLAN Domain"),
 		},
 	}
 }
 
 func dataSourcevlandomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s := meta.(*SOLIDserver)
 	d.SetId("")
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "vlmdomain_name='"+d.Get("name").(string)+"'")
+	parameters.Add("WHERE", "vlmdomain_name='"+whereescape(d.Get("name").(string))+"'")
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/vlmdomain_list", &parameters)
+	resp, body, err := waitforexistence(ctx, d, "rest/vlmdomain_list", &parameters, meta)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -76,6 +91,18 @@ func dataSourcevlandomainRead(ctx context.Context, d *schema.ResourceData, meta
 			d.Set("support_vxlan", vxlanSupport)
 			d.Set("class", buf[0]["vlmdomain_class_name"].(string))
 
+			// Updating the utilization of the domain
+			free, used, countErr := vlandomaincounts(buf[0]["vlmdomain_id"].(string), meta)
+
+			if countErr != nil {
+				// Reporting a failure
+				return diag.Errorf("Unable to retrieve the utilization of VLAN Domain: %s (%s)\n", d.Get("name").(string), countErr)
+			}
+
+			d.Set("vlan_count", used)
+			d.Set("free_count", free)
+			d.Set("total_capacity", vlandomaincapacity(buf[0], vxlanSupport))
+
 			// Updating local class_parameters
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["vlmdomain_class_parameters"].(string))
 			computedClassParameters := map[string]string{}
@@ -41,6 +41,21 @@ func dataSourcevlanrange() *schema.Resource {
 				Description: "The vlan range's higher vlan ID.",
 				Computed:    true,
 			},
+			"free_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of free VLANs within the VLAN Range.",
+				Computed:    true,
+			},
+			"used_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of used VLANs within the VLAN Range.",
+				Computed:    true,
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Description: "The total number of VLANs within the VLAN Range.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the VLAN Range.",
@@ -61,7 +76,7 @@ func dataSourcevlanrangeRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "vlmdomain_name='"+d.Get("vlan_domain").(string)+"' AND vlmrange_name='"+d.Get("name").(string)+"'")
+	parameters.Add("WHERE", "vlmdomain_name='"+whereescape(d.Get("vlan_domain").(string))+"' AND vlmrange_name='"+whereescape(d.Get("name").(string))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/vlmrange_list", &parameters)
@@ -82,6 +97,18 @@ func dataSourcevlanrangeRead(ctx context.Context, d *schema.ResourceData, meta i
 			d.Set("start", start)
 			d.Set("end", end)
 
+			// Updating the utilization of the range
+			free, used, total, countErr := vlanrangecounts(buf[0]["vlmrange_id"].(string), meta)
+
+			if countErr != nil {
+				// Reporting a failure
+				return diag.Errorf("Unable to retrieve the utilization of VLAN Range: %s (%s)\n", d.Get("name").(string), countErr)
+			}
+
+			d.Set("free_count", free)
+			d.Set("used_count", used)
+			d.Set("total_count", total)
+
 			d.Set("class", buf[0]["vlmrange_class_name"].(string))
 
 			// Updating local class_parameters
@@ -0,0 +1,59 @@
+package solidserver
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var hexipRegexp = regexp.MustCompile(`^([0-9a-fA-F]{8}|[0-9a-fA-F]{32})$`)
+
+type functionhextoip struct{}
+
+var _ function.Function = &functionhextoip{}
+
+func newFunctionhextoip() function.Function {
+	return &functionhextoip{}
+}
+
+func (f *functionhextoip) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hex_to_ip"
+}
+
+func (f *functionhextoip) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a SOLIDserver hexadecimal IP address into its standard representation",
+		Description: "Convert a SOLIDserver hexadecimal IP address (8 digits for IPv4, 32 digits for IPv6) into its standard representation, IPv6 addresses are returned compressed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "hex",
+				Description: "The hexadecimal IPv4 or IPv6 address to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionhextoip) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var hexip string
+
+	if resp.Error = req.Arguments.Get(ctx, &hexip); resp.Error != nil {
+		return
+	}
+
+	if !hexipRegexp.MatchString(hexip) {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid hexadecimal IP address: "+hexip)
+		return
+	}
+
+	res := ""
+
+	if len(hexip) == 8 {
+		res = hexiptoip(hexip)
+	} else {
+		res = longip6toshortip6(hexip6toip6(hexip))
+	}
+
+	resp.Error = resp.Result.Set(ctx, res)
+}
@@ -0,0 +1,51 @@
+package solidserver
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"inet.af/netaddr"
+)
+
+type functionip6compress struct{}
+
+var _ function.Function = &functionip6compress{}
+
+func newFunctionip6compress() function.Function {
+	return &functionip6compress{}
+}
+
+func (f *functionip6compress) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ip6_compress"
+}
+
+func (f *functionip6compress) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compress an IPv6 address",
+		Description: "Convert an IPv6 address into its compressed representation (e.g. 2001:db8::1).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ip",
+				Description: "The IPv6 address to compress.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionip6compress) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip string
+
+	if resp.Error = req.Arguments.Get(ctx, &ip); resp.Error != nil {
+		return
+	}
+
+	addr, err := netaddr.ParseIP(ip)
+
+	if err != nil || !addr.Is6() || addr.Zone() != "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid IPv6 address: "+ip)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, longip6toshortip6(addr.String()))
+}
@@ -0,0 +1,51 @@
+package solidserver
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"inet.af/netaddr"
+)
+
+type functionip6expand struct{}
+
+var _ function.Function = &functionip6expand{}
+
+func newFunctionip6expand() function.Function {
+	return &functionip6expand{}
+}
+
+func (f *functionip6expand) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ip6_expand"
+}
+
+func (f *functionip6expand) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Expand an IPv6 address",
+		Description: "Convert an IPv6 address into its fully expanded representation (e.g. 2001:0db8:0000:0000:0000:0000:0000:0001).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ip",
+				Description: "The IPv6 address to expand.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionip6expand) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip string
+
+	if resp.Error = req.Arguments.Get(ctx, &ip); resp.Error != nil {
+		return
+	}
+
+	addr, err := netaddr.ParseIP(ip)
+
+	if err != nil || !addr.Is6() || addr.Zone() != "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid IPv6 address: "+ip)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, shortip6tolongip6(addr.String()))
+}
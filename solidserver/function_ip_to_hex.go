@@ -0,0 +1,59 @@
+package solidserver
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"inet.af/netaddr"
+)
+
+type functioniptohex struct{}
+
+var _ function.Function = &functioniptohex{}
+
+func newFunctioniptohex() function.Function {
+	return &functioniptohex{}
+}
+
+func (f *functioniptohex) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ip_to_hex"
+}
+
+func (f *functioniptohex) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert an IP address into its SOLIDserver hexadecimal representation",
+		Description: "Convert an IPv4 or IPv6 address into its SOLIDserver hexadecimal representation (8 digits for IPv4, 32 digits for IPv6).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ip",
+				Description: "The IPv4 or IPv6 address to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functioniptohex) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip string
+
+	if resp.Error = req.Arguments.Get(ctx, &ip); resp.Error != nil {
+		return
+	}
+
+	addr, err := netaddr.ParseIP(ip)
+
+	if err != nil || addr.Zone() != "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid IP address: "+ip)
+		return
+	}
+
+	res := ""
+
+	if addr.Is4() {
+		res = iptohexip(addr.String())
+	} else {
+		res = ip6tohexip6(shortip6tolongip6(addr.String()))
+	}
+
+	resp.Error = resp.Result.Set(ctx, res)
+}
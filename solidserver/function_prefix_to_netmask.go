@@ -0,0 +1,49 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type functionprefixtonetmask struct{}
+
+var _ function.Function = &functionprefixtonetmask{}
+
+func newFunctionprefixtonetmask() function.Function {
+	return &functionprefixtonetmask{}
+}
+
+func (f *functionprefixtonetmask) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "prefix_to_netmask"
+}
+
+func (f *functionprefixtonetmask) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert an IPv4 prefix length into a netmask",
+		Description: "Convert an IPv4 prefix length (0 to 32) into its dotted decimal netmask (e.g. 24 into 255.255.255.0).",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:        "prefix_length",
+				Description: "The IPv4 prefix length to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionprefixtonetmask) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var length int64
+
+	if resp.Error = req.Arguments.Get(ctx, &length); resp.Error != nil {
+		return
+	}
+
+	if length < 0 || length > 32 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid IPv4 prefix length: %d, expected a value between 0 and 32", length))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, prefixlengthtohexip(int(length)))
+}
@@ -0,0 +1,59 @@
+package solidserver
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"inet.af/netaddr"
+)
+
+type functionptrname struct{}
+
+var _ function.Function = &functionptrname{}
+
+func newFunctionptrname() function.Function {
+	return &functionptrname{}
+}
+
+func (f *functionptrname) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ptr_name"
+}
+
+func (f *functionptrname) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compute the PTR record name of an IP address",
+		Description: "Compute the PTR record name of an IPv4 or IPv6 address (e.g. 10.0.0.1 into 1.0.0.10.in-addr.arpa).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ip",
+				Description: "The IPv4 or IPv6 address.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionptrname) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip string
+
+	if resp.Error = req.Arguments.Get(ctx, &ip); resp.Error != nil {
+		return
+	}
+
+	addr, err := netaddr.ParseIP(ip)
+
+	if err != nil || addr.Zone() != "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid IP address: "+ip)
+		return
+	}
+
+	res := ""
+
+	if addr.Is4() {
+		res = iptoptr(addr.String())
+	} else {
+		res = ip6toptr(addr.String())
+	}
+
+	resp.Error = resp.Result.Set(ctx, res)
+}
@@ -0,0 +1,384 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestImportIDParse(t *testing.T) {
+	if parts := importidparse("42", 3); parts != nil {
+		t.Errorf("oid parsed as a composite ID: %v", parts)
+	}
+
+	if parts := importidparse("ns01/internal/example.com", 3); len(parts) != 3 || parts[2] != "example.com" {
+		t.Errorf("unexpected parts: %v", parts)
+	}
+
+	if parts := importidparse("ns01/#/0/25.1.168.192.in-addr.arpa", 3); len(parts) != 3 || parts[2] != "0/25.1.168.192.in-addr.arpa" {
+		t.Errorf("unexpected parts: %v", parts)
+	}
+
+	if parts := importidparse("ns01.example.com:internal:example.com", 3); len(parts) != 3 || parts[0] != "ns01.example.com" || parts[1] != "internal" || parts[2] != "example.com" {
+		t.Errorf("unexpected parts: %v", parts)
+	}
+
+	if parts := importidparse("ns01:#:0/25.1.168.192.in-addr.arpa", 3); len(parts) != 3 || parts[1] != "#" || parts[2] != "0/25.1.168.192.in-addr.arpa" {
+		t.Errorf("unexpected parts: %v", parts)
+	}
+}
+
+func TestImportIDUnescape(t *testing.T) {
+	// Reserved characters can be URL-style escaped and round-trip
+	for _, name := range []string{"10.4.12.0/22 - Prod East", "O'Brien's lab", "R&D", " padded ", "a:b", "100%"} {
+		escaped := importidescape(name)
+		id := "ns01/" + escaped + "/" + escaped
+
+		if parts := importidparse(id, 3); len(parts) != 3 || parts[1] != name || parts[2] != name {
+			t.Errorf("unexpected parts of %s: %q", id, parts)
+		}
+
+		id = "local/" + escaped + "/" + escaped
+
+		if space, subnet, pool, composite := poolimportidsplit(id); !composite || space != "local" || subnet != name || pool != name {
+			t.Errorf("unexpected split of %s: %q, %q, %q", id, space, subnet, pool)
+		}
+	}
+
+	// Invalid escape sequences are kept as is
+	if parts := importidparse("ns01/100%/example.com", 3); len(parts) != 3 || parts[1] != "100%" {
+		t.Errorf("unexpected parts: %q", parts)
+	}
+}
+
+func TestDNSViewImportComposite(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("WHERE"), "dnsview_name='internal'") {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"dnsview_id": "4", "dnsview_name": "internal"}})
+			return
+		}
+
+		if strings.Contains(r.URL.Query().Get("WHERE"), "dnsview_name=") {
+			mockReply(w, http.StatusOK, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"dnsview_id": "4", "dnsview_name": "internal"},
+			{"dnsview_id": "5", "dnsview_name": "external"},
+		})
+	})
+
+	m.handle("/rest/dns_view_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "stop"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednsview().Schema, map[string]interface{}{})
+	d.SetId("ns01/internal")
+	resourcednsviewImportState(context.Background(), d, s)
+
+	if d.Id() != "4" {
+		t.Errorf("expected view oid 4, got %s", d.Id())
+	}
+
+	d.SetId("ns01/internl")
+	_, err := resourcednsviewImportState(context.Background(), d, s)
+
+	if err == nil || !strings.Contains(err.Error(), "candidates: internal, external") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDNSZoneImportComposite(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") == "dns_name='ns01' AND dnszone_name='example.com' AND dnsview_name='internal'" {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"dnszone_id": "7", "dnszone_name": "example.com"}})
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"dnszone_id": "7", "dnszone_name": "example.com"},
+			{"dnszone_id": "8", "dnszone_name": "example.com"},
+		})
+	})
+
+	m.handle("/rest/dns_zone_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "stop"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednszone().Schema, map[string]interface{}{})
+	d.SetId("ns01/internal/example.com")
+	resourcednszoneImportState(context.Background(), d, s)
+
+	if d.Id() != "7" {
+		t.Errorf("expected zone oid 7, got %s", d.Id())
+	}
+
+	d.SetId("ns01:internal:example.com")
+	resourcednszoneImportState(context.Background(), d, s)
+
+	if d.Id() != "7" {
+		t.Errorf("expected zone oid 7, got %s", d.Id())
+	}
+
+	d.SetId("ns01/example.com")
+	_, err := resourcednszoneImportState(context.Background(), d, s)
+
+	if err == nil || !strings.Contains(err.Error(), "Multiple DNS zones") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestIPPoolImportComposite(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "local"}})
+	})
+
+	m.handle("/rest/ip_pool_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "site_id='2' AND pool_name='pool01' AND subnet_name='subnet01'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"pool_id": "12", "pool_name": "pool01", "pool_size": "16"}})
+	})
+
+	m.handle("/rest/ip_pool_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"pool_id":               r.URL.Query().Get("pool_id"),
+			"pool_name":             "pool01",
+			"pool_class_name":       "",
+			"pool_class_parameters": "",
+			"site_name":             "local",
+			"subnet_name":           "subnet01",
+			"start_ip_addr":         "0a000010",
+			"pool_size":             "16",
+		}})
+	})
+
+	m.handle("/rest/ip_used_address_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id":     "5",
+			"subnet_name":   "subnet01",
+			"subnet_size":   "256",
+			"start_ip_addr": "0a000000",
+			"end_ip_addr":   "0a0000ff",
+		}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceippool().Schema, map[string]interface{}{})
+	d.SetId("local:subnet01:pool01")
+
+	if _, err := resourceippoolImportState(context.Background(), d, s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d.Id() != "12" || d.Get("space").(string) != "local" || d.Get("subnet").(string) != "subnet01" || d.Get("start").(string) != "10.0.0.16" || d.Get("size").(int) != 16 {
+		t.Fatalf("unexpected imported IP pool: %v", d.State().Attributes)
+	}
+
+	if diags := resourceippoolRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("prefix").(string) != "10.0.0.0/24" || d.Get("prefix_size").(int) != 24 {
+		t.Errorf("unexpected prefix: %s (%d)", d.Get("prefix").(string), d.Get("prefix_size").(int))
+	}
+
+	// Falling back to the oid
+	d.SetId("12")
+	if _, err := resourceippoolImportState(context.Background(), d, s); err != nil || d.Id() != "12" {
+		t.Errorf("unexpected import by oid: %s (%v)", d.Id(), err)
+	}
+}
+
+func TestPoolImportIDSplit(t *testing.T) {
+	if _, _, _, composite := poolimportidsplit("42"); composite {
+		t.Errorf("oid split as a composite ID")
+	}
+
+	if _, _, _, composite := poolimportidsplit("local//pool01"); composite {
+		t.Errorf("empty subnet name split as a composite ID")
+	}
+
+	if space, subnet, pool, composite := poolimportidsplit("local/10.0.0.0/24/pool01"); !composite || space != "local" || subnet != "10.0.0.0/24" || pool != "pool01" {
+		t.Errorf("unexpected split: %s, %s, %s", space, subnet, pool)
+	}
+
+	if space, subnet, pool, composite := poolimportidsplit("local:subnet%3A01:pool01"); !composite || space != "local" || subnet != "subnet:01" || pool != "pool01" {
+		t.Errorf("unexpected split: %s, %s, %s", space, subnet, pool)
+	}
+
+	if space, subnet, pool, composite := poolimportidsplit("local/2001:db8::/64/pool01"); !composite || space != "local" || subnet != "2001:db8::/64" || pool != "pool01" {
+		t.Errorf("unexpected split: %s, %s, %s", space, subnet, pool)
+	}
+}
+
+func TestIPPoolImportSlashComposite(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "local"}})
+	})
+
+	m.handle("/rest/ip_pool_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "site_id='2' AND pool_name='pool01' AND subnet_name='10.0.0.0/24'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"pool_id": "12", "pool_name": "pool01", "pool_size": "16"}})
+	})
+
+	m.handle("/rest/ip_pool_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"pool_id":               r.URL.Query().Get("pool_id"),
+			"pool_name":             "pool01",
+			"pool_class_name":       "",
+			"pool_class_parameters": "",
+			"site_name":             "local",
+			"subnet_name":           "10.0.0.0/24",
+			"start_ip_addr":         "0a000010",
+			"pool_size":             "16",
+		}})
+	})
+
+	m.handle("/rest/ip_used_address_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceippool().Schema, map[string]interface{}{})
+	d.SetId("local/10.0.0.0/24/pool01")
+
+	if _, err := resourceippoolImportState(context.Background(), d, s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d.Id() != "12" || d.Get("subnet").(string) != "10.0.0.0/24" || d.Get("start").(string) != "10.0.0.16" || d.Get("size").(int) != 16 {
+		t.Fatalf("unexpected imported IP pool: %v", d.State().Attributes)
+	}
+
+	// Read populates the boundaries of a pool imported by oid, keeping the local case of the names
+	d = schema.TestResourceDataRaw(t, resourceippool().Schema, map[string]interface{}{"space": "LOCAL"})
+	d.SetId("12")
+
+	if diags := resourceippoolRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("space").(string) != "LOCAL" || d.Get("subnet").(string) != "10.0.0.0/24" || d.Get("start").(string) != "10.0.0.16" || d.Get("size").(int) != 16 {
+		t.Errorf("unexpected IP pool: %v", d.State().Attributes)
+	}
+}
+
+func TestIP6PoolImportComposite(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "local"}})
+	})
+
+	m.handle("/rest/ip6_pool6_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "site_id='2' AND pool6_name='pool01' AND subnet6_name='2001:db8::/64'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"pool6_id": "12", "pool6_name": "pool01"}})
+	})
+
+	m.handle("/rest/ip6_pool6_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"pool6_id":               r.URL.Query().Get("pool6_id"),
+			"pool6_name":             "pool01",
+			"pool6_class_name":       "",
+			"pool6_class_parameters": "",
+			"site_name":              "local",
+			"subnet6_name":           "2001:db8::/64",
+			"pool6_start_ip6_addr":   "20010db8000000000000000000000010",
+			"pool6_end_ip6_addr":     "20010db80000000000000000000000ff",
+		}})
+	})
+
+	m.handle("/rest/ip6_address6_count", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "0"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceip6pool().Schema, map[string]interface{}{})
+	d.SetId("local/2001:db8::/64/pool01")
+
+	if _, err := resourceip6poolImportState(context.Background(), d, s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d.Id() != "12" || d.Get("space").(string) != "local" || d.Get("subnet").(string) != "2001:db8::/64" || d.Get("start").(string) != "2001:db8::10" || d.Get("end").(string) != "2001:db8::ff" {
+		t.Fatalf("unexpected imported IPv6 pool: %v", d.State().Attributes)
+	}
+
+	if diags := resourceip6poolRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("start").(string) != "2001:db8::10" || d.Get("end").(string) != "2001:db8::ff" {
+		t.Errorf("unexpected IPv6 pool boundaries: %v", d.State().Attributes)
+	}
+
+	// Unknown pools are reported as an error
+	d.SetId("local/2001:db8::/64/pool02")
+
+	m.handle("/rest/ip6_pool6_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	if _, err := resourceip6poolImportState(context.Background(), d, s); err == nil {
+		t.Errorf("expected an error importing an unknown IPv6 pool")
+	}
+}
+
+func TestUserImport(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// user_admin_info doesn't return the fields unknown to the appliance (ex: usr_class_parameters)
+	m.handle("/rest/user_admin_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"usr_id":        "9",
+			"usr_login":     "jdoe",
+			"usr_fname":     "John",
+			"usr_auth_mode": "LOCAL",
+		}})
+	})
+
+	m.handle("/rest/user_admin_group_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("usr_id") != "9" {
+			t.Errorf("unexpected user: %s", r.URL.Query().Get("usr_id"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"grp_name": "admins"}, {"grp_name": "operators"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceuser().Schema, map[string]interface{}{})
+	d.SetId("9")
+
+	imported, err := resourceuserImportState(context.Background(), d, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if imported[0].Get("login").(string) != "jdoe" || imported[0].Get("first_name").(string) != "John" || imported[0].Get("last_name").(string) != "" || imported[0].Get("account_type").(string) != "local" {
+		t.Errorf("unexpected imported user: %v", imported[0].State())
+	}
+
+	if groups := imported[0].Get("groups").(*schema.Set); groups.Len() != 2 || !groups.Contains("admins") || !groups.Contains("operators") {
+		t.Errorf("unexpected imported groups: %v", groups.List())
+	}
+
+	if m.count("/rest/user_info") != 0 {
+		t.Errorf("unexpected use of user_info")
+	}
+}
@@ -0,0 +1,107 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mockSOLIDserver is a minimal SOLIDserver REST API emulation for unit tests
+type mockSOLIDserver struct {
+	mutex    sync.Mutex
+	server   *httptest.Server
+	handlers map[string]http.HandlerFunc
+	calls    map[string]int
+}
+
+// Start a mock SOLIDserver and return it along with a configured provider client
+func newMockSOLIDserver(t *testing.T) (*mockSOLIDserver, *SOLIDserver) {
+	m := &mockSOLIDserver{
+		handlers: make(map[string]http.HandlerFunc),
+		calls:    make(map[string]int),
+	}
+
+	m.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mutex.Lock()
+		m.calls[r.URL.Path]++
+		handler, handlerExist := m.handlers[r.URL.Path]
+		m.mutex.Unlock()
+
+		if !handlerExist {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		handler(w, r)
+	}))
+
+	t.Cleanup(m.server.Close)
+
+	s := &SOLIDserver{
+		Ctx:           context.Background(),
+		Host:          m.server.Listener.Addr().String(),
+		Username:      "ipmadmin",
+		Password:      "admin",
+		BaseUrl:       m.server.URL,
+		SSLVerify:     false,
+		Timeout:       10,
+		Version:       800,
+		Authenticated: true,
+		Cache:         NewLookupCache(false),
+		RRConflicts:   NewRRConflictBatcher(),
+	}
+
+	return m, s
+}
+
+// Register the handler of a service (ex: /rest/ip_site_list)
+func (m *mockSOLIDserver) handle(path string, handler http.HandlerFunc) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.handlers[path] = handler
+}
+
+// Return the number of calls received by a service
+func (m *mockSOLIDserver) count(path string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.calls[path]
+}
+
+// Write a SOLIDserver like JSON answer, 204 when there is nothing to return
+func mockReply(w http.ResponseWriter, status int, rows []map[string]interface{}) {
+	if len(rows) == 0 && status == http.StatusOK {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(rows)
+}
+
+// Return the raw configuration Terraform sends along with a plan, from string and int values
+func mockRawConfig(r *schema.Resource, config map[string]interface{}) cty.Value {
+	attributes := map[string]cty.Value{}
+
+	for name, attributeType := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		switch value := config[name].(type) {
+		case string:
+			attributes[name] = cty.StringVal(value)
+		case int:
+			attributes[name] = cty.NumberIntVal(int64(value))
+		default:
+			attributes[name] = cty.NullVal(attributeType)
+		}
+	}
+
+	return cty.ObjectVal(attributes)
+}
@@ -2,6 +2,7 @@ package solidserver
 
 import (
 	"context"
+	"net"
 	"net/url"
 	"regexp"
 
@@ -19,7 +20,23 @@ func Provider() *schema.Provider {
 				Required:     true,
 				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_HOST", "SOLIDServer_HOST"}, nil),
 				ValidateFunc: validation.StringIsNotEmpty,
-				Description:  "SOLIDServer Hostname or IP address",
+				Description:  "SOLIDServer Hostname or IP address, or URL of the SOLIDServer (ex: https://lb.corp:8443/prod-ipam) whose path wins over base_path, a port given along with the host must match port unless left to its default",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     false,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_PORT", "SOLIDServer_PORT"}, 443),
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "SOLIDServer HTTPS port (Default: 443)",
+			},
+			"base_path": {
+				Type:         schema.TypeString,
+				Required:     false,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_BASE_PATH", "SOLIDServer_BASE_PATH"}, ""),
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(regexpBasePath), "base_path must start with a '/' and have no trailing slash (ex: /prod-ipam)"),
+				Description:  "Path prepended to the rest/ and rpc/ services of SOLIDServer, when fronted by a path-routing load balancer (ex: /prod-ipam; Default: none)",
 			},
 			"use_token": {
 				Type:        schema.TypeBool,
@@ -29,17 +46,38 @@ func Provider() *schema.Provider {
 			},
 			"username": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_USERNAME", "SOLIDServer_USERNAME"}, nil),
 				ValidateFunc: validation.StringIsNotEmpty,
-				Description:  "SOLIDServer API User ID or Token ID",
+				Description:  "SOLIDServer API User ID or Token ID (Required unless using api_key_id)",
 			},
 			"password": {
-				Type:         schema.TypeString,
-				Required:     true,
-				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_PASSWORD", "SOLIDServer_PASSWORD"}, nil),
-				ValidateFunc: validation.StringIsNotEmpty,
-				Description:  "SOLIDServer API user password or token secret",
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_PASSWORD", "SOLIDServer_PASSWORD"}, nil),
+				ValidateFunc:  validation.StringIsNotEmpty,
+				ConflictsWith: []string{"api_key_id", "api_key_secret"},
+				Description:   "SOLIDServer API user password or token secret (Required unless using api_key_id)",
+			},
+			"api_key_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_API_KEY_ID", "SOLIDServer_API_KEY_ID"}, nil),
+				ValidateFunc:  validation.StringIsNotEmpty,
+				RequiredWith:  []string{"api_key_secret"},
+				ConflictsWith: []string{"password"},
+				Description:   "SOLIDServer API key ID, alternative to the username/password authentication (Requires SOLIDserver 8.4 or later)",
+			},
+			"api_key_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_API_KEY_SECRET", "SOLIDServer_API_KEY_SECRET"}, nil),
+				ValidateFunc:  validation.StringIsNotEmpty,
+				RequiredWith:  []string{"api_key_id"},
+				ConflictsWith: []string{"password"},
+				Description:   "SOLIDServer API key secret",
 			},
 			"sslverify": {
 				Type:        schema.TypeBool,
@@ -78,40 +116,116 @@ func Provider() *schema.Provider {
 				Description:      "URL for a proxy to be used for SOLIDServer connectivity. Empty or unspecified means no proxy (direct connectivity). Supported URL schemes are 'http', 'https', and 'socks5'. If the scheme is empty, 'http' is assumed",
 				ValidateDiagFunc: validateProxyURLValue,
 			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Required:    false,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_TLS_SERVER_NAME", "SOLIDServer_TLS_SERVER_NAME"}, ""),
+				Description: "Name sent as SNI and used to verify the SOLIDServer certificate instead of the host, ex: when connecting to the IP address of an appliance whose certificate carries its FQDN (Default: host)",
+			},
+			"resolve_overrides": {
+				Type:             schema.TypeMap,
+				Required:         false,
+				Optional:         true,
+				Description:      "Map of hostnames to IP addresses used to connect to SOLIDServer instead of the DNS resolution, the host is still used for the Host header and the certificate verification (ex: { \"ipam.corp.example\" = \"10.0.0.10\" })",
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateResolveOverridesValue,
+			},
+			"disable_lookup_cache": {
+				Type:        schema.TypeBool,
+				Required:    false,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_DISABLE_LOOKUP_CACHE", "SOLIDServer_DISABLE_LOOKUP_CACHE"}, false),
+				Description: "Disable the caching of space, subnet, pool, vlan and device lookups by name for debugging purposes (Default: false)",
+			},
+			"disable_plan_validation": {
+				Type:        schema.TypeBool,
+				Required:    false,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_DISABLE_PLAN_VALIDATION", "SOLIDServer_DISABLE_PLAN_VALIDATION"}, false),
+				Description: "Disable the plan time validation of the referenced classes, DNS servers and views requiring to query SOLIDserver, for air-gapped plan runs or DNS servers and views created within the same plan under a literal name (Default: false)",
+			},
+			"stats_file": {
+				Type:        schema.TypeString,
+				Required:    false,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_STATS_FILE", "SOLIDServer_STATS_FILE"}, ""),
+				Description: "File to which API usage statistics (calls, durations, retries and errors per endpoint) are appended as a JSON line at the end of each run, no object names nor credentials are recorded (Default: disabled)",
+			},
+			"default_address_name_template": {
+				Type:             schema.TypeString,
+				Required:         false,
+				Optional:         true,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_DEFAULT_ADDRESS_NAME_TEMPLATE", "SOLIDServer_DEFAULT_ADDRESS_NAME_TEMPLATE"}, ""),
+				Description:      "Template of the name given to the IP addresses created without name, rendered once the address is allocated (Supported placeholders: {address}, {subnet}, {space}; ex: \"ip-{address}\"). Changing the template doesn't rename the existing addresses (Default: disabled)",
+				ValidateDiagFunc: validateAddressNameTemplateValue,
+			},
+			"verify_dns_conflicts": {
+				Type:         schema.TypeString,
+				Required:     false,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_VERIFY_DNS_CONFLICTS", "SOLIDServer_VERIFY_DNS_CONFLICTS"}, conflictModeDisabled),
+				Description:  "Verify that no RR with the same name and type, whatever its value, already exists in the SOLIDserver database (not in the live DNS) for the DNS RR to create, either failing the plan (error) or reporting a warning when creating the RR without ever failing (warning) (Supported: disabled, warning, error; Default: disabled)",
+				ValidateFunc: validation.StringInSlice([]string{conflictModeDisabled, conflictModeWarning, conflictModeError}, false),
+			},
+			"default_space": {
+				Type:        schema.TypeString,
+				Required:    false,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SOLIDSERVER_DEFAULT_SPACE", "SOLIDServer_DEFAULT_SPACE"}, ""),
+				Description: "Space of the IP addresses, subnets, pools and DNS zones created without space, the space set on a resource always wins. Existing objects keep the space they are in (Default: none)",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"solidserver_ip_space":         dataSourceipspace(),
-			"solidserver_ip_subnet":        dataSourceipsubnet(),
-			"solidserver_ip_subnet_query":  dataSourceipsubnetquery(),
-			"solidserver_ip6_subnet":       dataSourceip6subnet(),
-			"solidserver_ip6_subnet_query": dataSourceip6subnetquery(),
-			"solidserver_ip_pool":          dataSourceippool(),
-			"solidserver_ip6_pool":         dataSourceip6pool(),
-			"solidserver_ip_address":       dataSourceipaddress(),
-			"solidserver_ip6_address":      dataSourceip6address(),
-			"solidserver_ip_ptr":           dataSourceipptr(),
-			"solidserver_ip6_ptr":          dataSourceip6ptr(),
-			"solidserver_dns_smart":        dataSourcednssmart(),
-			"solidserver_dns_server":       dataSourcednsserver(),
-			"solidserver_dns_view":         dataSourcednsview(),
-			"solidserver_dns_zone":         dataSourcednszone(),
-			"solidserver_vlan_domain":      dataSourcevlandomain(),
-			"solidserver_vlan_range":       dataSourcevlanrange(),
-			"solidserver_vlan":             dataSourcevlan(),
-			"solidserver_usergroup":        dataSourceusergroup(),
-			"solidserver_cdb":              dataSourcecdb(),
-			"solidserver_cdb_data":         dataSourcecdbdata(),
+			"solidserver_ip_space":                 dataSourceipspace(),
+			"solidserver_ip_subnet":                dataSourceipsubnet(),
+			"solidserver_ip_subnet_query":          dataSourceipsubnetquery(),
+			"solidserver_ip_subnets":               dataSourceipsubnets(),
+			"solidserver_ip_subnet_next_available": dataSourceipsubnetnextavailable(),
+			"solidserver_subnet_plan":              dataSourcesubnetplan(),
+			"solidserver_ip6_subnet":               dataSourceip6subnet(),
+			"solidserver_ip6_subnet_query":         dataSourceip6subnetquery(),
+			"solidserver_ip6_subnets":              dataSourceip6subnets(),
+			"solidserver_ip_pool":                  dataSourceippool(),
+			"solidserver_ip6_pool":                 dataSourceip6pool(),
+			"solidserver_ip_address":               dataSourceipaddress(),
+			"solidserver_ip_address_by_mac":        dataSourceipaddressbymac(),
+			"solidserver_ip_lookup":                dataSourceiplookup(),
+			"solidserver_ip6_address":              dataSourceip6address(),
+			"solidserver_ip_ptr":                   dataSourceipptr(),
+			"solidserver_ip6_ptr":                  dataSourceip6ptr(),
+			"solidserver_dns_smart":                dataSourcednssmart(),
+			"solidserver_dns_server":               dataSourcednsserver(),
+			"solidserver_dns_server_params":        dataSourcednsserverparams(),
+			"solidserver_dns_view":                 dataSourcednsview(),
+			"solidserver_dns_zone":                 dataSourcednszone(),
+			"solidserver_dns_zone_count":           dataSourcednszonecount(),
+			"solidserver_vlan_domain":              dataSourcevlandomain(),
+			"solidserver_vlan_range":               dataSourcevlanrange(),
+			"solidserver_vlan":                     dataSourcevlan(),
+			"solidserver_usergroup":                dataSourceusergroup(),
+			"solidserver_group":                    dataSourcegroup(),
+			"solidserver_groups":                   dataSourcegroups(),
+			"solidserver_version":                  dataSourceversion(),
+			"solidserver_cdb":                      dataSourcecdb(),
+			"solidserver_cdb_data":                 dataSourcecdbdata(),
+			"solidserver_cdb_datas":                dataSourcecdbdatas(),
+			"solidserver_managed_objects":          dataSourcemanagedobjects(),
+			"solidserver_unmanaged_scan":           dataSourceunmanagedscan(),
+			"solidserver_dhcp_failover":            dataSourcedhcpfailover(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"solidserver_ip_space":         resourceipspace(),
 			"solidserver_ip_subnet":        resourceipsubnet(),
+			"solidserver_ip_subnet_split":  resourceipsubnetsplit(),
 			"solidserver_ip6_subnet":       resourceip6subnet(),
 			"solidserver_ip_pool":          resourceippool(),
 			"solidserver_ip6_pool":         resourceip6pool(),
 			"solidserver_ip_address":       resourceipaddress(),
 			"solidserver_ip6_address":      resourceip6address(),
+			"solidserver_ip_vip":           resourceipvip(),
 			"solidserver_ip_alias":         resourceipalias(),
 			"solidserver_ip6_alias":        resourceip6alias(),
 			"solidserver_ip_mac":           resourceipmac(),
@@ -122,10 +236,13 @@ func Provider() *schema.Provider {
 			"solidserver_vlan":             resourcevlan(),
 			"solidserver_dns_smart":        resourcednssmart(),
 			"solidserver_dns_server":       resourcednsserver(),
+			"solidserver_dns_server_param": resourcednsserverparam(),
 			"solidserver_dns_view":         resourcednsview(),
 			"solidserver_dns_zone":         resourcednszone(),
 			"solidserver_dns_forward_zone": resourcednsforwardzone(),
+			"solidserver_dns_zone_sync":    resourcednszonesync(),
 			"solidserver_dns_rr":           resourcednsrr(),
+			"solidserver_dns_host":         resourcednshost(),
 			"solidserver_app_application":  resourceapplication(),
 			"solidserver_app_pool":         resourceapplicationpool(),
 			"solidserver_app_node":         resourceapplicationnode(),
@@ -133,24 +250,65 @@ func Provider() *schema.Provider {
 			"solidserver_usergroup":        resourceusergroup(),
 			"solidserver_cdb":              resourcecdb(),
 			"solidserver_cdb_data":         resourcecdbdata(),
+			"solidserver_class":            resourceclass(),
+			"solidserver_dhcp_failover":    resourcedhcpfailover(),
+			"solidserver_dhcp_smart":       resourcedhcpsmart(),
 		},
 		ConfigureContextFunc: ProviderConfigure,
 	}
 }
 
 func ProviderConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	if d.Get("api_key_id").(string) == "" && (d.Get("username").(string) == "" || d.Get("password").(string) == "") {
+		return nil, diag.Errorf("Either username and password or api_key_id and api_key_secret must be set\n")
+	}
+
+	// Values from the environment are not subject to the schema validation
+	if err := addressnametemplatecheck(d.Get("default_address_name_template").(string)); err != nil {
+		return nil, diag.Errorf("Invalid default_address_name_template: %s\n", err)
+	}
+
+	resolveOverrides := map[string]string{}
+
+	for name, ip := range d.Get("resolve_overrides").(map[string]interface{}) {
+		resolveOverrides[name] = ip.(string)
+	}
+
 	s, err := NewSOLIDserver(
 		ctx,
 		d.Get("host").(string),
 		d.Get("use_token").(bool),
 		d.Get("username").(string),
 		d.Get("password").(string),
+		d.Get("api_key_id").(string),
+		d.Get("api_key_secret").(string),
 		d.Get("sslverify").(bool),
 		d.Get("additional_trust_certs_file").(string),
 		d.Get("timeout").(int),
 		d.Get("solidserverversion").(string),
 		d.Get("proxy_url").(string),
+		d.Get("tls_server_name").(string),
+		resolveOverrides,
+		d.Get("disable_lookup_cache").(bool),
+		d.Get("disable_plan_validation").(bool),
+		d.Get("stats_file").(string),
+		d.Get("default_address_name_template").(string),
+		d.Get("verify_dns_conflicts").(string),
+		d.Get("default_space").(string),
+		d.Get("port").(int),
+		d.Get("base_path").(string),
 	)
+
+	// Flushing the API usage statistics when Terraform stops the provider
+	if err == nil && s.Stats != nil {
+		if stopCtx, ok := schema.StopContext(ctx); ok {
+			go func() {
+				<-stopCtx.Done()
+				s.Stats.Flush()
+			}()
+		}
+	}
+
 	return s, err
 }
 
@@ -178,3 +336,21 @@ func validateProxyURLValue(value interface{}, path cty.Path) diag.Diagnostics {
 
 	return nil
 }
+
+func validateAddressNameTemplateValue(value interface{}, path cty.Path) diag.Diagnostics {
+	if err := addressnametemplatecheck(value.(string)); err != nil {
+		return diag.FromErr(path.NewError(err))
+	}
+
+	return nil
+}
+
+func validateResolveOverridesValue(value interface{}, path cty.Path) diag.Diagnostics {
+	for name, ip := range value.(map[string]interface{}) {
+		if net.ParseIP(ip.(string)) == nil {
+			return diag.FromErr(path.NewErrorf("invalid IP address for %s: %s", name, ip))
+		}
+	}
+
+	return nil
+}
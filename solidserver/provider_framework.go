@@ -0,0 +1,96 @@
+package solidserver
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// Plugin framework provider only serving the provider defined functions,
+// resources, data sources and the provider configuration are served by the SDKv2 provider
+type frameworkProvider struct{}
+
+var _ provider.ProviderWithFunctions = &frameworkProvider{}
+
+func newFrameworkProvider() provider.Provider {
+	return &frameworkProvider{}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "solidserver"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newFunctioniptohex,
+		newFunctionhextoip,
+		newFunctionip6expand,
+		newFunctionip6compress,
+		newFunctionprefixtonetmask,
+		newFunctionptrname,
+	}
+}
+
+// Protocol server of the framework provider leaving the provider configuration
+// to the SDKv2 provider, its schema depends on the environment (see DefaultFunc)
+// and can't be mirrored while the mux server requires identical provider schemas
+type frameworkFunctionServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s frameworkFunctionServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+
+	if resp != nil {
+		resp.Provider = nil
+	}
+
+	return resp, err
+}
+
+func (s frameworkFunctionServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	return &tfprotov5.PrepareProviderConfigResponse{}, nil
+}
+
+func (s frameworkFunctionServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	return &tfprotov5.ConfigureProviderResponse{}, nil
+}
+
+// ProviderServer combines the SDKv2 provider and the plugin framework provider
+// defined functions into a single protocol version 5 provider server
+func ProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	servers := []func() tfprotov5.ProviderServer{
+		Provider().GRPCProvider,
+		func() tfprotov5.ProviderServer {
+			return frameworkFunctionServer{providerserver.NewProtocol5(newFrameworkProvider())()}
+		},
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, servers...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
@@ -0,0 +1,120 @@
+package solidserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func testRunFunction(t *testing.T, f function.Function, arg attr.Value) (string, *function.FuncError) {
+	t.Helper()
+
+	resp := function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{arg}),
+	}, &resp)
+
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestProviderFunctions(t *testing.T) {
+	testCases := []struct {
+		f        function.Function
+		arg      attr.Value
+		expected string
+	}{
+		{newFunctioniptohex(), types.StringValue("10.0.8.12"), "0a00080c"},
+		{newFunctioniptohex(), types.StringValue("2001:db8::1"), "20010db8000000000000000000000001"},
+		{newFunctionhextoip(), types.StringValue("0a00080c"), "10.0.8.12"},
+		{newFunctionhextoip(), types.StringValue("20010db8000000000000000000000001"), "2001:db8::1"},
+		{newFunctionip6expand(), types.StringValue("2001:db8::1"), "2001:0db8:0000:0000:0000:0000:0000:0001"},
+		{newFunctionip6compress(), types.StringValue("2001:0db8:0000:0000:0000:0000:0000:0001"), "2001:db8::1"},
+		{newFunctionprefixtonetmask(), types.Int64Value(24), "255.255.255.0"},
+		{newFunctionprefixtonetmask(), types.Int64Value(0), "0.0.0.0"},
+		{newFunctionptrname(), types.StringValue("10.0.8.12"), "12.8.0.10.in-addr.arpa"},
+		{newFunctionptrname(), types.StringValue("2001:db8::1"), "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+	}
+
+	for _, tc := range testCases {
+		res, err := testRunFunction(t, tc.f, tc.arg)
+
+		if err != nil {
+			t.Errorf("%T(%s): unexpected error: %s", tc.f, tc.arg, err)
+		} else if res != tc.expected {
+			t.Errorf("%T(%s): expected %q, got %q", tc.f, tc.arg, tc.expected, res)
+		}
+	}
+}
+
+func TestProviderFunctionsMalformedInput(t *testing.T) {
+	testCases := []struct {
+		f   function.Function
+		arg attr.Value
+	}{
+		{newFunctioniptohex(), types.StringValue("10.0.8")},
+		{newFunctioniptohex(), types.StringValue("10.0.8.x")},
+		{newFunctioniptohex(), types.StringValue("fe80::1%eth0")},
+		{newFunctionhextoip(), types.StringValue("0a0008")},
+		{newFunctionhextoip(), types.StringValue("0a00080g")},
+		{newFunctionip6expand(), types.StringValue("10.0.8.12")},
+		{newFunctionip6expand(), types.StringValue("2001:db8:::1")},
+		{newFunctionip6compress(), types.StringValue("")},
+		{newFunctionprefixtonetmask(), types.Int64Value(33)},
+		{newFunctionprefixtonetmask(), types.Int64Value(-1)},
+		{newFunctionptrname(), types.StringValue("myhost.example.com")},
+	}
+
+	for _, tc := range testCases {
+		if res, err := testRunFunction(t, tc.f, tc.arg); err == nil {
+			t.Errorf("%T(%s): expected an error, got %q", tc.f, tc.arg, res)
+		} else if err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+			t.Errorf("%T(%s): expected an error on the first argument, got %s", tc.f, tc.arg, err)
+		}
+	}
+}
+
+func TestProviderServerMux(t *testing.T) {
+	ctx := context.Background()
+
+	providerServer, err := ProviderServer(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	server := providerServer()
+
+	// The provider schema is only served by the SDKv2 provider
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, d := range schemaResp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	if schemaResp.Provider == nil || len(schemaResp.ResourceSchemas) == 0 {
+		t.Errorf("expected the SDKv2 provider and resource schemas to be served")
+	}
+
+	for _, name := range []string{"ip_to_hex", "hex_to_ip", "ip6_expand", "ip6_compress", "prefix_to_netmask", "ptr_name"} {
+		if _, exist := schemaResp.Functions[name]; !exist {
+			t.Errorf("expected function %s to be served", name)
+		}
+	}
+}
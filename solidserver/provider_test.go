@@ -7,11 +7,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var testProviders map[string]terraform.ResourceProvider
+var testProviders map[string]*schema.Provider
 var testProvider *schema.Provider
 
 func testAccPreCheck(t *testing.T) {
@@ -38,8 +37,8 @@ func init() {
 		fmt.Println("[WARN] use SOLIDServer_SSLVERIFY=false to bypass certificate validation")
 	}
 
-	testProvider = Provider().(*schema.Provider)
-	testProviders = map[string]terraform.ResourceProvider{
+	testProvider = Provider()
+	testProviders = map[string]*schema.Provider{
 		"solidserver": testProvider,
 	}
 }
@@ -89,3 +88,25 @@ func TestValidateProxyURLValue(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAddressNameTemplateValue(t *testing.T) {
+	testCases := map[string]bool{
+		"":                           false,
+		"ip-{address}":               false,
+		"{space}-{subnet}-{address}": false,
+		"static-name":                false,
+		"ip-{addr}":                  true,
+		"{address}-{vlan}":           true,
+		"{}":                         true,
+	}
+
+	for template, isErr := range testCases {
+		t.Run(template, func(t *testing.T) {
+			result := validateAddressNameTemplateValue(template, cty.GetAttrPath("default_address_name_template"))
+
+			if result.HasError() != isErr {
+				t.Errorf("unexpected validation result for template '%s': %v", template, result)
+			}
+		})
+	}
+}
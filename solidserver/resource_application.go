@@ -7,7 +7,9 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
 	"strings"
 )
@@ -52,6 +54,14 @@ func resourceapplication() *schema.Resource {
 				//	return len(old) == 0 || reflect.DeepEqual(old, new)
 				//},
 			},
+			"gslb_algorithm": {
+				Type:         schema.TypeString,
+				Description:  "The load balancing strategy used by the GSLB servers to answer the application DNS queries (Supported: roundrobin, topology, latency, first-available; Default: roundrobin). Switching from topology to another strategy leaves the topology rules of the pools ignored, this is only reported as a warning in the provider logs (TF_LOG=WARN), not in the plan output.",
+				ValidateFunc: validation.StringInSlice([]string{"roundrobin", "topology", "latency", "first-available"}, false),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      "roundrobin",
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the application.",
@@ -60,15 +70,34 @@ func resourceapplication() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to application.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to application.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the application's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffvalidateclass("app_application"),
+			// The SDK can't report warnings at plan time, the switch from topology is only reported in the logs
+			customdiff.IfValueChange("gslb_algorithm", func(ctx context.Context, old, new, meta interface{}) bool {
+				return old.(string) == "topology" && new.(string) != "topology"
+			}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				tflog.Warn(ctx, fmt.Sprintf("Switching the GSLB algorithm of application %s from topology to %s, the topology rules of its pools will be ignored\n", d.Get("name").(string), d.Get("gslb_algorithm").(string)))
+				return nil
+			}),
+		),
 	}
 }
 
@@ -80,8 +109,9 @@ func resourceapplicationCreate(ctx context.Context, d *schema.ResourceData, meta
 	parameters.Add("add_flag", "new_only")
 	parameters.Add("name", d.Get("name").(string))
 	parameters.Add("fqdn", d.Get("fqdn").(string))
+	parameters.Add("appapplication_gslb_algo", d.Get("gslb_algorithm").(string))
 	parameters.Add("appapplication_class_name", d.Get("class").(string))
-	parameters.Add("appapplication_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("appapplication_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Building GSLB server list
 	GSLBList := ""
@@ -90,7 +120,7 @@ func resourceapplicationCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 	parameters.Add("gslbserver_list", GSLBList)
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -134,8 +164,9 @@ func resourceapplicationUpdate(ctx context.Context, d *schema.ResourceData, meta
 	parameters.Add("add_flag", "edit_only")
 	parameters.Add("name", d.Get("name").(string))
 	parameters.Add("fqdn", d.Get("fqdn").(string))
+	parameters.Add("appapplication_gslb_algo", d.Get("gslb_algorithm").(string))
 	parameters.Add("appapplication_class_name", d.Get("class").(string))
-	parameters.Add("appapplication_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("appapplication_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Building GSLB server list
 	GSLBList := ""
@@ -144,7 +175,7 @@ func resourceapplicationUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 	parameters.Add("gslbserver_list", GSLBList)
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -186,7 +217,7 @@ func resourceapplicationDelete(ctx context.Context, d *schema.ResourceData, meta
 	parameters := url.Values{}
 	parameters.Add("appapplication_id", d.Id())
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -231,7 +262,7 @@ func resourceapplicationRead(ctx context.Context, d *schema.ResourceData, meta i
 	parameters := url.Values{}
 	parameters.Add("appapplication_id", d.Id())
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -247,6 +278,11 @@ func resourceapplicationRead(ctx context.Context, d *schema.ResourceData, meta i
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("name", buf[0]["appapplication_name"].(string))
 			d.Set("fqdn", buf[0]["appapplication_fqdn"].(string))
+
+			if gslbAlgo, gslbAlgoExist := buf[0]["appapplication_gslb_algo"].(string); gslbAlgoExist && gslbAlgo != "" {
+				d.Set("gslb_algorithm", gslbAlgo)
+			}
+
 			d.Set("class", buf[0]["appapplication_class_name"].(string))
 
 			// Updating gslb_members information
@@ -266,6 +302,12 @@ func resourceapplicationRead(ctx context.Context, d *schema.ResourceData, meta i
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -305,7 +347,7 @@ func resourceapplicationImportState(ctx context.Context, d *schema.ResourceData,
 	parameters := url.Values{}
 	parameters.Add("appapplication_id", d.Id())
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return nil, fmt.Errorf("SOLIDServer - Object not supported in this SOLIDserver version")
 	}
@@ -321,6 +363,11 @@ func resourceapplicationImportState(ctx context.Context, d *schema.ResourceData,
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("name", buf[0]["appapplication_name"].(string))
 			d.Set("fqdn", buf[0]["appapplication_fqdn"].(string))
+
+			if gslbAlgo, gslbAlgoExist := buf[0]["appapplication_gslb_algo"].(string); gslbAlgoExist && gslbAlgo != "" {
+				d.Set("gslb_algorithm", gslbAlgo)
+			}
+
 			d.Set("class", buf[0]["appapplication_class_name"].(string))
 
 			// Updating gslb_members information
@@ -334,6 +381,12 @@ func resourceapplicationImportState(ctx context.Context, d *schema.ResourceData,
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
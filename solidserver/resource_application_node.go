@@ -110,8 +110,80 @@ func resourceapplicationnode() *schema.Resource {
 				Description: "The healthcheck parameters.",
 				Optional:    true,
 			},
+			"maintenance": {
+				Type:        schema.TypeBool,
+				Description: "Put the application node in maintenance, no traffic is sent to a node in maintenance (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+			"operational_state": {
+				Type:        schema.TypeString,
+				Description: "The operational state of the application node as reported by SOLIDserver.",
+				Computed:    true,
+			},
+			"last_healthcheck_result": {
+				Type:        schema.TypeString,
+				Description: "The result of the last healthcheck performed on the application node.",
+				Computed:    true,
+			},
+			"ip_version": {
+				Type:        schema.TypeString,
+				Description: "The IP protocol version of the application node (ipv4 or ipv6).",
+				Computed:    true,
+			},
 		},
+		CustomizeDiff: resourceapplicationnodediffipversion,
+	}
+}
+
+// Validate that the address family of the node matches the one of its pool
+// The check is skipped when the pool is not known yet (ex: created within the same plan)
+func resourceapplicationnodediffipversion(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("address", "pool") {
+		return nil
+	}
+
+	for _, key := range []string{"application", "fqdn", "pool", "address"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	nodeVersion := ipversion(d.Get("address").(string))
+	if nodeVersion == "" {
+		return nil
+	}
+
+	d.SetNew("ip_version", nodeVersion)
+
+	if s, ok := meta.(*SOLIDserver); !ok || s.DisablePlanValidation || s.Version < versionGSLB {
+		return nil
+	}
+
+	poolInfo, err := apppoolinfobyname(d.Get("application").(string), d.Get("fqdn").(string), d.Get("pool").(string), meta)
+
+	if err != nil {
+		return err
+	}
+
+	if poolInfo == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Application pool not found, skipping the address family check of application node: %s\n", d.Get("name").(string)))
+		return nil
+	}
+
+	if poolVersion := strings.ToLower(infostring(poolInfo, "apppool_type")); poolVersion != "" && poolVersion != nodeVersion {
+		return fmt.Errorf("The address %s of application node %s is %s while application pool %s is %s", d.Get("address").(string), d.Get("name").(string), nodeVersion, d.Get("pool").(string), poolVersion)
+	}
+
+	return nil
+}
+
+// Return the enable/disable value of an application node
+func appnodeenabled(maintenance bool) string {
+	if maintenance {
+		return "0"
 	}
+	return "1"
 }
 
 // Build healthcheck parameters string
@@ -260,8 +332,9 @@ func resourceapplicationnodeCreate(ctx context.Context, d *schema.ResourceData,
 	parameters.Add("apphealthcheck_failover", strconv.Itoa(d.Get("failure_threshold").(int)))
 	parameters.Add("apphealthcheck_failback", strconv.Itoa(d.Get("failback_threshold").(int)))
 	parameters.Add("apphealthcheck_params", stringfromhealcheckparams(d.Get("healthcheck").(string), d.Get("healthcheck_parameters")))
+	parameters.Add("appnode_is_enabled", appnodeenabled(d.Get("maintenance").(bool)))
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -309,14 +382,19 @@ func resourceapplicationnodeUpdate(ctx context.Context, d *schema.ResourceData,
 	parameters.Add("appapplication_fqdn", d.Get("fqdn").(string))
 	parameters.Add("apppool_name", d.Get("pool").(string))
 	parameters.Add("weight", strconv.Itoa(d.Get("weight").(int)))
-	parameters.Add("apphealthcheck_name", d.Get("healthcheck").(string))
-	parameters.Add("apphealthcheck_timeout", strconv.Itoa(d.Get("healthcheck_timeout").(int)))
-	parameters.Add("apphealthcheck_freq", strconv.Itoa(d.Get("healthcheck_frequency").(int)))
-	parameters.Add("apphealthcheck_failover", strconv.Itoa(d.Get("failure_threshold").(int)))
-	parameters.Add("apphealthcheck_failback", strconv.Itoa(d.Get("failback_threshold").(int)))
-	parameters.Add("apphealthcheck_params", stringfromhealcheckparams(d.Get("healthcheck").(string), d.Get("healthcheck_parameters")))
+	parameters.Add("appnode_is_enabled", appnodeenabled(d.Get("maintenance").(bool)))
+
+	// Healthcheck parameters are left untouched unless they changed (ex: maintenance only)
+	if d.HasChanges("healthcheck", "healthcheck_timeout", "healthcheck_frequency", "failure_threshold", "failback_threshold", "healthcheck_parameters") {
+		parameters.Add("apphealthcheck_name", d.Get("healthcheck").(string))
+		parameters.Add("apphealthcheck_timeout", strconv.Itoa(d.Get("healthcheck_timeout").(int)))
+		parameters.Add("apphealthcheck_freq", strconv.Itoa(d.Get("healthcheck_frequency").(int)))
+		parameters.Add("apphealthcheck_failover", strconv.Itoa(d.Get("failure_threshold").(int)))
+		parameters.Add("apphealthcheck_failback", strconv.Itoa(d.Get("failback_threshold").(int)))
+		parameters.Add("apphealthcheck_params", stringfromhealcheckparams(d.Get("healthcheck").(string), d.Get("healthcheck_parameters")))
+	}
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -358,7 +436,7 @@ func resourceapplicationnodeDelete(ctx context.Context, d *schema.ResourceData,
 	parameters := url.Values{}
 	parameters.Add("appnode_id", d.Id())
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -403,7 +481,7 @@ func resourceapplicationnodeRead(ctx context.Context, d *schema.ResourceData, me
 	parameters := url.Values{}
 	parameters.Add("appnode_id", d.Id())
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -424,8 +502,10 @@ func resourceapplicationnodeRead(ctx context.Context, d *schema.ResourceData, me
 
 			if ipAddrExist && ipAddr != "#" {
 				d.Set("address", hexiptoip(ipAddr))
+				d.Set("ip_version", "ipv4")
 			} else if ip6AddrExist && ip6Addr != "#" {
 				d.Set("address", hexip6toip6(ip6Addr))
+				d.Set("ip_version", "ipv6")
 			} else {
 				tflog.Debug(ctx, fmt.Sprintf("Error confilcting addressing IPv4/IPv6 on application node: %s\n", d.Get("name")))
 			}
@@ -453,6 +533,18 @@ func resourceapplicationnodeRead(ctx context.Context, d *schema.ResourceData, me
 
 			d.Set("healthcheck_parameters", healcheckparamsfromstring(buf[0]["apphealthcheck_name"].(string), buf[0]["apphealthcheck_params"].(string)))
 
+			if enabled, enabledExist := buf[0]["appnode_is_enabled"].(string); enabledExist {
+				d.Set("maintenance", enabled == "0")
+			}
+
+			if state, stateExist := buf[0]["appnode_status"].(string); stateExist {
+				d.Set("operational_state", strings.ToLower(state))
+			}
+
+			if result, resultExist := buf[0]["appnode_healthcheck_result"].(string); resultExist {
+				d.Set("last_healthcheck_result", result)
+			}
+
 			return nil
 		}
 
@@ -483,7 +575,7 @@ func resourceapplicationnodeImportState(ctx context.Context, d *schema.ResourceD
 	parameters := url.Values{}
 	parameters.Add("appnode_id", d.Id())
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return nil, fmt.Errorf("SOLIDServer - Object not supported in this SOLIDserver version")
 	}
@@ -504,8 +596,10 @@ func resourceapplicationnodeImportState(ctx context.Context, d *schema.ResourceD
 
 			if ipAddrExist && ipAddr != "#" {
 				d.Set("address", hexiptoip(ipAddr))
+				d.Set("ip_version", "ipv4")
 			} else if ip6AddrExist && ip6Addr != "#" {
 				d.Set("address", hexip6toip6(ip6Addr))
+				d.Set("ip_version", "ipv6")
 			} else {
 				tflog.Debug(ctx, fmt.Sprintf("Error confilcting addressing IPv4/IPv6 on application node: %s\n", d.Get("name")))
 			}
@@ -533,6 +627,18 @@ func resourceapplicationnodeImportState(ctx context.Context, d *schema.ResourceD
 
 			d.Set("healthcheck_parameters", healcheckparamsfromstring(buf[0]["apphealthcheck_name"].(string), buf[0]["apphealthcheck_params"].(string)))
 
+			if enabled, enabledExist := buf[0]["appnode_is_enabled"].(string); enabledExist {
+				d.Set("maintenance", enabled == "0")
+			}
+
+			if state, stateExist := buf[0]["appnode_status"].(string); stateExist {
+				d.Set("operational_state", strings.ToLower(state))
+			}
+
+			if result, resultExist := buf[0]["appnode_healthcheck_result"].(string); resultExist {
+				d.Set("last_healthcheck_result", result)
+			}
+
 			return []*schema.ResourceData{d}, nil
 		}
 
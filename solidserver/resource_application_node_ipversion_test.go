@@ -0,0 +1,67 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Value standing for an unknown value in raw resource configs
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestAppNodeIPVersionValidation(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// The application holds an IPv4 pool only
+	m.handle("/rest/app_pool_list", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("WHERE"), "apppool_name='pool4'") && r.URL.Query().Get("offset") == "0" {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"apppool_id": "5", "apppool_name": "pool4"}})
+			return
+		}
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	m.handle("/rest/app_pool_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"apppool_id": "5", "apppool_name": "pool4", "apppool_type": "ipv4"}})
+	})
+
+	r := resourceapplicationnode()
+	config := func(pool interface{}, address string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"application": "app",
+			"fqdn":        "app.example.com",
+			"pool":        pool,
+			"name":        "node01",
+			"address":     address,
+		})
+	}
+
+	// Matching address family
+	diff, err := r.Diff(context.Background(), nil, config("pool4", "10.0.0.1"), s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff.Attributes["ip_version"] == nil || diff.Attributes["ip_version"].New != "ipv4" {
+		t.Errorf("expected ip_version to be planned as ipv4: %v", diff.Attributes["ip_version"])
+	}
+
+	// Mismatching address family
+	if _, err := r.Diff(context.Background(), nil, config("pool4", "2001:db8::1"), s); err == nil || !strings.Contains(err.Error(), "is ipv6 while application pool pool4 is ipv4") {
+		t.Errorf("expected an address family error, got: %v", err)
+	}
+
+	// Pools created within the same plan are not checked
+	count := m.count("/rest/app_pool_list")
+	if _, err := r.Diff(context.Background(), nil, config(testUnknownValue, "2001:db8::1"), s); err != nil || m.count("/rest/app_pool_list") != count {
+		t.Errorf("unexpected check of an unknown pool: %v", err)
+	}
+
+	// Pools not found are not checked either
+	if _, err := r.Diff(context.Background(), nil, config("pool6", "2001:db8::1"), s); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build all || app_node
+// +build all app_node
+
+// to test only these features: -tags app_node -run="AccAppNode_XX"
+
+package solidserver
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+// disable an application node for maintenance then re-enable it
+func TestAccAppNode_Maintenance(t *testing.T) {
+	appname := testAccName("app")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: Config_TestAccAppNode_Maintenance(appname, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("solidserver_app_node.node", "id"),
+					resource.TestCheckResourceAttr("solidserver_app_node.node", "maintenance", "false"),
+					resource.TestCheckResourceAttrSet("solidserver_app_node.node", "operational_state"),
+				),
+			},
+
+			// drain the node
+			{
+				Config: Config_TestAccAppNode_Maintenance(appname, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("solidserver_app_node.node", "id"),
+					resource.TestCheckResourceAttr("solidserver_app_node.node", "maintenance", "true"),
+					resource.TestCheckResourceAttr("solidserver_app_node.node", "healthcheck", "tcp"),
+					resource.TestCheckResourceAttr("solidserver_app_node.node", "healthcheck_parameters.tcp_port", "443"),
+				),
+			},
+
+			// back in service
+			{
+				Config: Config_TestAccAppNode_Maintenance(appname, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("solidserver_app_node.node", "id"),
+					resource.TestCheckResourceAttr("solidserver_app_node.node", "maintenance", "false"),
+				),
+			},
+		},
+	})
+}
+
+func Config_TestAccAppNode_Maintenance(appname string, maintenance bool) string {
+	return fmt.Sprintf(`
+    resource "solidserver_app_application" "app" {
+      name = "%s"
+      fqdn = "%s.priv"
+    }
+
+    resource "solidserver_app_pool" "pool" {
+      name        = "pool"
+      application = "${solidserver_app_application.app.name}"
+      fqdn        = "${solidserver_app_application.app.fqdn}"
+    }
+
+    resource "solidserver_app_node" "node" {
+      name        = "node"
+      application = "${solidserver_app_application.app.name}"
+      fqdn        = "${solidserver_app_application.app.fqdn}"
+      pool        = "${solidserver_app_pool.pool.name}"
+      address     = "127.0.0.1"
+      healthcheck = "tcp"
+      healthcheck_parameters = {
+        tcp_port = "443"
+      }
+      maintenance = %t
+    }
+`, appname, appname, maintenance)
+}
@@ -108,7 +108,7 @@ func resourceapplicationpoolCreate(ctx context.Context, d *schema.ResourceData,
 		parameters.Add("best_active_nodes", strconv.Itoa(d.Get("best_active_nodes").(int)))
 	}
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -168,7 +168,7 @@ func resourceapplicationpoolUpdate(ctx context.Context, d *schema.ResourceData,
 		parameters.Add("best_active_nodes", strconv.Itoa(d.Get("best_active_nodes").(int)))
 	}
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -210,7 +210,7 @@ func resourceapplicationpoolDelete(ctx context.Context, d *schema.ResourceData,
 	parameters := url.Values{}
 	parameters.Add("apppool_id", d.Id())
 
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
@@ -251,127 +251,86 @@ func resourceapplicationpoolDelete(ctx context.Context, d *schema.ResourceData,
 func resourceapplicationpoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("apppool_id", d.Id())
-
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return diag.Errorf("Object not supported in this SOLIDserver version")
 	}
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/app_pool_info", &parameters)
+	poolInfo, poolErr := apppoolinfobyid(d.Id(), meta)
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	if poolErr != nil {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find application pool (oid): %s (%s)\n", d.Id(), poolErr))
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			d.Set("name", buf[0]["apppool_name"].(string))
-			d.Set("application", buf[0]["appapplication_name"].(string))
-			d.Set("fqdn", buf[0]["appapplication_fqdn"].(string))
-			d.Set("lb_mode", buf[0]["apppool_lb_mode"].(string))
-
-			// Updating affinity_state mode
-			if buf[0]["apppool_affinity_state"].(string) == "0" {
-				d.Set("affinity", false)
-			} else {
-				d.Set("affinity", true)
-
-				sessionTime, _ := strconv.Atoi(buf[0]["apppool_affinity_session_time"].(string))
-				d.Set("affinity_session_duration", sessionTime)
-			}
+		// Do not unset the local ID to avoid inconsistency
 
-			// Updating best active nodes value
-			if buf[0]["apppool_best_active_nodes"].(string) != "" {
-				bestActiveNodes, _ := strconv.Atoi(buf[0]["apppool_best_active_nodes"].(string))
-				d.Set("best_active_nodes", bestActiveNodes)
-			}
+		// Reporting a failure
+		return diag.Errorf("Unable to find application pool: %s\n", d.Get("name").(string))
+	}
 
-			return nil
-		}
+	d.Set("name", poolInfo["apppool_name"].(string))
+	d.Set("application", poolInfo["appapplication_name"].(string))
+	d.Set("fqdn", poolInfo["appapplication_fqdn"].(string))
+	d.Set("lb_mode", poolInfo["apppool_lb_mode"].(string))
 
-		if len(buf) > 0 {
-			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				// Log the error
-				tflog.Debug(ctx, fmt.Sprintf("Unable to find application pool: %s (%s)\n", d.Get("name"), errMsg))
-			}
-		} else {
-			// Log the error
-			tflog.Debug(ctx, fmt.Sprintf("Unable to find application pool (oid): %s\n", d.Id()))
-		}
+	// Updating affinity_state mode
+	if poolInfo["apppool_affinity_state"].(string) == "0" {
+		d.Set("affinity", false)
+	} else {
+		d.Set("affinity", true)
 
-		// Do not unset the local ID to avoid inconsistency
+		sessionTime, _ := strconv.Atoi(poolInfo["apppool_affinity_session_time"].(string))
+		d.Set("affinity_session_duration", sessionTime)
+	}
 
-		// Reporting a failure
-		return diag.Errorf("Unable to find application pool: %s\n", d.Get("name").(string))
+	// Updating best active nodes value
+	if poolInfo["apppool_best_active_nodes"].(string) != "" {
+		bestActiveNodes, _ := strconv.Atoi(poolInfo["apppool_best_active_nodes"].(string))
+		d.Set("best_active_nodes", bestActiveNodes)
 	}
 
-	// Reporting a failure
-	return diag.FromErr(err)
+	return nil
 }
 
 func resourceapplicationpoolImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("apppool_id", d.Id())
-
-	if s.Version < 710 {
+	if s.Version < versionGSLB {
 		// Reporting a failure
 		return nil, fmt.Errorf("SOLIDServer - Object not supported in this SOLIDserver version")
 	}
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/app_pool_info", &parameters)
+	poolInfo, poolErr := apppoolinfobyid(d.Id(), meta)
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	if poolErr != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find and import application pool (oid): %s (%s)\n", d.Id(), poolErr))
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			d.Set("name", buf[0]["apppool_name"].(string))
-			d.Set("application", buf[0]["appapplication_name"].(string))
-			d.Set("fqdn", buf[0]["appapplication_fqdn"].(string))
-			d.Set("lb_mode", buf[0]["apppool_lb_mode"].(string))
-
-			// Updating affinity_state mode
-			if buf[0]["apppool_affinity_state"].(string) == "0" {
-				d.Set("affinity_state", false)
-			} else {
-				d.Set("affinity_state", true)
-
-				sessionTime, _ := strconv.Atoi(buf[0]["apppool_affinity_session_time"].(string))
-				d.Set("affinity_session_duration", sessionTime)
-			}
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import application pool (oid): %s\n", d.Id())
+	}
 
-			// Updating best active nodes value
-			if buf[0]["apppool_best_active_nodes"].(string) != "" {
-				bestActiveNodes, _ := strconv.Atoi(buf[0]["apppool_best_active_nodes"].(string))
-				d.Set("best_active_nodes", bestActiveNodes)
-			} else {
-				d.Set("best_active_nodes", 0)
-			}
+	d.Set("name", poolInfo["apppool_name"].(string))
+	d.Set("application", poolInfo["appapplication_name"].(string))
+	d.Set("fqdn", poolInfo["appapplication_fqdn"].(string))
+	d.Set("lb_mode", poolInfo["apppool_lb_mode"].(string))
 
-			return []*schema.ResourceData{d}, nil
-		}
+	// Updating affinity_state mode
+	if poolInfo["apppool_affinity_state"].(string) == "0" {
+		d.Set("affinity_state", false)
+	} else {
+		d.Set("affinity_state", true)
 
-		if len(buf) > 0 {
-			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				tflog.Debug(ctx, fmt.Sprintf("Unable to import application pool (oid): %s (%s)\n", d.Id(), errMsg))
-			}
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import application pool (oid): %s\n", d.Id()))
-		}
+		sessionTime, _ := strconv.Atoi(poolInfo["apppool_affinity_session_time"].(string))
+		d.Set("affinity_session_duration", sessionTime)
+	}
 
-		// Reporting a failure
-		return nil, fmt.Errorf("SOLIDServer - Unable to find and import application pool (oid): %s\n", d.Id())
+	// Updating best active nodes value
+	if poolInfo["apppool_best_active_nodes"].(string) != "" {
+		bestActiveNodes, _ := strconv.Atoi(poolInfo["apppool_best_active_nodes"].(string))
+		d.Set("best_active_nodes", bestActiveNodes)
+	} else {
+		d.Set("best_active_nodes", 0)
 	}
 
-	// Reporting a failure
-	return nil, err
+	return []*schema.ResourceData{d}, nil
 }
@@ -0,0 +1,42 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAppPoolRead(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	s.Version = versionGSLB
+
+	m.handle("/rest/app_pool_info", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apppool_id") != "5" {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "Pool not found"}})
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"apppool_id": "5", "apppool_name": "pool4", "appapplication_name": "app", "appapplication_fqdn": "app.example.com",
+			"apppool_lb_mode": "weighted", "apppool_affinity_state": "1", "apppool_affinity_session_time": "600", "apppool_best_active_nodes": "",
+		}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceapplicationpool().Schema, map[string]interface{}{})
+	d.SetId("5")
+
+	if diags := resourceapplicationpoolRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("name").(string) != "pool4" || d.Get("fqdn").(string) != "app.example.com" || !d.Get("affinity").(bool) || d.Get("affinity_session_duration").(int) != 600 {
+		t.Errorf("unexpected application pool: %v", d.State().Attributes)
+	}
+
+	d.SetId("6")
+
+	if _, err := resourceapplicationpoolImportState(context.Background(), d, s); err == nil {
+		t.Errorf("expected an import error")
+	}
+}
@@ -136,6 +136,7 @@ func resourcecdbCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created Custom DB (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindCdb)
 				d.SetId(oid)
 				return nil
 			}
@@ -185,6 +186,7 @@ func resourcecdbUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated Custom DB (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindCdb)
 				d.SetId(oid)
 				return nil
 			}
@@ -232,6 +234,7 @@ func resourcecdbDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted Custom DB (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindCdb)
 
 		// Unset local ID
 		d.SetId("")
@@ -0,0 +1,248 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+)
+
+// Object types supporting classes
+var classTypes = []string{
+	"ip_site", "ip_subnet", "ip_pool", "ip_address",
+	"ip6_subnet", "ip6_pool", "ip6_address",
+	"dns_server", "dns_view", "dns_zone", "dns_rr",
+	"vlm_domain", "vlm_range", "vlm_vlan",
+	"hostdev", "app_application",
+}
+
+func resourceclass() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceclassCreate,
+		ReadContext:   resourceclassRead,
+		DeleteContext: resourceclassDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceclassImportState,
+		},
+
+		Description: heredoc.Doc(`
+			Class resource allows to create and manage (empty) object classes. Classes are referenced by the class
+			argument of other resources which is validated at plan time. The definition of class parameters is not
+			exposed by the REST API and must be achieved using the class editor.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"object_type": {
+				Type:         schema.TypeString,
+				Description:  "The type of objects the class applies to (Supported: ip_site, ip_subnet, ip_pool, ip_address, ip6_subnet, ip6_pool, ip6_address, dns_server, dns_view, dns_zone, dns_rr, vlm_domain, vlm_range, vlm_vlan, hostdev, app_application).",
+				ValidateFunc: validation.StringInSlice(classTypes, false),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the class to create.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"class_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the class once created, referencing it postpones the validation of the class argument of other resources until the class exists.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Return a CustomizeDiff function validating that the class of a resource exists
+// RR classes are only verified from the SOLIDserver version supporting them
+func resourcediffvalidateclass(classType string) schema.CustomizeDiffFunc {
+	return customdiff.IfValue("class", func(ctx context.Context, value, meta interface{}) bool {
+		if value.(string) == "" || meta == nil || meta.(*SOLIDserver).DisablePlanValidation {
+			return false
+		}
+
+		return classType != "dns_rr" || meta.(*SOLIDserver).Version >= versionRRClassParameters
+	}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if !d.NewValueKnown("class") {
+			return nil
+		}
+
+		if _, classErr := classidbyname(classType, d.Get("class").(string), meta); classErr != nil {
+			return classErr
+		}
+
+		return nil
+	})
+}
+
+func resourceclassCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("class_type", d.Get("object_type").(string))
+	parameters.Add("class_name", d.Get("name").(string))
+	parameters.Add("class_enabled", "1")
+
+	// Sending creation request
+	resp, body, err := s.Request("post", "rest/class_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Created class (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindClass)
+				d.SetId(oid)
+				d.Set("class_name", d.Get("name").(string))
+				return nil
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to create class: %s (%s)", d.Get("name").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to create class: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceclassDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("class_id", d.Id())
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/class_delete", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// Reporting a failure
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to delete class: %s (%s)", d.Get("name").(string), errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to delete class: %s", d.Get("name").(string))
+		}
+
+		// Log deletion
+		tflog.Debug(ctx, fmt.Sprintf("Deleted class (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindClass)
+
+		// Unset local ID
+		d.SetId("")
+
+		// Reporting a success
+		return nil
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceclassRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("class_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/class_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			d.Set("object_type", buf[0]["class_type"].(string))
+			d.Set("name", buf[0]["class_name"].(string))
+			d.Set("class_name", buf[0]["class_name"].(string))
+
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find class: %s (%s)\n", d.Get("name"), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find class (oid): %s\n", d.Id()))
+		}
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find class: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceclassImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("class_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/class_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			d.Set("object_type", buf[0]["class_type"].(string))
+			d.Set("name", buf[0]["class_name"].(string))
+			d.Set("class_name", buf[0]["class_name"].(string))
+
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to import class (oid): %s (%s)\n", d.Id(), errMsg))
+			}
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import class (oid): %s\n", d.Id()))
+		}
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import class (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return nil, err
+}
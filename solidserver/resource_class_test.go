@@ -0,0 +1,60 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestClassIDByName(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/class_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "class_type='ip_subnet' AND class_name='datacenter'" {
+			mockReply(w, http.StatusOK, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"class_id": "3"}})
+	})
+
+	if classID, err := classidbyname("ip_subnet", "datacenter", s); err != nil || classID != "3" {
+		t.Errorf("expected class ID 3, got %q (%v)", classID, err)
+	}
+
+	if _, err := classidbyname("ip_subnet", "unknown", s); err == nil {
+		t.Errorf("expected an error for an unknown class")
+	}
+
+	if _, err := classidbyname("ip_subnet", "datacenter", s); err != nil || m.count("/rest/class_list") != 2 {
+		t.Errorf("expected cached class lookup, got %d calls (%v)", m.count("/rest/class_list"), err)
+	}
+}
+
+func TestClassValidatePlan(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	s.Version = versionRRClassParameters
+
+	m.handle("/rest/dns_server_list", mockWhereList([]map[string]interface{}{{"dns_id": "1", "dns_name": "ns.example.com"}}))
+
+	m.handle("/rest/class_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errno": "1", "errmsg": "Permission denied"}})
+	})
+
+	// Lookup failures are reported as such rather than as missing classes
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"dnsserver": "ns.example.com", "name": "www.example.com", "type": "A", "value": "10.0.0.1", "class": "service"})
+
+	if _, err := resourcednsrr().Diff(context.Background(), nil, config, s); err == nil || !strings.Contains(err.Error(), "Permission denied") {
+		t.Errorf("expected the lookup failure, got: %v", err)
+	}
+
+	// RR classes aren't verified before SOLIDserver supports them
+	s.Version = versionRRClassParameters - 1
+
+	if _, err := resourcednsrr().Diff(context.Background(), nil, config, s); err != nil || m.count("/rest/class_list") != 1 {
+		t.Errorf("unexpected verification: %v (%d lookups)", err, m.count("/rest/class_list"))
+	}
+}
@@ -0,0 +1,92 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDeleteMissingObject(t *testing.T) {
+	resources := []struct {
+		name     string
+		resource *schema.Resource
+		service  string
+		config   map[string]interface{}
+		delete   func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+	}{
+		{
+			name:     "dns_rr",
+			resource: resourcednsrr(),
+			service:  "/rest/dns_rr_delete",
+			config:   map[string]interface{}{"name": "www.example.com"},
+			delete:   resourcednsrrDelete,
+		},
+		{
+			name:     "ip_address",
+			resource: resourceipaddress(),
+			service:  "/rest/ip_delete",
+			config:   map[string]interface{}{"name": "host01", "lock": true},
+			delete:   resourceipaddressDelete,
+		},
+		{
+			name:     "ip_pool",
+			resource: resourceippool(),
+			service:  "/rest/ip_pool_delete",
+			config:   map[string]interface{}{"name": "pool01"},
+			delete:   resourceippoolDelete,
+		},
+		{
+			name:     "vlan",
+			resource: resourcevlan(),
+			service:  "/rest/vlm_vlan_delete",
+			config:   map[string]interface{}{"name": "vlan100"},
+			delete:   resourcevlanDelete,
+		},
+	}
+
+	answers := []struct {
+		status int
+		errmsg string
+		fail   bool
+	}{
+		{status: http.StatusBadRequest, errmsg: "Object not found"},
+		{status: http.StatusBadRequest, errmsg: "The object does not exist"},
+		{status: http.StatusNotFound},
+		{status: http.StatusForbidden, errmsg: "Permission denied", fail: true},
+		{status: http.StatusBadRequest, errmsg: "Unable to delete, dependency exists", fail: true},
+	}
+
+	for _, r := range resources {
+		for _, answer := range answers {
+			m, s := newMockSOLIDserver(t)
+
+			reply := func(w http.ResponseWriter, req *http.Request) {
+				if answer.errmsg == "" {
+					mockReply(w, answer.status, nil)
+					return
+				}
+				mockReply(w, answer.status, []map[string]interface{}{{"errno": "1", "errmsg": answer.errmsg}})
+			}
+
+			m.handle(r.service, reply)
+
+			// The unlock of a missing IP address reports it missing as well
+			m.handle("/rest/ip_add", reply)
+
+			d := schema.TestResourceDataRaw(t, r.resource.Schema, r.config)
+			d.SetId("42")
+			diags := r.delete(context.Background(), d, s)
+
+			if answer.fail && (!diags.HasError() || d.Id() != "42") {
+				t.Errorf("%s: expected %d (%s) to fail, got %v (ID: %s)", r.name, answer.status, answer.errmsg, diags, d.Id())
+			}
+
+			if !answer.fail && (diags.HasError() || d.Id() != "") {
+				t.Errorf("%s: expected %d (%s) to succeed, got %v (ID: %s)", r.name, answer.status, answer.errmsg, diags, d.Id())
+			}
+		}
+	}
+}
@@ -45,15 +45,25 @@ func resourcedevice() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to device.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to device.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the device's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: resourcediffvalidateclass("hostdev"),
 	}
 }
 
@@ -74,7 +84,7 @@ func resourcedeviceCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	parameters.Add("add_flag", "new_only")
 	parameters.Add("hostdev_name", strings.ToLower(d.Get("name").(string)))
 	parameters.Add("hostdev_class_name", d.Get("class").(string))
-	parameters.Add("hostdev_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("hostdev_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/hostdev_add", &parameters)
@@ -87,6 +97,7 @@ func resourcedeviceCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created device (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindDevice)
 				d.SetId(oid)
 				return nil
 			}
@@ -115,7 +126,7 @@ func resourcedeviceUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	parameters.Add("add_flag", "edit_only")
 	parameters.Add("hostdev_name", strings.ToLower(d.Get("name").(string)))
 	parameters.Add("hostdev_class_name", d.Get("class").(string))
-	parameters.Add("hostdev_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("hostdev_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/hostdev_add", &parameters)
@@ -128,6 +139,7 @@ func resourcedeviceUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated device (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindDevice)
 				d.SetId(oid)
 				return nil
 			}
@@ -175,6 +187,7 @@ func resourcedeviceDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted device (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindDevice)
 
 		// Unset local ID
 		d.SetId("")
@@ -212,6 +225,12 @@ func resourcedeviceRead(ctx context.Context, d *schema.ResourceData, meta interf
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -269,6 +288,12 @@ func resourcedeviceImportState(ctx context.Context, d *schema.ResourceData, meta
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
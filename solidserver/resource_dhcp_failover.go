@@ -0,0 +1,364 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func resourcedhcpfailover() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcedhcpfailoverCreate,
+		ReadContext:   resourcedhcpfailoverRead,
+		UpdateContext: resourcedhcpfailoverUpdate,
+		DeleteContext: resourcedhcpfailoverDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcedhcpfailoverImportState,
+		},
+
+		Description: heredoc.Doc(`
+			DHCP failover resource allows to create and manage the failover channel between a primary and a secondary DHCP server.
+			Failover channels must exist before the DHCP ranges relying on them can be created.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the DHCP failover channel to create.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"primary": {
+				Type:        schema.TypeString,
+				Description: "The name of the primary DHCP server of the failover channel.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"secondary": {
+				Type:        schema.TypeString,
+				Description: "The name of the secondary DHCP server of the failover channel.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"split": {
+				Type:         schema.TypeInt,
+				Description:  "The load balancing split between the primary and the secondary DHCP servers, from 0 (all the clients are served by the secondary) to 256 (all the clients are served by the primary) (Default: 128).",
+				ValidateFunc: validation.IntBetween(0, 256),
+				Optional:     true,
+				Default:      128,
+			},
+			"mclt": {
+				Type:         schema.TypeInt,
+				Description:  "The Maximum Client Lead Time of the failover channel in seconds (Default: 3600).",
+				ValidateFunc: validation.IntAtLeast(1),
+				Optional:     true,
+				Default:      3600,
+			},
+			"max_response_delay": {
+				Type:         schema.TypeInt,
+				Description:  "The number of seconds without message from the peer before considering it down (Default: 60).",
+				ValidateFunc: validation.IntAtLeast(1),
+				Optional:     true,
+				Default:      60,
+			},
+			"force": {
+				Type:        schema.TypeBool,
+				Description: "Delete the failover channel even if DHCP ranges still rely on it (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if d.NewValueKnown("primary") && d.NewValueKnown("secondary") && strings.EqualFold(d.Get("primary").(string), d.Get("secondary").(string)) {
+					return fmt.Errorf("The primary and secondary DHCP servers of a failover channel must be different: %s", d.Get("primary").(string))
+				}
+
+				return nil
+			},
+		),
+	}
+}
+
+func resourcedhcpfailoverCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	if s.Version < 800 {
+		// Reporting a failure
+		return diag.Errorf("Object not supported in this SOLIDserver version")
+	}
+
+	// Gather required ID(s) from provided information
+	primaryID, primaryErr := dhcpserveridbyname(d.Get("primary").(string), meta)
+	if primaryErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to create DHCP failover channel: %s, primary DHCP server not found: %s\n", d.Get("name").(string), d.Get("primary").(string))
+	}
+
+	secondaryID, secondaryErr := dhcpserveridbyname(d.Get("secondary").(string), meta)
+	if secondaryErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to create DHCP failover channel: %s, secondary DHCP server not found: %s\n", d.Get("name").(string), d.Get("secondary").(string))
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("dhcpfailover_name", d.Get("name").(string))
+	parameters.Add("primary_dhcp_id", primaryID)
+	parameters.Add("secondary_dhcp_id", secondaryID)
+	parameters.Add("dhcpfailover_split", strconv.Itoa(d.Get("split").(int)))
+	parameters.Add("dhcpfailover_mclt", strconv.Itoa(d.Get("mclt").(int)))
+	parameters.Add("dhcpfailover_max_response_delay", strconv.Itoa(d.Get("max_response_delay").(int)))
+
+	// Sending creation request
+	resp, body, err := s.Request("post", "rest/dhcp_failover_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Created DHCP failover channel (oid): %s\n", oid))
+				d.SetId(oid)
+				return nil
+			}
+		}
+
+		// The service is not available on this appliance
+		if resp.StatusCode == 404 {
+			return diag.Errorf("Unable to create DHCP failover channel: %s, DHCP failover channels are not supported by this SOLIDserver\n", d.Get("name").(string))
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to create DHCP failover channel: %s (%s)", d.Get("name").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to create DHCP failover channel: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcedhcpfailoverUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Nothing to update on the appliance
+	if !d.HasChanges("split", "mclt", "max_response_delay") {
+		return nil
+	}
+
+	if s.Version < 800 {
+		// Reporting a failure
+		return diag.Errorf("Object not supported in this SOLIDserver version")
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dhcpfailover_id", d.Id())
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("dhcpfailover_split", strconv.Itoa(d.Get("split").(int)))
+	parameters.Add("dhcpfailover_mclt", strconv.Itoa(d.Get("mclt").(int)))
+	parameters.Add("dhcpfailover_max_response_delay", strconv.Itoa(d.Get("max_response_delay").(int)))
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/dhcp_failover_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Updated DHCP failover channel (oid): %s\n", oid))
+				d.SetId(oid)
+				return nil
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to update DHCP failover channel: %s (%s)", d.Get("name").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to update DHCP failover channel: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcedhcpfailoverDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	if s.Version < 800 {
+		// Reporting a failure
+		return diag.Errorf("Object not supported in this SOLIDserver version")
+	}
+
+	// Refuse to break the DHCP ranges still relying on the failover channel
+	if !d.Get("force").(bool) {
+		if rangeCount, rangeCountErr := dhcpfailoverrangecount(d.Id(), meta); rangeCountErr == nil && rangeCount > 0 {
+			return diag.Errorf("Unable to delete DHCP failover channel: %s, still used by %d DHCP range(s) (set force to true to delete it anyway)\n", d.Get("name").(string), rangeCount)
+		} else if rangeCountErr != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to count the DHCP ranges using the DHCP failover channel: %s\n", d.Get("name").(string)))
+		}
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dhcpfailover_id", d.Id())
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/dhcp_failover_delete", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// Reporting a failure
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to delete DHCP failover channel: %s (%s)", d.Get("name").(string), errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to delete DHCP failover channel: %s", d.Get("name").(string))
+		}
+
+		// Log deletion
+		tflog.Debug(ctx, fmt.Sprintf("Deleted DHCP failover channel (oid): %s\n", d.Id()))
+
+		// Unset local ID
+		d.SetId("")
+
+		// Reporting a success
+		return nil
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcedhcpfailoverRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	if s.Version < 800 {
+		// Reporting a failure
+		return diag.Errorf("Object not supported in this SOLIDserver version")
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dhcpfailover_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dhcp_failover_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			dhcpfailoverset(d, buf[0])
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find DHCP failover channel: %s (%s)\n", d.Get("name"), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find DHCP failover channel (oid): %s\n", d.Id()))
+		}
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find DHCP failover channel: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcedhcpfailoverImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := meta.(*SOLIDserver)
+
+	if s.Version < 800 {
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Object not supported in this SOLIDserver version")
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dhcpfailover_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dhcp_failover_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			dhcpfailoverset(d, buf[0])
+			d.Set("force", false)
+
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to import DHCP failover channel (oid): %s (%s)\n", d.Id(), errMsg))
+			}
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import DHCP failover channel (oid): %s\n", d.Id()))
+		}
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import DHCP failover channel (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return nil, err
+}
+
+// Set the attributes of a DHCP failover channel from its API representation
+func dhcpfailoverset(d *schema.ResourceData, failover map[string]interface{}) {
+	d.Set("name", failover["dhcpfailover_name"].(string))
+	d.Set("primary", failover["primary_dhcp_name"].(string))
+	d.Set("secondary", failover["secondary_dhcp_name"].(string))
+
+	split, _ := strconv.Atoi(failover["dhcpfailover_split"].(string))
+	mclt, _ := strconv.Atoi(failover["dhcpfailover_mclt"].(string))
+	maxResponseDelay, _ := strconv.Atoi(failover["dhcpfailover_max_response_delay"].(string))
+
+	d.Set("split", split)
+	d.Set("mclt", mclt)
+	d.Set("max_response_delay", maxResponseDelay)
+}
@@ -0,0 +1,92 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDHCPFailover(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentParameters := map[string]string{}
+	rangeCount := "2"
+
+	m.handle("/rest/dhcp_server_list", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("WHERE") {
+		case "dhcp_name='dhcp01.example.com'":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"dhcp_id": "3"}})
+		case "dhcp_name='dhcp02.example.com'":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"dhcp_id": "4"}})
+		default:
+			mockReply(w, http.StatusNoContent, nil)
+		}
+	})
+
+	m.handle("/rest/dhcp_failover_add", func(w http.ResponseWriter, r *http.Request) {
+		for k := range r.URL.Query() {
+			sentParameters[k] = r.URL.Query().Get(k)
+		}
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "7"}})
+	})
+
+	m.handle("/rest/dhcp_range_count", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": rangeCount}})
+	})
+
+	m.handle("/rest/dhcp_failover_delete", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "7"}})
+	})
+
+	// Both DHCP servers must exist
+	d := schema.TestResourceDataRaw(t, resourcedhcpfailover().Schema, map[string]interface{}{
+		"name":      "failover01",
+		"primary":   "dhcp01.example.com",
+		"secondary": "dhcp03.example.com",
+	})
+
+	if diags := resourcedhcpfailoverCreate(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "secondary DHCP server not found") {
+		t.Fatalf("expected a missing secondary DHCP server error, got %v", diags)
+	}
+
+	if m.count("/rest/dhcp_failover_add") != 0 {
+		t.Errorf("unexpected failover channel creation")
+	}
+
+	d = schema.TestResourceDataRaw(t, resourcedhcpfailover().Schema, map[string]interface{}{
+		"name":      "failover01",
+		"primary":   "dhcp01.example.com",
+		"secondary": "dhcp02.example.com",
+		"split":     200,
+	})
+
+	if diags := resourcedhcpfailoverCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "7" || sentParameters["primary_dhcp_id"] != "3" || sentParameters["secondary_dhcp_id"] != "4" || sentParameters["dhcpfailover_split"] != "200" || sentParameters["dhcpfailover_mclt"] != "3600" {
+		t.Errorf("unexpected failover channel creation: %v", sentParameters)
+	}
+
+	// The deletion is refused while DHCP ranges rely on the channel
+	if diags := resourcedhcpfailoverDelete(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "still used by 2 DHCP range(s)") {
+		t.Fatalf("expected a deletion error, got %v", diags)
+	}
+
+	if m.count("/rest/dhcp_failover_delete") != 0 {
+		t.Errorf("unexpected failover channel deletion")
+	}
+
+	// Unless forced
+	d.Set("force", true)
+
+	if diags := resourcedhcpfailoverDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "" || m.count("/rest/dhcp_failover_delete") != 1 {
+		t.Errorf("expected the failover channel to be deleted")
+	}
+}
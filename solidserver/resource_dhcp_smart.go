@@ -0,0 +1,432 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Initial delay between two deletion attempts of a DHCP SMART
+var dhcpSmartDeleteRetryDelay = 4 * time.Second
+
+func resourcedhcpsmart() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcedhcpsmartCreate,
+		ReadContext:   resourcedhcpsmartRead,
+		UpdateContext: resourcedhcpsmartUpdate,
+		DeleteContext: resourcedhcpsmartDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcedhcpsmartImportState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Description: heredoc.Doc(`
+			DHCP SMART resource allows to create and manage DHCP SMART architectures,
+			SMART(s) are abstract containers managing several DHCP servers as a unique entity.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Description:      "The name of the DHCP SMART to create.",
+				DiffSuppressFunc: resourcediffsuppresscase,
+				Required:         true,
+				ForceNew:         true,
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Description: "The DHCP servers managed by the DHCP SMART, in any order.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the DHCP server, in lowercase.",
+							Required:    true,
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Description: "The role of the DHCP server within the DHCP SMART (ex: master, backup).",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the DHCP SMART.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the DHCP SMART.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the DHCP SMART's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: resourcediffvalidateclass("dhcp_server"),
+	}
+}
+
+// Return the name and role of the members of a DHCP SMART indexed by their name
+func resourcedhcpsmartmembers(members *schema.Set) map[string]string {
+	result := map[string]string{}
+
+	for _, member := range members.List() {
+		result[strings.ToLower(member.(map[string]interface{})["name"].(string))] = member.(map[string]interface{})["role"].(string)
+	}
+
+	return result
+}
+
+// Add and remove the members of a DHCP SMART, a member whose role changed is removed then added back
+// Return an error in case of failure
+func resourcedhcpsmartreconcile(smartName string, oldMembers map[string]string, newMembers map[string]string, meta interface{}) error {
+	for name, role := range oldMembers {
+		if newRole, newExist := newMembers[name]; !newExist || newRole != role {
+			if !dhcpdeletefromsmart(smartName, name, meta) {
+				return fmt.Errorf("SOLIDServer - Unable to remove DHCP server: %s from DHCP SMART: %s\n", name, smartName)
+			}
+		}
+	}
+
+	for name, role := range newMembers {
+		if oldRole, oldExist := oldMembers[name]; !oldExist || oldRole != role {
+			if !dhcpaddtosmart(smartName, name, role, meta) {
+				return fmt.Errorf("SOLIDServer - Unable to add DHCP server: %s to DHCP SMART: %s\n", name, smartName)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourcedhcpsmartCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("dhcp_name", strings.ToLower(d.Get("name").(string)))
+	parameters.Add("dhcp_type", "vdhcp")
+	parameters.Add("dhcp_class_name", d.Get("class").(string))
+	parameters.Add("dhcp_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
+
+	// Sending creation request
+	resp, body, err := s.Request("post", "rest/dhcp_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Created DHCP SMART (oid): %s\n", oid))
+				d.SetId(oid)
+
+				// Registering the members once the SMART exists, the SMART is rolled back on failure
+				members := resourcedhcpsmartmembers(d.Get("members").(*schema.Set))
+				names := make([]string, 0, len(members))
+
+				for name := range members {
+					names = append(names, name)
+				}
+
+				sort.Strings(names)
+
+				steps := []string{}
+
+				for _, name := range names {
+					steps = append(steps, "registration of member "+name)
+				}
+
+				createSteps := newcreatesteps("DHCP SMART", strings.ToLower(d.Get("name").(string)), steps...)
+
+				for i, name := range names {
+					if !dhcpaddtosmart(strings.ToLower(d.Get("name").(string)), name, members[name], meta) {
+						return createSteps.fail(ctx, d, steps[i], fmt.Errorf("SOLIDServer - Unable to add DHCP server: %s to DHCP SMART\n", name), func() error {
+							return objectdelete("DHCP SMART", "rest/dhcp_delete", "dhcp_id", oid, meta)
+						})
+					}
+
+					createSteps.done(steps[i])
+				}
+
+				return nil
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to create DHCP SMART: %s (%s)", strings.ToLower(d.Get("name").(string)), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to create DHCP SMART: %s\n", strings.ToLower(d.Get("name").(string)))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcedhcpsmartUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	if d.HasChanges("class", "class_parameters") {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("dhcp_id", d.Id())
+		parameters.Add("add_flag", "edit_only")
+		parameters.Add("dhcp_name", strings.ToLower(d.Get("name").(string)))
+		parameters.Add("dhcp_type", "vdhcp")
+		parameters.Add("dhcp_class_name", d.Get("class").(string))
+		parameters.Add("dhcp_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
+
+		// Sending the update request
+		resp, body, err := s.Request("put", "rest/dhcp_add", &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode != 200 && resp.StatusCode != 201) || len(buf) == 0 {
+			// Reporting a failure
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to update DHCP SMART: %s (%s)", strings.ToLower(d.Get("name").(string)), errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to update DHCP SMART: %s\n", strings.ToLower(d.Get("name").(string)))
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Updated DHCP SMART (oid): %s\n", d.Id()))
+	}
+
+	if d.HasChange("members") {
+		oldMembers, newMembers := d.GetChange("members")
+
+		if err := resourcedhcpsmartreconcile(strings.ToLower(d.Get("name").(string)), resourcedhcpsmartmembers(oldMembers.(*schema.Set)), resourcedhcpsmartmembers(newMembers.(*schema.Set)), meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcedhcpsmartRead(ctx, d, meta)
+}
+
+func resourcedhcpsmartDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Retrying the deletion until SOLIDserver accepts it (ex: once its configuration is pushed)
+	err := waituntil(ctx, d.Timeout(schema.TimeoutDelete), dhcpSmartDeleteRetryDelay, func() (bool, error) {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("dhcp_id", d.Id())
+
+		// Sending the deletion request
+		resp, body, err := s.Request("delete", "rest/dhcp_delete", &parameters)
+
+		if err != nil {
+			return false, err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer, a DHCP SMART already deleted is reported as missing
+		if resp.StatusCode == 200 || resp.StatusCode == 204 || objectmissing(resp.StatusCode, buf) {
+			return true, nil
+		}
+
+		// Logging a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to delete DHCP SMART: %s (%s)", strings.ToLower(d.Get("name").(string)), errMsg))
+			}
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to delete DHCP SMART: %s", strings.ToLower(d.Get("name").(string))))
+		}
+
+		return false, nil
+	})
+
+	if err == errWaitTimeout {
+		// Reporting a failure
+		return diag.Errorf("Unable to delete DHCP SMART: Too many unsuccessful deletion attempts")
+	}
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted DHCP SMART (oid): %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+// Update the local state of a DHCP SMART from its information and members
+// Return an error in case of failure
+func resourcedhcpsmartsetinfo(d *schema.ResourceData, info map[string]interface{}, meta interface{}) error {
+	smartName := strings.ToLower(infostring(info, "dhcp_name"))
+	members, membersErr := dhcpsmartmembers(smartName, meta)
+
+	if membersErr != nil {
+		return membersErr
+	}
+
+	computedMembers := make([]interface{}, 0, len(members))
+
+	for _, member := range members {
+		computedMembers = append(computedMembers, map[string]interface{}{
+			"name": strings.ToLower(infostring(member, "dhcp_name")),
+			"role": infostring(member, "dhcp_role"),
+		})
+	}
+
+	d.Set("name", smartName)
+	d.Set("members", computedMembers)
+	d.Set("class", infostring(info, "dhcp_class_name"))
+
+	// Updating local class_parameters
+	currentClassParameters := d.Get("class_parameters").(map[string]interface{})
+	retrievedClassParameters, _ := url.ParseQuery(infostring(info, "dhcp_class_parameters"))
+	computedClassParameters := map[string]string{}
+
+	for ck := range currentClassParameters {
+		// Keeping the local value of the class parameters maintained by SOLIDserver
+		if classparamignored(d, ck) {
+			computedClassParameters[ck] = currentClassParameters[ck].(string)
+			continue
+		}
+
+		if rv, rvExist := retrievedClassParameters[ck]; rvExist {
+			computedClassParameters[ck] = rv[0]
+		} else {
+			computedClassParameters[ck] = ""
+		}
+	}
+
+	d.Set("class_parameters", computedClassParameters)
+
+	return nil
+}
+
+func resourcedhcpsmartRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dhcp_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dhcp_server_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if err := resourcedhcpsmartsetinfo(d, buf[0], meta); err != nil {
+				// Reporting a failure
+				return diag.FromErr(err)
+			}
+
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find DHCP SMART: %s (%s)\n", strings.ToLower(d.Get("name").(string)), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find DHCP SMART (oid): %s\n", d.Id()))
+		}
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find DHCP SMART: %s\n", strings.ToLower(d.Get("name").(string)))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcedhcpsmartImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dhcp_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dhcp_server_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if err := resourcedhcpsmartsetinfo(d, buf[0], meta); err != nil {
+				return nil, err
+			}
+
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to import DHCP SMART (oid): %s (%s)\n", d.Id(), errMsg))
+			}
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import DHCP SMART (oid): %s\n", d.Id()))
+		}
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import DHCP SMART (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return nil, err
+}
@@ -0,0 +1,212 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDHCPSmartMembers(t *testing.T) {
+	defer func(delay time.Duration) { dhcpSmartDeleteRetryDelay = delay }(dhcpSmartDeleteRetryDelay)
+	dhcpSmartDeleteRetryDelay = time.Millisecond
+
+	m, s := newMockSOLIDserver(t)
+
+	mutex := sync.Mutex{}
+	members := map[string]string{}
+	groupRoles := []string{}
+	deleted := false
+
+	m.handle("/rest/dhcp_add", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		// The members are registered by editing the whole role list
+		if groupRole := r.URL.Query().Get("vdhcp_dhcp_group_role"); r.Method == http.MethodPut && r.URL.Query().Has("vdhcp_dhcp_group_role") {
+			groupRoles = append(groupRoles, groupRole)
+			members = map[string]string{}
+
+			for _, member := range strings.Split(strings.TrimSuffix(groupRole, ";"), ";") {
+				if nameRole := strings.Split(member, "&"); len(nameRole) == 2 {
+					members[nameRole[0]] = nameRole[1]
+				}
+			}
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "7"}})
+	})
+
+	m.handle("/rest/dhcp_server_list", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Query().Get("WHERE") != "vdhcp_parent_name='smart.priv' AND dhcp_type!='vdhcp'" || len(members) == 0 {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		rows := []map[string]interface{}{}
+		for name, role := range members {
+			rows = append(rows, map[string]interface{}{"dhcp_name": name, "dhcp_role": role, "dhcp_type": "ipmdhcp"})
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i]["dhcp_name"].(string) < rows[j]["dhcp_name"].(string) })
+
+		mockReply(w, http.StatusOK, rows)
+	})
+
+	m.handle("/rest/dhcp_server_info", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"dhcp_id":               "7",
+			"dhcp_name":             "smart.priv",
+			"dhcp_type":             "vdhcp",
+			"dhcp_class_name":       "",
+			"dhcp_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/dhcp_delete", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		// A SMART already deleted is reported as missing
+		if deleted {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "The object does not exist"}})
+			return
+		}
+
+		deleted = true
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "7"}})
+	})
+
+	member := func(name string, role string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "role": role}
+	}
+
+	config := map[string]interface{}{
+		"name":    "smart.priv",
+		"members": []interface{}{member("dhcp01.priv", "master"), member("dhcp02.priv", "backup")},
+	}
+
+	r := resourcedhcpsmart()
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+	if diags := resourcedhcpsmartCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if diags := resourcedhcpsmartRead(context.Background(), d, s); diags.HasError() || d.Id() != "7" || d.Get("members").(*schema.Set).Len() != 2 {
+		t.Fatalf("unexpected DHCP SMART: %v (%v)", d.State().Attributes, diags)
+	}
+
+	// The order of the members doesn't matter
+	state := d.State()
+	config["members"] = []interface{}{member("dhcp02.priv", "backup"), member("dhcp01.priv", "master")}
+
+	if diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), s); err != nil || (diff != nil && !diff.Empty()) {
+		t.Errorf("unexpected diff on reordered members: %v (%v)", diff, err)
+	}
+
+	// Changing a role, removing and adding members
+	update := func(newMembers []interface{}) {
+		config["members"] = newMembers
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), s)
+
+		if err != nil {
+			t.Fatalf("unexpected diff error: %v", err)
+		}
+
+		d, err = schema.InternalMap(r.Schema).Data(state, diff)
+
+		if err != nil {
+			t.Fatalf("unexpected data error: %v", err)
+		}
+
+		if diags := resourcedhcpsmartUpdate(context.Background(), d, s); diags.HasError() {
+			t.Fatalf("unexpected update error: %v", diags)
+		}
+
+		state = d.State()
+	}
+
+	update([]interface{}{member("dhcp02.priv", "master"), member("dhcp03.priv", "backup")})
+
+	if len(members) != 2 || members["dhcp02.priv"] != "master" || members["dhcp03.priv"] != "backup" || len(strings.Split(groupRoles[len(groupRoles)-1], ";")) != 2 {
+		t.Errorf("unexpected members: %v (%v)", members, groupRoles)
+	}
+
+	if diags := resourcedhcpsmartDelete(context.Background(), d, s); diags.HasError() || d.Id() != "" || m.count("/rest/dhcp_delete") != 1 {
+		t.Errorf("unexpected deletion: %v", diags)
+	}
+
+	// Deleting a SMART already deleted succeeds
+	d.SetId("7")
+
+	if diags := resourcedhcpsmartDelete(context.Background(), d, s); diags.HasError() || d.Id() != "" || m.count("/rest/dhcp_delete") != 2 {
+		t.Errorf("unexpected deletion: %v", diags)
+	}
+}
+
+func TestDHCPSmartCreateMemberFailure(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	failDelete := false
+	smarts := map[string]bool{}
+
+	m.handle("/rest/dhcp_add", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Query().Get("vdhcp_dhcp_group_role"), "dhcp02.priv") {
+			mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errmsg": "unknown DHCP server"}})
+			return
+		}
+
+		smarts["7"] = true
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "7"}})
+	})
+
+	m.handle("/rest/dhcp_server_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	m.handle("/rest/dhcp_delete", func(w http.ResponseWriter, r *http.Request) {
+		if failDelete {
+			mockReply(w, http.StatusServiceUnavailable, nil)
+			return
+		}
+
+		delete(smarts, r.URL.Query().Get("dhcp_id"))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "7"}})
+	})
+
+	config := map[string]interface{}{
+		"name": "smart.priv",
+		"members": []interface{}{
+			map[string]interface{}{"name": "dhcp03.priv", "role": "backup"},
+			map[string]interface{}{"name": "dhcp02.priv", "role": "backup"},
+			map[string]interface{}{"name": "dhcp01.priv", "role": "master"},
+		},
+	}
+
+	// A failed member registration rolls back the SMART
+	d := schema.TestResourceDataRaw(t, resourcedhcpsmart().Schema, config)
+
+	if diags := resourcedhcpsmartCreate(context.Background(), d, s); !diags.HasError() || d.Id() != "" || smarts["7"] {
+		t.Fatalf("expected the DHCP SMART to be rolled back: %v", diags)
+	}
+
+	// A failed rollback keeps the SMART tainted, listing the missing members
+	failDelete = true
+	d = schema.TestResourceDataRaw(t, resourcedhcpsmart().Schema, config)
+	diags := resourcedhcpsmartCreate(context.Background(), d, s)
+
+	if !diags.HasError() || d.Id() != "7" || !strings.Contains(diags[0].Detail, "Completed: registration of member dhcp01.priv\nMissing: registration of member dhcp02.priv, registration of member dhcp03.priv") {
+		t.Errorf("expected the DHCP SMART to be kept with the missing members: %v", diags)
+	}
+}
@@ -70,15 +70,25 @@ func resourcednsforwardzone() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the forward zone.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the forward zone.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the forward zone's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: resourcediffvalidateclass("dns_zone"),
 	}
 }
 
@@ -107,7 +117,7 @@ func resourcednsforwardzoneCreate(ctx context.Context, d *schema.ResourceData, m
 	parameters.Add("dnszone_forwarders", fwdList)
 
 	// Building class_parameters
-	classParameters := urlfromclassparams(d.Get("class_parameters"))
+	classParameters := urlfromclassparams(resourceclassparams(d))
 	parameters.Add("dnszone_class_parameters", classParameters.Encode())
 
 	// Sending the creation request
@@ -160,7 +170,7 @@ func resourcednsforwardzoneUpdate(ctx context.Context, d *schema.ResourceData, m
 	parameters.Add("dnszone_forwarders", fwdList)
 
 	// Building class_parameters
-	classParameters := urlfromclassparams(d.Get("class_parameters"))
+	classParameters := urlfromclassparams(resourceclassparams(d))
 	parameters.Add("dnszone_class_parameters", classParameters.Encode())
 
 	// Sending the update request
@@ -277,6 +287,12 @@ func resourcednsforwardzoneRead(ctx context.Context, d *schema.ResourceData, met
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -354,6 +370,12 @@ func resourcednsforwardzoneImportState(ctx context.Context, d *schema.ResourceDa
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -0,0 +1,130 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDNSNameCanonical(t *testing.T) {
+	cases := map[string]string{
+		"www.example.com":      "www.example.com",
+		"WWW.Example.COM.":     "www.example.com",
+		`\*.Apps.example.com.`: "*.apps.example.com",
+		"example.com..":        "example.com.",
+		"":                     "",
+	}
+
+	for name, expected := range cases {
+		if canonical := dnsnamecanonical(name); canonical != expected {
+			t.Errorf("unexpected canonical name of %q: %q instead of %q", name, canonical, expected)
+		}
+	}
+}
+
+func TestDNSRRFqdn(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// The appliance returns the name as stored, with its case, trailing dot and escaped wildcard label
+	rr := map[string]interface{}{
+		"rr_id":               "42",
+		"dns_name":            "ns.example.com",
+		"dnsview_name":        "#",
+		"dnszone_name":        "#",
+		"rr_full_name":        `\*.Apps.Example.com.`,
+		"rr_type":             "A",
+		"value1":              "10.0.0.1",
+		"ttl":                 "3600",
+		"rr_class_name":       "",
+		"rr_class_parameters": "",
+	}
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{rr})
+	})
+
+	m.handle("/rest/dns_rr_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{rr})
+	})
+
+	// Read and import agree on the FQDN and the zone
+	read := schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "*.apps.example.com",
+		"type":      "A",
+		"value":     "10.0.0.1",
+	})
+	read.SetId("42")
+
+	if diags := resourcednsrrRead(context.Background(), read, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	imported := schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{})
+	imported.SetId("42")
+
+	if _, err := resourcednsrrImportState(context.Background(), imported, s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, d := range []*schema.ResourceData{read, imported} {
+		if d.Get("fqdn").(string) != "*.apps.example.com" || d.Get("dnszone").(string) != "" {
+			t.Errorf("unexpected RR state: %v", d.State().Attributes)
+		}
+	}
+}
+
+func TestDNSZoneFqdn(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_zone_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"dnszone_id": "12", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_name": "Example.COM.", "dnszone_type": "Master",
+			"dnszone_site_name": "#", "dnszone_class_name": "", "dnszone_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"dnszone_id": "12", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_name": "Example.COM.", "dnszone_type": "Master",
+			"dnszone_class_name": "", "dnszone_class_parameters": "", "dnszone_last_modified": "1700000000",
+		}})
+	})
+
+	m.handle("/rest/dns_rr_count", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "2"}})
+	})
+
+	read := schema.TestResourceDataRaw(t, resourcednszone().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+	})
+	read.SetId("12")
+
+	if diags := resourcednszoneRead(context.Background(), read, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	imported := schema.TestResourceDataRaw(t, resourcednszone().Schema, map[string]interface{}{})
+	imported.SetId("12")
+
+	if _, err := resourcednszoneImportState(context.Background(), imported, s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	source := schema.TestResourceDataRaw(t, dataSourcednszone().Schema, map[string]interface{}{
+		"name": "example.com",
+	})
+
+	if diags := dataSourcednszoneRead(context.Background(), source, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for _, d := range []*schema.ResourceData{read, imported, source} {
+		if d.Get("fqdn").(string) != "example.com" {
+			t.Errorf("unexpected zone FQDN: %s", d.Get("fqdn").(string))
+		}
+	}
+}
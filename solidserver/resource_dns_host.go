@@ -0,0 +1,365 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+func resourcednshost() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcednshostCreate,
+		ReadContext:   resourcednshostRead,
+		UpdateContext: resourcednshostUpdate,
+		DeleteContext: resourcednshostDelete,
+
+		Description: heredoc.Doc(`
+			DNS host resource allows to create and manage a host entry made of an A (or AAAA) RR and its matching PTR RR.
+			Both RRs are created, kept in sync and deleted together.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The managed SMART DNS server name, or DNS server name hosting the A (or AAAA) RR's zone.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dnsview": {
+				Type:        schema.TypeString,
+				Description: "The View name of the A (or AAAA) RR to create.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"dnszone": {
+				Type:             schema.TypeString,
+				Description:      "The Zone name of the A (or AAAA) RR to create (Default: the zone of the server, and view, matching the longest suffix of the host name).",
+				DiffSuppressFunc: resourcediffsuppresscase,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+			},
+			"reverse_dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The managed SMART DNS server name, or DNS server name hosting the PTR RR's zone (Default: the dnsserver).",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"reverse_dnsview": {
+				Type:        schema.TypeString,
+				Description: "The View name of the PTR RR to create (Default: the dnsview if the PTR RR is hosted by the dnsserver).",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"reverse_dnszone": {
+				Type:        schema.TypeString,
+				Description: "The Zone name of the PTR RR, matching the longest suffix of its name.",
+				Computed:    true,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Description:      "The Fully Qualified Domain Name of the host to create.",
+				DiffSuppressFunc: resourcediffsuppresscase,
+				Required:         true,
+				ForceNew:         true,
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Description: "The Fully Qualified Domain Name of the host, lower-cased and without trailing dot (ex: www.example.com).",
+				Computed:    true,
+			},
+			"address": {
+				Type:             schema.TypeString,
+				Description:      "The IP address (IPv4 or IPv6) of the host to create.",
+				ValidateFunc:     validation.IsIPAddress,
+				DiffSuppressFunc: resourcediffsuppressIPv6Format,
+				Required:         true,
+				ForceNew:         true,
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Description: "The DNS Time To Live of the RRs to create.",
+				Optional:    true,
+				Default:     3600,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The type of the forward RR, computed from the address family (A or AAAA).",
+				Computed:    true,
+			},
+			"ptr_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the PTR RR, computed from the address.",
+				Computed:    true,
+			},
+			"ptr_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the PTR RR.",
+				Computed:    true,
+			},
+			"forward_in_sync": {
+				Type:        schema.TypeBool,
+				Description: "Whether the A (or AAAA) RR still exists and points to the address.",
+				Computed:    true,
+			},
+			"reverse_in_sync": {
+				Type:        schema.TypeBool,
+				Description: "Whether the PTR RR still exists and points to the host name.",
+				Computed:    true,
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			resourcediffvalidatednsserver("dnsview"),
+			// Plan the repair of the RRs found missing or pointing elsewhere
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if d.Id() == "" {
+					return nil
+				}
+
+				for _, key := range []string{"forward_in_sync", "reverse_in_sync"} {
+					if !d.Get(key).(bool) {
+						if err := d.SetNew(key, true); err != nil {
+							return err
+						}
+					}
+				}
+
+				return nil
+			},
+		),
+	}
+}
+
+// Return the type of the forward RR of an address (A or AAAA)
+func resourcednshosttype(address string) string {
+	if addr, addrErr := netip.ParseAddr(address); addrErr == nil && addr.Is6() && !addr.Is4In6() {
+		return "AAAA"
+	}
+
+	return "A"
+}
+
+// Return the server and view hosting the PTR RR of a host
+func resourcednshostreverse(d *schema.ResourceData) (string, string) {
+	reverseServer := d.Get("reverse_dnsserver").(string)
+	reverseView := d.Get("reverse_dnsview").(string)
+
+	if reverseServer == "" {
+		reverseServer = d.Get("dnsserver").(string)
+	}
+
+	if reverseView == "" && strings.EqualFold(reverseServer, d.Get("dnsserver").(string)) {
+		reverseView = d.Get("dnsview").(string)
+	}
+
+	return reverseServer, reverseView
+}
+
+// Create the forward RR of a host
+func resourcednshostaddforward(d *schema.ResourceData, meta interface{}) (string, error) {
+	return dnsrradd(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string), d.Get("name").(string), d.Get("type").(string), d.Get("address").(string), d.Get("ttl").(int), meta)
+}
+
+// Create the PTR RR of a host
+func resourcednshostaddreverse(d *schema.ResourceData, meta interface{}) (string, error) {
+	return dnsrradd(d.Get("reverse_dnsserver").(string), d.Get("reverse_dnsview").(string), d.Get("reverse_dnszone").(string), d.Get("ptr_name").(string), "PTR", d.Get("name").(string), d.Get("ttl").(int), meta)
+}
+
+func resourcednshostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// If no view is specified and server has some configured, trigger an error
+	if len(d.Get("dnsview").(string)) == 0 && dnsserverhasviews(d.Get("dnsserver").(string), meta) {
+		return diag.Errorf("Unable to create DNS host: %s, this DNS server has views. Please specify a view name.\n", d.Get("name").(string))
+	}
+
+	reverseServer, reverseView := resourcednshostreverse(d)
+
+	d.Set("type", resourcednshosttype(d.Get("address").(string)))
+	d.Set("fqdn", dnsnamecanonical(d.Get("name").(string)))
+	d.Set("ptr_name", rrptrname(d.Get("address").(string)))
+	d.Set("reverse_dnsserver", reverseServer)
+	d.Set("reverse_dnsview", reverseView)
+
+	// Resolve the zones matching the longest suffix of the RR names to avoid any ambiguity with nested zones
+	if len(d.Get("dnszone").(string)) == 0 {
+		zoneName, zoneErr := dnszonefindbyrrname(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("name").(string), meta)
+
+		if zoneErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create DNS host: %s, unable to resolve its zone (%s)\n", d.Get("name").(string), zoneErr)
+		}
+
+		if zoneName == "" {
+			return diag.Errorf("Unable to create DNS host: %s, no zone matching its name on DNS server: %s\n", d.Get("name").(string), d.Get("dnsserver").(string))
+		}
+
+		d.Set("dnszone", zoneName)
+	}
+
+	reverseZoneName, reverseZoneErr := dnszonefindbyrrname(reverseServer, reverseView, d.Get("ptr_name").(string), meta)
+
+	if reverseZoneErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to create DNS host: %s, unable to resolve its reverse zone (%s)\n", d.Get("name").(string), reverseZoneErr)
+	}
+
+	if reverseZoneName == "" {
+		return diag.Errorf("Unable to create DNS host: %s, no reverse zone matching: %s on DNS server: %s\n", d.Get("name").(string), d.Get("ptr_name").(string), reverseServer)
+	}
+
+	d.Set("reverse_dnszone", reverseZoneName)
+
+	// Creating the forward RR
+	forwardID, forwardErr := resourcednshostaddforward(d, meta)
+
+	if forwardErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to create DNS host: %s (%s)", d.Get("name").(string), forwardErr)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created %s RR (oid): %s\n", d.Get("type").(string), forwardID))
+
+	// Creating the PTR RR, rolling back the forward RR on failure
+	reverseID, reverseErr := resourcednshostaddreverse(d, meta)
+
+	if reverseErr != nil {
+		if rollbackErr := dnsrrdelete(forwardID, meta); rollbackErr != nil {
+			return diag.Errorf("Unable to create DNS host: %s, unable to create its PTR RR (%s) nor to roll back its %s RR (oid): %s (%s)", d.Get("name").(string), reverseErr, d.Get("type").(string), forwardID, rollbackErr)
+		}
+
+		return diag.Errorf("Unable to create DNS host: %s, unable to create its PTR RR (%s)", d.Get("name").(string), reverseErr)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created PTR RR (oid): %s\n", reverseID))
+
+	d.SetId(forwardID)
+	d.Set("ptr_id", reverseID)
+	d.Set("forward_in_sync", true)
+	d.Set("reverse_in_sync", true)
+
+	return nil
+}
+
+func resourcednshostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Repairing the forward RR, replacing the one pointing elsewhere if any
+	if d.HasChange("forward_in_sync") {
+		if err := dnsrrdelete(d.Id(), meta); err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to delete the previous %s RR (oid): %s\n", d.Get("type").(string), d.Id()))
+		}
+
+		forwardID, forwardErr := resourcednshostaddforward(d, meta)
+
+		if forwardErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to update DNS host: %s (%s)", d.Get("name").(string), forwardErr)
+		}
+
+		d.SetId(forwardID)
+		d.Set("forward_in_sync", true)
+	} else if d.HasChange("ttl") {
+		if err := dnsrrupdate(d.Id(), d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string), d.Get("name").(string), d.Get("type").(string), d.Get("address").(string), d.Get("ttl").(int), meta); err != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to update DNS host: %s (%s)", d.Get("name").(string), err)
+		}
+	}
+
+	// Repairing the PTR RR, replacing the one pointing elsewhere if any
+	if d.HasChange("reverse_in_sync") {
+		if d.Get("ptr_id").(string) != "" {
+			if err := dnsrrdelete(d.Get("ptr_id").(string), meta); err != nil {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to delete the previous PTR RR (oid): %s\n", d.Get("ptr_id").(string)))
+			}
+		}
+
+		reverseID, reverseErr := resourcednshostaddreverse(d, meta)
+
+		if reverseErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to update DNS host: %s, unable to create its PTR RR (%s)", d.Get("name").(string), reverseErr)
+		}
+
+		d.Set("ptr_id", reverseID)
+		d.Set("reverse_in_sync", true)
+	} else if d.HasChange("ttl") {
+		if err := dnsrrupdate(d.Get("ptr_id").(string), d.Get("reverse_dnsserver").(string), d.Get("reverse_dnsview").(string), d.Get("reverse_dnszone").(string), d.Get("ptr_name").(string), "PTR", d.Get("name").(string), d.Get("ttl").(int), meta); err != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to update DNS host: %s, unable to update its PTR RR (%s)", d.Get("name").(string), err)
+		}
+	}
+
+	return nil
+}
+
+func resourcednshostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Deleting the PTR RR first, the host remains resolvable until its deletion
+	if d.Get("ptr_id").(string) != "" {
+		if err := dnsrrdelete(d.Get("ptr_id").(string), meta); err != nil && d.Get("reverse_in_sync").(bool) {
+			// Reporting a failure
+			return diag.Errorf("Unable to delete DNS host: %s, unable to delete its PTR RR (%s)", d.Get("name").(string), err)
+		}
+	}
+
+	if err := dnsrrdelete(d.Id(), meta); err != nil && d.Get("forward_in_sync").(bool) {
+		// Reporting a failure
+		return diag.Errorf("Unable to delete DNS host: %s (%s)", d.Get("name").(string), err)
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted DNS host (oid): %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourcednshostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// We do not rely on the IDs that may change due to DNS behavior
+	forward, forwardErr := dnsrrinfo(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string), d.Get("name").(string), d.Get("type").(string), d.Get("address").(string), meta)
+
+	if forwardErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to find DNS host: %s (%s)", d.Get("name").(string), forwardErr)
+	}
+
+	if forward != nil {
+		d.SetId(forward["rr_id"].(string))
+		d.Set("fqdn", dnsnamecanonical(infostring(forward, "rr_full_name")))
+		d.Set("forward_in_sync", true)
+
+		if ttl, ttlErr := strconv.Atoi(forward["ttl"].(string)); ttlErr == nil {
+			d.Set("ttl", ttl)
+		}
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("The %s RR of DNS host: %s is missing or no longer points to: %s\n", d.Get("type").(string), d.Get("name").(string), d.Get("address").(string)))
+		d.Set("forward_in_sync", false)
+	}
+
+	reverse, reverseErr := dnsrrinfo(d.Get("reverse_dnsserver").(string), d.Get("reverse_dnsview").(string), d.Get("reverse_dnszone").(string), d.Get("ptr_name").(string), "PTR", d.Get("name").(string), meta)
+
+	if reverseErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to find DNS host: %s, unable to read its PTR RR (%s)", d.Get("name").(string), reverseErr)
+	}
+
+	if reverse != nil {
+		d.Set("ptr_id", reverse["rr_id"].(string))
+		d.Set("reverse_in_sync", true)
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("The PTR RR of DNS host: %s is missing or no longer points to it\n", d.Get("name").(string)))
+		d.Set("reverse_in_sync", false)
+	}
+
+	return nil
+}
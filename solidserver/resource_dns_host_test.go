@@ -0,0 +1,163 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSHost(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mutex := sync.Mutex{}
+	records := map[string]map[string]interface{}{}
+	nextID := 40
+	failPTR := false
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"dnszone_name": "example.com"},
+			{"dnszone_name": "1.168.192.in-addr.arpa"},
+			{"dnszone_name": "8.b.d.0.1.0.0.2.ip6.arpa"},
+		})
+	})
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if failPTR && r.URL.Query().Get("rr_type") == "PTR" {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "zone not writable"}})
+			return
+		}
+
+		nextID++
+		id := strconv.Itoa(nextID)
+		records[id] = map[string]interface{}{
+			"rr_id":        id,
+			"rr_full_name": r.URL.Query().Get("rr_name"),
+			"rr_type":      r.URL.Query().Get("rr_type"),
+			"value1":       r.URL.Query().Get("value1"),
+			"dnszone_name": r.URL.Query().Get("dnszone_name"),
+			"ttl":          r.URL.Query().Get("rr_ttl"),
+		}
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": id}})
+	})
+
+	m.handle("/rest/dns_rr_delete", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		delete(records, r.URL.Query().Get("rr_id"))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": r.URL.Query().Get("rr_id")}})
+	})
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		where := r.URL.Query().Get("WHERE")
+
+		for _, rr := range records {
+			if strings.Contains(where, "rr_full_name='"+rr["rr_full_name"].(string)+"'") && strings.Contains(where, "value1='"+rr["value1"].(string)+"'") && strings.Contains(where, "dnszone_name='"+rr["dnszone_name"].(string)+"'") {
+				mockReply(w, http.StatusOK, []map[string]interface{}{rr})
+				return
+			}
+		}
+
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	r := resourcednshost()
+	config := map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "host01.example.com",
+		"address":   "192.168.1.10",
+	}
+
+	// The A RR is rolled back when the PTR RR can't be created
+	failPTR = true
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+	if diags := resourcednshostCreate(context.Background(), d, s); len(diags) != 1 || !strings.Contains(diags[0].Summary, "unable to create its PTR RR") {
+		t.Fatalf("expected a single PTR creation error, got %v", diags)
+	}
+
+	if len(records) != 0 || m.count("/rest/dns_rr_delete") != 1 {
+		t.Fatalf("expected the A RR to be rolled back, remaining RRs: %v", records)
+	}
+
+	// Both RRs are created
+	failPTR = false
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+
+	if diags := resourcednshostCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("type").(string) != "A" || d.Get("fqdn").(string) != "host01.example.com" || d.Get("ptr_name").(string) != "10.1.168.192.in-addr.arpa" || d.Get("reverse_dnszone").(string) != "1.168.192.in-addr.arpa" || len(records) != 2 {
+		t.Fatalf("unexpected DNS host: %v (RRs: %v)", d.State().Attributes, records)
+	}
+
+	if records[d.Get("ptr_id").(string)]["value1"] != "host01.example.com" {
+		t.Errorf("unexpected PTR RR: %v", records[d.Get("ptr_id").(string)])
+	}
+
+	// The PTR RR pointing elsewhere is reported as a drift to repair
+	records[d.Get("ptr_id").(string)]["value1"] = "other.example.com"
+
+	if diags := resourcednshostRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !d.Get("forward_in_sync").(bool) || d.Get("reverse_in_sync").(bool) {
+		t.Fatalf("expected only the PTR RR to be out of sync")
+	}
+
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attr, attrExist := diff.Attributes["reverse_in_sync"]; !attrExist || attr.New != "true" || diff.RequiresNew() {
+		t.Fatalf("expected an in-place repair of the PTR RR, got %v", diff.Attributes)
+	}
+
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourcednshostUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(records) != 2 || records[d.Get("ptr_id").(string)]["value1"] != "host01.example.com" {
+		t.Errorf("expected the PTR RR to be repaired, RRs: %v", records)
+	}
+
+	// Both RRs are deleted
+	if diags := resourcednshostDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(records) != 0 {
+		t.Errorf("expected both RRs to be deleted, remaining RRs: %v", records)
+	}
+}
+
+func TestDNSHostType(t *testing.T) {
+	if resourcednshosttype("192.168.1.10") != "A" || resourcednshosttype("2001:db8::10") != "AAAA" {
+		t.Errorf("unexpected RR types")
+	}
+}
@@ -7,14 +7,20 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// Initial delay between two checks of the readability of a newly created RR
+var dnsRRReadableRetryDelay = 1 * time.Second
+
 func resourcednsrr() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourcednsrrCreate,
 		ReadContext:   resourcednsrrRead,
 		UpdateContext: resourcednsrrUpdate,
@@ -22,6 +28,9 @@ func resourcednsrr() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcednsrrImportState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+		},
 
 		Description: heredoc.Doc(`
 			DNS RR resource allows to create and manage DNS resource records of type A, AAAA, PTR, CNAME, DNAME, NS.
@@ -42,17 +51,34 @@ func resourcednsrr() *schema.Resource {
 				Default:     "",
 			},
 			"dnszone": {
-				Type:        schema.TypeString,
-				Description: "The Zone name of the RR to create.",
-				Optional:    true,
-				ForceNew:    true,
-				Default:     "",
+				Type:             schema.TypeString,
+				Description:      "The Zone name of the RR to create (Default: the zone of the server, and view, matching the longest suffix of the RR name).",
+				DiffSuppressFunc: resourcediffsuppresscase,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
 			},
 			"name": {
+				Type:             schema.TypeString,
+				Description:      "The Fully Qualified Domain Name of the RR to create, \"@\" for the apex of dnszone, wildcard names use '*' as their leftmost label (Computed when ptr_address is set).",
+				DiffSuppressFunc: resourcednsrrdiffsuppressname,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"name", "ptr_address"},
+			},
+			"fqdn": {
 				Type:        schema.TypeString,
-				Description: "The Fully Qualified Domain Name of the RR to create.",
-				Required:    true,
-				ForceNew:    true,
+				Description: "The Fully Qualified Domain Name of the RR, lower-cased and without trailing dot (ex: www.example.com).",
+				Computed:    true,
+			},
+			"ptr_address": {
+				Type:         schema.TypeString,
+				Description:  "The IP address (IPv4 or IPv6) of a PTR RR, used to compute its name within the reverse zone.",
+				ValidateFunc: validation.IsIPAddress,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "ptr_address"},
 			},
 			"type": {
 				Type:         schema.TypeString,
@@ -69,11 +95,23 @@ func resourcednsrr() *schema.Resource {
 				ForceNew:         true,
 				DiffSuppressFunc: resourcediffsuppressIPv6Format,
 			},
+			// No state migration is required for the former default TTL (3600): being computed, the TTL held by
+			// existing states is kept as is when not configured, and only the RRs created from now on get the zone default
 			"ttl": {
 				Type:        schema.TypeInt,
-				Description: "The DNS Time To Live of the RR to create.",
+				Description: "The DNS Time To Live of the RR to create (Default: the default TTL of the zone, reported once created).",
 				Optional:    true,
-				Default:     3600,
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The creation date of the RR (RFC3339 format, empty if not reported by SOLIDserver).",
+				Computed:    true,
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Description: "The last modification date of the RR (RFC3339 format, empty if not reported by SOLIDserver).",
+				Computed:    true,
 			},
 			"class": {
 				Type:        schema.TypeString,
@@ -83,16 +121,191 @@ func resourcednsrr() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the view.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the view.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the RR's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffvalidateclass("dns_rr"),
+			resourcediffvalidatednsserver("dnsview"),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if !d.NewValueKnown("name") || !d.NewValueKnown("type") {
+					return nil
+				}
+
+				// The apex requires the zone to be set, it is only resolved from the RR name otherwise
+				if d.Get("name").(string) == "@" {
+					if config := d.GetRawConfig(); !config.IsNull() && config.GetAttr("dnszone").IsNull() {
+						return fmt.Errorf("The apex RR name '@' requires the dnszone to be set")
+					}
+				}
+
+				return rrnamewildcardcheck(d.Get("name").(string), d.Get("type").(string))
+			},
+			customdiff.IfValue("ptr_address", func(ctx context.Context, value, meta interface{}) bool {
+				return value.(string) != ""
+			}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if strings.ToUpper(d.Get("type").(string)) != "PTR" {
+					return fmt.Errorf("ptr_address can only be used with RR of type PTR")
+				}
+				// Computing the RR name from the IP address, the one in the state (from SOLIDserver) is kept afterward
+				if d.Id() == "" || d.HasChange("ptr_address") {
+					return d.SetNew("name", rrptrname(d.Get("ptr_address").(string)))
+				}
+				return nil
+			}),
+			resourcednsrrdiffverifyconflicts,
+		),
+	}
+
+	// Resources created by older provider versions may not be identified by their oid
+	r.SchemaVersion = stateVersionOID
+	r.StateUpgraders = []schema.StateUpgrader{
+		stateupgraderoid("RR", r, "rest/dns_rr_info", "rr_id", resourcednsrrstatelookup, resourcednsrrstatematch),
+	}
+
+	return r
+}
+
+// Return the oid of a RR from the server, view, zone, name, type and value stored in a raw state
+// Or an empty string if not found
+func resourcednsrrstatelookup(rawState map[string]interface{}, meta interface{}) (string, error) {
+	zoneName := statestring(rawState, "dnszone")
+
+	// The zone of the RR was not stored by older provider versions
+	if zoneName == "" {
+		var zoneErr error = nil
+
+		zoneName, zoneErr = dnszonefindbyrrname(statestring(rawState, "dnsserver"), statestring(rawState, "dnsview"), statestring(rawState, "name"), meta)
+		if zoneErr != nil {
+			return "", zoneErr
+		}
+	}
+
+	rrInfo, rrErr := dnsrrinfo(statestring(rawState, "dnsserver"), statestring(rawState, "dnsview"), zoneName, statestring(rawState, "name"), statestring(rawState, "type"), statestring(rawState, "value"), meta)
+	if rrInfo == nil || rrErr != nil {
+		return "", rrErr
+	}
+
+	return rrInfo["rr_id"].(string), nil
+}
+
+// Return true if a RR matches the server, name and type stored in a raw state
+func resourcednsrrstatematch(rawState map[string]interface{}, info map[string]interface{}) bool {
+	return strings.EqualFold(infostring(info, "dns_name"), statestring(rawState, "dnsserver")) &&
+		dnsnamecanonical(infostring(info, "rr_full_name")) == dnsnamecanonical(statestring(rawState, "name")) &&
+		strings.EqualFold(infostring(info, "rr_type"), statestring(rawState, "type"))
+}
+
+// Verify that no RR with the same name and type, whatever its value, exists for the RR to create
+// The verification is skipped when disabled or when the RR name or DNS server are not known yet
+func resourcednsrrdiffverifyconflicts(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	s, ok := meta.(*SOLIDserver)
+
+	// In warning mode, the conflicts are reported when creating the RR
+	if !ok || s.DisablePlanValidation || s.VerifyDNSConflicts != conflictModeError {
+		return nil
+	}
+
+	if d.Id() != "" || !d.NewValueKnown("dnsserver") || !d.NewValueKnown("dnsview") || !d.NewValueKnown("name") || !d.NewValueKnown("type") {
+		return nil
+	}
+
+	// The apex can't be resolved before the zone is known
+	if d.Get("name").(string) == "@" && !d.NewValueKnown("dnszone") {
+		return nil
+	}
+
+	name, nameErr := rrnamenormalize(d.Get("name").(string), d.Get("dnszone").(string))
+
+	if nameErr != nil || name == "" {
+		return nil
+	}
+
+	message, err := rrconflictmessage(d.Get("dnsserver").(string), d.Get("dnsview").(string), name, d.Get("type").(string), meta)
+
+	if err != nil {
+		return err
+	}
+
+	if message != "" {
+		return fmt.Errorf("SOLIDServer - %s", message)
+	}
+
+	return nil
+}
+
+// Return a warning listing the RR conflicting with the RR to create in warning mode
+// Lookup failures are only logged, the verification never prevents the creation
+func resourcednsrrconflictwarning(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	if s.VerifyDNSConflicts != conflictModeWarning {
+		return nil
+	}
+
+	message, err := rrconflictmessage(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("name").(string), d.Get("type").(string), meta)
+
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to verify the conflicts of RR: %s (%s)\n", d.Get("name").(string), err))
+		return nil
 	}
+
+	if message == "" {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The %s RR: %s conflicts with existing RRs", strings.ToUpper(d.Get("type").(string)), d.Get("name").(string)),
+		Detail:   message,
+	}}
+}
+
+// Return a message describing the RR of the SOLIDserver database having the name and type of a RR, whatever their value
+// Or an empty message if there is none, or an error in case of failure
+func rrconflictmessage(serverName string, viewName string, name string, rrType string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	conflicts, err := s.RRConflicts.Lookup(serverName, viewName, name, rrType, meta)
+
+	if err != nil || len(conflicts) == 0 {
+		return "", err
+	}
+
+	return fmt.Sprintf("The %s RR: %s already exists on DNS server: %s with value(s): %s\n", strings.ToUpper(rrType), name, serverName, rrconflictdescription(conflicts)), nil
+}
+
+// Ignore the apex written "@" and the escaped wildcard label when comparing remote and local RR names
+func resourcednsrrdiffsuppressname(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	name, nameErr := rrnamenormalize(new, d.Get("dnszone").(string))
+	if nameErr != nil {
+		return false
+	}
+
+	if new == "@" {
+		return strings.EqualFold(rrnameunescape(old), name)
+	}
+
+	return rrnameunescape(old) == name
 }
 
 func resourcednsrrvalidatetype(v interface{}, _ string) ([]string, []error) {
@@ -119,6 +332,23 @@ func resourcednsrrvalidatetype(v interface{}, _ string) ([]string, []error) {
 func resourcednsrrCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Computing the name of PTR RR from their IP address
+	if ptrAddress := d.Get("ptr_address").(string); ptrAddress != "" {
+		d.Set("name", rrptrname(ptrAddress))
+	}
+
+	// Replacing the apex "@" by the name of the zone
+	name, nameErr := rrnamenormalize(d.Get("name").(string), d.Get("dnszone").(string))
+	if nameErr != nil {
+		// Reporting a failure
+		return diag.FromErr(nameErr)
+	}
+
+	d.Set("name", name)
+
+	// Reporting the existing RR with the same name and type before the creation
+	warnings := resourcednsrrconflictwarning(ctx, d, meta)
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("add_flag", "new_only")
@@ -126,7 +356,15 @@ func resourcednsrrCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	parameters.Add("rr_name", d.Get("name").(string))
 	parameters.Add("rr_type", strings.ToUpper(d.Get("type").(string)))
 	parameters.Add("value1", d.Get("value").(string))
-	parameters.Add("rr_ttl", strconv.Itoa(d.Get("ttl").(int)))
+
+	// The default TTL of the zone applies unless a TTL is set, including 0
+	if config := d.GetRawConfig(); !config.IsNull() {
+		if !config.GetAttr("ttl").IsNull() {
+			parameters.Add("rr_ttl", strconv.Itoa(d.Get("ttl").(int)))
+		}
+	} else if ttl, ttlSet := d.GetOk("ttl"); ttlSet {
+		parameters.Add("rr_ttl", strconv.Itoa(ttl.(int)))
+	}
 
 	// Add dnsview parameter if it is supplied
 	// If no view is specified and server has some configured, trigger an error
@@ -139,15 +377,29 @@ func resourcednsrrCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	// Add dnszone parameter if it is supplied
+	// Otherwise, resolve the zone matching the longest suffix of the RR name to avoid any ambiguity with nested zones
 	if len(d.Get("dnszone").(string)) != 0 {
 		parameters.Add("dnszone_name", strings.ToLower(d.Get("dnszone").(string)))
+	} else {
+		zoneName, zoneErr := dnszonefindbyrrname(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("name").(string), meta)
+
+		if zoneErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create RR: %s, unable to resolve its zone (%s)\n", d.Get("name").(string), zoneErr)
+		}
+
+		if zoneName != "" {
+			tflog.Debug(ctx, fmt.Sprintf("Using zone %s for RR: %s\n", zoneName, d.Get("name").(string)))
+			parameters.Add("dnszone_name", zoneName)
+			d.Set("dnszone", zoneName)
+		}
 	}
 
-	if s.Version < 800 {
-		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+	if s.Version < versionRRClassParameters {
+		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 	} else {
 		parameters.Add("rr_class_name", d.Get("class").(string))
-		parameters.Add("rr_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+		parameters.Add("rr_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 	}
 
 	// Sending the creation request
@@ -161,8 +413,30 @@ func resourcednsrrCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created RR (oid): %s\n", oid))
-				d.SetId(oid)
-				return nil
+
+				// Waiting for the RR to be readable, it may only be after a propagation delay within clustered appliances
+				// The local ID is set once the RR is found (along with the TTL applied by the zone), so any later lookup succeeds
+				err := waituntil(ctx, d.Timeout(schema.TimeoutCreate), dnsRRReadableRetryDelay, func() (bool, error) {
+					return !resourcednsrrRead(ctx, d, meta).HasError(), nil
+				})
+
+				if err != nil {
+					if err == errWaitTimeout {
+						err = fmt.Errorf("SOLIDServer - RR (oid): %s still not found after %s\n", oid, d.Timeout(schema.TimeoutCreate))
+					}
+
+					d.SetId(oid)
+
+					return newcreatesteps("RR", d.Get("name").(string), "confirmation of its readability").fail(ctx, d, "confirmation of its readability", err, func() error {
+						return objectdelete("RR", "rest/dns_rr_delete", "rr_id", oid, meta)
+					})
+				}
+
+				if d.Id() == "" {
+					d.SetId(oid)
+				}
+
+				return warnings
 			}
 		}
 
@@ -191,7 +465,11 @@ func resourcednsrrUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	parameters.Add("rr_name", d.Get("name").(string))
 	parameters.Add("rr_type", strings.ToUpper(d.Get("type").(string)))
 	parameters.Add("value1", d.Get("value").(string))
-	parameters.Add("rr_ttl", strconv.Itoa(d.Get("ttl").(int)))
+
+	// The TTL is left untouched unless it changed (ex: not set, relying on the default TTL of the zone)
+	if d.HasChange("ttl") {
+		parameters.Add("rr_ttl", strconv.Itoa(d.Get("ttl").(int)))
+	}
 
 	// Add dnsview parameter if it is supplied
 	if len(d.Get("dnsview").(string)) != 0 {
@@ -203,11 +481,11 @@ func resourcednsrrUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		parameters.Add("dnszone_name", strings.ToLower(d.Get("dnszone").(string)))
 	}
 
-	if s.Version < 800 {
-		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+	if s.Version < versionRRClassParameters {
+		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 	} else {
 		parameters.Add("rr_class_name", d.Get("class").(string))
-		parameters.Add("rr_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+		parameters.Add("rr_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 	}
 
 	// Sending the update request
@@ -261,6 +539,13 @@ func resourcednsrrDelete(ctx context.Context, d *schema.ResourceData, meta inter
 
 		// Checking the answer
 		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// An RR already deleted (ex: along with its zone) is not a failure
+			if objectmissing(resp.StatusCode, buf) {
+				tflog.Debug(ctx, fmt.Sprintf("RR already deleted (oid): %s\n", d.Id()))
+				d.SetId("")
+				return nil
+			}
+
 			// Reporting a failure
 			if len(buf) > 0 {
 				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
@@ -293,26 +578,26 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	// Sending the read request
 	// We do not rely on the ID that may change due to DNS behavior
-	whereClause := "dns_name='" + d.Get("dnsserver").(string) + "' AND rr_full_name='" + d.Get("name").(string) + "' AND rr_type='" + strings.ToUpper(d.Get("type").(string))
+	whereClause := "dns_name='" + whereescape(d.Get("dnsserver").(string)) + "' AND " + rrnamewhere(d.Get("name").(string)) + " AND rr_type='" + strings.ToUpper(d.Get("type").(string))
 
 	if strings.ToUpper(d.Get("type").(string)) == "AAAA" {
 		value := shortip6tolongip6(d.Get("value").(string))
 		tflog.Debug(ctx, fmt.Sprintf("Using Expanded IPv6 format: %s\n", value))
-		whereClause += "' AND value1='" + value + "' "
+		whereClause += "' AND value1='" + whereescape(value) + "' "
 	} else {
-		whereClause += "' AND value1='" + d.Get("value").(string) + "' "
+		whereClause += "' AND value1='" + whereescape(d.Get("value").(string)) + "' "
 	}
 
 	// Handle dnsview parameter
 	if len(d.Get("dnsview").(string)) != 0 {
-		whereClause += "AND dnsview_name='" + d.Get("dnsview").(string) + "' "
+		whereClause += "AND dnsview_name='" + whereescape(d.Get("dnsview").(string)) + "' "
 	} else {
 		whereClause += "AND dnsview_name='#' "
 	}
 
 	// Add dnszone parameter if it is supplied
 	if len(d.Get("dnszone").(string)) != 0 {
-		whereClause += "AND dnszone_name='" + d.Get("dnszone").(string) + "' "
+		whereClause += "AND dnszone_name='" + whereescape(d.Get("dnszone").(string)) + "' "
 	}
 
 	parameters.Add("WHERE", whereClause)
@@ -331,7 +616,8 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 			ttl, _ := strconv.Atoi(buf[0]["ttl"].(string))
 
 			d.Set("dnsserver", buf[0]["dns_name"].(string))
-			d.Set("name", buf[0]["rr_full_name"].(string))
+			d.Set("name", rrnameunescape(buf[0]["rr_full_name"].(string)))
+			d.Set("fqdn", dnsnamecanonical(buf[0]["rr_full_name"].(string)))
 			d.Set("type", buf[0]["rr_type"].(string))
 
 			if strings.ToUpper(buf[0]["rr_type"].(string)) == "AAAA" {
@@ -342,12 +628,22 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 
 			d.Set("ttl", ttl)
 
+			// Updating the timestamps, absent from older SOLIDserver versions
+			createTime, _ := buf[0]["rr_create_time"].(string)
+			updateTime, _ := buf[0]["rr_update_time"].(string)
+			d.Set("created_at", timestamptorfc3339(createTime))
+			d.Set("updated_at", timestamptorfc3339(updateTime))
+
+			if zoneName, zoneNameExist := buf[0]["dnszone_name"].(string); zoneNameExist && zoneName != "" && zoneName != "#" {
+				d.Set("dnszone", zoneName)
+			}
+
 			if buf[0]["dnsview_name"].(string) != "#" {
 				d.Set("dnsview", buf[0]["dnsview_name"].(string))
 			}
 
-			if s.Version < 800 {
-				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+			if s.Version < versionRRClassParameters {
+				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 			} else {
 				d.Set("class", buf[0]["rr_class_name"].(string))
 
@@ -357,6 +653,12 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 				computedClassParameters := map[string]string{}
 
 				for ck := range currentClassParameters {
+					// Keeping the local value of the class parameters maintained by SOLIDserver
+					if classparamignored(d, ck) {
+						computedClassParameters[ck] = currentClassParameters[ck].(string)
+						continue
+					}
+
 					if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 						computedClassParameters[ck] = rv[0]
 					} else {
@@ -408,7 +710,8 @@ func resourcednsrrImportState(ctx context.Context, d *schema.ResourceData, meta
 			ttl, _ := strconv.Atoi(buf[0]["ttl"].(string))
 
 			d.Set("dnsserver", buf[0]["dns_name"].(string))
-			d.Set("name", buf[0]["rr_full_name"].(string))
+			d.Set("name", rrnameunescape(buf[0]["rr_full_name"].(string)))
+			d.Set("fqdn", dnsnamecanonical(buf[0]["rr_full_name"].(string)))
 			d.Set("type", buf[0]["rr_type"].(string))
 
 			if strings.ToUpper(buf[0]["rr_type"].(string)) == "AAAA" {
@@ -419,16 +722,22 @@ func resourcednsrrImportState(ctx context.Context, d *schema.ResourceData, meta
 
 			d.Set("ttl", ttl)
 
-			if buf[0]["dnszone_name"].(string) != "#" {
-				d.Set("dnszone", buf[0]["dnszone_name"].(string))
+			// Updating the timestamps, absent from older SOLIDserver versions
+			createTime, _ := buf[0]["rr_create_time"].(string)
+			updateTime, _ := buf[0]["rr_update_time"].(string)
+			d.Set("created_at", timestamptorfc3339(createTime))
+			d.Set("updated_at", timestamptorfc3339(updateTime))
+
+			if zoneName, zoneNameExist := buf[0]["dnszone_name"].(string); zoneNameExist && zoneName != "" && zoneName != "#" {
+				d.Set("dnszone", zoneName)
 			}
 
 			if buf[0]["dnsview_name"].(string) != "#" {
 				d.Set("dnsview", buf[0]["dnsview_name"].(string))
 			}
 
-			if s.Version < 800 {
-				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+			if s.Version < versionRRClassParameters {
+				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 			} else {
 				d.Set("class", buf[0]["rr_class_name"].(string))
 
@@ -438,6 +747,12 @@ func resourcednsrrImportState(ctx context.Context, d *schema.ResourceData, meta
 				computedClassParameters := map[string]string{}
 
 				for ck := range currentClassParameters {
+					// Keeping the local value of the class parameters maintained by SOLIDserver
+					if classparamignored(d, ck) {
+						computedClassParameters[ck] = currentClassParameters[ck].(string)
+						continue
+					}
+
 					if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 						computedClassParameters[ck] = rv[0]
 					} else {
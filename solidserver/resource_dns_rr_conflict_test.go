@@ -0,0 +1,172 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSRRVerifyConflicts(t *testing.T) {
+	defer func(delay time.Duration) { rrConflictBatchDelay = delay }(rrConflictBatchDelay)
+	rrConflictBatchDelay = 200 * time.Millisecond
+
+	m, s := newMockSOLIDserver(t)
+	s.VerifyDNSConflicts = conflictModeError
+
+	m.handle("/rest/dns_server_list", mockWhereList([]map[string]interface{}{{"dns_id": "1", "dns_name": "ns.example.com"}}))
+
+	records := []map[string]interface{}{
+		{"rr_id": "10", "rr_full_name": "www.example.com", "rr_type": "A", "value1": "10.0.0.9", "dnszone_name": "example.com", "dnsview_name": "#"},
+		{"rr_id": "11", "rr_full_name": "www.example.com", "rr_type": "AAAA", "value1": "2001:0db8:0000:0000:0000:0000:0000:0001", "dnszone_name": "example.com", "dnsview_name": "#"},
+		{"rr_id": "12", "rr_full_name": "api.example.com", "rr_type": "A", "value1": "10.0.0.8", "dnszone_name": "example.com", "dnsview_name": "internal"},
+	}
+
+	// Return the records whose name is part of the WHERE clause
+	wheres := []string{}
+	mutex := sync.Mutex{}
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		where := r.URL.Query().Get("WHERE")
+
+		mutex.Lock()
+		wheres = append(wheres, where)
+		mutex.Unlock()
+
+		matched := []map[string]interface{}{}
+
+		for _, record := range records {
+			if strings.Contains(where, "rr_full_name='"+record["rr_full_name"].(string)+"'") {
+				matched = append(matched, record)
+			}
+		}
+
+		mockReply(w, http.StatusOK, matched)
+	})
+
+	plan := func(configs []map[string]interface{}) []error {
+		errs := make([]error, len(configs))
+		wg := sync.WaitGroup{}
+
+		for i, config := range configs {
+			wg.Add(1)
+
+			go func(i int, config map[string]interface{}) {
+				defer wg.Done()
+				_, errs[i] = resourcednsrr().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), s)
+			}(i, config)
+		}
+
+		wg.Wait()
+
+		return errs
+	}
+
+	// A conflicting and a non-conflicting RR planned together share a single lookup
+	errs := plan([]map[string]interface{}{
+		{"dnsserver": "ns.example.com", "name": "www.example.com", "type": "A", "value": "10.0.0.1"},
+		{"dnsserver": "ns.example.com", "name": "mail.example.com", "type": "A", "value": "10.0.0.2"},
+		{"dnsserver": "ns.example.com", "name": "api.example.com", "type": "A", "value": "10.0.0.3"},
+	})
+
+	if errs[0] == nil || !strings.Contains(errs[0].Error(), "'10.0.0.9' (zone: example.com, view: #)") || strings.Contains(errs[0].Error(), "2001:") {
+		t.Errorf("expected a conflict error, got: %v", errs[0])
+	}
+
+	// RR of other views don't conflict
+	if errs[1] != nil || errs[2] != nil {
+		t.Errorf("unexpected conflict errors: %v", errs[1:])
+	}
+
+	if len(wheres) != 1 || !strings.Contains(wheres[0], "rr_full_name='mail.example.com' OR rr_full_name='www.example.com'") {
+		t.Errorf("expected a single batched lookup, got: %v", wheres)
+	}
+
+	// Conflicts are reported at creation in warning mode, the plan doesn't look them up
+	s.VerifyDNSConflicts = conflictModeWarning
+	wheres = []string{}
+
+	if errs = plan([]map[string]interface{}{{"dnsserver": "ns.example.com", "name": "www.example.com", "type": "A", "value": "10.0.0.1"}}); errs[0] != nil || len(wheres) != 0 {
+		t.Errorf("unexpected verification in warning mode: %v (lookups: %v)", errs[0], wheres)
+	}
+
+	// Names are looked up with their original case
+	s.VerifyDNSConflicts = conflictModeError
+	wheres = []string{}
+
+	if errs = plan([]map[string]interface{}{{"dnsserver": "ns.example.com", "name": "Ftp.Example.com", "type": "A", "value": "10.0.0.1"}}); errs[0] != nil || len(wheres) != 1 || !strings.Contains(wheres[0], "rr_full_name='Ftp.Example.com'") {
+		t.Errorf("unexpected lookup: %v (lookups: %v)", errs[0], wheres)
+	}
+
+	// The verification is skipped for unknown names and when disabled
+	s.VerifyDNSConflicts = conflictModeError
+	wheres = []string{}
+
+	errs = plan([]map[string]interface{}{{"dnsserver": "ns.example.com", "name": testUnknownValue, "type": "A", "value": "10.0.0.1"}})
+	s.VerifyDNSConflicts = conflictModeDisabled
+	errs = append(errs, plan([]map[string]interface{}{{"dnsserver": "ns.example.com", "name": "www.example.com", "type": "A", "value": "10.0.0.1"}})...)
+
+	if errs[0] != nil || errs[1] != nil || len(wheres) != 0 {
+		t.Errorf("unexpected verification: %v (lookups: %v)", errs, wheres)
+	}
+}
+
+func TestDNSRRConflictWarning(t *testing.T) {
+	defer func(delay time.Duration) { rrConflictBatchDelay = delay }(rrConflictBatchDelay)
+	rrConflictBatchDelay = time.Millisecond
+
+	m, s := newMockSOLIDserver(t)
+	s.VerifyDNSConflicts = conflictModeWarning
+	failLookup := true
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	rr := map[string]interface{}{
+		"rr_id": "42", "ttl": "3600", "dns_name": "ns.example.com", "rr_full_name": "www.example.com", "rr_type": "A", "value1": "10.0.0.1",
+		"dnszone_name": "example.com", "dnsview_name": "internal", "rr_class_name": "", "rr_class_parameters": "",
+	}
+
+	// The conflict lookup fails first, then finds another A RR with the same name
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("WHERE"), " AND (") {
+			if failLookup {
+				mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errno": "1", "errmsg": "Forbidden"}})
+				return
+			}
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"rr_id": "10", "rr_full_name": "www.example.com", "rr_type": "A", "value1": "10.0.0.9", "dnszone_name": "example.com", "dnsview_name": "internal"}})
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{rr})
+	})
+
+	create := func() diag.Diagnostics {
+		d := schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{
+			"dnsserver": "ns.example.com",
+			"dnsview":   "internal",
+			"dnszone":   "example.com",
+			"name":      "www.example.com",
+			"type":      "A",
+			"value":     "10.0.0.1",
+		})
+
+		return resourcednsrrCreate(context.Background(), d, s)
+	}
+
+	if diags := create(); len(diags) != 0 {
+		t.Errorf("expected a lookup failure to be ignored in warning mode, got: %v", diags)
+	}
+
+	failLookup = false
+
+	if diags := create(); diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "'10.0.0.9'") {
+		t.Errorf("expected a conflict warning, got: %v", diags)
+	}
+}
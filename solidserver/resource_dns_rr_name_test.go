@@ -0,0 +1,162 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRRNameNormalize(t *testing.T) {
+	if name, err := rrnamenormalize("@", "Example.com"); err != nil || name != "example.com" {
+		t.Errorf("unexpected apex name: %s (%v)", name, err)
+	}
+
+	if _, err := rrnamenormalize("@", ""); err == nil {
+		t.Errorf("expected an error for the apex without zone")
+	}
+
+	if name, err := rrnamenormalize(`\*.apps.example.com`, ""); err != nil || name != "*.apps.example.com" {
+		t.Errorf("unexpected wildcard name: %s (%v)", name, err)
+	}
+
+	if where := rrnamewhere("*.apps.example.com"); where != `(rr_full_name='*.apps.example.com' OR rr_full_name='\*.apps.example.com')` {
+		t.Errorf("unexpected wildcard condition: %s", where)
+	}
+
+	cases := []struct {
+		name    string
+		rrType  string
+		isValid bool
+	}{
+		{"*.apps.example.com", "A", true},
+		{"*.apps.example.com", "txt", true},
+		{"*", "CNAME", true},
+		{"www.example.com", "NS", true},
+		{"*.apps.example.com", "NS", false},
+		{"*.apps.example.com", "DNAME", false},
+		{"www.*.example.com", "A", false},
+		{"*www.example.com", "A", false},
+		{"*.*.example.com", "A", false},
+	}
+
+	for _, c := range cases {
+		if err := rrnamewildcardcheck(c.name, c.rrType); (err == nil) != c.isValid {
+			t.Errorf("unexpected validation of %s RR %s: %v", c.rrType, c.name, err)
+		}
+	}
+}
+
+func TestDNSRRWildcardApex(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentNames := []string{}
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		sentNames = append(sentNames, r.URL.Query().Get("rr_name"))
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	// The appliance stores the wildcard label escaped
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		where := r.URL.Query().Get("WHERE")
+		rr := map[string]interface{}{
+			"rr_id":               "42",
+			"dns_name":            "ns.example.com",
+			"dnsview_name":        "#",
+			"dnszone_name":        "example.com",
+			"ttl":                 "3600",
+			"rr_class_name":       "",
+			"rr_class_parameters": "",
+		}
+
+		switch {
+		case strings.Contains(where, `rr_full_name='\*.apps.example.com'`):
+			rr["rr_full_name"], rr["rr_type"], rr["value1"] = `\*.apps.example.com`, "A", "10.0.0.1"
+		case strings.Contains(where, "rr_full_name='example.com'"):
+			rr["rr_full_name"], rr["rr_type"], rr["value1"] = "example.com", "TXT", "v=spf1 -all"
+		default:
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{rr})
+	})
+
+	// Wildcard A RR
+	d := schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"dnszone":   "example.com",
+		"name":      "*.apps.example.com",
+		"type":      "A",
+		"value":     "10.0.0.1",
+	})
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("name").(string) != "*.apps.example.com" || d.Get("ttl").(int) != 3600 {
+		t.Errorf("unexpected wildcard RR state: %v", d.State().Attributes)
+	}
+
+	// Apex TXT RR written "@" or as the zone name
+	apex := map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"dnszone":   "example.com",
+		"name":      "@",
+		"type":      "TXT",
+		"value":     "v=spf1 -all",
+	}
+
+	d = schema.TestResourceDataRaw(t, resourcednsrr().Schema, apex)
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("name").(string) != "example.com" || strings.Join(sentNames, ",") != "*.apps.example.com,example.com" {
+		t.Errorf("unexpected apex RR state: %s (sent: %v)", d.Get("name").(string), sentNames)
+	}
+
+	for _, name := range []string{"@", "example.com"} {
+		apex["name"] = name
+		diff, err := resourcednsrr().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(apex), nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if diff != nil && diff.Attributes["name"] != nil {
+			t.Errorf("unexpected name diff for %s: %v", name, diff.Attributes["name"])
+		}
+	}
+
+	// Plan time validation
+	for _, config := range []map[string]interface{}{
+		{"dnsserver": "ns.example.com", "name": "*.example.com", "type": "NS", "value": "ns1.example.com"},
+		{"dnsserver": "ns.example.com", "name": "www.*.example.com", "type": "A", "value": "10.0.0.1"},
+	} {
+		if _, err := resourcednsrr().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err == nil || !strings.Contains(err.Error(), "Wildcard") {
+			t.Errorf("expected a wildcard error for %v, got: %v", config, err)
+		}
+	}
+
+	// The apex requires the zone
+	d = schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "@",
+		"type":      "TXT",
+		"value":     "v=spf1 -all",
+	})
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "requires the dnszone") {
+		t.Errorf("expected a missing zone error, got: %v", diags)
+	}
+}
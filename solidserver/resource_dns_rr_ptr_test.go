@@ -0,0 +1,83 @@
+package solidserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRRPTRName(t *testing.T) {
+	cases := map[string]string{
+		"10.1.2.3":        "3.2.1.10.in-addr.arpa",
+		"192.168.100.254": "254.100.168.192.in-addr.arpa",
+		"2001:db8::1":     "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		"2001:0db8:0000:0000:0000:0000:0000:00a1": "1.a.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		"not-an-ip": "",
+	}
+
+	for ip, expected := range cases {
+		if name := rrptrname(ip); name != expected {
+			t.Errorf("%s: expected %q, got %q", ip, expected, name)
+		}
+	}
+}
+
+func TestDNSRRPTRAddressDiff(t *testing.T) {
+	r := resourcednsrr()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver":   "ns.example.com",
+		"dnszone":     "1.10.in-addr.arpa",
+		"ptr_address": "10.1.2.3",
+		"type":        "PTR",
+		"value":       "host.example.com",
+	})
+
+	// The name is computed at plan time
+	diff, err := r.Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attr, attrExist := diff.Attributes["name"]; !attrExist || attr.New != "3.2.1.10.in-addr.arpa" {
+		t.Errorf("expected computed name 3.2.1.10.in-addr.arpa, got %v", attr)
+	}
+
+	// The name read from SOLIDserver must not trigger any change
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"id":          "42",
+			"dnsserver":   "ns.example.com",
+			"dnsview":     "",
+			"dnszone":     "1.10.in-addr.arpa",
+			"name":        "3.2.1.10.in-addr.arpa",
+			"ptr_address": "10.1.2.3",
+			"type":        "PTR",
+			"value":       "host.example.com",
+			"ttl":         "3600",
+			"class":       "",
+		},
+	}
+
+	diff, err = r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("unexpected diff: %v", diff.Attributes)
+	}
+
+	// ptr_address is restricted to PTR RR
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver":   "ns.example.com",
+		"ptr_address": "10.1.2.3",
+		"type":        "A",
+		"value":       "10.1.2.3",
+	})
+
+	if _, err := r.Diff(context.Background(), nil, config, nil); err == nil {
+		t.Errorf("expected an error for ptr_address on an A RR")
+	}
+}
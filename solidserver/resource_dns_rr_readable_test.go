@@ -0,0 +1,84 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDNSRRCreateWaitsReadable(t *testing.T) {
+	defer func(delay time.Duration) { dnsRRReadableRetryDelay = delay }(dnsRRReadableRetryDelay)
+	dnsRRReadableRetryDelay = time.Millisecond
+
+	m, s := newMockSOLIDserver(t)
+	pending := 2
+	failDelete := false
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	// The RR is only listed after a propagation delay
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		if pending > 0 {
+			pending--
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"rr_id": "43", "ttl": "3600", "dns_name": "ns.example.com", "rr_full_name": "www.example.com", "rr_type": "A", "value1": "10.0.0.1",
+			"dnszone_name": "example.com", "dnsview_name": "#", "rr_class_name": "", "rr_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/dns_rr_delete", func(w http.ResponseWriter, r *http.Request) {
+		if failDelete {
+			mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errno": "1", "errmsg": "Forbidden"}})
+			return
+		}
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	config := map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"dnsview":   "internal",
+		"dnszone":   "example.com",
+		"name":      "www.example.com",
+		"type":      "A",
+		"value":     "10.0.0.1",
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcednsrr().Schema, config)
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "43" || d.Get("ttl").(int) != 3600 || m.count("/rest/dns_rr_list") != 3 {
+		t.Errorf("expected the RR to be read after 3 attempts, got %s after %d", d.Id(), m.count("/rest/dns_rr_list"))
+	}
+
+	// The RR never gets readable, it is deleted
+	pending = 1000
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	d = schema.TestResourceDataRaw(t, resourcednsrr().Schema, config)
+	diags := resourcednsrrCreate(ctx, d, s)
+
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "confirmation of its readability") || d.Id() != "" || m.count("/rest/dns_rr_delete") != 1 {
+		t.Fatalf("expected the RR to be rolled back, got %v (ID: %s)", diags, d.Id())
+	}
+
+	// Unless its deletion fails, it is then kept to be replaced
+	failDelete = true
+	d = schema.TestResourceDataRaw(t, resourcednsrr().Schema, config)
+
+	if diags := resourcednsrrCreate(ctx, d, s); !diags.HasError() || d.Id() != "42" {
+		t.Errorf("expected the RR to be kept, got %v (ID: %s)", diags, d.Id())
+	}
+}
@@ -0,0 +1,67 @@
+//go:build all || dns_rr
+// +build all dns_rr
+
+// to test only these features: -tags dns_rr -run="dnsrr_XX"
+
+package solidserver
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+// create a wildcard A RR and an apex TXT RR written "@", the apex written as the zone name leading to an empty plan
+func TestAccdnsrr_01(t *testing.T) {
+	zonename := testAccName("01-zone") + ".lab"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: Config_TestAccdnsrr_01(zonename, "@"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("solidserver_dns_rr.wildcard", "id"),
+					resource.TestCheckResourceAttr("solidserver_dns_rr.wildcard", "name", "*.apps."+zonename),
+					resource.TestCheckResourceAttr("solidserver_dns_rr.wildcard", "type", "A"),
+					resource.TestCheckResourceAttrSet("solidserver_dns_rr.apex", "id"),
+					resource.TestCheckResourceAttr("solidserver_dns_rr.apex", "name", zonename),
+					resource.TestCheckResourceAttr("solidserver_dns_rr.apex", "type", "TXT"),
+				),
+			},
+			{
+				Config:             Config_TestAccdnsrr_01(zonename, zonename),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func Config_TestAccdnsrr_01(zonename string, apexname string) string {
+	return fmt.Sprintf(`
+    resource "solidserver_dns_zone" "zone" {
+      dnsserver = "ns.local"
+      name      = "%s"
+    }
+
+    resource "solidserver_dns_rr" "wildcard" {
+      dnsserver = "ns.local"
+      dnszone   = solidserver_dns_zone.zone.name
+      name      = "*.apps.%s"
+      type      = "A"
+      value     = "10.0.0.1"
+    }
+
+    resource "solidserver_dns_rr" "apex" {
+      dnsserver = "ns.local"
+      dnszone   = solidserver_dns_zone.zone.name
+      name      = "%s"
+      type      = "TXT"
+      value     = "v=spf1 -all"
+    }
+`, zonename, zonename, apexname)
+}
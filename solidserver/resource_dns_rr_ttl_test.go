@@ -0,0 +1,154 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSRRDefaultTTL(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sent := url.Values{}
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		sent = r.URL.Query()
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	// The zone default TTL applies to the RR unless a TTL is set
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		ttl := "86400"
+		if sent.Get("rr_ttl") != "" {
+			ttl = sent.Get("rr_ttl")
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"rr_id": "42", "ttl": ttl, "dns_name": "ns.example.com", "rr_full_name": "www.example.com", "rr_type": "A", "value1": "10.0.0.1",
+			"dnszone_name": "example.com", "dnsview_name": "#", "rr_class_name": "", "rr_class_parameters": "",
+		}})
+	})
+
+	config := map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"dnszone":   "example.com",
+		"name":      "www.example.com",
+		"type":      "A",
+		"value":     "10.0.0.1",
+	}
+
+	// Creating an RR without TTL
+	d := schema.TestResourceDataRaw(t, resourcednsrr().Schema, config)
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if _, ttlSent := sent["rr_ttl"]; ttlSent || d.Get("ttl").(int) != 86400 {
+		t.Errorf("expected the zone default TTL to apply, sent %v and got %d", sent["rr_ttl"], d.Get("ttl").(int))
+	}
+
+	// Existing states holding the former default TTL are kept as is
+	r := resourcednsrr()
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"id":        "42",
+			"dnsserver": "ns.example.com",
+			"dnsview":   "",
+			"dnszone":   "example.com",
+			"name":      "www.example.com",
+			"type":      "A",
+			"value":     "10.0.0.1",
+			"ttl":       "3600",
+			"class":     "",
+		},
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff != nil && diff.Attributes["ttl"] != nil {
+		t.Errorf("unexpected TTL diff: %v", diff.Attributes["ttl"])
+	}
+
+	// Updating an RR without TTL leaves it untouched
+	config["class"] = "rr_class"
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourcednsrrUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if _, ttlSent := sent["rr_ttl"]; ttlSent || sent.Get("rr_class_name") != "rr_class" {
+		t.Errorf("unexpected RR update: %v", sent)
+	}
+
+	// Setting a TTL keeps the exact semantics
+	config["ttl"] = 300
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourcednsrrUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sent.Get("rr_ttl") != "300" {
+		t.Errorf("expected TTL 300 to be sent, got %v", sent["rr_ttl"])
+	}
+
+	d = schema.TestResourceDataRaw(t, resourcednsrr().Schema, config)
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sent.Get("rr_ttl") != "300" || d.Get("ttl").(int) != 300 {
+		t.Errorf("expected TTL 300 to be sent on creation, got %v", sent["rr_ttl"])
+	}
+
+	// An explicit TTL of 0 is sent, rather than relying on the zone default TTL
+	config["ttl"] = 0
+	diff, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	diff.RawConfig = mockRawConfig(r, config)
+
+	d, err = schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sent.Get("rr_ttl") != "0" || d.Get("ttl").(int) != 0 {
+		t.Errorf("expected TTL 0 to be sent on creation, got %v", sent["rr_ttl"])
+	}
+}
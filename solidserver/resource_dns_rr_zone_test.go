@@ -0,0 +1,179 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDNSZoneLongestSuffix(t *testing.T) {
+	zones := []string{"example.com", "internal.example.com", "ple.com", "com.internal.example.com"}
+
+	cases := map[string]string{
+		"app.internal.example.com":  "internal.example.com",
+		"APP.Internal.Example.com.": "internal.example.com",
+		"internal.example.com":      "internal.example.com",
+		"www.example.com":           "example.com",
+		"xinternal.example.com":     "example.com",
+		"www.example.org":           "",
+	}
+
+	for name, expected := range cases {
+		if zone := dnszonelongestsuffix(name, zones); zone != expected {
+			t.Errorf("%s: expected zone %q, got %q", name, expected, zone)
+		}
+	}
+}
+
+func TestDNSZoneFindByRRNamePaginated(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// The nested zone is only listed on the second page
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		zones := []map[string]interface{}{}
+
+		for i := offset; i < 1500 && i < offset+listPageSize; i++ {
+			zones = append(zones, map[string]interface{}{"dnszone_name": fmt.Sprintf("zone%d.example.com", i)})
+		}
+
+		if offset == 0 {
+			zones[0]["dnszone_name"] = "example.com"
+		}
+
+		if len(zones) == 0 {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		if offset == listPageSize {
+			zones[len(zones)-1]["dnszone_name"] = "internal.example.com"
+		}
+
+		mockReply(w, http.StatusOK, zones)
+	})
+
+	if zone, err := dnszonefindbyrrname("ns.example.com", "", "app.internal.example.com", s); err != nil || zone != "internal.example.com" {
+		t.Errorf("expected zone internal.example.com, got %q (%v)", zone, err)
+	}
+}
+
+func TestDNSRRCreateResolvesNestedZone(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentZone := ""
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"dnszone_name": "example.com"},
+			{"dnszone_name": "internal.example.com"},
+		})
+	})
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		sentZone = r.URL.Query().Get("dnszone_name")
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"rr_id": "42", "ttl": "3600", "dns_name": "ns.example.com", "rr_full_name": "app.internal.example.com", "rr_type": "A", "value1": "10.0.0.1",
+			"dnszone_name": sentZone, "dnsview_name": "#", "rr_class_name": "", "rr_class_parameters": "",
+		}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "app.internal.example.com",
+		"type":      "A",
+		"value":     "10.0.0.1",
+	})
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sentZone != "internal.example.com" {
+		t.Errorf("expected RR to be created in internal.example.com, got %q", sentZone)
+	}
+
+	if d.Get("dnszone").(string) != "internal.example.com" {
+		t.Errorf("expected dnszone to be stored in state, got %q", d.Get("dnszone").(string))
+	}
+
+	// An explicit zone overrides the resolution
+	d = schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"dnszone":   "example.com",
+		"name":      "app.internal.example.com",
+		"type":      "A",
+		"value":     "10.0.0.1",
+	})
+
+	if diags := resourcednsrrCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sentZone != "example.com" || m.count("/rest/dns_zone_list") != 1 {
+		t.Errorf("expected explicit zone example.com without lookup, got %q (%d lookups)", sentZone, m.count("/rest/dns_zone_list"))
+	}
+}
+
+func TestDNSRRReadPopulatesZone(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	rr := map[string]interface{}{
+		"rr_id":               "42",
+		"ttl":                 "3600",
+		"dns_name":            "ns.example.com",
+		"rr_full_name":        "app.internal.example.com",
+		"rr_type":             "A",
+		"value1":              "10.0.0.1",
+		"dnszone_name":        "internal.example.com",
+		"dnsview_name":        "#",
+		"rr_class_name":       "",
+		"rr_class_parameters": "",
+	}
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{rr})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "app.internal.example.com",
+		"type":      "A",
+		"value":     "10.0.0.1",
+	})
+	d.SetId("42")
+
+	if diags := resourcednsrrRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("dnszone").(string) != "internal.example.com" {
+		t.Errorf("expected dnszone to be read, got %q", d.Get("dnszone").(string))
+	}
+
+	// Timestamps are not reported by older SOLIDserver versions
+	if d.Get("created_at").(string) != "" || d.Get("updated_at").(string) != "" {
+		t.Errorf("expected empty timestamps, got %q and %q", d.Get("created_at").(string), d.Get("updated_at").(string))
+	}
+
+	rr["rr_create_time"] = "1700000000"
+	rr["rr_update_time"] = "1700003600"
+
+	if diags := resourcednsrrRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("created_at").(string) != "2023-11-14T22:13:20Z" || d.Get("updated_at").(string) != "2023-11-14T23:13:20Z" {
+		t.Errorf("unexpected timestamps: %q and %q", d.Get("created_at").(string), d.Get("updated_at").(string))
+	}
+}
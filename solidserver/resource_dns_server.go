@@ -17,6 +17,13 @@ import (
 	"time"
 )
 
+var (
+	// Initial delay between two checks of the readiness of a DNS server
+	dnsServerReadyRetryDelay = 4 * time.Second
+	// Initial delay between two attempts of deleting a DNS server
+	dnsServerDeleteRetryDelay = 8 * time.Second
+)
+
 func resourcednsserver() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcednsserverCreate,
@@ -26,6 +33,10 @@ func resourcednsserver() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcednsserverImportState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Description: heredoc.Doc(`
 			DNS Server resource allows to register and configure DNS servers.
@@ -156,15 +167,25 @@ func resourcednsserver() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the DNS server.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the DNS server.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the DNS server's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: resourcediffvalidateclass("dns_server"),
 	}
 }
 
@@ -241,7 +262,7 @@ func resourcednsserverCreate(ctx context.Context, d *schema.ResourceData, meta i
 	parameters.Add("dns_allow_recursion", allowRecursions)
 
 	parameters.Add("dns_class_name", d.Get("class").(string))
-	parameters.Add("dns_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("dns_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/dns_add", &parameters)
@@ -254,6 +275,7 @@ func resourcednsserverCreate(ctx context.Context, d *schema.ResourceData, meta i
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created DNS server (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindDNSServerParam)
 				d.SetId(oid)
 
 				loginHash := sha256.Sum256([]byte(d.Get("login").(string)))
@@ -262,17 +284,22 @@ func resourcednsserverCreate(ctx context.Context, d *schema.ResourceData, meta i
 				d.Set("login", hex.EncodeToString(loginHash[:]))
 				d.Set("password", hex.EncodeToString(passwordHash[:]))
 
-				if strings.ToLower(d.Get("smart").(string)) != "" {
-					//FIXME - Handle Errors
-					dnsaddtosmart(strings.ToLower(d.Get("smart").(string)), strings.ToLower(d.Get("name").(string)), strings.ToLower(d.Get("smart_role").(string)), meta)
-				}
+				// Registering the server in its SMART, the server is rolled back on failure
+				if smartName := strings.ToLower(d.Get("smart").(string)); smartName != "" {
+					createSteps := newcreatesteps("DNS server", strings.ToLower(d.Get("name").(string)), "registration in SMART "+smartName)
 
-				// Wait as much as possible for for the DNS server to be ready
-				for attempts := 0; attempts < 12; attempts++ {
-					if dnsserverstatus(d.Id(), meta) == "Y" {
-						break
+					if !dnsaddtosmart(smartName, strings.ToLower(d.Get("name").(string)), strings.ToLower(d.Get("smart_role").(string)), meta) {
+						return createSteps.fail(ctx, d, "registration in SMART "+smartName, fmt.Errorf("SOLIDServer - Unable to add DNS server to SMART: %s\n", smartName), func() error {
+							return objectdelete("DNS server", "rest/dns_delete", "dns_id", oid, meta)
+						})
 					}
-					time.Sleep(time.Duration(8 * time.Second))
+				}
+
+				// Wait as much as possible for the DNS server to be ready
+				if err := waituntil(ctx, d.Timeout(schema.TimeoutCreate), dnsServerReadyRetryDelay, func() (bool, error) {
+					return dnsserverstatus(d.Id(), meta) == "Y", nil
+				}); err != nil {
+					tflog.Warn(ctx, fmt.Sprintf("DNS server not ready yet: %s (%s)\n", strings.ToLower(d.Get("name").(string)), err))
 				}
 
 				return nil
@@ -363,7 +390,7 @@ func resourcednsserverUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	parameters.Add("dns_allow_recursion", allowRecursions)
 
 	parameters.Add("dns_class_name", d.Get("class").(string))
-	parameters.Add("dns_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("dns_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/dns_add", &parameters)
@@ -376,6 +403,7 @@ func resourcednsserverUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated DNS server (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindDNSServerParam)
 				d.SetId(oid)
 				return nil
 			}
@@ -398,70 +426,78 @@ func resourcednsserverUpdate(ctx context.Context, d *schema.ResourceData, meta i
 func resourcednsserverDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	for i := 0; i < 3; i++ {
+	if strings.ToLower(d.Get("smart").(string)) != "" {
+		//FIXME - Handle Errors
+		dnsdeletefromsmart(strings.ToLower(d.Get("smart").(string)), strings.ToLower(d.Get("name").(string)), meta)
+
+		//FIXME - Based on a given option set to false by default, use the following to clean up the server
+		//call "object_delete?calling_action=mod_dns_zone_list&selected_query=" + urlencode("dns_zone_list WHERE=dns_id+%3D'<ID>')
+		//call "object_delete?calling_action=mod_dns_view_list&selected_query=" + urlencode("dns_view_list WHERE=dns_id+%3D'<ID>')
+	}
+
+	// Retrying the deletion once all views and zones are deleted
+	pending := false
+
+	err := waituntil(ctx, d.Timeout(schema.TimeoutDelete), dnsServerDeleteRetryDelay, func() (bool, error) {
+		// Failures are left to the deletion request, reporting the DNS server as missing or the transport error
+		pendingCount, pendingErr := dnsserverpendingdeletions(d.Id(), meta)
+		if pending = pendingErr == nil && pendingCount != 0; pending {
+			return false, nil
+		}
+
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("dns_id", d.Id())
 
-		if strings.ToLower(d.Get("smart").(string)) != "" {
-			//FIXME - Handle Errors
-			dnsdeletefromsmart(strings.ToLower(d.Get("smart").(string)), strings.ToLower(d.Get("name").(string)), meta)
+		// Sending the deletion request
+		resp, body, err := s.Request("delete", "rest/dns_delete", &parameters)
+
+		if err != nil {
+			return false, err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
 
-			//FIXME - Based on a given option set to false by default, use the following to clean up the server
-			//call "object_delete?calling_action=mod_dns_zone_list&selected_query=" + urlencode("dns_zone_list WHERE=dns_id+%3D'<ID>')
-			//call "object_delete?calling_action=mod_dns_view_list&selected_query=" + urlencode("dns_view_list WHERE=dns_id+%3D'<ID>')
+		// Checking the answer, a DNS server already deleted is reported as missing
+		if resp.StatusCode == 200 || resp.StatusCode == 204 || objectmissing(resp.StatusCode, buf) {
+			return true, nil
 		}
 
-		// Wait for all views and zones to be deleted, fail after 3 attempts
-		attempts := 0
-		for attempts = 0; attempts < 3; attempts++ {
-			if dnsserverpendingdeletions(d.Id(), meta) == 0 {
-				break
+		// Logging a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS server: %s (%s)", strings.ToLower(d.Get("name").(string)), errMsg))
 			}
-			time.Sleep(time.Duration(32 * time.Second))
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS server: %s", strings.ToLower(d.Get("name").(string))))
 		}
 
+		return false, nil
+	})
+
+	if err == errWaitTimeout {
 		// Reporting a failure
-		if attempts >= 3 {
+		if pending {
 			return diag.Errorf("Unable to delete DNS server: Too many unsuccessful deletion attempts (Pending operations)")
 		}
 
-		// Sending the deletion request
-		resp, body, err := s.Request("delete", "rest/dns_delete", &parameters)
-
-		if err == nil {
-			var buf [](map[string]interface{})
-			json.Unmarshal([]byte(body), &buf)
+		return diag.Errorf("Unable to delete DNS server: Too many unsuccessful deletion attempts")
+	}
 
-			// Checking the answer
-			if resp.StatusCode == 200 || resp.StatusCode == 204 {
-				// Log deletion
-				tflog.Debug(ctx, fmt.Sprintf("Deleted DNS server (oid): %s\n", d.Id()))
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
 
-				// Unset local ID
-				d.SetId("")
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted DNS server (oid): %s\n", d.Id()))
 
-				// Reporting a success
-				return nil
-			} else {
-				// Logging a failure
-				if len(buf) > 0 {
-					if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-						tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS server: %s (%s)", strings.ToLower(d.Get("name").(string)), errMsg))
-					}
-				} else {
-					tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS server: %s", strings.ToLower(d.Get("name").(string))))
-				}
-				time.Sleep(time.Duration(8 * time.Second))
-			}
-		} else {
-			// Reporting a failure
-			return diag.FromErr(err)
-		}
-	}
+	// Unset local ID
+	d.SetId("")
 
-	// Reporting a failure
-	return diag.Errorf("Unable to delete DNS server: Too many unsuccessful deletion attempts")
+	// Reporting a success
+	return nil
 }
 
 func resourcednsserverRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -546,6 +582,12 @@ func resourcednsserverRead(ctx context.Context, d *schema.ResourceData, meta int
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -660,6 +702,12 @@ func resourcednsserverImportState(ctx context.Context, d *schema.ResourceData, m
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
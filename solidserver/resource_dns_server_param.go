@@ -0,0 +1,167 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strings"
+)
+
+// DNS server params managed by the solidserver_dns_server and solidserver_dns_view resources (forward mode and forwarders)
+var dnsServerParamBlockedKeys = []string{"forward", "forwarders", "recursion", "allow-query", "allow-transfer", "allow-recursion"}
+
+func resourcednsserverparam() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcednsserverparamCreate,
+		ReadContext:   resourcednsserverparamRead,
+		UpdateContext: resourcednsserverparamUpdate,
+		DeleteContext: resourcednsserverparamDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcednsserverparamImportState,
+		},
+
+		Description: heredoc.Doc(`
+			DNS server param resource allows to set and manage a global option of a DNS server (ex: recursion, allow-query, max-cache-ttl).
+			Values are sent as is, lists must be provided as semicolon separated values (ex: 10.0.0.0/8;192.168.0.0/16;).
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The name of DNS server to set the param on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Description:  "The key of the DNS server param to set. The following keys are managed by the solidserver_dns_server and solidserver_dns_view resources and can't be used: " + strings.Join(dnsServerParamBlockedKeys, ", ") + ".",
+				ValidateFunc: validation.All(validation.NoZeroValues, validation.StringNotInSlice(dnsServerParamBlockedKeys, true)),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Description: "The value of the DNS server param.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func resourcednsserverparamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serverName := d.Get("dnsserver").(string)
+	paramKey := d.Get("key").(string)
+
+	// Refuse to take over a param already set on the DNS server
+	if _, paramFound, paramErr := dnsparamget(serverName, "", paramKey, meta); paramErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to create DNS server param: %s on %s (%s)", paramKey, serverName, paramErr)
+	} else if paramFound {
+		// Reporting a failure
+		return diag.Errorf("Unable to create DNS server param: %s on %s, already set (import it using: %s/%s)\n", paramKey, serverName, serverName, paramKey)
+	}
+
+	if !dnsparamset(serverName, "", paramKey, d.Get("value").(string), meta) {
+		// Reporting a failure
+		return diag.Errorf("Unable to create DNS server param: %s on %s\n", paramKey, serverName)
+	}
+
+	lookupcacheinvalidate(meta, cacheKindDNSServerParam)
+
+	tflog.Debug(ctx, fmt.Sprintf("Created DNS server param: %s on %s\n", paramKey, serverName))
+	d.SetId(serverName + "/" + paramKey)
+
+	return nil
+}
+
+func resourcednsserverparamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serverName := d.Get("dnsserver").(string)
+	paramKey := d.Get("key").(string)
+
+	if !dnsparamset(serverName, "", paramKey, d.Get("value").(string), meta) {
+		// Reporting a failure
+		return diag.Errorf("Unable to update DNS server param: %s on %s\n", paramKey, serverName)
+	}
+
+	lookupcacheinvalidate(meta, cacheKindDNSServerParam)
+
+	tflog.Debug(ctx, fmt.Sprintf("Updated DNS server param: %s on %s\n", paramKey, serverName))
+
+	return nil
+}
+
+func resourcednsserverparamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serverName := d.Get("dnsserver").(string)
+	paramKey := d.Get("key").(string)
+
+	if !dnsparamunset(serverName, "", paramKey, meta) {
+		// Reporting a failure
+		return diag.Errorf("Unable to delete DNS server param: %s on %s\n", paramKey, serverName)
+	}
+
+	lookupcacheinvalidate(meta, cacheKindDNSServerParam)
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted DNS server param: %s on %s\n", paramKey, serverName))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourcednsserverparamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serverName := d.Get("dnsserver").(string)
+	paramKey := d.Get("key").(string)
+
+	paramValue, paramFound, paramErr := dnsparamget(serverName, "", paramKey, meta)
+
+	if paramErr != nil {
+		// Reporting a failure
+		return diag.FromErr(paramErr)
+	}
+
+	if !paramFound {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find DNS server param: %s on %s\n", paramKey, serverName))
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find DNS server param: %s on %s\n", paramKey, serverName)
+	}
+
+	d.Set("value", paramValue)
+
+	return nil
+}
+
+func resourcednsserverparamImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := importidparse(d.Id(), 2)
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("SOLIDServer - Unable to import DNS server param: %s, expected ID: <dnsserver>/<key>\n", d.Id())
+	}
+
+	paramValue, paramFound, paramErr := dnsparamget(parts[0], "", parts[1], meta)
+
+	if paramErr != nil {
+		return nil, paramErr
+	}
+
+	if !paramFound {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import DNS server param: %s on %s\n", parts[1], parts[0])
+	}
+
+	d.SetId(parts[0] + "/" + parts[1])
+	d.Set("dnsserver", parts[0])
+	d.Set("key", parts[1])
+	d.Set("value", paramValue)
+
+	return []*schema.ResourceData{d}, nil
+}
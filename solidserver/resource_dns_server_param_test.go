@@ -0,0 +1,170 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Emulate the DNS server params of ns.example.com
+func mockDNSServerParams(t *testing.T, m *mockSOLIDserver, params map[string]string) {
+	m.handle("/rest/dns_server_param_list", func(w http.ResponseWriter, r *http.Request) {
+		where := r.URL.Query().Get("WHERE")
+		if !strings.HasPrefix(where, "dns_name='ns.example.com'") || (r.URL.Query().Get("offset") != "" && r.URL.Query().Get("offset") != "0") {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		keys := []string{}
+		for k := range params {
+			if where == "dns_name='ns.example.com'" || where == "dns_name='ns.example.com' AND param_key='"+k+"'" {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		rows := []map[string]interface{}{}
+		for _, k := range keys {
+			rows = append(rows, map[string]interface{}{"dns_name": "ns.example.com", "param_key": k, "param_value": params[k]})
+		}
+		mockReply(w, http.StatusOK, rows)
+	})
+
+	m.handle("/rest/dns_server_param_add", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("dns_name") != "ns.example.com" {
+			t.Errorf("unexpected DNS server: %s", r.URL.Query().Get("dns_name"))
+		}
+		params[r.URL.Query().Get("param_key")] = r.URL.Query().Get("param_value")
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "1"}})
+	})
+
+	m.handle("/rest/dns_server_param_delete", func(w http.ResponseWriter, r *http.Request) {
+		delete(params, r.URL.Query().Get("param_key"))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "1"}})
+	})
+}
+
+func TestDNSServerParam(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	params := map[string]string{"notify": "yes"}
+	mockDNSServerParams(t, m, params)
+
+	// Lists of values are kept as is, including their trailing semicolon
+	blackhole := "10.0.0.0/8;!192.168.1.0/24;192.168.0.0/16;"
+
+	d := schema.TestResourceDataRaw(t, resourcednsserverparam().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"key":       "blackhole",
+		"value":     blackhole,
+	})
+
+	if diags := resourcednsserverparamCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "ns.example.com/blackhole" || params["blackhole"] != blackhole {
+		t.Errorf("unexpected DNS server param creation: %s (%v)", d.Id(), params)
+	}
+
+	d.Set("value", "")
+	if diags := resourcednsserverparamRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("value").(string) != blackhole {
+		t.Errorf("expected the value to round-trip, got: %s", d.Get("value").(string))
+	}
+
+	// Params already set must be imported
+	d = schema.TestResourceDataRaw(t, resourcednsserverparam().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"key":       "notify",
+		"value":     "no",
+	})
+
+	if diags := resourcednsserverparamCreate(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "already set") {
+		t.Fatalf("expected an already set error, got %v", diags)
+	}
+
+	if params["notify"] != "yes" {
+		t.Errorf("unexpected DNS server param update: %v", params)
+	}
+
+	d.SetId("ns.example.com/notify")
+	imported, err := resourcednsserverparamImportState(context.Background(), d, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if imported[0].Get("dnsserver").(string) != "ns.example.com" || imported[0].Get("key").(string) != "notify" || imported[0].Get("value").(string) != "yes" {
+		t.Errorf("unexpected imported DNS server param: %v", imported[0].State())
+	}
+
+	// Update then delete the param
+	d.Set("value", "no")
+	if diags := resourcednsserverparamUpdate(context.Background(), d, s); diags.HasError() || params["notify"] != "no" {
+		t.Fatalf("unexpected DNS server param update: %v (%v)", params, diags)
+	}
+
+	if diags := resourcednsserverparamDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if _, exist := params["notify"]; exist || d.Id() != "" {
+		t.Errorf("expected the DNS server param to be deleted: %v", params)
+	}
+
+	// Importing a param not set fails
+	d.SetId("ns.example.com/notify")
+	if _, err := resourcednsserverparamImportState(context.Background(), d, s); err == nil {
+		t.Errorf("expected an import error")
+	}
+}
+
+func TestDNSServerParamBlockedKeys(t *testing.T) {
+	r := resourcednsserverparam()
+
+	for _, key := range []string{"forward", "Forwarders", "recursion", "allow-query", "Allow-Transfer", "allow-recursion"} {
+		diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"dnsserver": "ns.example.com",
+			"key":       key,
+			"value":     "only",
+		}))
+
+		if !diags.HasError() {
+			t.Errorf("expected key %s to be refused", key)
+		}
+	}
+
+	if diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"key":       "max-cache-ttl",
+		"value":     "3600",
+	})); diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+}
+
+func TestDNSServerParamsDataSource(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mockDNSServerParams(t, m, map[string]string{"recursion": "yes", "allow-query": "10.0.0.0/8;192.168.0.0/16;"})
+
+	d := schema.TestResourceDataRaw(t, dataSourcednsserverparams().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+	})
+
+	if diags := dataSourcednsserverparamsRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	params := d.Get("params").(map[string]interface{})
+
+	if len(params) != 2 || params["recursion"] != "yes" || params["allow-query"] != "10.0.0.0/8;192.168.0.0/16;" {
+		t.Errorf("unexpected DNS server params: %v", params)
+	}
+}
@@ -0,0 +1,41 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDNSServerDeleteMissing(t *testing.T) {
+	defer func(delay time.Duration) { dnsServerDeleteRetryDelay = delay }(dnsServerDeleteRetryDelay)
+	dnsServerDeleteRetryDelay = time.Millisecond
+
+	m, s := newMockSOLIDserver(t)
+
+	// The pending operations of a DNS server already deleted can't be counted
+	for _, service := range []string{"/rest/dns_zone_count", "/rest/dns_view_count"} {
+		m.handle(service, func(w http.ResponseWriter, r *http.Request) {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "DNS server not found"}})
+		})
+	}
+
+	m.handle("/rest/dns_delete", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "The object does not exist"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednsserver().Schema, map[string]interface{}{
+		"name": "ns01.example.com",
+	})
+	d.SetId("42")
+
+	if diags := resourcednsserverDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "" || m.count("/rest/dns_delete") != 1 {
+		t.Errorf("expected the missing DNS server to be deleted at once, got %d attempts", m.count("/rest/dns_delete"))
+	}
+}
@@ -113,15 +113,25 @@ func resourcednssmart() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the DNS SMART.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the DNS SMART.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the DNS SMART's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: resourcediffvalidateclass("dns_server"),
 	}
 }
 
@@ -193,7 +203,7 @@ func resourcednssmartCreate(ctx context.Context, d *schema.ResourceData, meta in
 	parameters.Add("dns_allow_recursion", allowRecursions)
 
 	parameters.Add("dns_class_name", d.Get("class").(string))
-	parameters.Add("dns_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("dns_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/dns_add", &parameters)
@@ -206,6 +216,7 @@ func resourcednssmartCreate(ctx context.Context, d *schema.ResourceData, meta in
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created DNS SMART (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindDNSServerParam)
 				d.SetId(oid)
 				return nil
 			}
@@ -293,7 +304,7 @@ func resourcednssmartUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	parameters.Add("dns_allow_recursion", allowRecursions)
 
 	parameters.Add("dns_class_name", d.Get("class").(string))
-	parameters.Add("dns_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("dns_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/dns_add", &parameters)
@@ -306,6 +317,7 @@ func resourcednssmartUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated DNS SMART (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindDNSServerParam)
 				d.SetId(oid)
 				return nil
 			}
@@ -447,6 +459,12 @@ func resourcednssmartRead(ctx context.Context, d *schema.ResourceData, meta inte
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -561,6 +579,12 @@ func resourcednssmartImportState(ctx context.Context, d *schema.ResourceData, me
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -18,6 +18,9 @@ import (
 	"time"
 )
 
+// Initial delay between two attempts of deleting a DNS view
+var dnsViewDeleteRetryDelay = 8 * time.Second
+
 func resourcednsview() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcednsviewCreate,
@@ -27,11 +30,16 @@ func resourcednsview() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcednsviewImportState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
 
 		Description: heredoc.Doc(`
 			DNS View resource allows to create and configure DNS views.
 			View(s) are virutal containers mostly used to implement DNS split horizon
 			providing different answers depending on matching criterias.
+			Views can be imported using their oid or their server and name (ex: "ns01.local/internal").
+			Reserved characters within names can be URL-style escaped (ex: %2F for "/", %3A for ":").
 		`),
 
 		Schema: map[string]*schema.Schema{
@@ -77,6 +85,69 @@ func resourcednsview() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"effective_forward": {
+				Type:        schema.TypeString,
+				Description: "The forwarding mode in effect on the DNS view, inherited from the DNS server when the view doesn't override it.",
+				Computed:    true,
+			},
+			"effective_forwarders": {
+				Type:        schema.TypeList,
+				Description: "The forwarders in effect on the DNS view, inherited from the DNS server when the view doesn't override them.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"allow_new_zones": {
+				Type:        schema.TypeBool,
+				Description: "Allow new zones to be added to the DNS view (Default: true). Zones created in the view by the same configuration must exist before it is set to false.",
+				Optional:    true,
+				Default:     true,
+			},
+			"sortlist": {
+				Type:        schema.TypeList,
+				Description: "The sortlist of the DNS view, answers matching the prefer prefix are returned first to the clients matching the source prefix.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:         schema.TypeString,
+							Description:  "The network prefix matching the clients.",
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(regexpNetworkAcl), "Only network prefixes are supported for DNS view's sortlist"),
+							Required:     true,
+						},
+						"prefer": {
+							Type:         schema.TypeString,
+							Description:  "The network prefix of the answers to return first.",
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(regexpNetworkAcl), "Only network prefixes are supported for DNS view's sortlist"),
+							Required:     true,
+						},
+					},
+				},
+			},
+			"rate_limit": {
+				Type:        schema.TypeList,
+				Description: "The response rate limiting of the DNS view.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"responses_per_second": {
+							Type:         schema.TypeInt,
+							Description:  "The number of identical responses per second allowed to a client network.",
+							ValidateFunc: validation.IntAtLeast(0),
+							Required:     true,
+						},
+						"window": {
+							Type:         schema.TypeInt,
+							Description:  "The number of seconds over which the responses are accounted (Default: 15).",
+							ValidateFunc: validation.IntBetween(1, 3600),
+							Optional:     true,
+							Default:      15,
+						},
+					},
+				},
+			},
 			// ACL(s)
 			// Views and Servers/SMARTs
 			"allow_transfer": {
@@ -133,16 +204,27 @@ func resourcednsview() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the view.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the view.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the view's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
 		CustomizeDiff: customdiff.All(
+			resourcediffvalidateclass("dns_view"),
+			resourcediffvalidatednsserver(""),
 			customdiff.ValidateChange("name", func(ctx context.Context, old, new, meta any) error {
 				if strings.ToLower(new.(string)) != new.(string) && strings.ToLower(old.(string)) == old.(string) && strings.ToLower(new.(string)) == strings.ToLower(old.(string)) {
 					return fmt.Errorf("View name contains upper case characters (%s), remote name matches but is lower case (%s). Consider fixing your .tf file(s).", new.(string), old.(string))
@@ -156,6 +238,37 @@ func resourcednsview() *schema.Resource {
 func resourcednsviewCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Building forward mode and forward list
+	fwdList := ""
+	for _, fwd := range toStringArray(d.Get("forwarders").([]interface{})) {
+		fwdList += fwd + ";"
+	}
+
+	if d.Get("forward").(string) == "none" && fwdList != "" {
+		return diag.Errorf("Error creating DNS view: %s (Forward mode set to 'none' but forwarders list is not empty).", d.Get("name").(string))
+	}
+
+	// Building the parameters set once the view is created, the forward mode of a new view is none
+	viewParams := [][2]string{}
+
+	if d.Get("forward").(string) != "none" {
+		viewParams = append(viewParams, [2]string{"forward", strings.ToLower(d.Get("forward").(string))}, [2]string{"forwarders", fwdList})
+	}
+
+	// Locking the view against new zones
+	if !d.Get("allow_new_zones").(bool) {
+		viewParams = append(viewParams, [2]string{"allow-new-zones", "no"})
+	}
+
+	// Configuring sortlist and response rate limiting
+	if sortlist := dnsviewsortlistfromlist(d.Get("sortlist").([]interface{})); sortlist != "" {
+		viewParams = append(viewParams, [2]string{"sortlist", sortlist})
+	}
+
+	if rateLimit := dnsviewratelimitfromlist(d.Get("rate_limit").([]interface{})); rateLimit != "" {
+		viewParams = append(viewParams, [2]string{"rate-limit", rateLimit})
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("add_flag", "new_only")
@@ -221,7 +334,7 @@ func resourcednsviewCreate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("dnsview_match_to", matchTos)
 
 	parameters.Add("dnsview_class_name", d.Get("class").(string))
-	parameters.Add("dnsview_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("dnsview_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/dns_view_add", &parameters)
@@ -236,21 +349,23 @@ func resourcednsviewCreate(ctx context.Context, d *schema.ResourceData, meta int
 				tflog.Debug(ctx, fmt.Sprintf("Created DNS view (oid): %s\n", oid))
 				d.SetId(oid)
 
-				// Building forward mode and forward list
-				fwdList := ""
-				for _, fwd := range toStringArray(d.Get("forwarders").([]interface{})) {
-					fwdList += fwd + ";"
+				// Setting the view parameters, the view is rolled back on failure
+				steps := []string{}
+
+				for _, viewParam := range viewParams {
+					steps = append(steps, viewParam[0]+" parameter")
 				}
 
-				if d.Get("forward").(string) == "none" {
-					if fwdList != "" {
-						return diag.Errorf("Error creating DNS view: %s (Forward mode set to 'none' but forwarders list is not empty).", d.Get("name").(string))
+				createSteps := newcreatesteps("DNS view", d.Get("name").(string), steps...)
+
+				for i, viewParam := range viewParams {
+					if !dnsparamset(d.Get("dnsserver").(string), oid, viewParam[0], viewParam[1], meta) {
+						return createSteps.fail(ctx, d, steps[i], fmt.Errorf("SOLIDServer - Unable to set DNS view parameter: %s\n", viewParam[0]), func() error {
+							return objectdelete("DNS view", "rest/dns_view_delete", "dnsview_id", oid, meta)
+						})
 					}
-					// NOT required at creation time - dnsparamunset(d.Get("dnsserver").(string), oid, "forward", meta)
-					dnsparamset(d.Get("dnsserver").(string), oid, "forwarders", "", meta)
-				} else {
-					dnsparamset(d.Get("dnsserver").(string), oid, "forward", strings.ToLower(d.Get("forward").(string)), meta)
-					dnsparamset(d.Get("dnsserver").(string), oid, "forwarders", fwdList, meta)
+
+					createSteps.done(steps[i])
 				}
 
 				return nil
@@ -343,7 +458,7 @@ func resourcednsviewUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("dnsview_match_to", matchTos)
 
 	parameters.Add("dnsview_class_name", d.Get("class").(string))
-	parameters.Add("dnsview_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("dnsview_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/dns_view_add", &parameters)
@@ -364,16 +479,63 @@ func resourcednsviewUpdate(ctx context.Context, d *schema.ResourceData, meta int
 					fwdList += fwd + ";"
 				}
 
+				// Parameters to set (key and value) or to unset (key only)
+				viewParams := [][]string{}
+
+				// Params are only unset when changed or set on the view, leaving the ones managed outside Terraform untouched
+				unsetParam := func(paramKey string, changed bool) {
+					if !changed {
+						if _, paramFound, paramErr := dnsparamget(d.Get("dnsserver").(string), oid, paramKey, meta); paramErr != nil || !paramFound {
+							return
+						}
+					}
+					viewParams = append(viewParams, []string{paramKey})
+				}
+
 				if d.Get("forward").(string) == "none" {
 					if fwdList != "" {
 						return diag.Errorf("Error creating DNS view: %s (Forward mode set to 'none' but forwarders list is not empty).", d.Get("name").(string))
 					}
-					dnsparamunset(d.Get("dnsserver").(string), oid, "forward", meta)
-					dnsparamset(d.Get("dnsserver").(string), oid, "forwarders", "", meta)
+					unsetParam("forward", d.HasChange("forward"))
+					unsetParam("forwarders", d.HasChange("forwarders"))
 				} else {
-					dnsparamset(d.Get("dnsserver").(string), oid, "forward", strings.ToLower(d.Get("forward").(string)), meta)
-					dnsparamset(d.Get("dnsserver").(string), oid, "forwarders", fwdList, meta)
+					viewParams = append(viewParams, []string{"forward", strings.ToLower(d.Get("forward").(string))}, []string{"forwarders", fwdList})
+				}
+
+				// Updating zone creation permissions
+				if d.Get("allow_new_zones").(bool) {
+					unsetParam("allow-new-zones", d.HasChange("allow_new_zones"))
+				} else {
+					viewParams = append(viewParams, []string{"allow-new-zones", "no"})
+				}
+
+				// Updating sortlist and response rate limiting, removed blocks unset the related params
+				if d.HasChange("sortlist") {
+					if sortlist := dnsviewsortlistfromlist(d.Get("sortlist").([]interface{})); sortlist != "" {
+						viewParams = append(viewParams, []string{"sortlist", sortlist})
+					} else {
+						viewParams = append(viewParams, []string{"sortlist"})
+					}
 				}
+
+				if d.HasChange("rate_limit") {
+					if rateLimit := dnsviewratelimitfromlist(d.Get("rate_limit").([]interface{})); rateLimit != "" {
+						viewParams = append(viewParams, []string{"rate-limit", rateLimit})
+					} else {
+						viewParams = append(viewParams, []string{"rate-limit"})
+					}
+				}
+
+				for _, viewParam := range viewParams {
+					if len(viewParam) == 1 && !dnsparamunset(d.Get("dnsserver").(string), oid, viewParam[0], meta) {
+						return diag.Errorf("Unable to update DNS view: %s, unable to unset its %s parameter\n", d.Get("name").(string), viewParam[0])
+					}
+
+					if len(viewParam) == 2 && !dnsparamset(d.Get("dnsserver").(string), oid, viewParam[0], viewParam[1], meta) {
+						return diag.Errorf("Unable to update DNS view: %s, unable to set its %s parameter\n", d.Get("name").(string), viewParam[0])
+					}
+				}
+
 				return nil
 			}
 		}
@@ -398,7 +560,8 @@ func resourcednsviewUpdate(ctx context.Context, d *schema.ResourceData, meta int
 func resourcednsviewDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	for i := 0; i < 3; i++ {
+	// Retrying the deletion until the view gets deletable (ex: zones pending deletion)
+	err := waituntil(ctx, d.Timeout(schema.TimeoutDelete), dnsViewDeleteRetryDelay, func() (bool, error) {
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("dnsview_id", d.Id())
@@ -406,39 +569,48 @@ func resourcednsviewDelete(ctx context.Context, d *schema.ResourceData, meta int
 		// Sending the deletion request
 		resp, body, err := s.Request("delete", "rest/dns_view_delete", &parameters)
 
-		if err == nil {
-			var buf [](map[string]interface{})
-			json.Unmarshal([]byte(body), &buf)
+		if err != nil {
+			return false, err
+		}
 
-			// Checking the answer
-			if resp.StatusCode == 200 || resp.StatusCode == 204 {
-				// Log deletion
-				tflog.Debug(ctx, fmt.Sprintf("Deleted DNS view (oid): %s\n", d.Id()))
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
 
-				// Unset local ID
-				d.SetId("")
+		// Checking the answer
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			return true, nil
+		}
 
-				// Reporting a success
-				return nil
-			} else {
-				// Logging a failure
-				if len(buf) > 0 {
-					if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-						tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS view: %s (%s)", d.Get("name").(string), errMsg))
-					}
-				} else {
-					tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS view: %s", d.Get("name").(string)))
-				}
-				time.Sleep(time.Duration(8 * time.Second))
+		// Logging a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS view: %s (%s)", d.Get("name").(string), errMsg))
 			}
 		} else {
-			// Reporting a failure
-			return diag.FromErr(err)
+			tflog.Debug(ctx, fmt.Sprintf("Unable to delete DNS view: %s", d.Get("name").(string)))
 		}
+
+		return false, nil
+	})
+
+	if err == errWaitTimeout {
+		// Reporting a failure
+		return diag.Errorf("Unable to delete DNS view: Too many unsuccessful deletion attempts")
 	}
 
-	// Reporting a failure
-	return diag.Errorf("Unable to delete DNS view: Too many unsuccessful deletion attempts")
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted DNS view (oid): %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
 }
 
 func resourcednsviewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -470,31 +642,8 @@ func resourcednsviewRead(ctx context.Context, d *schema.ResourceData, meta inter
 				d.Set("recursion", false)
 			}
 
-			// Updating forward mode
-			forward, forwardErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forward", meta)
-			if forwardErr == nil {
-				if forward == "" {
-					d.Set("forward", "none")
-				} else {
-					d.Set("forward", strings.ToLower(forward))
-				}
-			} else {
-				tflog.Debug(ctx, fmt.Sprintf("Unable to DNS view's forward mode (oid): %s\n", d.Id()))
-				d.Set("forward", "none")
-			}
-
-			// Updating forwarder information
-			forwarders, forwardersErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forwarders", meta)
-			if forwardersErr == nil {
-				if forwarders != "" {
-					d.Set("forwarders", toStringArrayInterface(strings.Split(strings.TrimSuffix(forwarders, ";"), ";")))
-				} else {
-					d.Set("forwarders", make([]string, 0))
-				}
-			} else {
-				tflog.Debug(ctx, fmt.Sprintf("Unable to DNS view's forwarders list (oid): %s\n", d.Id()))
-				d.Set("forwarders", make([]string, 0))
-			}
+			// Updating forward mode, forwarders, zone creation permissions, sortlist and rate limiting
+			resourcednsviewreadparams(ctx, d, buf[0]["dns_name"].(string), meta)
 
 			// Only look for network prefixes, acl(s) names will be ignored during the sync process with SOLIDserver
 			// Building allow_transfer ACL
@@ -559,6 +708,12 @@ func resourcednsviewRead(ctx context.Context, d *schema.ResourceData, meta inter
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -591,9 +746,201 @@ func resourcednsviewRead(ctx context.Context, d *schema.ResourceData, meta inter
 	return diag.FromErr(err)
 }
 
+// Update the forward mode, forwarders, zone creation permissions, sortlist and rate limiting of a DNS view from its DNS params
+// The forward mode is none, new zones are allowed and no sortlist nor rate limiting apply when the related params are not set,
+// the local values are kept if the params can't be read
+func resourcednsviewreadparams(ctx context.Context, d *schema.ResourceData, serverName string, meta interface{}) {
+	forward, forwardFound, forwardErr := dnsparamget(serverName, d.Id(), "forward", meta)
+	if forwardErr == nil {
+		if forwardFound {
+			d.Set("forward", strings.ToLower(forward))
+		} else {
+			d.Set("forward", "none")
+		}
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read DNS view's forward mode (oid): %s\n", d.Id()))
+	}
+
+	forwarders, forwardersFound, forwardersErr := dnsparamget(serverName, d.Id(), "forwarders", meta)
+	if forwardersErr == nil {
+		if forwarders != "" {
+			d.Set("forwarders", toStringArrayInterface(strings.Split(strings.TrimSuffix(forwarders, ";"), ";")))
+		} else {
+			d.Set("forwarders", make([]string, 0))
+		}
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read DNS view's forwarders list (oid): %s\n", d.Id()))
+	}
+
+	// Fallback to the DNS server's forward mode and forwarders when not overridden by the view
+	if forwardErr == nil {
+		if !forwardFound {
+			forward, forwardFound, forwardErr = dnsserverparamget(serverName, "forward", meta)
+		}
+
+		if forwardErr == nil {
+			if forwardFound && forward != "" {
+				d.Set("effective_forward", strings.ToLower(forward))
+			} else {
+				d.Set("effective_forward", "none")
+			}
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to read DNS server's forward mode: %s\n", serverName))
+		}
+	}
+
+	if forwardersErr == nil {
+		if !forwardersFound {
+			forwarders, _, forwardersErr = dnsserverparamget(serverName, "forwarders", meta)
+		}
+
+		if forwardersErr == nil {
+			if forwarders != "" {
+				d.Set("effective_forwarders", toStringArrayInterface(strings.Split(strings.TrimSuffix(forwarders, ";"), ";")))
+			} else {
+				d.Set("effective_forwarders", make([]string, 0))
+			}
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to read DNS server's forwarders list: %s\n", serverName))
+		}
+	}
+
+	allowNewZones, allowNewZonesFound, allowNewZonesErr := dnsparamget(serverName, d.Id(), "allow-new-zones", meta)
+	if allowNewZonesErr == nil {
+		d.Set("allow_new_zones", !allowNewZonesFound || strings.ToLower(allowNewZones) != "no")
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read DNS view's zone creation permissions (oid): %s\n", d.Id()))
+	}
+
+	sortlist, sortlistFound, sortlistErr := dnsparamget(serverName, d.Id(), "sortlist", meta)
+	if sortlistErr == nil {
+		if sortlistFound {
+			d.Set("sortlist", dnsviewsortlisttolist(sortlist))
+		} else {
+			d.Set("sortlist", make([]interface{}, 0))
+		}
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read DNS view's sortlist (oid): %s\n", d.Id()))
+	}
+
+	rateLimit, rateLimitFound, rateLimitErr := dnsparamget(serverName, d.Id(), "rate-limit", meta)
+	if rateLimitErr == nil {
+		if rateLimitFound {
+			d.Set("rate_limit", dnsviewratelimittolist(rateLimit))
+		} else {
+			d.Set("rate_limit", make([]interface{}, 0))
+		}
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read DNS view's response rate limiting (oid): %s\n", d.Id()))
+	}
+}
+
+// Build the sortlist DNS param of a view from its sortlist blocks (ex: "{ 10.0.0.0/8; 10.1.0.0/16; };")
+// Return an empty string when no block is set
+func dnsviewsortlistfromlist(sortlist []interface{}) string {
+	res := ""
+
+	for _, entry := range sortlist {
+		if e, ok := entry.(map[string]interface{}); ok {
+			res += "{ " + e["source"].(string) + "; " + e["prefer"].(string) + "; }; "
+		}
+	}
+
+	return strings.TrimSuffix(res, " ")
+}
+
+// Return the sortlist blocks of a view from its sortlist DNS param
+// Entries that are not a pair of network prefixes are ignored
+func dnsviewsortlisttolist(sortlist string) []interface{} {
+	res := make([]interface{}, 0)
+
+	for _, entry := range strings.Split(sortlist, "};") {
+		prefixes := []string{}
+		for _, prefix := range strings.Split(strings.Trim(entry, " {}"), ";") {
+			if prefix = strings.TrimSpace(prefix); prefix != "" {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+
+		if len(prefixes) != 2 {
+			continue
+		}
+
+		if match, _ := regexp.MatchString(regexpNetworkAcl, prefixes[0]); match == false {
+			continue
+		}
+		if match, _ := regexp.MatchString(regexpNetworkAcl, prefixes[1]); match == false {
+			continue
+		}
+
+		res = append(res, map[string]interface{}{
+			"source": prefixes[0],
+			"prefer": prefixes[1],
+		})
+	}
+
+	return res
+}
+
+// Build the rate-limit DNS param of a view from its rate_limit block (ex: "responses-per-second 10; window 15;")
+// Return an empty string when the block is not set
+func dnsviewratelimitfromlist(rateLimit []interface{}) string {
+	if len(rateLimit) == 0 || rateLimit[0] == nil {
+		return ""
+	}
+
+	r := rateLimit[0].(map[string]interface{})
+
+	return fmt.Sprintf("responses-per-second %d; window %d;", r["responses_per_second"].(int), r["window"].(int))
+}
+
+// Return the rate_limit block of a view from its rate-limit DNS param
+// The window defaults to 15 seconds when not part of the param
+func dnsviewratelimittolist(rateLimit string) []interface{} {
+	r := map[string]interface{}{
+		"responses_per_second": 0,
+		"window":               15,
+	}
+
+	for _, option := range strings.Split(rateLimit, ";") {
+		fields := strings.Fields(option)
+		if len(fields) != 2 {
+			continue
+		}
+
+		value, valueErr := strconv.Atoi(fields[1])
+		if valueErr != nil {
+			continue
+		}
+
+		switch fields[0] {
+		case "responses-per-second":
+			r["responses_per_second"] = value
+		case "window":
+			r["window"] = value
+		}
+	}
+
+	return []interface{}{r}
+}
+
 func resourcednsviewImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	s := meta.(*SOLIDserver)
 
+	// Resolve composite import ID (server/view)
+	if parts := importidparse(d.Id(), 2); parts != nil {
+		oid, oidErr := importidbywhere("DNS view", "rest/dns_view_list",
+			"dns_name='"+whereescape(parts[0])+"' AND dnsview_name='"+whereescape(parts[1])+"'", "dns_name='"+whereescape(parts[0])+"'",
+			"dnsview_id", "dnsview_name", meta)
+
+		if oidErr != nil {
+			// Reporting a failure
+			return nil, oidErr
+		}
+
+		d.SetId(oid)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("dnsview_id", d.Id())
@@ -620,31 +967,8 @@ func resourcednsviewImportState(ctx context.Context, d *schema.ResourceData, met
 				d.Set("recursion", false)
 			}
 
-			// Updating forward mode
-			forward, forwardErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forward", meta)
-			if forwardErr == nil {
-				if forward == "" {
-					d.Set("forward", "none")
-				} else {
-					d.Set("forward", strings.ToLower(forward))
-				}
-			} else {
-				tflog.Debug(ctx, fmt.Sprintf("Unable to DNS view's forward mode (oid): %s\n", d.Id()))
-				d.Set("forward", "none")
-			}
-
-			// Updating forwarder information
-			forwarders, forwardersErr := dnsparamget(buf[0]["dns_name"].(string), d.Id(), "forwarders", meta)
-			if forwardersErr == nil {
-				if forwarders != "" {
-					d.Set("forwarders", toStringArrayInterface(strings.Split(strings.TrimSuffix(forwarders, ";"), ";")))
-				} else {
-					d.Set("forwarders", make([]string, 0))
-				}
-			} else {
-				tflog.Debug(ctx, fmt.Sprintf("Unable to DNS view's forwarders list (oid): %s\n", d.Id()))
-				d.Set("forwarders", make([]string, 0))
-			}
+			// Updating forward mode, forwarders, zone creation permissions, sortlist and rate limiting
+			resourcednsviewreadparams(ctx, d, buf[0]["dns_name"].(string), meta)
 
 			// Only look for network prefixes, acl(s) names will be ignored during the sync process with SOLIDserver
 			// Building allow_transfer ACL
@@ -709,6 +1033,12 @@ func resourcednsviewImportState(ctx context.Context, d *schema.ResourceData, met
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
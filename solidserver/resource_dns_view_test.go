@@ -0,0 +1,337 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSViewReadParams(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	params := map[string]string{}
+	failing := false
+
+	m.handle("/rest/dns_view_param_list", func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			mockReply(w, http.StatusServiceUnavailable, nil)
+			return
+		}
+
+		for k, v := range params {
+			if strings.Contains(r.URL.Query().Get("WHERE"), "param_key='"+k+"'") {
+				mockReply(w, http.StatusOK, []map[string]interface{}{{"param_key": k, "param_value": v}})
+				return
+			}
+		}
+
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednsview().Schema, map[string]interface{}{
+		"name":            "internal",
+		"dnsserver":       "ns01",
+		"forward":         "first",
+		"forwarders":      []interface{}{"10.0.0.53"},
+		"allow_new_zones": false,
+	})
+	d.SetId("4")
+
+	// Params set on the view
+	params = map[string]string{"forward": "first", "forwarders": "10.0.0.53;", "allow-new-zones": "no"}
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if d.Get("forward").(string) != "first" || len(d.Get("forwarders").([]interface{})) != 1 || d.Get("allow_new_zones").(bool) {
+		t.Fatalf("unexpected view params: %v", d.State().Attributes)
+	}
+
+	// Lookup errors keep the local values
+	failing = true
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if d.Get("forward").(string) != "first" || len(d.Get("forwarders").([]interface{})) != 1 || d.Get("allow_new_zones").(bool) {
+		t.Fatalf("expected lookup errors to keep the view params: %v", d.State().Attributes)
+	}
+
+	// Params deleted out-of-band
+	failing = false
+	params = map[string]string{}
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if d.Get("forward").(string) != "none" || len(d.Get("forwarders").([]interface{})) != 0 || !d.Get("allow_new_zones").(bool) {
+		t.Errorf("expected the view params to be reset: %v", d.State().Attributes)
+	}
+}
+
+func TestDNSViewEffectiveForwarders(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	viewParams := map[string]string{}
+	serverParams := map[string]string{"forward": "only", "forwarders": "192.0.2.53;192.0.2.54;"}
+
+	reply := func(params map[string]string) func(w http.ResponseWriter, r *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range params {
+				if strings.Contains(r.URL.Query().Get("WHERE"), "param_key='"+k+"'") {
+					mockReply(w, http.StatusOK, []map[string]interface{}{{"param_key": k, "param_value": v}})
+					return
+				}
+			}
+
+			mockReply(w, http.StatusNoContent, nil)
+		}
+	}
+
+	m.handle("/rest/dns_view_param_list", reply(viewParams))
+	m.handle("/rest/dns_server_param_list", reply(serverParams))
+
+	d := schema.TestResourceDataRaw(t, resourcednsview().Schema, map[string]interface{}{
+		"name":      "internal",
+		"dnsserver": "ns01",
+	})
+	d.SetId("4")
+
+	// Inherit-only: the server's values are in effect while the view's arguments stay empty
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if d.Get("forward").(string) != "none" || len(d.Get("forwarders").([]interface{})) != 0 {
+		t.Fatalf("unexpected view params: %v", d.State().Attributes)
+	}
+
+	if d.Get("effective_forward").(string) != "only" || strings.Join(toStringArray(d.Get("effective_forwarders").([]interface{})), ",") != "192.0.2.53,192.0.2.54" {
+		t.Fatalf("expected the server's forwarding to be in effect: %v", d.State().Attributes)
+	}
+
+	// The server's params are looked up once
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if m.count("/rest/dns_server_param_list") != 2 {
+		t.Errorf("expected 2 server param lookups, got %d", m.count("/rest/dns_server_param_list"))
+	}
+
+	// Override-present: the view's values are in effect
+	viewParams["forward"] = "first"
+	viewParams["forwarders"] = "10.0.0.53;"
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if d.Get("effective_forward").(string) != "first" || strings.Join(toStringArray(d.Get("effective_forwarders").([]interface{})), ",") != "10.0.0.53" {
+		t.Errorf("expected the view's forwarding to be in effect: %v", d.State().Attributes)
+	}
+
+	if d.Get("forward").(string) != "first" || len(d.Get("forwarders").([]interface{})) != 1 {
+		t.Errorf("unexpected view params: %v", d.State().Attributes)
+	}
+
+	// An empty list set on the view overrides the server's forwarders
+	viewParams["forwarders"] = ""
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if len(d.Get("effective_forwarders").([]interface{})) != 0 {
+		t.Errorf("expected no forwarders in effect: %v", d.State().Attributes)
+	}
+}
+
+func TestDNSViewSortlistRateLimit(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	params := map[string]string{}
+
+	m.handle("/rest/dns_view_param_list", func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range params {
+			if strings.Contains(r.URL.Query().Get("WHERE"), "param_key='"+k+"'") {
+				mockReply(w, http.StatusOK, []map[string]interface{}{{"param_key": k, "param_value": v}})
+				return
+			}
+		}
+
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednsview().Schema, map[string]interface{}{
+		"name":      "internal",
+		"dnsserver": "ns01",
+		"sortlist": []interface{}{
+			map[string]interface{}{"source": "10.0.0.0/8", "prefer": "10.1.0.0/16"},
+			map[string]interface{}{"source": "2001:db8::/32", "prefer": "2001:db8:1::/48"},
+		},
+		"rate_limit": []interface{}{
+			map[string]interface{}{"responses_per_second": 10},
+		},
+	})
+	d.SetId("4")
+
+	sortlist := dnsviewsortlistfromlist(d.Get("sortlist").([]interface{}))
+	if sortlist != "{ 10.0.0.0/8; 10.1.0.0/16; }; { 2001:db8::/32; 2001:db8:1::/48; };" {
+		t.Fatalf("unexpected sortlist param: %s", sortlist)
+	}
+
+	rateLimit := dnsviewratelimitfromlist(d.Get("rate_limit").([]interface{}))
+	if rateLimit != "responses-per-second 10; window 15;" {
+		t.Fatalf("unexpected rate-limit param: %s", rateLimit)
+	}
+
+	// Params modified out-of-band are reported as drift
+	params = map[string]string{"sortlist": "{ 192.0.2.0/24; 192.0.2.0/25; };", "rate-limit": "responses-per-second 5; window 30;"}
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if d.Get("sortlist.#").(int) != 1 || d.Get("sortlist.0.source").(string) != "192.0.2.0/24" || d.Get("sortlist.0.prefer").(string) != "192.0.2.0/25" {
+		t.Fatalf("unexpected sortlist: %v", d.State().Attributes)
+	}
+
+	if d.Get("rate_limit.0.responses_per_second").(int) != 5 || d.Get("rate_limit.0.window").(int) != 30 {
+		t.Fatalf("unexpected rate_limit: %v", d.State().Attributes)
+	}
+
+	// Params deleted out-of-band
+	params = map[string]string{}
+	resourcednsviewreadparams(context.Background(), d, "ns01", s)
+
+	if d.Get("sortlist.#").(int) != 0 || d.Get("rate_limit.#").(int) != 0 {
+		t.Errorf("expected the sortlist and rate_limit to be reset: %v", d.State().Attributes)
+	}
+
+	// Only network prefixes are accepted
+	diags := resourcednsview().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":      "internal",
+		"dnsserver": "ns01",
+		"sortlist": []interface{}{
+			map[string]interface{}{"source": "internal-acl", "prefer": "10.1.0.0/16"},
+		},
+	}))
+
+	if !diags.HasError() {
+		t.Errorf("expected a validation error for a named ACL in the sortlist")
+	}
+}
+
+func TestDNSViewCreateParamFailure(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	failParam := ""
+	failDelete := false
+	params := map[string]string{}
+	views := map[string]bool{}
+
+	m.handle("/rest/dns_view_add", func(w http.ResponseWriter, r *http.Request) {
+		views["4"] = true
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "4"}})
+	})
+
+	m.handle("/rest/dns_view_param_add", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("param_key") == failParam {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "invalid parameter"}})
+			return
+		}
+
+		params[r.URL.Query().Get("param_key")] = r.URL.Query().Get("param_value")
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "4"}})
+	})
+
+	m.handle("/rest/dns_view_delete", func(w http.ResponseWriter, r *http.Request) {
+		if failDelete {
+			mockReply(w, http.StatusServiceUnavailable, nil)
+			return
+		}
+
+		delete(views, r.URL.Query().Get("dnsview_id"))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "4"}})
+	})
+
+	config := map[string]interface{}{
+		"name":            "internal",
+		"dnsserver":       "ns01",
+		"forward":         "first",
+		"forwarders":      []interface{}{"10.0.0.53"},
+		"allow_new_zones": false,
+	}
+
+	// Each failed parameter rolls back the view
+	for _, param := range []string{"forward", "forwarders", "allow-new-zones"} {
+		failParam = param
+		d := schema.TestResourceDataRaw(t, resourcednsview().Schema, config)
+		diags := resourcednsviewCreate(context.Background(), d, s)
+
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, param+" parameter failed") || d.Id() != "" || views["4"] {
+			t.Fatalf("expected the view to be rolled back on a failed %s parameter: %v", param, diags)
+		}
+	}
+
+	// A failed rollback keeps the view tainted, listing the missing parameters
+	failDelete = true
+	d := schema.TestResourceDataRaw(t, resourcednsview().Schema, config)
+	diags := resourcednsviewCreate(context.Background(), d, s)
+
+	if !diags.HasError() || d.Id() != "4" || !strings.Contains(diags[0].Detail, "Completed: forward parameter, forwarders parameter\nMissing: allow-new-zones parameter") {
+		t.Errorf("expected the view to be kept with the missing parameters: %v", diags)
+	}
+}
+
+func TestDNSViewUpdateParamFailure(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	failParam := ""
+
+	m.handle("/rest/dns_view_add", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "4"}})
+	})
+
+	failing := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("param_key") == failParam {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "invalid parameter"}})
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "4"}})
+	}
+
+	m.handle("/rest/dns_view_param_add", failing)
+	m.handle("/rest/dns_view_param_delete", failing)
+
+	// Only the forwarders are set on the view
+	m.handle("/rest/dns_view_param_list", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("WHERE"), "param_key='forwarders'") {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"param_key": "forwarders", "param_value": "10.0.0.1;"}})
+			return
+		}
+
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	cases := []struct {
+		config map[string]interface{}
+		param  string
+		action string
+	}{
+		{map[string]interface{}{"name": "internal", "dnsserver": "ns01", "allow_new_zones": false}, "allow-new-zones", "set"},
+		{map[string]interface{}{"name": "internal", "dnsserver": "ns01", "forward": "none"}, "forwarders", "unset"},
+		{map[string]interface{}{"name": "internal", "dnsserver": "ns01"}, "", ""},
+	}
+
+	for _, c := range cases {
+		failParam = c.param
+		d := schema.TestResourceDataRaw(t, resourcednsview().Schema, c.config)
+		d.SetId("4")
+		diags := resourcednsviewUpdate(context.Background(), d, s)
+
+		if c.param == "" && diags.HasError() {
+			t.Errorf("unexpected error: %v", diags)
+		}
+
+		if c.param != "" && (!diags.HasError() || !strings.Contains(diags[0].Summary, "unable to "+c.action+" its "+c.param+" parameter")) {
+			t.Errorf("expected the failed %s parameter to be reported, got %v", c.param, diags)
+		}
+	}
+
+	// Unchanged params that are not set on the view are left untouched
+	failParam = "allow-new-zones"
+	d, err := schema.InternalMap(resourcednsview().Schema).Data(&terraform.InstanceState{
+		ID:         "4",
+		Attributes: map[string]string{"id": "4", "name": "internal", "dnsserver": "ns01", "forward": "none", "allow_new_zones": "true"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourcednsviewUpdate(context.Background(), d, s); diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+}
@@ -7,13 +7,27 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+var (
+	// Initial delay between two checks of a DNS zone pending deletion
+	dnsZoneDeleteRetryDelay = 2 * time.Second
+	// Initial delay before retrying the creation of a DNS zone whose previous instance is pending deletion
+	dnsZoneCreateRetryDelay = 10 * time.Second
+)
+
+// Error message pattern reported when a zone with the same name is pending deletion
+var dnsZonePendingDeletion = regexp.MustCompile(`(?i)pending.delet|delayed.delet`)
+
 func resourcednszone() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcednszoneCreate,
@@ -23,9 +37,15 @@ func resourcednszone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcednszoneImportState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Description: heredoc.Doc(`
 			DNS Zone resource allows to create and configure DNS zones.
+			Zones can be imported using their oid or their server, optional view and name (ex: "ns01.local/internal/example.com").
+			Reserved characters within names can be URL-style escaped (ex: %2F for "/", %3A for ":").
 		`),
 
 		Schema: map[string]*schema.Schema{
@@ -48,12 +68,17 @@ func resourcednszone() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Description: "The Domain Name hosted by the zone, lower-cased and without trailing dot (ex: example.com).",
+				Computed:    true,
+			},
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of a space associated to the zone.",
+				Description: "The name of a space associated to the zone (Default: the provider's default_space, if any). Set to an empty string, or leave it out without default_space, to dissociate the zone from its space.",
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    false,
-				Default:     "",
 			},
 			"type": {
 				Type:         schema.TypeString,
@@ -70,9 +95,22 @@ func resourcednszone() *schema.Resource {
 				ForceNew:    false,
 				Default:     false,
 			},
+			"acknowledge_ptr_resync": {
+				Type:        schema.TypeBool,
+				Description: "Acknowledge the re-synchronization of the PTR records when moving a zone with createptr to another space, its A and AAAA records being re-applied for their PTR records to follow (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+			"force_reload": {
+				Type:        schema.TypeBool,
+				Description: "Trigger a reload of the zone on SOLIDserver when set to true, the attribute is reset to false once the reload is requested (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
 			"notify": {
 				Type:         schema.TypeString,
-				Description:  "The expected notify behavior (Supported: empty (Inherited), Yes, No, Explicit; Default: empty (Inherited).",
+				Description:  "The expected notify behavior (Supported: empty (Inherited), Yes, No, Explicit; Default: empty (Inherited). When inherited, the notify settings of the zone are never written and follow the ones of its server or SMART.",
 				Optional:     true,
 				ForceNew:     false,
 				Default:      "",
@@ -87,6 +125,25 @@ func resourcednszone() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"effective_notify": {
+				Type:        schema.TypeString,
+				Description: "The notify behavior actually applied to the zone, including the one inherited from its server or SMART.",
+				Computed:    true,
+			},
+			"effective_also_notify": {
+				Type:        schema.TypeList,
+				Description: "The list of IP addresses (Format <IP>:<Port>) actually receiving zone change notifications, including the ones inherited from its server or SMART.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"lock": {
+				Type:        schema.TypeBool,
+				Description: "Mark the DNS zone as locked through the __eip_lock class parameter, an advisory flag for GUI users that SOLIDserver does not enforce; Terraform unlocks it before destroying it (Supported from SOLIDserver 8.2; Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the zone.",
@@ -95,18 +152,111 @@ func resourcednszone() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the zone.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the zone.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the zone's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"populate_statistics": {
+				Type:        schema.TypeBool,
+				Description: "Compute record_counts and delegations, listing all the RRs of the zone on each refresh (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+			"record_counts": {
+				Type:        schema.TypeMap,
+				Description: "The number of RRs of the zone by type (only computed with populate_statistics).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"delegations": {
+				Type:        schema.TypeList,
+				Description: "The names of the child zones delegated by the zone, owning NS RRs not at the apex (only computed with populate_statistics).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_records": {
+				Type:        schema.TypeList,
+				Description: "The records created along with the zone, their lifecycle is tied to the zone.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the record relative to the zone ('@' for the zone apex).",
+							Required:    true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Description:  "The type of the record (Supported: A, AAAA, PTR, CNAME, DNAME, TXT and NS).",
+							ValidateFunc: resourcednsrrvalidatetype,
+							Required:     true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The value of the record.",
+							Required:    true,
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Description: "The DNS Time To Live of the record (Default: 3600).",
+							Optional:    true,
+							Default:     3600,
+						},
+					},
+				},
+			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(false),
+			resourcediffvalidateclass("dns_zone"),
+			resourcediffvalidatednsserver("dnsview"),
+			resourcednszonediffspace,
+			customdiff.ComputedIf("record_counts", resourcednszonediffstatistics),
+			customdiff.ComputedIf("delegations", resourcednszonediffstatistics),
+		),
 	}
 }
 
+// Recompute the statistics of the zone when they get enabled or disabled
+func resourcednszonediffstatistics(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+	return d.HasChange("populate_statistics")
+}
+
+// Require an explicit acknowledgment before moving a zone with createptr to another space
+// SOLIDserver doesn't move the PTR records created through the former space along with the zone
+func resourcednszonediffspace(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("space") || !d.Get("createptr").(bool) {
+		return nil
+	}
+
+	if d.Get("acknowledge_ptr_resync").(bool) {
+		return nil
+	}
+
+	oldSpace, newSpace := d.GetChange("space")
+
+	return fmt.Errorf("Moving DNS zone: %s with createptr from space '%s' to '%s' breaks the synchronization of its PTR records, "+
+		"the ones created through the former space are left stale. Set acknowledge_ptr_resync = true to move the zone and re-apply its A and AAAA records for their PTR records to follow",
+		d.Get("name").(string), oldSpace.(string), newSpace.(string))
+}
+
 func resourcednszonevalidatetype(v interface{}, _ string) ([]string, []error) {
 	switch strings.ToLower(v.(string)) {
 	case "master":
@@ -146,50 +296,71 @@ func resourcednszoneCreate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("dnszone_site_id", siteID)
 
 	// Building Notify and Also Notify Statements
-	parameters.Add("dnszone_notify", strings.ToLower(d.Get("notify").(string)))
-
-	alsoNotifies := ""
-	for _, alsoNotify := range toStringArray(d.Get("also_notify").([]interface{})) {
-		if match, _ := regexp.MatchString(regexpIPPort, alsoNotify); match == false {
-			return diag.Errorf("Only IP:Port format is supported")
-		}
-		alsoNotifies += strings.Replace(alsoNotify, ":", " port ", 1) + ";"
-	}
-
-	if d.Get("notify").(string) == "" || strings.ToLower(d.Get("notify").(string)) == "no" {
-		if alsoNotifies != "" {
-			return diag.Errorf("Error creating DNS zone: %s (Notify set to 'Inherited' or 'No' but also_notify list is not empty).", strings.ToLower(d.Get("name").(string)))
-		}
-		parameters.Add("dnszone_also_notify", alsoNotifies)
-	} else {
-		parameters.Add("dnszone_also_notify", alsoNotifies)
+	if notifyErr := resourcednszonenotifyparams(d, &parameters, false); notifyErr != nil {
+		return diag.Errorf("Error creating DNS zone: %s (%s).", strings.ToLower(d.Get("name").(string)), notifyErr)
 	}
 
 	parameters.Add("dnszone_class_name", d.Get("class").(string))
 
 	// Building class_parameters
-	classParameters := urlfromclassparams(d.Get("class_parameters"))
+	classParameters := urlfromclassparams(resourceclassparams(d))
 	// Generate class parameter for createptr if required
 	if d.Get("createptr").(bool) {
 		classParameters.Add("dnsptr", "1")
 	} else {
 		classParameters.Add("dnsptr", "0")
 	}
+
+	if lockErr := classparamsetlock(d, classParameters, meta); lockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(lockErr)
+	}
+
 	parameters.Add("dnszone_class_parameters", classParameters.Encode())
 
 	// Sending the creation request
-	resp, body, err := s.Request("post", "rest/dns_zone_add", &parameters)
+	// A zone with the same name may still be pending deletion (ex: ForceNew replacement), retrying until the timeout expires
+	var resp *http.Response
+	var buf [](map[string]interface{})
 
-	if err == nil {
-		var buf [](map[string]interface{})
+	err := waituntil(ctx, d.Timeout(schema.TimeoutCreate), dnsZoneCreateRetryDelay, func() (bool, error) {
+		var body string
+		var err error
+
+		if resp, body, err = s.Request("post", "rest/dns_zone_add", &parameters); err != nil {
+			return false, err
+		}
+
+		buf = [](map[string]interface{}){}
 		json.Unmarshal([]byte(body), &buf)
 
+		if resp.StatusCode != 200 && resp.StatusCode != 201 && len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist && dnsZonePendingDeletion.MatchString(errMsg) {
+				tflog.Debug(ctx, fmt.Sprintf("DNS zone pending deletion, retrying creation: %s (%s)\n", d.Get("name").(string), errMsg))
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+
+	if err == nil || err == errWaitTimeout {
 		// Checking the answer
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created DNS zone (oid): %s\n", oid))
 				d.SetId(oid)
-				return nil
+				d.Set("fqdn", dnsnamecanonical(d.Get("name").(string)))
+
+				if err := resourcednszonesyncdefaultrecords(ctx, d, meta); err != nil {
+					return diag.Errorf("Unable to create the default records of DNS zone: %s (%s)", d.Get("name").(string), err)
+				}
+
+				if err := resourcednszonesetstatistics(d, meta); err != nil {
+					return diag.Errorf("Unable to compute the statistics of DNS zone: %s (%s)", d.Get("name").(string), err)
+				}
+
+				return resourcednszonereload(ctx, d, meta)
 			}
 		}
 
@@ -210,9 +381,155 @@ func resourcednszoneCreate(ctx context.Context, d *schema.ResourceData, meta int
 	return diag.FromErr(err)
 }
 
+// Return the fully qualified name of a record relative to a zone
+func resourcednszonerrname(name string, zoneName string) string {
+	if name == "" || name == "@" {
+		return zoneName
+	}
+	return name + "." + zoneName
+}
+
+// Return the key identifying a default record of the zone
+func resourcednszonerrkey(record map[string]interface{}) string {
+	return strings.ToLower(record["name"].(string)) + "|" + strings.ToUpper(record["type"].(string)) + "|" + record["value"].(string) + "|" + strconv.Itoa(record["ttl"].(int))
+}
+
+// Apply the changes of the default records of the zone
+func resourcednszonesyncdefaultrecords(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("default_records") {
+		return nil
+	}
+
+	oldRecords, newRecords := d.GetChange("default_records")
+	oldKeys := map[string]map[string]interface{}{}
+	newKeys := map[string]map[string]interface{}{}
+
+	for _, record := range oldRecords.([]interface{}) {
+		oldKeys[resourcednszonerrkey(record.(map[string]interface{}))] = record.(map[string]interface{})
+	}
+	for _, record := range newRecords.([]interface{}) {
+		newKeys[resourcednszonerrkey(record.(map[string]interface{}))] = record.(map[string]interface{})
+	}
+
+	// Deleting the records removed from the configuration (or modified)
+	for key, record := range oldKeys {
+		if _, exist := newKeys[key]; exist {
+			continue
+		}
+
+		rrName := resourcednszonerrname(record["name"].(string), d.Get("name").(string))
+		rrInfo, rrErr := dnsrrinfo(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("name").(string), rrName, record["type"].(string), record["value"].(string), meta)
+
+		if rrErr != nil {
+			return rrErr
+		}
+
+		if rrInfo != nil {
+			if err := dnsrrdelete(rrInfo["rr_id"].(string), meta); err != nil {
+				return err
+			}
+			tflog.Debug(ctx, fmt.Sprintf("Deleted default record %s (%s) of DNS zone: %s\n", rrName, record["type"].(string), d.Get("name").(string)))
+		}
+	}
+
+	// Creating the records added to the configuration (or modified)
+	for _, record := range newRecords.([]interface{}) {
+		record := record.(map[string]interface{})
+
+		if _, exist := oldKeys[resourcednszonerrkey(record)]; exist {
+			continue
+		}
+
+		rrName := resourcednszonerrname(record["name"].(string), d.Get("name").(string))
+		if _, err := dnsrradd(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("name").(string), rrName, record["type"].(string), record["value"].(string), record["ttl"].(int), meta); err != nil {
+			return err
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Created default record %s (%s) of DNS zone: %s\n", rrName, record["type"].(string), d.Get("name").(string)))
+	}
+
+	return nil
+}
+
+// Refresh the default records of the zone, dropping the ones deleted or modified out-of-band
+func resourcednszonereaddefaultrecords(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	records := []map[string]interface{}{}
+
+	for _, record := range d.Get("default_records").([]interface{}) {
+		record := record.(map[string]interface{})
+		rrName := resourcednszonerrname(record["name"].(string), d.Get("name").(string))
+		rrInfo, rrErr := dnsrrinfo(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("name").(string), rrName, record["type"].(string), record["value"].(string), meta)
+
+		if rrErr != nil {
+			return rrErr
+		}
+
+		if rrInfo == nil {
+			tflog.Debug(ctx, fmt.Sprintf("Default record %s (%s) of DNS zone not found: %s\n", rrName, record["type"].(string), d.Get("name").(string)))
+			continue
+		}
+
+		if ttl, ttlExist := rrInfo["ttl"].(string); ttlExist {
+			record["ttl"], _ = strconv.Atoi(ttl)
+		}
+
+		records = append(records, record)
+	}
+
+	d.Set("default_records", records)
+
+	return nil
+}
+
+// Set the RR counts and the delegations of the zone when populate_statistics is enabled, reset them otherwise
+func resourcednszonesetstatistics(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("populate_statistics").(bool) {
+		d.Set("record_counts", map[string]int{})
+		d.Set("delegations", []string{})
+		return nil
+	}
+
+	counts, delegations, err := dnszonestatistics(d.Id(), d.Get("name").(string), meta)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("record_counts", counts)
+	d.Set("delegations", delegations)
+
+	return nil
+}
+
+// Trigger a reload of the zone if requested, resetting force_reload in the state
+func resourcednszonereload(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.Get("force_reload").(bool) {
+		return nil
+	}
+
+	if err := dnszonereload(d.Id(), meta); err != nil {
+		return diag.Errorf("Unable to reload DNS zone: %s (%s)", d.Get("name").(string), err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Reloaded DNS zone (oid): %s\n", d.Id()))
+	d.Set("force_reload", false)
+
+	return nil
+}
+
 func resourcednszoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Updating the default records and reloading the zone without touching its configuration
+	if !d.HasChangesExcept("force_reload", "default_records", "acknowledge_ptr_resync", "populate_statistics") {
+		if err := resourcednszonesyncdefaultrecords(ctx, d, meta); err != nil {
+			return diag.Errorf("Unable to update the default records of DNS zone: %s (%s)", d.Get("name").(string), err)
+		}
+		if err := resourcednszonesetstatistics(d, meta); err != nil {
+			return diag.Errorf("Unable to compute the statistics of DNS zone: %s (%s)", d.Get("name").(string), err)
+		}
+		return resourcednszonereload(ctx, d, meta)
+	}
+
 	// Gather required ID(s) from provided information
 	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
 	if siteErr != nil {
@@ -230,35 +547,26 @@ func resourcednszoneUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("dnszone_site_id", siteID)
 
 	// Building Notify and Also Notify Statements
-	parameters.Add("dnszone_notify", strings.ToLower(d.Get("notify").(string)))
-
-	alsoNotifies := ""
-	for _, alsoNotify := range toStringArray(d.Get("also_notify").([]interface{})) {
-		if match, _ := regexp.MatchString(regexpIPPort, alsoNotify); match == false {
-			return diag.Errorf("Only IP:Port format is supported")
-		}
-		alsoNotifies += strings.Replace(alsoNotify, ":", " port ", 1) + ";"
-	}
-
-	if d.Get("notify").(string) == "" || strings.ToLower(d.Get("notify").(string)) == "no" {
-		if alsoNotifies != "" {
-			return diag.Errorf("Error updating DNS zone: %s (Notify set to 'Inherited' or 'No' but also_notify list is not empty).", strings.ToLower(d.Get("name").(string)))
-		}
-		parameters.Add("dnszone_also_notify", alsoNotifies)
-	} else {
-		parameters.Add("dnszone_also_notify", alsoNotifies)
+	if notifyErr := resourcednszonenotifyparams(d, &parameters, true); notifyErr != nil {
+		return diag.Errorf("Error updating DNS zone: %s (%s).", strings.ToLower(d.Get("name").(string)), notifyErr)
 	}
 
 	parameters.Add("dnszone_class_name", d.Get("class").(string))
 
 	// Building class_parameters
-	classParameters := urlfromclassparams(d.Get("class_parameters"))
+	classParameters := urlfromclassparams(resourceclassparams(d))
 	// Generate class parameter for createptr if required
 	if d.Get("createptr").(bool) {
 		classParameters.Add("dnsptr", "1")
 	} else {
 		classParameters.Add("dnsptr", "0")
 	}
+
+	if lockErr := classparamsetlock(d, classParameters, meta); lockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(lockErr)
+	}
+
 	parameters.Add("dnszone_class_parameters", classParameters.Encode())
 
 	// Sending the update request
@@ -273,7 +581,25 @@ func resourcednszoneUpdate(ctx context.Context, d *schema.ResourceData, meta int
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated DNS zone (oid): %s\n", oid))
 				d.SetId(oid)
-				return nil
+
+				// Re-synchronizing the PTR records after a change of space
+				if d.HasChange("space") && d.Get("createptr").(bool) {
+					count, resyncErr := dnszoneptrresync(oid, meta)
+					if resyncErr != nil {
+						return diag.Errorf("Unable to re-synchronize the PTR records of DNS zone: %s (%s)", d.Get("name").(string), resyncErr)
+					}
+					tflog.Debug(ctx, fmt.Sprintf("Re-synchronized the PTR records of %d RR(s) of DNS zone: %s\n", count, d.Get("name").(string)))
+				}
+
+				if err := resourcednszonesyncdefaultrecords(ctx, d, meta); err != nil {
+					return diag.Errorf("Unable to update the default records of DNS zone: %s (%s)", d.Get("name").(string), err)
+				}
+
+				if err := resourcednszonesetstatistics(d, meta); err != nil {
+					return diag.Errorf("Unable to compute the statistics of DNS zone: %s (%s)", d.Get("name").(string), err)
+				}
+
+				return resourcednszonereload(ctx, d, meta)
 			}
 		}
 
@@ -297,6 +623,14 @@ func resourcednszoneUpdate(ctx context.Context, d *schema.ResourceData, meta int
 func resourcednszoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Unlock the DNS zone, the lock only protecting it from the GUI
+	if d.Get("lock").(bool) {
+		if unlockErr := objectunlock("rest/dns_zone_add", "dnszone_id", d.Id(), "dnszone_class_parameters", meta); unlockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(unlockErr)
+		}
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("dnszone_id", d.Id())
@@ -324,6 +658,14 @@ func resourcednszoneDelete(ctx context.Context, d *schema.ResourceData, meta int
 			return diag.Errorf("Unable to delete DNS zone: %s", d.Get("name").(string))
 		}
 
+		// Waiting for the delayed deletion to complete, allowing the zone to be recreated right away
+		if err := waituntil(ctx, d.Timeout(schema.TimeoutDelete), dnsZoneDeleteRetryDelay, func() (bool, error) {
+			exists, existsErr := dnszoneexists(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("name").(string), meta)
+			return existsErr != nil || !exists, nil
+		}); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("DNS zone still pending deletion after timeout: %s\n", d.Get("name").(string)))
+		}
+
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted DNS zone (oid): %s\n", d.Id()))
 
@@ -338,6 +680,54 @@ func resourcednszoneDelete(ctx context.Context, d *schema.ResourceData, meta int
 	return diag.FromErr(err)
 }
 
+// Add the notify and also_notify parameters of the zone
+// Inherited notify settings are never written, except to reset explicit ones on update
+func resourcednszonenotifyparams(d *schema.ResourceData, parameters *url.Values, update bool) error {
+	notify := strings.ToLower(d.Get("notify").(string))
+
+	alsoNotifies := ""
+	for _, alsoNotify := range toStringArray(d.Get("also_notify").([]interface{})) {
+		if match, _ := regexp.MatchString(regexpIPPort, alsoNotify); match == false {
+			return fmt.Errorf("Only IP:Port format is supported")
+		}
+		alsoNotifies += strings.Replace(alsoNotify, ":", " port ", 1) + ";"
+	}
+
+	if (notify == "" || notify == "no") && alsoNotifies != "" {
+		return fmt.Errorf("Notify set to 'Inherited' or 'No' but also_notify list is not empty")
+	}
+
+	if notify == "" && !(update && d.HasChange("notify")) {
+		return nil
+	}
+
+	parameters.Add("dnszone_notify", notify)
+	parameters.Add("dnszone_also_notify", alsoNotifies)
+
+	return nil
+}
+
+// Set the notify settings of the zone from its information
+// Inherited notify settings are only reported through effective_notify and effective_also_notify unless imported
+func resourcednszonesetnotify(d *schema.ResourceData, info map[string]interface{}, imported bool) {
+	effectiveNotify := strings.ToLower(infostring(info, "dnszone_notify"))
+	effectiveAlsoNotifies := []interface{}{}
+
+	if alsoNotifies := strings.TrimSuffix(infostring(info, "dnszone_also_notify"), ";"); alsoNotifies != "" {
+		effectiveAlsoNotifies = toStringArrayInterface(strings.Split(strings.ReplaceAll(alsoNotifies, " port ", ":"), ";"))
+	}
+
+	d.Set("effective_notify", effectiveNotify)
+	d.Set("effective_also_notify", effectiveAlsoNotifies)
+
+	if d.Get("notify").(string) == "" && !imported {
+		return
+	}
+
+	d.Set("notify", effectiveNotify)
+	d.Set("also_notify", effectiveAlsoNotifies)
+}
+
 func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
@@ -357,6 +747,7 @@ func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta inter
 			d.Set("dnsserver", buf[0]["dns_name"].(string))
 			d.Set("dnsview", buf[0]["dnsview_name"].(string))
 			d.Set("name", buf[0]["dnszone_name"].(string))
+			d.Set("fqdn", dnsnamecanonical(buf[0]["dnszone_name"].(string)))
 			d.Set("type", buf[0]["dnszone_type"].(string))
 
 			if buf[0]["dnszone_site_name"].(string) != "#" {
@@ -365,16 +756,15 @@ func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta inter
 				d.Set("space", "")
 			}
 
-			d.Set("notify", strings.ToLower(buf[0]["dnszone_notify"].(string)))
-			if buf[0]["dnszone_also_notify"].(string) != "" {
-				d.Set("also_notify", toStringArrayInterface(strings.Split(strings.ReplaceAll(strings.TrimSuffix(buf[0]["dnszone_also_notify"].(string), ";"), " port ", ":"), ";")))
-			}
+			resourcednszonesetnotify(d, buf[0], false)
 
 			d.Set("class", buf[0]["dnszone_class_name"].(string))
 
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["dnszone_class_parameters"].(string))
+
+			d.Set("lock", classparamlocked(retrievedClassParameters))
 			computedClassParameters := map[string]string{}
 
 			if createptr, createptrExist := retrievedClassParameters["dnsptr"]; createptrExist {
@@ -387,6 +777,12 @@ func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -396,6 +792,14 @@ func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 			d.Set("class_parameters", computedClassParameters)
 
+			if err := resourcednszonereaddefaultrecords(ctx, d, meta); err != nil {
+				return diag.Errorf("Unable to read the default records of DNS zone: %s (%s)", d.Get("name").(string), err)
+			}
+
+			if err := resourcednszonesetstatistics(d, meta); err != nil {
+				return diag.Errorf("Unable to compute the statistics of DNS zone: %s (%s)", d.Get("name").(string), err)
+			}
+
 			return nil
 		}
 
@@ -422,6 +826,24 @@ func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta inter
 func resourcednszoneImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	s := meta.(*SOLIDserver)
 
+	// Resolve composite import ID (server/zone, server/view/zone or server:view:zone, use '#' as view for zones outside of any view)
+	if parts := importidparse(d.Id(), 3); parts != nil {
+		whereClause := "dns_name='" + whereescape(parts[0]) + "' AND dnszone_name='" + whereescape(parts[len(parts)-1]) + "'"
+
+		if len(parts) == 3 {
+			whereClause += " AND dnsview_name='" + whereescape(parts[1]) + "'"
+		}
+
+		oid, oidErr := importidbywhere("DNS zone", "rest/dns_zone_list", whereClause, "dns_name='"+whereescape(parts[0])+"'", "dnszone_id", "dnszone_name", meta)
+
+		if oidErr != nil {
+			// Reporting a failure
+			return nil, oidErr
+		}
+
+		d.SetId(oid)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("dnszone_id", d.Id())
@@ -438,6 +860,7 @@ func resourcednszoneImportState(ctx context.Context, d *schema.ResourceData, met
 			d.Set("dnsserver", buf[0]["dns_name"].(string))
 			d.Set("dnsview", buf[0]["dnsview_name"].(string))
 			d.Set("name", buf[0]["dnszone_name"].(string))
+			d.Set("fqdn", dnsnamecanonical(buf[0]["dnszone_name"].(string)))
 			d.Set("type", buf[0]["dnszone_type"].(string))
 
 			if buf[0]["dnszone_site_name"].(string) != "#" {
@@ -446,16 +869,15 @@ func resourcednszoneImportState(ctx context.Context, d *schema.ResourceData, met
 				d.Set("space", "")
 			}
 
-			d.Set("notify", strings.ToLower(buf[0]["dnszone_notify"].(string)))
-			if buf[0]["dnszone_also_notify"].(string) != "" {
-				d.Set("also_notify", toStringArrayInterface(strings.Split(strings.ReplaceAll(strings.TrimSuffix(buf[0]["dnszone_also_notify"].(string), ";"), " port ", ":"), ";")))
-			}
+			resourcednszonesetnotify(d, buf[0], true)
 
 			d.Set("class", buf[0]["dnszone_class_name"].(string))
 
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["dnszone_class_parameters"].(string))
+
+			d.Set("lock", classparamlocked(retrievedClassParameters))
 			computedClassParameters := map[string]string{}
 
 			if createptr, createptrExist := retrievedClassParameters["dnsptr"]; createptrExist {
@@ -468,6 +890,12 @@ func resourcednszoneImportState(ctx context.Context, d *schema.ResourceData, met
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -0,0 +1,119 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDNSZoneDelayedDeletion(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mutex := sync.Mutex{}
+	pending := 0
+
+	dnsZoneDeleteRetryDelay = time.Millisecond
+	dnsZoneCreateRetryDelay = time.Millisecond
+	defer func() {
+		dnsZoneDeleteRetryDelay = 2 * time.Second
+		dnsZoneCreateRetryDelay = 10 * time.Second
+	}()
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "Local"}})
+	})
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	// The appliance keeps listing the zone for a few calls after its deletion
+	m.handle("/rest/dns_zone_delete", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		pending = 3
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	m.handle("/rest/dns_zone_list", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if pending > 0 {
+			pending--
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"dnszone_id": "12", "dnszone_name": "example.com"}})
+			return
+		}
+
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	m.handle("/rest/dns_zone_add", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if pending > 0 {
+			pending--
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "3019", "errmsg": "Zone already exists (pending deletion)"}})
+			return
+		}
+
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "13"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednszone().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+	})
+	d.SetId("12")
+
+	// Deletion must wait until the zone is no longer listed
+	if diags := resourcednszoneDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if m.count("/rest/dns_zone_list") != 4 {
+		t.Errorf("expected 4 zone lookups while waiting for the deletion, got %d", m.count("/rest/dns_zone_list"))
+	}
+
+	// Creation is retried when the previous zone is still pending deletion
+	pending = 1
+
+	d = schema.TestResourceDataRaw(t, resourcednszone().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+	})
+
+	if diags := resourcednszoneCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "13" || m.count("/rest/dns_zone_add") != 2 {
+		t.Errorf("expected zone 13 created after 2 attempts, got %q after %d", d.Id(), m.count("/rest/dns_zone_add"))
+	}
+
+	// Creation is retried as long as the previous zone is pending deletion
+	pending = 2
+
+	if diags := resourcednszoneCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if m.count("/rest/dns_zone_add") != 5 {
+		t.Errorf("expected zone 13 created after 3 more attempts, got %d", m.count("/rest/dns_zone_add")-2)
+	}
+
+	// Until the timeout expires
+	pending = 1000
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if diags := resourcednszoneCreate(ctx, d, s); !diags.HasError() {
+		t.Errorf("expected an error when the zone is still pending deletion")
+	}
+}
@@ -0,0 +1,130 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testDNSZoneNotifyState(notify string, alsoNotify []string) *terraform.InstanceState {
+	state := &terraform.InstanceState{
+		ID: "12",
+		Attributes: map[string]string{
+			"id":           "12",
+			"dnsserver":    "ns.example.com",
+			"dnsview":      "#",
+			"name":         "example.com",
+			"space":        "",
+			"type":         "Master",
+			"createptr":    "false",
+			"force_reload": "false",
+			"notify":       notify,
+			"class":        "",
+		},
+	}
+
+	if len(alsoNotify) > 0 {
+		state.Attributes["also_notify.#"] = "1"
+		state.Attributes["also_notify.0"] = alsoNotify[0]
+	}
+
+	return state
+}
+
+// Return the notify parameters sent when applying config over state
+func testDNSZoneNotifyParams(t *testing.T, state *terraform.InstanceState, config map[string]interface{}) (*schema.ResourceData, url.Values) {
+	r := resourcednszone()
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parameters := url.Values{}
+	if err := resourcednszonenotifyparams(d, &parameters, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return d, parameters
+}
+
+func TestDNSZoneNotifyInheritance(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// The zone inherits the notify settings of its SMART
+	m.handle("/rest/dns_zone_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"dnszone_id": "12", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_name": "example.com", "dnszone_type": "master",
+			"dnszone_site_name": "#", "dnszone_class_name": "", "dnszone_class_parameters": "dnsptr=0",
+			"dnszone_notify": "Yes", "dnszone_also_notify": "10.0.0.1 port 53;",
+		}})
+	})
+
+	inherited := map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+	}
+
+	// Inherited notify settings are never written on create
+	d := schema.TestResourceDataRaw(t, resourcednszone().Schema, inherited)
+	parameters := url.Values{}
+	if err := resourcednszonenotifyparams(d, &parameters, false); err != nil || parameters.Has("dnszone_notify") || parameters.Has("dnszone_also_notify") {
+		t.Errorf("unexpected notify parameters on create: %v (%v)", parameters, err)
+	}
+
+	// Reading an inherited zone only reports the effective settings
+	d, parameters = testDNSZoneNotifyParams(t, testDNSZoneNotifyState("", nil), inherited)
+	if parameters.Has("dnszone_notify") || parameters.Has("dnszone_also_notify") {
+		t.Errorf("unexpected notify parameters of an inherited zone: %v", parameters)
+	}
+
+	if diags := resourcednszoneRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("notify").(string) != "" || len(d.Get("also_notify").([]interface{})) != 0 {
+		t.Errorf("expected the notify settings to remain inherited: %s %v", d.Get("notify").(string), d.Get("also_notify"))
+	}
+
+	if d.Get("effective_notify").(string) != "yes" || len(d.Get("effective_also_notify").([]interface{})) != 1 || d.Get("effective_also_notify.0").(string) != "10.0.0.1:53" {
+		t.Errorf("unexpected effective notify settings: %s %v", d.Get("effective_notify").(string), d.Get("effective_also_notify"))
+	}
+
+	// The SMART settings do not cause any drift
+	diff, err := resourcednszone().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(inherited), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff != nil && (diff.Attributes["notify"] != nil || diff.Attributes["also_notify.#"] != nil) {
+		t.Errorf("unexpected notify drift: %v", diff.Attributes)
+	}
+
+	// Inherited to explicit
+	_, parameters = testDNSZoneNotifyParams(t, testDNSZoneNotifyState("", nil), map[string]interface{}{
+		"dnsserver":   "ns.example.com",
+		"name":        "example.com",
+		"notify":      "explicit",
+		"also_notify": []interface{}{"10.0.0.2:53"},
+	})
+
+	if parameters.Get("dnszone_notify") != "explicit" || parameters.Get("dnszone_also_notify") != "10.0.0.2 port 53;" {
+		t.Errorf("unexpected explicit notify parameters: %v", parameters)
+	}
+
+	// Explicit to inherited resets the zone settings once
+	_, parameters = testDNSZoneNotifyParams(t, testDNSZoneNotifyState("explicit", []string{"10.0.0.2:53"}), inherited)
+
+	if !parameters.Has("dnszone_notify") || parameters.Get("dnszone_notify") != "" || !parameters.Has("dnszone_also_notify") || parameters.Get("dnszone_also_notify") != "" {
+		t.Errorf("expected the notify parameters to be reset: %v", parameters)
+	}
+}
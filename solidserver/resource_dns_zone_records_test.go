@@ -0,0 +1,68 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDNSZoneDefaultRecords(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	created := []string{}
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "Local"}})
+	})
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	m.handle("/rest/dns_zone_add", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		created = append(created, r.URL.Query().Get("rr_name")+" "+r.URL.Query().Get("rr_type"))
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	// The www CNAME has been modified out-of-band
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("WHERE"), "rr_type='TXT'") {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"rr_id": "42", "ttl": "300"}})
+			return
+		}
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednszone().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+		"default_records": []interface{}{
+			map[string]interface{}{"name": "@", "type": "TXT", "value": "v=spf1 -all"},
+			map[string]interface{}{"name": "www", "type": "CNAME", "value": "web.example.com"},
+		},
+	})
+
+	if diags := resourcednszoneCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if strings.Join(created, ",") != "example.com TXT,www.example.com CNAME" {
+		t.Errorf("unexpected records created: %v", created)
+	}
+
+	if diags := resourcednszonereaddefaultrecords(context.Background(), d, s); diags != nil {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	records := d.Get("default_records").([]interface{})
+
+	if len(records) != 1 || records[0].(map[string]interface{})["ttl"].(int) != 300 {
+		t.Errorf("expected the TXT record only with its remote TTL, got %v", records)
+	}
+}
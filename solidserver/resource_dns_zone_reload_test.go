@@ -0,0 +1,70 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSZoneForceReload(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_zone_reload", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("dnszone_id") != "12" {
+			t.Errorf("unexpected zone reloaded: %s", r.URL.Query().Get("dnszone_id"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	m.handle("/rest/dns_zone_add", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	r := resourcednszone()
+	state := &terraform.InstanceState{
+		ID: "12",
+		Attributes: map[string]string{
+			"id":           "12",
+			"dnsserver":    "ns.example.com",
+			"dnsview":      "#",
+			"name":         "example.com",
+			"space":        "",
+			"type":         "Master",
+			"createptr":    "false",
+			"force_reload": "false",
+			"notify":       "",
+			"class":        "",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver":    "ns.example.com",
+		"name":         "example.com",
+		"force_reload": true,
+	})
+
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only force_reload changed, the zone configuration must be left untouched
+	if diags := resourcednszoneUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if m.count("/rest/dns_zone_reload") != 1 || m.count("/rest/dns_zone_add") != 0 {
+		t.Errorf("expected a single reload and no update, got %d reload(s) and %d update(s)", m.count("/rest/dns_zone_reload"), m.count("/rest/dns_zone_add"))
+	}
+
+	if d.Get("force_reload").(bool) {
+		t.Errorf("expected force_reload to be reset to false")
+	}
+}
@@ -0,0 +1,184 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testDNSZoneSpaceState(createptr string) *terraform.InstanceState {
+	return &terraform.InstanceState{
+		ID: "12",
+		Attributes: map[string]string{
+			"id":                     "12",
+			"dnsserver":              "ns.example.com",
+			"dnsview":                "#",
+			"name":                   "example.com",
+			"space":                  "space01",
+			"type":                   "Master",
+			"createptr":              createptr,
+			"acknowledge_ptr_resync": "false",
+			"force_reload":           "false",
+			"notify":                 "",
+			"class":                  "",
+		},
+	}
+}
+
+func TestDNSZoneSpaceMoveRequiresAcknowledgment(t *testing.T) {
+	r := resourcednszone()
+
+	// Moving a zone with createptr requires the acknowledgment
+	_, err := r.Diff(context.Background(), testDNSZoneSpaceState("true"), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+		"space":     "space02",
+		"createptr": true,
+	}), nil)
+
+	if err == nil || !strings.Contains(err.Error(), "acknowledge_ptr_resync") {
+		t.Errorf("expected an error requiring acknowledge_ptr_resync, got: %v", err)
+	}
+
+	// Moving a zone without createptr is allowed
+	if _, err := r.Diff(context.Background(), testDNSZoneSpaceState("false"), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+		"space":     "space02",
+	}), nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Creating a zone with createptr within a space is allowed
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+		"space":     "space02",
+		"createptr": true,
+	}), nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDNSZoneSpaceMovePTRResync(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// The mock SOLIDserver creates the PTR RRs of the A and AAAA RRs within the space of their zone
+	zoneSiteID := "2"
+	ptrs := map[string]string{"10.0.0.1": "2", "2001:db8::1": "2"}
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "3", "site_name": "space02"}})
+	})
+
+	m.handle("/rest/dns_zone_add", func(w http.ResponseWriter, r *http.Request) {
+		zoneSiteID = r.URL.Query().Get("dnszone_site_id")
+		classParameters, _ := url.ParseQuery(r.URL.Query().Get("dnszone_class_parameters"))
+		if classParameters.Get("dnsptr") != "1" {
+			t.Errorf("expected createptr to be kept: %s", r.URL.Query().Get("dnszone_class_parameters"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "dnszone_id='12' AND rr_type IN ('A','AAAA')" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"rr_id": "100", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_name": "example.com", "rr_full_name": "www.example.com", "rr_type": "A", "value1": "10.0.0.1", "ttl": "3600"},
+			{"rr_id": "101", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_name": "example.com", "rr_full_name": "www.example.com", "rr_type": "AAAA", "value1": "2001:db8::1", "ttl": "3600"},
+		})
+	})
+
+	m.handle("/rest/dns_rr_add", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("add_flag") != "edit_only" || r.URL.Query().Get("rr_ttl") != "3600" {
+			t.Errorf("unexpected RR update: %s", r.URL.RawQuery)
+		}
+		ptrs[r.URL.Query().Get("value1")] = zoneSiteID
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": r.URL.Query().Get("rr_id")}})
+	})
+
+	r := resourcednszone()
+	state := testDNSZoneSpaceState("true")
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dnsserver":              "ns.example.com",
+		"name":                   "example.com",
+		"space":                  "space02",
+		"createptr":              true,
+		"acknowledge_ptr_resync": true,
+	})
+
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourcednszoneUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// Every PTR RR follows the zone into its new space
+	for address, siteID := range ptrs {
+		if siteID != "3" {
+			t.Errorf("stale PTR RR of %s left in space (oid): %s", address, siteID)
+		}
+	}
+
+	if m.count("/rest/dns_rr_add") != 2 {
+		t.Errorf("expected 2 RRs to be re-applied, got %d", m.count("/rest/dns_rr_add"))
+	}
+}
+
+func TestDNSZoneDefaultSpace(t *testing.T) {
+	_, s := newMockSOLIDserver(t)
+	s.DisablePlanValidation = true
+
+	r := resourcednszone()
+	config := map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+	}
+
+	// The space of a zone is optional
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), s)
+
+	if err != nil || diff.Attributes["space"] == nil || diff.Attributes["space"].New != "" {
+		t.Fatalf("expected the zone to be planned without space: %v (%v)", diff, err)
+	}
+
+	s.DefaultSpace = "tenant-a"
+	diff, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), s)
+
+	if err != nil || diff.Attributes["space"].New != "tenant-a" {
+		t.Fatalf("expected the default space to be planned: %v (%v)", diff, err)
+	}
+
+	// The zones within a space keep it
+	if diff, err := r.Diff(context.Background(), testDNSZoneSpaceState("false"), terraform.NewResourceConfigRaw(config), s); err != nil || (diff != nil && diff.Attributes["space"] != nil) {
+		t.Errorf("unexpected space diff: %v (%v)", diff, err)
+	}
+
+	// Without default_space, a space left out of the configuration dissociates the zone from its space
+	s.DefaultSpace = ""
+	state := testDNSZoneSpaceState("false")
+	state.RawConfig = mockRawConfig(r, config)
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), s)
+
+	if err != nil || diff == nil || diff.Attributes["space"] == nil || diff.Attributes["space"].New != "" {
+		t.Errorf("expected the space to be dissociated: %v (%v)", diff, err)
+	}
+}
@@ -0,0 +1,95 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSZoneStatistics(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_zone_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"dnszone_id": "12", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_name": "example.com", "dnszone_type": "Master",
+			"dnszone_site_name": "#", "dnszone_class_name": "", "dnszone_class_parameters": "dnsptr=0",
+		}})
+	})
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "dnszone_id='12'" {
+			t.Errorf("unexpected RR filter: %s", r.URL.Query().Get("WHERE"))
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"rr_full_name": "example.com", "rr_type": "SOA"},
+			{"rr_full_name": "example.com", "rr_type": "NS"},
+			{"rr_full_name": "www.example.com", "rr_type": "A"},
+			{"rr_full_name": "lab.example.com", "rr_type": "NS"},
+		})
+	})
+
+	m.handle("/rest/dns_zone_add", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected DNS zone update: %v", r.URL.Query())
+	})
+
+	r := resourcednszone()
+	config := map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.com",
+	}
+
+	// Statistics are not computed by default
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("12")
+
+	if diags := resourcednszoneRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(d.Get("record_counts").(map[string]interface{})) != 0 || len(d.Get("delegations").([]interface{})) != 0 || m.count("/rest/dns_rr_list") != 0 {
+		t.Fatalf("unexpected statistics: %v", d.State().Attributes)
+	}
+
+	state := d.State()
+
+	if diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil); err != nil || (diff != nil && !diff.Empty()) {
+		t.Fatalf("unexpected diff without statistics: %v (%v)", diff, err)
+	}
+
+	// Enabling the statistics only refreshes them
+	config["populate_statistics"] = true
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+
+	if err != nil || diff == nil || !diff.Attributes["record_counts.%"].NewComputed {
+		t.Fatalf("expected the statistics to be recomputed: %v (%v)", diff, err)
+	}
+
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourcednszoneUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	counts := d.Get("record_counts").(map[string]interface{})
+
+	if len(counts) != 3 || counts["NS"].(int) != 2 || counts["SOA"].(int) != 1 || strings.Join(toStringArray(d.Get("delegations").([]interface{})), ",") != "lab.example.com" {
+		t.Fatalf("unexpected statistics: %v", d.State().Attributes)
+	}
+
+	// Refreshed statistics never introduce a diff
+	if diags := resourcednszoneRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil); err != nil || (diff != nil && !diff.Empty()) {
+		t.Errorf("unexpected diff with statistics: %v (%v)", diff, err)
+	}
+}
@@ -0,0 +1,179 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+// Initial delay between two checks of the serial of a reloaded DNS zone
+var dnsZoneSyncRetryDelay = 2 * time.Second
+
+func resourcednszonesync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcednszonesyncCreate,
+		ReadContext:   resourcednszonesyncRead,
+		DeleteContext: resourcednszonesyncDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Description: heredoc.Doc(`
+			DNS Zone Sync resource allows to reload a DNS zone on its server so its secondaries pick the changes up immediately.
+			The zone is reloaded when the resource is created and again each time its triggers change, the resource doesn't manage any object
+			and its deletion only removes it from the state.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The name of DNS server or DNS SMART hosting the DNS zone to reload.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dnsview": {
+				Type:        schema.TypeString,
+				Description: "The name of DNS view hosting the DNS zone to reload.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the DNS zone to reload.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values whose change reloads the DNS zone again (ex: the IDs or values of the RRs of the zone).",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait_for_serial": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the serial of the DNS zone to increase once reloaded, a reload of an unchanged zone doesn't bump it (Default: false).",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"dnszone_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the reloaded DNS zone.",
+				Computed:    true,
+			},
+			"serial": {
+				Type:        schema.TypeInt,
+				Description: "The serial of the DNS zone once reloaded.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcednszonesyncCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zoneName := d.Get("name").(string)
+
+	zone, zoneErr := dnszoneinfobyname(d.Get("dnsserver").(string), d.Get("dnsview").(string), zoneName, meta)
+
+	if zoneErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to reload DNS zone: %s (%s)", zoneName, zoneErr)
+	}
+
+	if zone == nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to reload DNS zone: %s, not found on DNS server: %s\n", zoneName, d.Get("dnsserver").(string))
+	}
+
+	zoneID := infostring(zone, "dnszone_id")
+	previousSerial, _, serialErr := dnszoneserial(zoneID, meta)
+
+	if serialErr != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to reload DNS zone: %s (%s)", zoneName, serialErr)
+	}
+
+	if err := dnszonereload(zoneID, meta); err != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to reload DNS zone: %s (%s)", zoneName, err)
+	}
+
+	serial := previousSerial
+
+	// Waiting for the serial to be bumped if requested
+	if d.Get("wait_for_serial").(bool) {
+		err := waituntil(ctx, d.Timeout(schema.TimeoutCreate), dnsZoneSyncRetryDelay, func() (bool, error) {
+			var exists bool
+			var err error
+
+			serial, exists, err = dnszoneserial(zoneID, meta)
+
+			if err == nil && !exists {
+				err = fmt.Errorf("DNS zone deleted while being reloaded")
+			}
+
+			return err == nil && dnsserialgreater(serial, previousSerial), err
+		})
+
+		if err == errWaitTimeout {
+			// Reporting a failure
+			return diag.Errorf("Unable to reload DNS zone: %s, serial still %d after %s\n", zoneName, serial, d.Timeout(schema.TimeoutCreate))
+		}
+
+		if err != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to reload DNS zone: %s (%s)", zoneName, err)
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Reloaded DNS zone: %s, serial %d => %d\n", zoneName, previousSerial, serial))
+
+	// Several syncs may reload the same zone, each one gets its own ID
+	d.SetId(id.UniqueId())
+	d.Set("dnszone_id", zoneID)
+	d.Set("serial", int(serial))
+
+	return nil
+}
+
+func resourcednszonesyncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Syncs created by older provider versions are identified by the oid of their zone
+	zoneID := d.Get("dnszone_id").(string)
+	if zoneID == "" {
+		zoneID = d.Id()
+	}
+
+	// The serial recorded at reload time is kept, later changes of the zone don't require a new reload
+	_, exists, err := dnszoneserial(zoneID, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to read DNS zone: %s (%s)", d.Get("name").(string), err)
+	}
+
+	if !exists {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find DNS zone (oid): %s, forgetting its sync\n", zoneID))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("dnszone_id", zoneID)
+
+	return nil
+}
+
+func resourcednszonesyncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Nothing to delete on SOLIDserver
+	d.SetId("")
+
+	return nil
+}
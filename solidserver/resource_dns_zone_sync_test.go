@@ -0,0 +1,138 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSSerialGreater(t *testing.T) {
+	cases := []struct {
+		serial  uint32
+		than    uint32
+		greater bool
+	}{
+		{2024010102, 2024010101, true},
+		{2024010101, 2024010101, false},
+		{2024010100, 2024010101, false},
+		{5, 4294967290, true},
+		{4294967290, 5, false},
+	}
+
+	for _, c := range cases {
+		if dnsserialgreater(c.serial, c.than) != c.greater {
+			t.Errorf("unexpected comparison of serial %d with %d", c.serial, c.than)
+		}
+	}
+}
+
+func TestDNSZoneSync(t *testing.T) {
+	defer func(delay time.Duration) { dnsZoneSyncRetryDelay = delay }(dnsZoneSyncRetryDelay)
+	dnsZoneSyncRetryDelay = time.Millisecond
+
+	m, s := newMockSOLIDserver(t)
+	serial := int64(2024010101)
+	infoCalls := int64(0)
+
+	m.handle("/rest/dns_zone_list", mockWhereList([]map[string]interface{}{
+		{"dnszone_id": "12", "dns_name": "ns.example.com", "dnsview_name": "#", "dnszone_name": "example.com"},
+	}))
+
+	// The serial is bumped a few checks after the reload
+	m.handle("/rest/dns_zone_info", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("dnszone_id") != "12" {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "not found"}})
+			return
+		}
+
+		if atomic.AddInt64(&infoCalls, 1) == 4 {
+			atomic.AddInt64(&serial, 1)
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"dnszone_id": "12", "dnszone_serial": strconv.FormatInt(atomic.LoadInt64(&serial), 10)}})
+	})
+
+	m.handle("/rest/dns_zone_reload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Query().Get("dnszone_id") != "12" {
+			t.Errorf("unexpected reload request: %s %s", r.Method, r.URL.RawQuery)
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	config := map[string]interface{}{
+		"dnsserver":       "ns.example.com",
+		"name":            "example.com",
+		"triggers":        map[string]interface{}{"www": "10.0.0.1"},
+		"wait_for_serial": true,
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcednszonesync().Schema, config)
+
+	if diags := resourcednszonesyncCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() == "12" || d.Get("dnszone_id").(string) != "12" || d.Get("serial").(int) != 2024010102 || m.count("/rest/dns_zone_reload") != 1 {
+		t.Errorf("unexpected state: %v (reloads: %d)", d.State().Attributes, m.count("/rest/dns_zone_reload"))
+	}
+
+	// Later changes of the serial don't trigger a new reload
+	atomic.AddInt64(&serial, 10)
+	state := d.State()
+
+	if diags := resourcednszonesyncRead(context.Background(), d, s); diags.HasError() || d.Get("serial").(int) != 2024010102 {
+		t.Errorf("unexpected read: %v (%v)", d.State().Attributes, diags)
+	}
+
+	if diff, err := resourcednszonesync().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil); err != nil || (diff != nil && !diff.Empty()) {
+		t.Errorf("unexpected diff without trigger change: %v (%v)", diff, err)
+	}
+
+	config["triggers"] = map[string]interface{}{"www": "10.0.0.2"}
+
+	if diff, err := resourcednszonesync().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil); err != nil || diff == nil || !diff.RequiresNew() {
+		t.Errorf("expected a replacement on trigger change, got: %v (%v)", diff, err)
+	}
+
+	// Syncs of the same zone get their own ID, an unchanged serial is accepted without waiting for it
+	delete(config, "wait_for_serial")
+	other := schema.TestResourceDataRaw(t, resourcednszonesync().Schema, config)
+
+	if diags := resourcednszonesyncCreate(context.Background(), other, s); diags.HasError() || other.Id() == d.Id() || other.Get("serial").(int) != 2024010112 {
+		t.Errorf("unexpected second sync: %v (%v)", other.State().Attributes, diags)
+	}
+
+	// The sync is forgotten along with its zone
+	d.Set("dnszone_id", "13")
+
+	if diags := resourcednszonesyncRead(context.Background(), d, s); diags.HasError() || d.Id() != "" {
+		t.Errorf("expected the sync to be forgotten, got: %s (%v)", d.Id(), diags)
+	}
+
+	// Unknown zones and failed reloads are reported
+	d = schema.TestResourceDataRaw(t, resourcednszonesync().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "example.org",
+	})
+
+	if diags := resourcednszonesyncCreate(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "not found") {
+		t.Errorf("expected a missing zone error, got: %v", diags)
+	}
+
+	m.handle("/rest/dns_zone_reload", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "zone locked"}})
+	})
+
+	d = schema.TestResourceDataRaw(t, resourcednszonesync().Schema, config)
+
+	if diags := resourcednszonesyncCreate(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "zone locked") {
+		t.Errorf("expected a reload error, got: %v", diags)
+	}
+}
@@ -6,17 +6,15 @@
 package solidserver
 
 import (
-	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/satori/go.uuid"
 	"testing"
 )
 
 // create non terminal subnet
 func TestAccdnszone_01(t *testing.T) {
-	spacename := fmt.Sprintf("01-space-%s", uuid.Must(uuid.NewV4()))
-	blockname := fmt.Sprintf("01-block-%s", uuid.Must(uuid.NewV4()))
+	spacename := testAccName("01-space")
+	blockname := testAccName("01-block")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -7,10 +7,12 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -32,15 +34,26 @@ func resourceip6address() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space into which creating the IPv6 address.",
-				Required:    true,
+				Description: "The name of the space into which creating the IPv6 address (Default: the provider's default_space).",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Description: "The name of the subnet into which creating the IPv6 address.",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Description:  "The name of the subnet into which creating the IPv6 address (Computed when subnet_id is set).",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
+			},
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Description:  "The oid of the subnet into which creating the IPv6 address, to use when several subnets share the same name within the space.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
 			},
 			"pool": {
 				Type:        schema.TypeString,
@@ -51,7 +64,7 @@ func resourceip6address() *schema.Resource {
 			},
 			"request_ip": {
 				Type:         schema.TypeString,
-				Description:  "The optionally requested IPv6 address.",
+				Description:  "The optionally requested IPv6 address, required within point-to-point (/127) and host (/128) subnets as no free address is looked up in these.",
 				ValidateFunc: validation.IsIPAddress,
 				Optional:     true,
 				ForceNew:     true,
@@ -63,6 +76,17 @@ func resourceip6address() *schema.Resource {
 				Computed:    true,
 				ForceNew:    true,
 			},
+			"address_cidr": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IP address in CIDR notation (ex: 2001:db8::5/128, or 2001:db8::5/64 with host_prefix).",
+				Computed:    true,
+			},
+			"host_prefix": {
+				Type:        schema.TypeBool,
+				Description: "Use the prefix length of the subnet rather than a host prefix (/128) in address_cidr (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
 			"device": {
 				Type:        schema.TypeString,
 				Description: "Device Name to associate with the IPv6 address (Require a 'Device Manager' license).",
@@ -72,8 +96,9 @@ func resourceip6address() *schema.Resource {
 			},
 			"name": {
 				Type:        schema.TypeString,
-				Description: "The short name or FQDN of the IPv6 address to create.",
-				Required:    true,
+				Description: "The short name or FQDN of the IPv6 address to create, rendered from the provider's default_address_name_template when not set.",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    false,
 			},
 			"mac": {
@@ -82,7 +107,8 @@ func resourceip6address() *schema.Resource {
 				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"), "Unsupported MAC address format."),
 				Optional:         true,
 				ForceNew:         false,
-				DiffSuppressFunc: resourcediffsuppresscase,
+				DiffSuppressFunc: resourcediffsuppressmac,
+				StateFunc:        resourcestatemac,
 				Default:          "",
 			},
 			"class": {
@@ -94,16 +120,52 @@ func resourceip6address() *schema.Resource {
 				Default: "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the IPv6 address.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the IPv6 address.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the IPv6 address's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(true),
+			resourcediffvalidateclass("ip6_address"),
+			resourcediffvalidatesubnetname(true),
+			resourcediffvalidateaddressname("an IPv6 address"),
+			customdiff.ComputedIf("address_cidr", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("host_prefix") && d.Id() != ""
+			}),
+		),
+	}
+}
+
+// Set the address_cidr of an IPv6 address, using the prefix length of its subnet when host_prefix is set
+// The subnet is looked up when its prefix length is unknown (0), keeping the current value on failure
+func resourceip6addresssetcidr(d *schema.ResourceData, subnetPrefixLength int, meta interface{}) {
+	if !d.Get("host_prefix").(bool) {
+		subnetPrefixLength = 0
+	} else if subnetPrefixLength == 0 {
+		subnetInfo, subnetErr := ip6subnetinfobyid(d.Get("subnet_id").(string), meta)
+
+		if subnetErr != nil {
+			return
+		}
+
+		subnetPrefixLength, _ = subnetInfo["prefix_length"].(int)
 	}
+
+	d.Set("address_cidr", ipaddresscidr(d.Get("address").(string), subnetPrefixLength))
 }
 
 func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -121,7 +183,7 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(siteErr)
 	}
 
-	subnetInfo, subnetErr := ip6subnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+	subnetInfo, subnetErr := resourceip6subnetref(d, siteID, meta)
 	if subnetInfo == nil || subnetErr != nil {
 		// Reporting a failure
 		if subnetInfo == nil {
@@ -156,9 +218,10 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 	// Determining if an IP address was submitted in or if we should get one from the IPAM
 	if len(d.Get("request_ip").(string)) > 0 {
 		// Ensure IP Address is within the given subnet start and end IP addresses
+		subnetPrefixLength, _ := subnetInfo["prefix_length"].(int)
+
 		if strings.Compare(subnetInfo["terminal"].(string), "1") == 0 &&
-			strings.Compare(subnetInfo["start_hex_addr"].(string), requestedHexIP) == -1 &&
-			strings.Compare(requestedHexIP, subnetInfo["end_hex_addr"].(string)) == -1 {
+			iphexaddrinsubnet(subnetInfo["start_hex_addr"].(string), subnetInfo["end_hex_addr"].(string), requestedHexIP, subnetPrefixLength, 128) {
 
 			if poolInfo != nil && (strings.Compare(poolInfo["start_hex_addr"].(string), requestedHexIP) == 1 ||
 				strings.Compare(requestedHexIP, poolInfo["end_hex_addr"].(string)) == 1) {
@@ -177,6 +240,7 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 			poolID = poolInfo["id"].(string)
 		}
 
+		// Unlike IPv4, there is no fallback for point-to-point (/127) and host (/128) subnets, request_ip is required within these
 		ipAddresses, ipErr = ip6addressfindfree(subnetInfo["id"].(string), poolID, meta)
 
 		if ipErr != nil {
@@ -186,21 +250,27 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	for i := 0; i < len(ipAddresses); i++ {
+		// Naming the address once allocated when no name is provided
+		addressName := d.Get("name").(string)
+		if addressName == "" {
+			addressName = addressnamefromtemplate(s.AddressNameTemplate, ipAddresses[i], d.Get("subnet").(string), d.Get("space").(string))
+		}
+
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("site_id", siteID)
 		parameters.Add("add_flag", "new_only")
-		parameters.Add("ip6_name", d.Get("name").(string))
+		parameters.Add("ip6_name", addressName)
 		parameters.Add("hostaddr", ipAddresses[i])
 		parameters.Add("hostdev_id", deviceID)
 		parameters.Add("ip6_class_name", d.Get("class").(string))
 
 		if d.Get("mac").(string) != "" {
-			parameters.Add("mac_addr", d.Get("mac").(string))
+			parameters.Add("mac_addr", macnormalize(d.Get("mac").(string)))
 		}
 
 		// Building class_parameters
-		parameters.Add("ip6_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+		parameters.Add("ip6_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip6_address6_add", &parameters)
@@ -214,7 +284,10 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IPv6 address (oid): %s\n", oid))
 					d.SetId(oid)
+					d.Set("name", addressName)
 					d.Set("address", ipAddresses[i])
+					subnetPrefixLength, _ := subnetInfo["prefix_length"].(int)
+					resourceip6addresssetcidr(d, subnetPrefixLength, meta)
 					return nil
 				}
 			} else {
@@ -264,11 +337,11 @@ func resourceip6addressUpdate(ctx context.Context, d *schema.ResourceData, meta
 	parameters.Add("ip6_class_name", d.Get("class").(string))
 
 	if d.Get("mac").(string) != "" {
-		parameters.Add("mac_addr", d.Get("mac").(string))
+		parameters.Add("mac_addr", macnormalize(d.Get("mac").(string)))
 	}
 
 	// Building class_parameters
-	parameters.Add("ip6_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("ip6_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip6_address6_add", &parameters)
@@ -282,6 +355,11 @@ func resourceip6addressUpdate(ctx context.Context, d *schema.ResourceData, meta
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 address (oid): %s\n", oid))
 				d.SetId(oid)
+
+				if d.HasChange("host_prefix") {
+					resourceip6addresssetcidr(d, 0, meta)
+				}
+
 				return nil
 			}
 		}
@@ -358,14 +436,15 @@ func resourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta in
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
 			d.Set("subnet", buf[0]["subnet6_name"].(string))
+			d.Set("subnet_id", infostring(buf[0], "subnet6_id"))
 			d.Set("address", hexip6toip6(buf[0]["ip6_addr"].(string)))
+
+			subnetPrefixLength, _ := strconv.Atoi(infostring(buf[0], "subnet6_prefix"))
+			resourceip6addresssetcidr(d, subnetPrefixLength, meta)
+
 			d.Set("name", buf[0]["ip6_name"].(string))
 
-			if macIgnore, _ := regexp.MatchString("^EIP:", buf[0]["ip6_mac_addr"].(string)); !macIgnore {
-				d.Set("mac", buf[0]["ip6_mac_addr"].(string))
-			} else {
-				d.Set("mac", "")
-			}
+			d.Set("mac", macnormalize(buf[0]["ip6_mac_addr"].(string)))
 
 			d.Set("class", buf[0]["ip6_class_name"].(string))
 
@@ -375,6 +454,12 @@ func resourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta in
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -425,9 +510,10 @@ func resourceip6addressImportState(ctx context.Context, d *schema.ResourceData,
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
 			d.Set("subnet", buf[0]["subnet6_name"].(string))
+			d.Set("subnet_id", infostring(buf[0], "subnet6_id"))
 			d.Set("address", hexip6toip6(buf[0]["ip6_addr"].(string)))
 			d.Set("name", buf[0]["ip6_name"].(string))
-			d.Set("mac", buf[0]["mac_addr"].(string))
+			d.Set("mac", macnormalize(buf[0]["ip6_mac_addr"].(string)))
 			d.Set("class", buf[0]["ip6_class_name"].(string))
 
 			// Updating local class_parameters
@@ -436,6 +522,12 @@ func resourceip6addressImportState(ctx context.Context, d *schema.ResourceData,
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
 	"regexp"
-	"strings"
 )
 
 func resourceip6mac() *schema.Resource {
@@ -44,7 +43,8 @@ func resourceip6mac() *schema.Resource {
 				Type:             schema.TypeString,
 				Description:      "The MAC Address o map with the IPv6 address.",
 				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"), "Unsupported MAC address format."),
-				DiffSuppressFunc: resourcediffsuppresscase,
+				DiffSuppressFunc: resourcediffsuppressmac,
+				StateFunc:        resourcestatemac,
 				Required:         true,
 				ForceNew:         true,
 			},
@@ -60,7 +60,7 @@ func resourceip6macCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	parameters.Add("site_name", d.Get("space").(string))
 	parameters.Add("add_flag", "edit_only")
 	parameters.Add("hostaddr", d.Get("address").(string))
-	parameters.Add("ip6_mac_addr", strings.ToLower(d.Get("mac").(string)))
+	parameters.Add("ip6_mac_addr", macnormalize(d.Get("mac").(string)))
 	parameters.Add("keep_class_parameters", "1")
 
 	// Sending the creation request
@@ -139,7 +139,7 @@ func resourceip6macRead(ctx context.Context, d *schema.ResourceData, meta interf
 		// Checking the answer
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if ip6Mac, ip6MacExist := buf[0]["ip6_mac_addr"].(string); ip6MacExist {
-				if strings.ToLower(ip6Mac) == strings.ToLower(d.Get("mac").(string)) {
+				if macnormalize(ip6Mac) == macnormalize(d.Get("mac").(string)) {
 					return nil
 				}
 				// Log the error
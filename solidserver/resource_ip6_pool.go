@@ -7,6 +7,7 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
@@ -33,29 +34,42 @@ func resourceip6pool() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space into which creating the IPv6 pool.",
-				Required:    true,
+				Description: "The name of the space into which creating the IPv6 pool (Default: the provider's default_space).",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Description: "The name of the parent IP subnet into which creating the IPv6 pool.",
-				Required:    true,
-				ForceNew:    true,
-			},
-			"start": {
 				Type:         schema.TypeString,
-				Description:  "The IPv6 pool's lower IPv6 address.",
-				ValidateFunc: validation.IsIPAddress,
-				Required:     true,
+				Description:  "The name of the parent IP subnet into which creating the IPv6 pool (Computed when subnet_id is set).",
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
 			},
-			"end": {
+			"subnet_id": {
 				Type:         schema.TypeString,
-				Description:  "The IPv6 pool's higher IPv6 address.",
-				ValidateFunc: validation.IsIPAddress,
-				Required:     true,
+				Description:  "The oid of the parent IP subnet into which creating the IPv6 pool, to use when several subnets share the same name within the space.",
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
+			},
+			"start": {
+				Type:             schema.TypeString,
+				Description:      "The IPv6 pool's lower IPv6 address.",
+				ValidateFunc:     validation.IsIPAddress,
+				DiffSuppressFunc: resourcediffsuppressIPv6Format,
+				Required:         true,
+				ForceNew:         true,
+			},
+			"end": {
+				Type:             schema.TypeString,
+				Description:      "The IPv6 pool's higher IPv6 address.",
+				ValidateFunc:     validation.IsIPAddress,
+				DiffSuppressFunc: resourcediffsuppressIPv6Format,
+				Required:         true,
+				ForceNew:         true,
 			},
 			"dhcp_range": {
 				Type:        schema.TypeBool,
@@ -80,6 +94,21 @@ func resourceip6pool() *schema.Resource {
 				Description: "The size prefix of the parent subnet of the pool.",
 				Computed:    true,
 			},
+			"used_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of IPv6 addresses in use within the IPv6 pool.",
+				Computed:    true,
+			},
+			"free_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of free IPv6 addresses within the IPv6 pool (capped to the largest supported integer).",
+				Computed:    true,
+			},
+			"utilization_percent": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of IPv6 addresses in use within the IPv6 pool.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IPv6 pool.",
@@ -88,15 +117,29 @@ func resourceip6pool() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the IPv6 pool.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the IPv6 pool.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the IPv6 pool's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(true),
+			resourcediffvalidateclass("ip6_pool"),
+			resourcediffvalidatesubnetname(true),
+		),
 	}
 }
 
@@ -111,7 +154,7 @@ func resourceip6poolCreate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	// Gather required ID(s) from provided subnet information
-	subnetInfo, subnetErr := ip6subnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+	subnetInfo, subnetErr := resourceip6subnetref(d, siteID, meta)
 	if subnetErr != nil {
 		// Reporting a failure
 		return diag.FromErr(subnetErr)
@@ -138,7 +181,7 @@ func resourceip6poolCreate(ctx context.Context, d *schema.ResourceData, meta int
 		classParameters.Add("dhcprange6", "0")
 	}
 
-	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+	for k, v := range resourceclassparams(d) {
 		classParameters.Add(k, v.(string))
 	}
 
@@ -155,11 +198,14 @@ func resourceip6poolCreate(ctx context.Context, d *schema.ResourceData, meta int
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created IPv6 pool (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIP6Pool)
 				d.SetId(oid)
 
 				d.Set("prefix", subnetInfo["start_addr"].(string)+"/"+strconv.Itoa(subnetInfo["prefix_length"].(int)))
 				d.Set("prefix_size", subnetInfo["prefix_length"].(int))
 
+				resourceip6poolsetusage(ctx, d, ip6tohexip6(d.Get("start").(string)), ip6tohexip6(d.Get("end").(string)), meta)
+
 				return nil
 			}
 		}
@@ -200,7 +246,7 @@ func resourceip6poolUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		classParameters.Add("dhcprange6", "0")
 	}
 
-	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+	for k, v := range resourceclassparams(d) {
 		classParameters.Add(k, v.(string))
 	}
 
@@ -217,6 +263,7 @@ func resourceip6poolUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 pool (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIP6Pool)
 				d.SetId(oid)
 				return nil
 			}
@@ -264,6 +311,7 @@ func resourceip6poolDelete(ctx context.Context, d *schema.ResourceData, meta int
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted IPv6 pool (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindIP6Pool)
 
 		// Unset local ID
 		d.SetId("")
@@ -295,6 +343,9 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 			d.Set("name", buf[0]["pool6_name"].(string))
 			d.Set("class", buf[0]["pool6_class_name"].(string))
 
+			// Updating the location and boundaries of the pool, keeping the local case of the names
+			resourceip6poolsetlocation(d, buf[0])
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool6_class_parameters"].(string))
@@ -309,6 +360,12 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -318,6 +375,10 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 			d.Set("class_parameters", computedClassParameters)
 
+			startHexIP, _ := buf[0]["pool6_start_ip6_addr"].(string)
+			endHexIP, _ := buf[0]["pool6_end_ip6_addr"].(string)
+			resourceip6poolsetusage(ctx, d, startHexIP, endHexIP, meta)
+
 			return nil
 		}
 
@@ -341,9 +402,72 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 	return diag.FromErr(err)
 }
 
+// Set the usage attributes of the IPv6 pool from its hexa boundaries
+// The usage being informative, failing to count the used addresses is only logged
+func resourceip6poolsetusage(ctx context.Context, d *schema.ResourceData, startHexIP string, endHexIP string, meta interface{}) {
+	used, countErr := countall("rest/ip6_address6_count", "pool6_id='"+d.Id()+"'", meta)
+	if countErr != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to count the used IPv6 addresses of IPv6 pool (oid): %s\n", d.Id()))
+		return
+	}
+
+	free, utilization := ip6rangeusage(startHexIP, endHexIP, used)
+
+	d.Set("used_count", used)
+	d.Set("free_count", free)
+	d.Set("utilization_percent", utilization)
+}
+
+// Set the space, subnet and boundaries of the IPv6 pool from its information
+// The names are only updated when they differ from the local ones regardless of the case
+func resourceip6poolsetlocation(d *schema.ResourceData, info map[string]interface{}) {
+	if siteName, siteNameExist := info["site_name"].(string); siteNameExist && !strings.EqualFold(siteName, d.Get("space").(string)) {
+		d.Set("space", siteName)
+	}
+
+	if subnetName, subnetNameExist := info["subnet6_name"].(string); subnetNameExist && !strings.EqualFold(subnetName, d.Get("subnet").(string)) {
+		d.Set("subnet", subnetName)
+	}
+
+	d.Set("subnet_id", infostring(info, "subnet6_id"))
+
+	if startAddr, startAddrExist := info["pool6_start_ip6_addr"].(string); startAddrExist {
+		d.Set("start", longip6toshortip6(hexip6toip6(startAddr)))
+	}
+
+	if endAddr, endAddrExist := info["pool6_end_ip6_addr"].(string); endAddrExist {
+		d.Set("end", longip6toshortip6(hexip6toip6(endAddr)))
+	}
+}
+
 func resourceip6poolImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	s := meta.(*SOLIDserver)
 
+	// Resolve composite import ID (space/subnet/pool), fallback to the pool oid otherwise
+	if spaceName, subnetName, poolName, composite := poolimportidsplit(d.Id()); composite {
+		siteID, siteErr := ipsiteidbyname(spaceName, meta)
+		if siteErr != nil {
+			// Reporting a failure
+			return nil, siteErr
+		}
+
+		poolInfo, poolErr := ip6poolinfobyname(siteID, poolName, subnetName, meta)
+		if poolErr != nil {
+			// Reporting a failure
+			return nil, poolErr
+		}
+
+		poolID, poolIDExist := poolInfo["id"].(string)
+		if !poolIDExist {
+			// Reporting a failure
+			return nil, fmt.Errorf("SOLIDServer - Unable to find and import IPv6 pool: %s\n", d.Id())
+		}
+
+		d.SetId(poolID)
+		d.Set("space", spaceName)
+		d.Set("subnet", subnetName)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("pool6_id", d.Id())
@@ -360,6 +484,8 @@ func resourceip6poolImportState(ctx context.Context, d *schema.ResourceData, met
 			d.Set("name", buf[0]["pool6_name"].(string))
 			d.Set("class", buf[0]["pool6_class_name"].(string))
 
+			resourceip6poolsetlocation(d, buf[0])
+
 			// Setting local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool6_class_parameters"].(string))
@@ -374,6 +500,12 @@ func resourceip6poolImportState(ctx context.Context, d *schema.ResourceData, met
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
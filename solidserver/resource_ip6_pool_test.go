@@ -0,0 +1,89 @@
+//go:build all || ip6_pool
+// +build all ip6_pool
+
+// to test only these features: -tags ip6_pool -run="ip6pool_XX"
+
+package solidserver
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+// create IPv6 pool and import it using its space/subnet/pool composite key then its oid, the following plan being empty
+func TestAccip6pool_01(t *testing.T) {
+	spacename := testAccName("01-space")
+	blockname := testAccName("01-block")
+	subnetname := testAccName("01-subnet")
+	poolname := testAccName("01-pool")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: Config_TestAccip6pool_01(spacename, blockname, subnetname, poolname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("solidserver_ip6_pool.pool", "id"),
+				),
+			},
+			{
+				ResourceName: "solidserver_ip6_pool.pool",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s/%s/%s", spacename, subnetname, poolname), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"dhcp_range", "prefix", "prefix_size"},
+			},
+			{
+				ResourceName:       "solidserver_ip6_pool.pool",
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				Config:             Config_TestAccip6pool_01(spacename, blockname, subnetname, poolname),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func Config_TestAccip6pool_01(spacename string, blockname string, subnetname string, poolname string) string {
+	return fmt.Sprintf(`
+    %s
+
+    resource "solidserver_ip6_subnet" "block" {
+      space            = "${solidserver_ip_space.space.name}"
+      request_ip       = "2001:db8::"
+      prefix_size      = 48
+      name             = "%s"
+      terminal         = false
+    }
+
+    resource "solidserver_ip6_subnet" "subnet" {
+      space            = "${solidserver_ip_space.space.name}"
+      block            = "${solidserver_ip6_subnet.block.name}"
+      request_ip       = "2001:db8::"
+      prefix_size      = 64
+      name             = "%s"
+      terminal         = true
+    }
+
+    resource "solidserver_ip6_pool" "pool" {
+      space            = "${solidserver_ip_space.space.name}"
+      subnet           = "${solidserver_ip6_subnet.subnet.name}"
+      start            = "2001:db8::10"
+      end              = "2001:db8::ff"
+      name             = "%s"
+    }
+`, Config_CreateSpace(spacename),
+		blockname,
+		subnetname,
+		poolname)
+}
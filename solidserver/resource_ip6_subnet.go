@@ -7,10 +7,12 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"math/big"
 	"math/rand"
+	"net/netip"
 	"net/url"
 	"strconv"
 	"time"
@@ -35,16 +37,22 @@ func resourceip6subnet() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space into which creating the IPv6 subnet.",
-				Required:    true,
+				Description: "The name of the space into which creating the IPv6 subnet (Default: the provider's default_space).",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"block": {
 				Type:        schema.TypeString,
-				Description: "The name of the block intyo which creating the IPv6 subnet.",
+				Description: "The name of the block intyo which creating the IPv6 subnet. When not set, a non terminal IPv6 block (ex: a top-level /32 or /48) is created at the requested address (request_ip, request_prefix or cidr).",
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"is_block": {
+				Type:        schema.TypeBool,
+				Description: "Whether the IPv6 subnet is a block, a top-level network without parent.",
+				Computed:    true,
+			},
 			"request_ip": {
 				Type:         schema.TypeString,
 				Description:  "The optionally requested subnet IPv6 address.",
@@ -53,11 +61,33 @@ func resourceip6subnet() *schema.Resource {
 				ForceNew:     true,
 				Default:      "",
 			},
+			"request_prefix": {
+				Type:          schema.TypeString,
+				Description:   "The optionally requested IPv6 prefix in CIDR notation (ex: 2001:db8:1::/48), its length must match the prefix_size.",
+				ValidateFunc:  validation.IsCIDR,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"request_ip"},
+				Default:       "",
+			},
 			"prefix_size": {
-				Type:        schema.TypeInt,
-				Description: "The expected IPv6 subnet's prefix length (ex: 24 for a '/24').",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeInt,
+				Description:  "The expected IPv6 subnet's prefix length (ex: 24 for a '/24'), computed from the cidr if specified.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"prefix_size", "cidr"},
+			},
+			"cidr": {
+				Type:             schema.TypeString,
+				Description:      "The requested IPv6 subnet in CIDR notation (ex: 2001:db8:1::/48), instead of request_ip and prefix_size; computed from the provisionned IPv6 subnet otherwise.",
+				ValidateFunc:     validatenetworkprefix(true),
+				DiffSuppressFunc: resourcediffsuppressprefix,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"request_ip", "request_prefix"},
+				ExactlyOneOf:     []string{"prefix_size", "cidr"},
 			},
 			"prefix": {
 				Type:        schema.TypeString,
@@ -118,18 +148,120 @@ func resourceip6subnet() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the IPv6 subnet.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the IPv6 subnet.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the IPv6 subnet's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(true),
+			resourcediffvalidateclass("ip6_subnet"),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				// Only IPv6 blocks to be created, without parent to look for a free prefix in
+				if d.Id() != "" || !d.NewValueKnown("block") || d.Get("block").(string) != "" {
+					return nil
+				}
+
+				if d.NewValueKnown("terminal") && d.Get("terminal").(bool) {
+					return fmt.Errorf("Can't create a terminal IPv6 block subnet: %s, terminal must be set to false when no block is specified", d.Get("name").(string))
+				}
+
+				// The cidr and prefix_size being exclusive, a cidr is provided when the prefix_size is not known yet
+				if !d.NewValueKnown("prefix_size") {
+					return nil
+				}
+
+				for _, key := range []string{"request_ip", "request_prefix"} {
+					if !d.NewValueKnown(key) || d.Get(key).(string) != "" {
+						return nil
+					}
+				}
+
+				return fmt.Errorf("Can't create the IPv6 block subnet: %s without request_ip (or request_prefix, cidr), there is no parent block to look for a free prefix in", d.Get("name").(string))
+			},
+			customdiff.IfValueChange("cidr", func(ctx context.Context, old, new, meta interface{}) bool {
+				return new.(string) != "" && old.(string) != new.(string)
+			}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				prefix, prefixErr := netip.ParsePrefix(d.Get("cidr").(string))
+
+				if prefixErr != nil {
+					return fmt.Errorf("Invalid IPv6 subnet cidr: %s", d.Get("cidr").(string))
+				}
+
+				// Map the cidr onto the prefix_size to plan the subnet size
+				return d.SetNew("prefix_size", prefix.Bits())
+			}),
+			customdiff.IfValue("request_prefix", func(ctx context.Context, value, meta interface{}) bool {
+				return value.(string) != ""
+			}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				prefix, prefixErr := netip.ParsePrefix(d.Get("request_prefix").(string))
+
+				if prefixErr != nil || !prefix.Addr().Is6() {
+					return fmt.Errorf("Invalid IPv6 requested prefix: %s", d.Get("request_prefix").(string))
+				}
+
+				if d.NewValueKnown("prefix_size") && prefix.Bits() != d.Get("prefix_size").(int) {
+					return fmt.Errorf("Requested prefix length (/%d) does not match the prefix_size (%d)", prefix.Bits(), d.Get("prefix_size").(int))
+				}
+
+				return nil
+			}),
+		),
 	}
 }
 
+// Return the normalized (compressed) form of an IPv6 prefix
+func ip6subnetcidr(prefix string) string {
+	if p, pErr := netip.ParsePrefix(prefix); pErr == nil {
+		return p.Masked().String()
+	}
+
+	return prefix
+}
+
+// Return the information of the terminal IPv6 subnet referenced by a resource, either by subnet_id or by name
+// And record both the subnet name and oid locally
+func resourceip6subnetref(d *schema.ResourceData, siteID string, meta interface{}) (map[string]interface{}, error) {
+	var subnetInfo map[string]interface{}
+	var subnetErr error
+
+	if subnetID := d.Get("subnet_id").(string); subnetID != "" {
+		subnetInfo, subnetErr = ip6subnetinfobyid(subnetID, meta)
+
+		if subnetErr != nil {
+			return nil, subnetErr
+		}
+
+		if infostring(subnetInfo, "site_id") != siteID || infostring(subnetInfo, "terminal") != "1" {
+			return nil, fmt.Errorf("SOLIDServer - Unable to find IPv6 subnet (oid): %s in space: %s\n", subnetID, d.Get("space").(string))
+		}
+	} else {
+		subnetInfo, subnetErr = ip6subnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+		if subnetErr != nil {
+			return nil, subnetErr
+		}
+	}
+
+	d.Set("subnet", infostring(subnetInfo, "name"))
+	d.Set("subnet_id", infostring(subnetInfo, "id"))
+
+	return subnetInfo, nil
+}
+
 func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	blockInfo := make(map[string]interface{})
 	s := meta.(*SOLIDserver)
@@ -179,7 +311,39 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	subnetAddresses, subnetErr := ip6subnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), meta)
+	requestedIP := d.Get("request_ip").(string)
+
+	// Expand the requested address (ex: 2001:db8::), as expected by the hexadecimal conversion
+	if addr, addrErr := netip.ParseAddr(requestedIP); addrErr == nil && addr.Is6() {
+		requestedIP = addr.StringExpanded()
+	}
+
+	// Use the network address of the requested prefix if any, instead of looking for a free prefix
+	if len(d.Get("request_prefix").(string)) > 0 {
+		prefix, prefixErr := netip.ParsePrefix(d.Get("request_prefix").(string))
+
+		if prefixErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create IPv6 subnet: %s, invalid requested prefix: %s\n", d.Get("name").(string), d.Get("request_prefix").(string))
+		}
+
+		requestedIP = prefix.Masked().Addr().StringExpanded()
+	}
+
+	// Use the network address and prefix length of the cidr if any
+	if len(d.Get("cidr").(string)) > 0 {
+		prefix, prefixErr := netip.ParsePrefix(d.Get("cidr").(string))
+
+		if prefixErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create IPv6 subnet: %s, invalid cidr: %s\n", d.Get("name").(string), d.Get("cidr").(string))
+		}
+
+		requestedIP = prefix.Masked().Addr().StringExpanded()
+		d.Set("prefix_size", prefix.Bits())
+	}
+
+	subnetAddresses, subnetErr := ip6subnetfindbysize(siteID, blockInfo["id"].(string), requestedIP, d.Get("prefix_size").(int), meta)
 
 	if subnetErr != nil {
 		// Reporting a failure
@@ -239,7 +403,7 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 			tflog.Debug(ctx, fmt.Sprintf("Subnet computed gateway: %s\n", gateway))
 		}
 
-		for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+		for k, v := range resourceclassparams(d) {
 			classParameters.Add(k, v.(string))
 		}
 		parameters.Add("subnet6_class_parameters", classParameters.Encode())
@@ -260,9 +424,12 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IPv6 subnet (oid): %s\n", oid))
+					lookupcacheinvalidate(meta, cacheKindIP6Subnet)
 					d.SetId(oid)
 					d.Set("prefix", prefix)
+					d.Set("cidr", ip6subnetcidr(prefix))
 					d.Set("address", hexip6toip6(subnetAddresses[i]))
+					d.Set("is_block", len(d.Get("block").(string)) == 0)
 					if goffset != 0 {
 						d.Set("gateway", gateway)
 					}
@@ -339,7 +506,7 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		tflog.Debug(ctx, fmt.Sprintf("Subnet updated gateway: %s\n", d.Get("gateway").(string)))
 	}
 
-	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+	for k, v := range resourceclassparams(d) {
 		classParameters.Add(k, v.(string))
 	}
 
@@ -356,6 +523,7 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 subnet (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIP6Subnet)
 				d.SetId(oid)
 				return nil
 			}
@@ -439,18 +607,30 @@ func resourceip6subnetDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 		// Checking the answer
 		if resp.StatusCode != 200 && resp.StatusCode != 204 {
-			// Reporting a failure
+			errMsg := ""
+
 			if len(buf) > 0 {
-				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-					return diag.Errorf("Unable to delete IPv6 subnet : %s (%s)", d.Get("name").(string), errMsg)
+				errMsg, _ = buf[0]["errmsg"].(string)
+			}
+
+			// Reporting the child subnets preventing the deletion of a block
+			if !d.Get("terminal").(bool) {
+				if childCount, childCountErr := ip6subnetchildcount(d.Id(), meta); childCountErr == nil && childCount > 0 {
+					return diag.Errorf("Unable to delete IPv6 subnet : %s (%s), %d child subnet(s) remaining", d.Get("name").(string), errMsg, childCount)
 				}
 			}
 
+			// Reporting a failure
+			if errMsg != "" {
+				return diag.Errorf("Unable to delete IPv6 subnet : %s (%s)", d.Get("name").(string), errMsg)
+			}
+
 			return diag.Errorf("Unable to delete IPv6 subnet : %s", d.Get("name").(string))
 		}
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted IPv6 subnet (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindIP6Subnet, cacheKindIP6Pool)
 
 		// Unset local ID
 		d.SetId("")
@@ -480,9 +660,17 @@ func resourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta int
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("block", buf[0]["parent_subnet6_name"].(string))
 			d.Set("name", buf[0]["subnet6_name"].(string))
-			d.Set("class", buf[0]["subnet6_class_name"].(string))
+
+			// Blocks have no parent
+			if infostring(buf[0], "subnet_level") == "0" {
+				d.Set("block", "")
+				d.Set("is_block", true)
+			} else {
+				d.Set("block", buf[0]["parent_subnet6_name"].(string))
+				d.Set("is_block", false)
+			}
+			d.Set("class", ip6subnetfield(buf[0], "class_name", meta))
 
 			if buf[0]["is_terminal"].(string) == "1" {
 				d.Set("terminal", true)
@@ -501,7 +689,7 @@ func resourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta int
 
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
-			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet6_class_parameters"].(string))
+			retrievedClassParameters, _ := url.ParseQuery(ip6subnetfield(buf[0], "class_parameters", meta))
 			computedClassParameters := map[string]string{}
 
 			if gateway, gatewayExist := retrievedClassParameters["gateway"]; gatewayExist {
@@ -509,6 +697,12 @@ func resourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta int
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -558,9 +752,17 @@ func resourceip6subnetImportState(ctx context.Context, d *schema.ResourceData, m
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("block", buf[0]["parent_subnet6_name"].(string))
 			d.Set("name", buf[0]["subnet6_name"].(string))
-			d.Set("class", buf[0]["subnet6_class_name"].(string))
+
+			// Blocks have no parent
+			if infostring(buf[0], "subnet_level") == "0" {
+				d.Set("block", "")
+				d.Set("is_block", true)
+			} else {
+				d.Set("block", buf[0]["parent_subnet6_name"].(string))
+				d.Set("is_block", false)
+			}
+			d.Set("class", ip6subnetfield(buf[0], "class_name", meta))
 
 			if buf[0]["is_terminal"].(string) == "1" {
 				d.Set("terminal", true)
@@ -568,6 +770,21 @@ func resourceip6subnetImportState(ctx context.Context, d *schema.ResourceData, m
 				d.Set("terminal", false)
 			}
 
+			d.Set("request_ip", "")
+			d.Set("request_prefix", "")
+
+			if startAddr, startAddrExist := buf[0]["start_ip6_addr"].(string); startAddrExist {
+				address := hexip6toip6(startAddr)
+				prefixSize, _ := strconv.Atoi(ip6subnetfield(buf[0], "prefix", meta))
+
+				d.Set("address", address)
+				d.Set("prefix", address+"/"+strconv.Itoa(prefixSize))
+				d.Set("cidr", ip6subnetcidr(address+"/"+strconv.Itoa(prefixSize)))
+				d.Set("prefix_size", prefixSize)
+			}
+
+			d.Set("gateway_offset", 0)
+
 			if buf[0]["vlmdomain_name"].(string) != "#" {
 				d.Set("vlan_domain", buf[0]["vlmdomain_name"].(string))
 			}
@@ -579,7 +796,7 @@ func resourceip6subnetImportState(ctx context.Context, d *schema.ResourceData, m
 
 			// Setting local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
-			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet6_class_parameters"].(string))
+			retrievedClassParameters, _ := url.ParseQuery(ip6subnetfield(buf[0], "class_parameters", meta))
 			computedClassParameters := map[string]string{}
 
 			if gateway, gatewayExist := retrievedClassParameters["gateway"]; gatewayExist {
@@ -587,6 +804,12 @@ func resourceip6subnetImportState(ctx context.Context, d *schema.ResourceData, m
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -0,0 +1,195 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIP6SubnetReadClassParametersPayloads(t *testing.T) {
+	payloads := map[int]map[string]interface{}{
+		// 7.3 appliances
+		730: {
+			"site_name":               "space01",
+			"parent_subnet6_name":     "block01",
+			"subnet6_name":            "subnet01",
+			"subnet_class_name":       "net",
+			"subnet_class_parameters": "owner=netops&gateway=2001%3Adb8%3A%3A1",
+			"is_terminal":             "1",
+			"vlmdomain_name":          "#",
+			"start_ip6_addr":          "20010db8000000000000000000000000",
+			"subnet_prefix":           "64",
+		},
+		// 8.3 appliances
+		830: {
+			"site_name":                "space01",
+			"parent_subnet6_name":      "block01",
+			"subnet6_name":             "subnet01",
+			"subnet6_class_name":       "net",
+			"subnet6_class_parameters": "owner=netops&gateway=2001%3Adb8%3A%3A1",
+			"is_terminal":              "1",
+			"vlmdomain_name":           "#",
+			"start_ip6_addr":           "20010db8000000000000000000000000",
+			"subnet6_prefix":           "64",
+		},
+	}
+
+	for version, payload := range payloads {
+		m, s := newMockSOLIDserver(t)
+		s.Version = version
+
+		m.handle("/rest/ip6_block6_subnet6_info", func(w http.ResponseWriter, r *http.Request) {
+			mockReply(w, http.StatusOK, []map[string]interface{}{payload})
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceip6subnet().Schema, map[string]interface{}{
+			"space":            "space01",
+			"block":            "block01",
+			"prefix_size":      64,
+			"name":             "subnet01",
+			"class":            "net",
+			"class_parameters": map[string]interface{}{"owner": "netops"},
+		})
+		d.SetId("12")
+
+		if diags := resourceip6subnetRead(context.Background(), d, s); diags.HasError() {
+			t.Fatalf("%d: unexpected error: %v", version, diags)
+		}
+
+		if d.Get("class").(string) != "net" || d.Get("class_parameters").(map[string]interface{})["owner"] != "netops" {
+			t.Errorf("%d: unexpected class information: %s %v", version, d.Get("class").(string), d.Get("class_parameters"))
+		}
+
+		if d.Get("gateway").(string) != "2001:db8::1" {
+			t.Errorf("%d: unexpected gateway: %s", version, d.Get("gateway").(string))
+		}
+
+		imported, err := resourceip6subnetImportState(context.Background(), d, s)
+
+		if err != nil {
+			t.Fatalf("%d: unexpected import error: %s", version, err)
+		}
+
+		if prefix := imported[0].Get("prefix").(string); prefix != "2001:0db8:0000:0000:0000:0000:0000:0000/64" {
+			t.Errorf("%d: unexpected imported prefix: %s", version, prefix)
+		}
+	}
+}
+
+func TestIP6SubnetBlockValidation(t *testing.T) {
+	r := resourceip6subnet()
+
+	// Creating a block requires its address, there is no parent to look for a free prefix in
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":       "space01",
+		"name":        "block01",
+		"prefix_size": 32,
+		"terminal":    false,
+	}), nil); err == nil || !strings.Contains(err.Error(), "request_ip") {
+		t.Errorf("expected an error requiring request_ip, got: %v", err)
+	}
+
+	for _, config := range []map[string]interface{}{
+		{"space": "space01", "name": "block01", "prefix_size": 32, "terminal": false, "request_ip": "2001:db8::"},
+		{"space": "space01", "name": "block01", "cidr": "2001:db8::/32", "terminal": false},
+		{"space": "space01", "name": "subnet01", "prefix_size": 64, "block": "block01"},
+	} {
+		if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err != nil {
+			t.Errorf("unexpected error: %v (%v)", err, config)
+		}
+	}
+
+	// Blocks can't be terminal
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":       "space01",
+		"name":        "block01",
+		"prefix_size": 32,
+		"request_ip":  "2001:db8::",
+	}), nil); err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Errorf("expected a terminal block error, got: %v", err)
+	}
+}
+
+func TestIP6SubnetBlock(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentParameters := url.Values{}
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip6_subnet6_add", func(w http.ResponseWriter, r *http.Request) {
+		sentParameters = r.URL.Query()
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "15"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceip6subnet().Schema, map[string]interface{}{
+		"space":       "space01",
+		"name":        "block01",
+		"request_ip":  "2001:db8::",
+		"prefix_size": 32,
+		"terminal":    false,
+	})
+
+	if diags := resourceip6subnetCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sentParameters.Get("subnet_level") != "0" || sentParameters.Get("is_terminal") != "0" || sentParameters.Get("subnet6_prefix") != "32" || sentParameters.Get("relative_position") != "" {
+		t.Errorf("unexpected IPv6 block creation: %v", sentParameters)
+	}
+
+	if m.count("/rpc/ip6_find_free_subnet6") != 0 {
+		t.Errorf("unexpected free IPv6 subnet lookup")
+	}
+
+	if d.Id() != "15" || !d.Get("is_block").(bool) || d.Get("cidr").(string) != "2001:db8::/32" {
+		t.Errorf("unexpected IPv6 block: %s %t %s", d.Id(), d.Get("is_block").(bool), d.Get("cidr").(string))
+	}
+
+	// Blocks are read back without parent
+	m.handle("/rest/ip6_block6_subnet6_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_name":           "space01",
+			"parent_subnet6_name": "#",
+			"subnet6_name":        "block01",
+			"subnet_level":        "0",
+			"is_terminal":         "0",
+			"vlmdomain_name":      "#",
+		}})
+	})
+
+	if diags := resourceip6subnetRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("block").(string) != "" || !d.Get("is_block").(bool) {
+		t.Errorf("unexpected IPv6 block parent: %q %t", d.Get("block").(string), d.Get("is_block").(bool))
+	}
+
+	// Deleting a block with children reports them
+	m.handle("/rest/ip6_subnet6_delete", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "Block not empty"}})
+	})
+
+	m.handle("/rest/ip6_block6_subnet6_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "parent_subnet6_id='15'" || r.URL.Query().Get("offset") != "0" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"subnet6_id": "16"}, {"subnet6_id": "17"}, {"subnet6_id": "18"}})
+	})
+
+	if diags := resourceip6subnetDelete(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "(Block not empty), 3 child subnet(s) remaining") {
+		t.Errorf("expected a deletion error reporting the child subnets, got: %v", diags)
+	}
+
+	if d.Id() != "15" {
+		t.Errorf("unexpected deletion of the IPv6 block")
+	}
+}
@@ -7,15 +7,17 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 func resourceipaddress() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceipaddressCreate,
 		ReadContext:   resourceipaddressRead,
 		UpdateContext: resourceipaddressUpdate,
@@ -32,15 +34,26 @@ func resourceipaddress() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space into which creating the IP address.",
-				Required:    true,
+				Description: "The name of the space into which creating the IP address (Default: the provider's default_space).",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Description: "The name of the subnet into which creating the IP address.",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Description:  "The name of the subnet into which creating the IP address (Computed when subnet_id is set).",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
+			},
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Description:  "The oid of the subnet into which creating the IP address, to use when several subnets share the same name within the space.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
 			},
 			"pool": {
 				Type:        schema.TypeString,
@@ -63,6 +76,17 @@ func resourceipaddress() *schema.Resource {
 				Computed:    true,
 				ForceNew:    true,
 			},
+			"address_cidr": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IP address in CIDR notation (ex: 10.4.12.5/32, or 10.4.12.5/22 with host_prefix).",
+				Computed:    true,
+			},
+			"host_prefix": {
+				Type:        schema.TypeBool,
+				Description: "Use the prefix length of the subnet rather than a host prefix (/32) in address_cidr (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
 			"device": {
 				Type:        schema.TypeString,
 				Description: "Device Name to associate with the IP address (Require a 'Device Manager' license).",
@@ -72,8 +96,9 @@ func resourceipaddress() *schema.Resource {
 			},
 			"name": {
 				Type:        schema.TypeString,
-				Description: "The short name or FQDN of the IP address to create.",
-				Required:    true,
+				Description: "The short name or FQDN of the IP address to create, rendered from the provider's default_address_name_template when not set.",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    false,
 			},
 			"mac": {
@@ -82,9 +107,45 @@ func resourceipaddress() *schema.Resource {
 				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"), "Unsupported MAC address format."),
 				Optional:         true,
 				ForceNew:         false,
-				DiffSuppressFunc: resourcediffsuppresscase,
+				DiffSuppressFunc: resourcediffsuppressmac,
+				StateFunc:        resourcestatemac,
 				Default:          "",
 			},
+			"allocation_lock": {
+				Type:        schema.TypeBool,
+				Description: "Reduce concurrent allocations of IP addresses within the subnet using the 'tf_allocation_lock' class parameter of the subnet; the lock is best effort and not atomic, it is only honored by resources enabling this option, expires after 60 seconds and requires the permission to update the subnet (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"ip_type": {
+				Type:         schema.TypeString,
+				Description:  "The usage type of the IP address, stored within the 'ip_type' class parameter (Supported: host, network, gateway, vrrp, anycast; Default: host).",
+				ValidateFunc: validation.StringInSlice([]string{"host", "network", "gateway", "vrrp", "anycast"}, false),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      "host",
+			},
+			"device_type": {
+				Type:        schema.TypeString,
+				Description: "The type of the device using the IP address (ex: router, server, switch), stored within the 'device_type' class parameter.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"device_role": {
+				Type:        schema.TypeString,
+				Description: "The role of the device using the IP address (ex: spine, leaf, gateway), stored within the 'device_role' class parameter.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"lock": {
+				Type:        schema.TypeBool,
+				Description: "Mark the IP address as locked through the __eip_lock class parameter, an advisory flag for GUI users that SOLIDserver does not enforce; Terraform unlocks it before destroying it (Supported from SOLIDserver 8.2; Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP address.",
@@ -93,16 +154,144 @@ func resourceipaddress() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the IP address.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the IP address.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the IP address's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"last_seen": {
+				Type:        schema.TypeString,
+				Description: "The end time (RFC3339) of the most recent DHCP lease of the IP address, empty if the IP address has no DHCP lease.",
+				Computed:    true,
+			},
+			"verify_dns_consistency": {
+				Type:        schema.TypeBool,
+				Description: "Look up on each refresh the A and PTR records of the IP address name pointing at another address (ex: left behind after a move to another subnet), reporting them as a warning without deleting them (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+			"verify_dns_server": {
+				Type:        schema.TypeString,
+				Description: "The name of the DNS server or DNS SMART whose records are looked up by verify_dns_consistency (Required with verify_dns_consistency).",
+				Optional:    true,
+				Default:     "",
+			},
+			"verify_dns_view": {
+				Type:        schema.TypeString,
+				Description: "The name of the DNS view of verify_dns_server whose records are looked up by verify_dns_consistency (Default: all the views of the server).",
+				Optional:    true,
+				Default:     "",
+			},
+			"dns_consistent": {
+				Type:        schema.TypeBool,
+				Description: "Whether no A nor PTR record of the IP address name points at another address (Always true unless verify_dns_consistency is enabled).",
+				Computed:    true,
+			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(true),
+			resourcediffvalidateclass("ip_address"),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if d.Get("verify_dns_consistency").(bool) && d.NewValueKnown("verify_dns_server") && d.Get("verify_dns_server").(string) == "" {
+					return fmt.Errorf("verify_dns_server is required when verify_dns_consistency is enabled")
+				}
+
+				return nil
+			},
+			resourcediffvalidatesubnetname(false),
+			resourcediffvalidateaddressname("an IP address"),
+			customdiff.ComputedIf("address_cidr", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("host_prefix") && d.Id() != ""
+			}),
+		),
+	}
+
+	// Resources created by older provider versions may not be identified by their oid
+	r.SchemaVersion = stateVersionOID
+	r.StateUpgraders = []schema.StateUpgrader{
+		stateupgraderoid("IP address", r, "rest/ip_address_info", "ip_id", resourceipaddressstatelookup, resourceipaddressstatematch),
 	}
+
+	return r
+}
+
+// Return the oid of an IP address from the space and address stored in a raw state
+// Or an empty string if not found
+func resourceipaddressstatelookup(rawState map[string]interface{}, meta interface{}) (string, error) {
+	if statestring(rawState, "space") == "" || statestring(rawState, "address") == "" {
+		return "", nil
+	}
+
+	siteID, siteErr := ipsiteidbyname(statestring(rawState, "space"), meta)
+	if siteErr != nil {
+		return "", siteErr
+	}
+
+	return ipaddressidbyip(siteID, statestring(rawState, "address"), meta)
+}
+
+// Return true if an IP address matches the space and address stored in a raw state
+func resourceipaddressstatematch(rawState map[string]interface{}, info map[string]interface{}) bool {
+	return strings.EqualFold(infostring(info, "site_name"), statestring(rawState, "space")) &&
+		infostring(info, "ip_addr") == iptohexip(statestring(rawState, "address"))
+}
+
+// Fields of the IP address stored within its class and class parameters
+var ipAddressClassParamsFields = []string{"class", "class_parameters", "ip_type", "device_type", "device_role", "lock"}
+
+// Build the class parameters of an IP address including its usage type and device metadata
+func resourceipaddressclassparams(d *schema.ResourceData) url.Values {
+	classParameters := urlfromclassparams(resourceclassparams(d))
+
+	// Only send the usage type when configured or reset to its default
+	ipTypeSet := d.HasChange("ip_type")
+
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("ip_type").IsNull() {
+		ipTypeSet = true
+	}
+
+	if ipTypeSet {
+		classParameters.Set("ip_type", d.Get("ip_type").(string))
+	}
+
+	// Only send the device metadata when set or cleared
+	for _, key := range []string{"device_type", "device_role"} {
+		if d.Get(key).(string) != "" || d.HasChange(key) {
+			classParameters.Set(key, d.Get(key).(string))
+		}
+	}
+
+	return classParameters
+}
+
+// Set the address_cidr of an IP address, using the prefix length of its subnet when host_prefix is set
+// The subnet is looked up when its prefix length is unknown (0), keeping the current value on failure
+func resourceipaddresssetcidr(d *schema.ResourceData, subnetPrefixLength int, meta interface{}) {
+	if !d.Get("host_prefix").(bool) {
+		subnetPrefixLength = 0
+	} else if subnetPrefixLength == 0 {
+		subnetInfo, subnetErr := ipsubnetinfobyid(d.Get("subnet_id").(string), meta)
+
+		if subnetErr != nil {
+			return
+		}
+
+		subnetPrefixLength, _ = subnetInfo["prefix_length"].(int)
+	}
+
+	d.Set("address_cidr", ipaddresscidr(d.Get("address").(string), subnetPrefixLength))
 }
 
 func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -121,13 +310,7 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(siteErr)
 	}
 
-	//subnetID, subnetErr := ipsubnetidbyname(siteID, d.Get("subnet").(string), true, meta)
-	//if subnetErr != nil {
-	//	// Reporting a failure
-	//	return diag.FromErr(subnetErr)
-	//}
-
-	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+	subnetInfo, subnetErr := resourceipsubnetref(d, siteID, meta)
 
 	if subnetInfo == nil || subnetErr != nil {
 		// Reporting a failure
@@ -138,6 +321,32 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(subnetErr)
 	}
 
+	// Serialize the allocations within the subnet if requested
+	if d.Get("allocation_lock").(bool) {
+		unlock, lockErr := allocationlock(subnetInfo["id"].(string), meta)
+
+		if lockErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create IP address: %s, %s", d.Get("name").(string), lockErr)
+		}
+
+		defer unlock()
+	}
+
+	// Ensure the name is not already registered within the subnet
+	if d.Get("name").(string) != "" {
+		duplicateInfo, duplicateErr := ipaddressinfobyname(subnetInfo["id"].(string), d.Get("name").(string), meta)
+
+		if duplicateErr != nil {
+			// Reporting a failure
+			return diag.FromErr(duplicateErr)
+		}
+
+		if duplicateInfo != nil && duplicateInfo["id"].(string) != d.Id() {
+			return diag.Errorf("Address with name '%s' already exists in subnet '%s' at IP %s\n", d.Get("name").(string), d.Get("subnet").(string), duplicateInfo["address"].(string))
+		}
+	}
+
 	if len(d.Get("pool").(string)) > 0 {
 		var poolErr error = nil
 
@@ -162,9 +371,10 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 	// Determining if an IP address was submitted in or if we should get one from the IPAM
 	if len(d.Get("request_ip").(string)) > 0 {
 		// Ensure IP Address is within the given subnet start and end IP addresses
+		subnetPrefixLength, _ := subnetInfo["prefix_length"].(int)
+
 		if strings.Compare(subnetInfo["terminal"].(string), "1") == 0 &&
-			strings.Compare(subnetInfo["start_hex_addr"].(string), requestedHexIP) == -1 &&
-			strings.Compare(requestedHexIP, subnetInfo["end_hex_addr"].(string)) == -1 {
+			iphexaddrinsubnet(subnetInfo["start_hex_addr"].(string), subnetInfo["end_hex_addr"].(string), requestedHexIP, subnetPrefixLength, 32) {
 
 			if poolInfo != nil && (strings.Compare(poolInfo["start_hex_addr"].(string), requestedHexIP) == 1 ||
 				strings.Compare(requestedHexIP, poolInfo["end_hex_addr"].(string)) == 1) {
@@ -189,24 +399,47 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 			// Reporting a failure
 			return diag.FromErr(ipErr)
 		}
+
+		// Point-to-point and host subnets only hold single address free ranges
+		if subnetPrefixLength, _ := subnetInfo["prefix_length"].(int); len(ipAddresses) == 0 && poolID == "" && subnetPrefixLength >= 31 {
+			ipAddresses, ipErr = ipaddressfindfreeinranges(subnetInfo["id"].(string), meta)
+
+			if ipErr != nil {
+				// Reporting a failure
+				return diag.FromErr(ipErr)
+			}
+		}
 	}
 
 	for i := 0; i < len(ipAddresses); i++ {
+		// Naming the address once allocated when no name is provided
+		addressName := d.Get("name").(string)
+		if addressName == "" {
+			addressName = addressnamefromtemplate(s.AddressNameTemplate, ipAddresses[i], d.Get("subnet").(string), d.Get("space").(string))
+		}
+
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("site_id", siteID)
 		parameters.Add("add_flag", "new_only")
-		parameters.Add("ip_name", d.Get("name").(string))
+		parameters.Add("ip_name", addressName)
 		parameters.Add("hostaddr", ipAddresses[i])
 		parameters.Add("hostdev_id", deviceID)
 		parameters.Add("ip_class_name", d.Get("class").(string))
 
 		if d.Get("mac").(string) != "" {
-			parameters.Add("mac_addr", d.Get("mac").(string))
+			parameters.Add("mac_addr", macnormalize(d.Get("mac").(string)))
 		}
 
 		// Building class_parameters
-		parameters.Add("ip_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+		classParameters := resourceipaddressclassparams(d)
+
+		if lockErr := classparamsetlock(d, classParameters, meta); lockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(lockErr)
+		}
+
+		parameters.Add("ip_class_parameters", classParameters.Encode())
 
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip_add", &parameters)
@@ -220,7 +453,10 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IP address (oid): %s\n", oid))
 					d.SetId(oid)
+					d.Set("name", addressName)
 					d.Set("address", ipAddresses[i])
+					subnetPrefixLength, _ := subnetInfo["prefix_length"].(int)
+					resourceipaddresssetcidr(d, subnetPrefixLength, meta)
 					return nil
 				}
 			} else {
@@ -247,34 +483,60 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	var deviceID string = ""
+	// Building parameters, only the changed fields are sent
+	parameters := url.Values{}
+	parameters.Add("ip_id", d.Id())
+	parameters.Add("add_flag", "edit_only")
 
-	// Retrieving device ID
-	if len(d.Get("device").(string)) > 0 {
-		var err error = nil
+	if d.HasChange("name") {
+		parameters.Add("ip_name", d.Get("name").(string))
+	}
 
-		deviceID, err = hostdevidbyname(d.Get("device").(string), meta)
+	if d.HasChange("device") {
+		var deviceID string = ""
 
-		if err != nil {
-			// Reporting a failure
-			return diag.FromErr(err)
+		// Retrieving device ID
+		if len(d.Get("device").(string)) > 0 {
+			var err error = nil
+
+			deviceID, err = hostdevidbyname(d.Get("device").(string), meta)
+
+			if err != nil {
+				// Reporting a failure
+				return diag.FromErr(err)
+			}
 		}
+
+		parameters.Add("hostdev_id", deviceID)
 	}
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("ip_id", d.Id())
-	parameters.Add("add_flag", "edit_only")
-	parameters.Add("ip_name", d.Get("name").(string))
-	parameters.Add("hostdev_id", deviceID)
-	parameters.Add("ip_class_name", d.Get("class").(string))
+	if d.HasChange("mac") {
+		parameters.Add("mac_addr", macnormalize(d.Get("mac").(string)))
+	}
+
+	// Rewriting the class parameters may reset the ones maintained by SOLIDserver (ex: DNS linked records)
+	if d.HasChanges(ipAddressClassParamsFields...) {
+		parameters.Add("ip_class_name", d.Get("class").(string))
 
-	if d.Get("mac").(string) != "" {
-		parameters.Add("mac_addr", d.Get("mac").(string))
+		// Building class_parameters
+		classParameters := resourceipaddressclassparams(d)
+
+		if lockErr := classparamsetlock(d, classParameters, meta); lockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(lockErr)
+		}
+
+		parameters.Add("ip_class_parameters", classParameters.Encode())
 	}
 
-	// Building class_parameters
-	parameters.Add("ip_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	// Nothing to update on SOLIDserver (ex: host_prefix or allocation_lock changed)
+	if len(parameters) == 2 {
+		if d.HasChange("host_prefix") {
+			resourceipaddresssetcidr(d, 0, meta)
+		}
+
+		return nil
+	}
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip_add", &parameters)
@@ -288,6 +550,11 @@ func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP address (oid): %s\n", oid))
 				d.SetId(oid)
+
+				if d.HasChange("host_prefix") {
+					resourceipaddresssetcidr(d, 0, meta)
+				}
+
 				return nil
 			}
 		}
@@ -309,6 +576,14 @@ func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta i
 func resourceipaddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Unlock the IP address, the lock only protecting it from the GUI
+	if d.Get("lock").(bool) {
+		if unlockErr := objectunlock("rest/ip_add", "ip_id", d.Id(), "ip_class_parameters", meta); unlockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(unlockErr)
+		}
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("ip_id", d.Id())
@@ -322,6 +597,13 @@ func resourceipaddressDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 		// Checking the answer
 		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// An IP address already deleted (ex: along with its subnet) is not a failure
+			if objectmissing(resp.StatusCode, buf) {
+				tflog.Debug(ctx, fmt.Sprintf("IP address already deleted (oid): %s\n", d.Id()))
+				d.SetId("")
+				return nil
+			}
+
 			// Reporting a failure
 			if len(buf) > 0 {
 				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
@@ -364,14 +646,18 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
 			d.Set("subnet", buf[0]["subnet_name"].(string))
+			d.Set("subnet_id", infostring(buf[0], "subnet_id"))
 			d.Set("address", hexiptoip(buf[0]["ip_addr"].(string)))
-			d.Set("name", buf[0]["name"].(string))
 
-			if macIgnore, _ := regexp.MatchString("^EIP:", buf[0]["mac_addr"].(string)); !macIgnore {
-				d.Set("mac", buf[0]["mac_addr"].(string))
-			} else {
-				d.Set("mac", "")
+			subnetPrefixLength := 0
+			if subnetSize, subnetSizeErr := strconv.Atoi(infostring(buf[0], "subnet_size")); subnetSizeErr == nil {
+				subnetPrefixLength = sizetoprefixlength(subnetSize)
 			}
+			resourceipaddresssetcidr(d, subnetPrefixLength, meta)
+
+			d.Set("name", buf[0]["name"].(string))
+
+			d.Set("mac", macnormalize(buf[0]["mac_addr"].(string)))
 
 			d.Set("class", buf[0]["ip_class_name"].(string))
 			d.Set("pool", buf[0]["pool_name"].(string))
@@ -379,9 +665,31 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["ip_class_parameters"].(string))
+
+			d.Set("lock", classparamlocked(retrievedClassParameters))
 			computedClassParameters := map[string]string{}
 
+			if ipType, ipTypeExist := retrievedClassParameters["ip_type"]; ipTypeExist {
+				d.Set("ip_type", ipType[0])
+			} else {
+				d.Set("ip_type", "host")
+			}
+
+			for _, key := range []string{"device_type", "device_role"} {
+				if rv, rvExist := retrievedClassParameters[key]; rvExist {
+					d.Set(key, rv[0])
+				} else {
+					d.Set(key, "")
+				}
+			}
+
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -391,7 +699,14 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 
 			d.Set("class_parameters", computedClassParameters)
 
-			return nil
+			// Updating the last time the IP address was seen through DHCP
+			if lastSeen, lastSeenErr := ipaddresslastseen(buf[0]["site_id"].(string), buf[0]["ip_addr"].(string), meta); lastSeenErr == nil {
+				d.Set("last_seen", lastSeen)
+			} else {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to retrieve the DHCP leases of IP address: %s (%s)\n", d.Get("address").(string), lastSeenErr))
+			}
+
+			return resourceipaddressverifydns(ctx, d, meta)
 		}
 
 		if len(buf) > 0 {
@@ -414,6 +729,35 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 	return diag.FromErr(err)
 }
 
+// Report the A and PTR records of the IP address name pointing at another address on verify_dns_server when verify_dns_consistency is enabled
+// The records are not owned by the IP address, they are never deleted
+func resourceipaddressverifydns(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.Get("verify_dns_consistency").(bool) {
+		d.Set("dns_consistent", true)
+		return nil
+	}
+
+	staleRecords, staleErr := ipaddressstalerecords(d.Get("name").(string), d.Get("address").(string), d.Get("verify_dns_server").(string), d.Get("verify_dns_view").(string), meta)
+
+	if staleErr != nil {
+		// Keeping the last known consistency
+		tflog.Warn(ctx, fmt.Sprintf("Unable to verify the DNS records of IP address: %s (%s)\n", d.Get("address").(string), staleErr))
+		return nil
+	}
+
+	d.Set("dns_consistent", len(staleRecords) == 0)
+
+	if len(staleRecords) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Stale DNS records for IP address: %s (%s)", d.Get("name").(string), d.Get("address").(string)),
+		Detail:   fmt.Sprintf("The following records point at another address and are not managed by this resource: %s", strings.Join(staleRecords, ", ")),
+	}}
+}
+
 func resourceipaddressImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	s := meta.(*SOLIDserver)
 
@@ -432,18 +776,41 @@ func resourceipaddressImportState(ctx context.Context, d *schema.ResourceData, m
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
 			d.Set("subnet", buf[0]["subnet_name"].(string))
+			d.Set("subnet_id", infostring(buf[0], "subnet_id"))
 			d.Set("address", hexiptoip(buf[0]["ip_addr"].(string)))
 			d.Set("name", buf[0]["name"].(string))
-			d.Set("mac", buf[0]["mac_addr"].(string))
+			d.Set("mac", macnormalize(buf[0]["mac_addr"].(string)))
 			d.Set("class", buf[0]["ip_class_name"].(string))
 			d.Set("pool", buf[0]["pool_name"].(string))
 
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["ip_class_parameters"].(string))
+
+			d.Set("lock", classparamlocked(retrievedClassParameters))
 			computedClassParameters := map[string]string{}
 
+			if ipType, ipTypeExist := retrievedClassParameters["ip_type"]; ipTypeExist {
+				d.Set("ip_type", ipType[0])
+			} else {
+				d.Set("ip_type", "host")
+			}
+
+			for _, key := range []string{"device_type", "device_role"} {
+				if rv, rvExist := retrievedClassParameters[key]; rvExist {
+					d.Set(key, rv[0])
+				} else {
+					d.Set(key, "")
+				}
+			}
+
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -453,6 +820,10 @@ func resourceipaddressImportState(ctx context.Context, d *schema.ResourceData, m
 
 			d.Set("class_parameters", computedClassParameters)
 
+			if lastSeen, lastSeenErr := ipaddresslastseen(buf[0]["site_id"].(string), buf[0]["ip_addr"].(string), meta); lastSeenErr == nil {
+				d.Set("last_seen", lastSeen)
+			}
+
 			return []*schema.ResourceData{d}, nil
 		}
 
@@ -0,0 +1,768 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIPAddressCreateDuplicateName(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id":     "12",
+			"subnet_name":   "subnet01",
+			"subnet_size":   "256",
+			"start_ip_addr": "0a000000",
+			"end_ip_addr":   "0a0000ff",
+			"is_terminal":   "1",
+			"subnet_level":  "2",
+		}})
+	})
+
+	m.handle("/rest/ip_used_address_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "subnet_id='12' AND name='host01'" {
+			mockReply(w, http.StatusOK, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ip_id": "77", "ip_addr": "0a000005"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, map[string]interface{}{
+		"space":  "space01",
+		"subnet": "subnet01",
+		"name":   "host01",
+	})
+
+	diags := resourceipaddressCreate(context.Background(), d, s)
+
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Address with name 'host01' already exists in subnet 'subnet01' at IP 10.0.0.5") {
+		t.Fatalf("expected a duplicate name error, got: %v", diags)
+	}
+
+	if m.count("/rest/ip_add") != 0 {
+		t.Errorf("address creation attempted despite the duplicate name")
+	}
+}
+
+func TestIPAddressReadLastSeen(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_address_info", func(w http.ResponseWriter, r *http.Request) {
+		ipAddr := "0a000001"
+
+		if r.URL.Query().Get("ip_id") == "43" {
+			ipAddr = "0a000002"
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_id":             "2",
+			"site_name":           "space01",
+			"subnet_name":         "subnet01",
+			"ip_addr":             ipAddr,
+			"name":                "host01",
+			"mac_addr":            "",
+			"ip_class_name":       "",
+			"pool_name":           "",
+			"ip_class_parameters": "",
+		}})
+	})
+
+	// Only 10.0.0.1 holds DHCP leases, the most recent one is reported
+	m.handle("/rest/dhcp_lease_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "ip_addr='0a000001' AND site_id='2'" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"ip_addr": "0a000001", "lease_end_time": "2024-03-01 10:00:00"},
+			{"ip_addr": "0a000001", "lease_end_time": "2024-03-02 10:00:00"},
+		})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, map[string]interface{}{
+		"space":  "space01",
+		"subnet": "subnet01",
+		"name":   "host01",
+	})
+	d.SetId("42")
+
+	if diags := resourceipaddressRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("last_seen").(string) != "2024-03-02T10:00:00Z" {
+		t.Errorf("unexpected last_seen: %q", d.Get("last_seen").(string))
+	}
+
+	// Statically registered address without DHCP lease
+	d.SetId("43")
+
+	if diags := resourceipaddressRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("last_seen").(string) != "" {
+		t.Errorf("expected an empty last_seen, got %q", d.Get("last_seen").(string))
+	}
+}
+
+func TestIPAddressDeviceMetadata(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_address_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_id":             "2",
+			"site_name":           "space01",
+			"subnet_name":         "subnet01",
+			"ip_addr":             "0a000001",
+			"name":                "leaf01",
+			"mac_addr":            "",
+			"ip_class_name":       "",
+			"pool_name":           "",
+			"ip_class_parameters": "ip_type=host&device_type=switch&device_role=leaf&owner=netops",
+		}})
+	})
+
+	m.handle("/rest/dhcp_lease_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	// The device metadata are only sent when set
+	d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, map[string]interface{}{
+		"space":            "space01",
+		"subnet":           "subnet01",
+		"name":             "leaf01",
+		"device_type":      "router",
+		"class_parameters": map[string]interface{}{"owner": "netops"},
+	})
+
+	classParameters := resourceipaddressclassparams(d)
+
+	if classParameters.Get("device_type") != "router" || classParameters.Has("device_role") || classParameters.Get("owner") != "netops" {
+		t.Errorf("unexpected class parameters: %v", classParameters)
+	}
+
+	// The device metadata are read from the class parameters
+	d.SetId("42")
+
+	if diags := resourceipaddressRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("device_type").(string) != "switch" || d.Get("device_role").(string) != "leaf" {
+		t.Errorf("unexpected device metadata: %v", d.State().Attributes)
+	}
+
+	if _, exist := d.Get("class_parameters").(map[string]interface{})["device_role"]; exist {
+		t.Errorf("expected the device metadata to be left out of class_parameters: %v", d.Get("class_parameters"))
+	}
+}
+
+func TestIPAddressSubnetNameUniqueness(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_block_subnet_list", mockWhereList([]map[string]interface{}{
+		{"subnet_id": "12", "site_name": "space01", "subnet_name": "prod", "subnet_size": "256", "start_ip_addr": "0a000000", "is_terminal": "1"},
+		{"subnet_id": "13", "site_name": "space01", "subnet_name": "prod", "subnet_size": "256", "start_ip_addr": "0a000100", "is_terminal": "1"},
+		{"subnet_id": "14", "site_name": "space01", "subnet_name": "lab", "subnet_size": "256", "start_ip_addr": "0a000200", "is_terminal": "1"},
+		{"subnet_id": "15", "site_name": "space02", "subnet_name": "lab", "subnet_size": "256", "start_ip_addr": "0a000200", "is_terminal": "1"},
+	}))
+
+	m.handle("/rest/ip_block_subnet_info", func(w http.ResponseWriter, r *http.Request) {
+		siteID := "2"
+
+		if r.URL.Query().Get("subnet_id") == "15" {
+			siteID = "3"
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_name":   "prod",
+			"site_id":       siteID,
+			"site_name":     "space01",
+			"subnet_size":   "256",
+			"start_ip_addr": "0a000100",
+			"end_ip_addr":   "0a0001ff",
+			"is_terminal":   "1",
+			"subnet_level":  "2",
+		}})
+	})
+
+	r := resourceipaddress()
+
+	// A subnet name shared within the space is refused, listing the candidates
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":  "space01",
+		"subnet": "prod",
+		"name":   "host01",
+	}), s)
+
+	if err == nil || !strings.Contains(err.Error(), "10.0.0.0/24 (oid: 12), 10.0.1.0/24 (oid: 13)") || !strings.Contains(err.Error(), "use subnet_id") {
+		t.Fatalf("expected a duplicate subnet name error, got: %v", err)
+	}
+
+	// A subnet name unique within the space is accepted
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":  "space01",
+		"subnet": "lab",
+		"name":   "host01",
+	}), s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Selecting the subnet by oid skips the name lookup
+	listCalls := m.count("/rest/ip_block_subnet_list")
+
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":     "space01",
+		"subnet_id": "13",
+		"name":      "host01",
+	}), s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m.count("/rest/ip_block_subnet_list") != listCalls {
+		t.Errorf("subnet name lookup performed despite subnet_id")
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"space":     "space01",
+		"subnet_id": "13",
+		"name":      "host01",
+	})
+
+	subnetInfo, subnetErr := resourceipsubnetref(d, "2", s)
+
+	if subnetErr != nil || subnetInfo["end_hex_addr"] != "0a0001ff" {
+		t.Fatalf("unexpected subnet resolution: %v (%v)", subnetInfo, subnetErr)
+	}
+
+	if d.Get("subnet").(string) != "prod" || d.Get("subnet_id").(string) != "13" {
+		t.Errorf("unexpected subnet: %q (oid: %q)", d.Get("subnet").(string), d.Get("subnet_id").(string))
+	}
+
+	// A subnet oid from another space is refused
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"space":     "space01",
+		"subnet_id": "15",
+		"name":      "host01",
+	})
+
+	if _, subnetErr := resourceipsubnetref(d, "2", s); subnetErr == nil {
+		t.Errorf("expected a subnet from another space to be refused")
+	}
+}
+
+func TestIPAddressSubnetNameLookupFailure(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errno": "1", "errmsg": "Forbidden"}})
+	})
+
+	// A failed lookup fails the plan rather than skipping the verification
+	_, err := resourceipaddress().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":  "space01",
+		"subnet": "prod",
+		"name":   "host01",
+	}), s)
+
+	if err == nil || !strings.Contains(err.Error(), "Unable to look up subnet name 'prod'") {
+		t.Errorf("expected a lookup error, got: %v", err)
+	}
+}
+
+func TestIPAddressCIDR(t *testing.T) {
+	cases := []struct {
+		address      string
+		prefixLength int
+		expected     string
+	}{
+		{"10.4.12.5", 0, "10.4.12.5/32"},
+		{"10.4.12.5", 22, "10.4.12.5/22"},
+		{"2001:0db8:0000:0000:0000:0000:0000:0005", 0, "2001:db8::5/128"},
+		{"2001:0db8:0000:0000:0000:0000:0000:0005", 64, "2001:db8::5/64"},
+		{"10.4.12.5", 64, "10.4.12.5/32"},
+		{"", 24, ""},
+	}
+
+	for _, c := range cases {
+		if cidr := ipaddresscidr(c.address, c.prefixLength); cidr != c.expected {
+			t.Errorf("ipaddresscidr(%q, %d): expected %q, got %q", c.address, c.prefixLength, c.expected, cidr)
+		}
+	}
+
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_address_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_id":             "2",
+			"site_name":           "space01",
+			"subnet_id":           "12",
+			"subnet_name":         "subnet01",
+			"subnet_size":         "1024",
+			"ip_addr":             "0a040c05",
+			"name":                "host01",
+			"mac_addr":            "",
+			"ip_class_name":       "",
+			"pool_name":           "",
+			"ip_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/ip6_address6_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_name":            "space01",
+			"subnet6_id":           "16",
+			"subnet6_name":         "subnet01",
+			"ip6_addr":             "20010db8000000000000000000000005",
+			"ip6_name":             "host01",
+			"ip6_mac_addr":         "",
+			"ip6_class_name":       "",
+			"ip6_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/ip6_block6_subnet6_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"subnet6_id": "16", "subnet6_prefix": "64"}})
+	})
+
+	m.handle("/rest/dhcp_lease_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	// The CIDR notation is stable across refreshes
+	for _, hostPrefix := range []bool{false, true} {
+		expected := map[bool][]string{false: {"10.4.12.5/32", "2001:db8::5/128"}, true: {"10.4.12.5/22", "2001:db8::5/64"}}[hostPrefix]
+
+		d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, map[string]interface{}{
+			"space":       "space01",
+			"subnet":      "subnet01",
+			"name":        "host01",
+			"host_prefix": hostPrefix,
+		})
+		d.SetId("42")
+
+		d6 := schema.TestResourceDataRaw(t, resourceip6address().Schema, map[string]interface{}{
+			"space":       "space01",
+			"subnet":      "subnet01",
+			"name":        "host01",
+			"host_prefix": hostPrefix,
+		})
+		d6.SetId("43")
+
+		for i := 0; i < 2; i++ {
+			if diags := resourceipaddressRead(context.Background(), d, s); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if diags := resourceip6addressRead(context.Background(), d6, s); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if d.Get("address_cidr").(string) != expected[0] || d6.Get("address_cidr").(string) != expected[1] {
+				t.Errorf("unexpected address_cidr (host_prefix: %t): %q, %q", hostPrefix, d.Get("address_cidr").(string), d6.Get("address_cidr").(string))
+			}
+		}
+	}
+}
+
+func TestIPAddressLock(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	s.Version = 820
+	calls := []string{}
+	locked := "0"
+
+	m.handle("/rest/ip_add", func(w http.ResponseWriter, r *http.Request) {
+		classParameters, _ := url.ParseQuery(r.URL.Query().Get("ip_class_parameters"))
+
+		if value, valueExist := classParameters[lockClassParameter]; valueExist {
+			locked = value[0]
+		}
+
+		calls = append(calls, "ip_add:"+classParameters.Get(lockClassParameter))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	m.handle("/rest/ip_delete", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "ip_delete:"+locked)
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	m.handle("/rest/ip_address_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_id":             "2",
+			"site_name":           "space01",
+			"subnet_name":         "subnet01",
+			"ip_addr":             "0a000001",
+			"name":                "host01",
+			"mac_addr":            "",
+			"ip_class_name":       "",
+			"pool_name":           "",
+			"ip_class_parameters": "ip_type=host&" + lockClassParameter + "=" + locked,
+		}})
+	})
+
+	m.handle("/rest/dhcp_lease_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, map[string]interface{}{
+		"space":  "space01",
+		"subnet": "subnet01",
+		"name":   "host01",
+		"lock":   true,
+	})
+	d.SetId("42")
+
+	// Locking in place
+	if diags := resourceipaddressUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if diags := resourceipaddressRead(context.Background(), d, s); diags.HasError() || !d.Get("lock").(bool) {
+		t.Fatalf("expected a locked IP address: %v", diags)
+	}
+
+	if _, classParameterExist := d.Get("class_parameters").(map[string]interface{})[lockClassParameter]; classParameterExist {
+		t.Errorf("lock class parameter reported within class_parameters")
+	}
+
+	// Terraform unlocks the IP address before deleting it
+	if diags := resourceipaddressDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if strings.Join(calls, ",") != "ip_add:1,ip_add:0,ip_delete:0" {
+		t.Errorf("unexpected calls: %v", calls)
+	}
+
+	// Locking is refused by older SOLIDserver versions
+	s.Version = 810
+
+	if diags := resourceipaddressUpdate(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "Locking is not supported") {
+		t.Errorf("expected a version error, got: %v", diags)
+	}
+}
+
+func TestIPAddressNameTemplate(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	names := []string{}
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id":     "12",
+			"subnet_name":   "subnet01",
+			"subnet_size":   "256",
+			"start_ip_addr": "0a000000",
+			"end_ip_addr":   "0a0000ff",
+			"is_terminal":   "1",
+			"subnet_level":  "2",
+		}})
+	})
+
+	m.handle("/rest/ip_add", func(w http.ResponseWriter, r *http.Request) {
+		names = append(names, r.URL.Query().Get("ip_name"))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	config := map[string]interface{}{
+		"space":      "space01",
+		"subnet":     "subnet01",
+		"request_ip": "10.0.0.5",
+	}
+
+	// Without template the name is required at plan time
+	r := resourceipaddress()
+
+	if _, err := r.Diff(context.Background(), &terraform.InstanceState{RawConfig: mockRawConfig(r, config)}, terraform.NewResourceConfigRaw(config), s); err == nil || !strings.Contains(err.Error(), "default_address_name_template") {
+		t.Fatalf("expected a missing name error, got: %v", err)
+	}
+
+	// The template is rendered once the address is allocated
+	s.AddressNameTemplate = "ip-{address}.{subnet}.{space}"
+
+	if _, err := r.Diff(context.Background(), &terraform.InstanceState{RawConfig: mockRawConfig(r, config)}, terraform.NewResourceConfigRaw(config), s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, config)
+
+	if diags := resourceipaddressCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("name").(string) != "ip-10.0.0.5.subnet01.space01" || strings.Join(names, ",") != "ip-10.0.0.5.subnet01.space01" {
+		t.Fatalf("unexpected rendered name: %s (sent: %v)", d.Get("name").(string), names)
+	}
+
+	// The rendered name is kept in the state, changing the template doesn't rename the address
+	s.AddressNameTemplate = "host-{address}"
+	diff, err := resourceipaddress().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff != nil && diff.Attributes["name"] != nil {
+		t.Errorf("unexpected name diff: %v", diff.Attributes["name"])
+	}
+}
+
+func TestIPAddressMinimalUpdate(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	var sent url.Values
+
+	m.handle("/rest/ip_add", func(w http.ResponseWriter, r *http.Request) {
+		sent = r.URL.Query()
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	m.handle("/rest/hostdev_list", mockWhereList([]map[string]interface{}{{"hostdev_id": "7", "hostdev_name": "dev02"}}))
+
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"id":                        "42",
+			"space":                     "space01",
+			"subnet":                    "subnet01",
+			"pool":                      "",
+			"request_ip":                "",
+			"address":                   "10.0.0.1",
+			"address_cidr":              "10.0.0.1/32",
+			"host_prefix":               "false",
+			"device":                    "dev01",
+			"name":                      "host01",
+			"mac":                       "00:11:22:33:44:55",
+			"allocation_lock":           "false",
+			"ip_type":                   "host",
+			"device_type":               "",
+			"device_role":               "",
+			"lock":                      "false",
+			"class":                     "",
+			"class_parameters.%":        "1",
+			"class_parameters.owner":    "netops",
+			"ignore_class_parameters.#": "0",
+		},
+	}
+
+	config := map[string]interface{}{
+		"space":            "space01",
+		"subnet":           "subnet01",
+		"device":           "dev01",
+		"name":             "host01",
+		"mac":              "00:11:22:33:44:55",
+		"class_parameters": map[string]interface{}{"owner": "netops"},
+	}
+
+	cases := []struct {
+		key      string
+		value    interface{}
+		expected string
+	}{
+		{"device", "dev02", "add_flag=edit_only&hostdev_id=7&ip_id=42"},
+		{"device", "", "add_flag=edit_only&hostdev_id=&ip_id=42"},
+		{"name", "host02", "add_flag=edit_only&ip_id=42&ip_name=host02"},
+		{"mac", "00-11-22-33-44-AA", "add_flag=edit_only&ip_id=42&mac_addr=00%3A11%3A22%3A33%3A44%3Aaa"},
+		{"class_parameters", map[string]interface{}{"owner": "sysops"}, "add_flag=edit_only&ip_class_name=&ip_class_parameters=owner%3Dsysops&ip_id=42"},
+		{"ip_type", "gateway", "add_flag=edit_only&ip_class_name=&ip_class_parameters=ip_type%3Dgateway%26owner%3Dnetops&ip_id=42"},
+		{"host_prefix", true, ""},
+	}
+
+	for _, c := range cases {
+		changed := map[string]interface{}{}
+		for k, v := range config {
+			changed[k] = v
+		}
+		changed[c.key] = c.value
+
+		r := resourceipaddress()
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(changed), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		d, err := schema.InternalMap(r.Schema).Data(state, diff)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sent = nil
+
+		if diags := resourceipaddressUpdate(context.Background(), d, s); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		if sent.Encode() != c.expected {
+			t.Errorf("unexpected parameters sent on %s change: %s, expected %s", c.key, sent.Encode(), c.expected)
+		}
+	}
+}
+
+func TestIPAddressDefaultSpace(t *testing.T) {
+	_, s := newMockSOLIDserver(t)
+	s.DisablePlanValidation = true
+
+	r := resourceipaddress()
+	config := map[string]interface{}{
+		"subnet": "lan",
+		"name":   "host01",
+	}
+
+	// The space is required without default_space
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), s); err == nil || !strings.Contains(err.Error(), "default_space") {
+		t.Fatalf("expected an error requiring the space, got: %v", err)
+	}
+
+	// The default space applies to the addresses created without space
+	s.DefaultSpace = "tenant-a"
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), s)
+
+	if err != nil || diff.Attributes["space"] == nil || diff.Attributes["space"].New != "tenant-a" {
+		t.Fatalf("expected the default space to be planned: %v (%v)", diff, err)
+	}
+
+	// The space set on the resource always wins
+	config["space"] = "tenant-b"
+	diff, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), s)
+
+	if err != nil || diff.Attributes["space"].New != "tenant-b" {
+		t.Fatalf("expected the resource space to be planned: %v (%v)", diff, err)
+	}
+
+	// Existing addresses keep their space, whether it is set or not
+	state := &terraform.InstanceState{
+		ID: "10",
+		Attributes: map[string]string{
+			"id":                   "10",
+			"space":                "tenant-b",
+			"subnet":               "lan",
+			"name":                 "host01",
+			"address":              "10.0.0.1",
+			"mac":                  "",
+			"device":               "",
+			"class":                "",
+			"host_prefix":          "false",
+			"lock":                 "false",
+			"allow_duplicate_name": "false",
+		},
+	}
+
+	for _, space := range []interface{}{"tenant-b", nil} {
+		if space == nil {
+			delete(config, "space")
+		}
+
+		diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), s)
+
+		if err != nil || (diff != nil && diff.Attributes["space"] != nil && diff.Attributes["space"].Old != diff.Attributes["space"].New) {
+			t.Errorf("unexpected space diff: %v (%v)", diff, err)
+		}
+	}
+}
+
+func TestIPAddressVerifyDNSConsistency(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_address_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_id":             "2",
+			"site_name":           "space01",
+			"subnet_name":         "subnet02",
+			"ip_addr":             "0a000102",
+			"name":                "host01.example.com",
+			"mac_addr":            "",
+			"ip_class_name":       "",
+			"pool_name":           "",
+			"ip_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/dhcp_lease_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	// The records created from the former address 10.0.0.1 are left behind
+	stale := true
+
+	m.handle("/rest/dns_rr_list", func(w http.ResponseWriter, r *http.Request) {
+		where := r.URL.Query().Get("WHERE")
+
+		switch {
+		case where == "dns_name='ns.example.com' AND dnsview_name='internal' AND rr_full_name='host01.example.com' AND rr_type='A'":
+			records := []map[string]interface{}{{"rr_full_name": "host01.example.com", "value1": "10.0.1.2", "dnszone_name": "example.com", "dnsview_name": "#"}}
+			if stale {
+				records = append(records, map[string]interface{}{"rr_full_name": "host01.example.com", "value1": "10.0.0.1", "dnszone_name": "example.com", "dnsview_name": "#"})
+			}
+			mockReply(w, http.StatusOK, records)
+		case where == "dns_name='ns.example.com' AND dnsview_name='internal' AND rr_type='PTR' AND (value1='host01.example.com' OR value1='host01.example.com.')":
+			records := []map[string]interface{}{{"rr_full_name": "2.1.0.10.in-addr.arpa", "value1": "host01.example.com", "dnszone_name": "1.0.10.in-addr.arpa", "dnsview_name": "#"}}
+			if stale {
+				records = append(records, map[string]interface{}{"rr_full_name": "1.0.0.10.in-addr.arpa", "value1": "host01.example.com", "dnszone_name": "0.0.10.in-addr.arpa", "dnsview_name": "#"})
+			}
+			mockReply(w, http.StatusOK, records)
+		default:
+			t.Errorf("unexpected RR lookup: %s", where)
+			mockReply(w, http.StatusNoContent, nil)
+		}
+	})
+
+	config := map[string]interface{}{
+		"space":  "space01",
+		"subnet": "subnet02",
+		"name":   "host01.example.com",
+	}
+
+	// The records are only verified on demand
+	d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, config)
+	d.SetId("42")
+
+	if diags := resourceipaddressRead(context.Background(), d, s); len(diags) != 0 || !d.Get("dns_consistent").(bool) || m.count("/rest/dns_rr_list") != 0 {
+		t.Fatalf("unexpected DNS verification: %v", diags)
+	}
+
+	// The records are looked up on a DNS server
+	config["verify_dns_consistency"] = true
+
+	if _, err := resourceipaddress().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), s); err == nil || !strings.Contains(err.Error(), "verify_dns_server is required") {
+		t.Fatalf("expected a missing DNS server error, got: %v", err)
+	}
+
+	config["verify_dns_server"] = "ns.example.com"
+	config["verify_dns_view"] = "internal"
+	d = schema.TestResourceDataRaw(t, resourceipaddress().Schema, config)
+	d.SetId("42")
+	diags := resourceipaddressRead(context.Background(), d, s)
+
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning || d.Get("dns_consistent").(bool) {
+		t.Fatalf("expected a stale records warning: %v", diags)
+	}
+
+	if !strings.Contains(diags[0].Detail, "A host01.example.com -> 10.0.0.1 (zone: example.com, view: #), PTR 1.0.0.10.in-addr.arpa -> host01.example.com") || strings.Contains(diags[0].Detail, "10.0.1.2") {
+		t.Errorf("unexpected stale records: %s", diags[0].Detail)
+	}
+
+	// Once the stale records are cleaned up
+	stale = false
+
+	if diags := resourceipaddressRead(context.Background(), d, s); len(diags) != 0 || !d.Get("dns_consistent").(bool) {
+		t.Errorf("expected the DNS records to be consistent: %v", diags)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
 	"regexp"
-	"strings"
 )
 
 func resourceipmac() *schema.Resource {
@@ -44,7 +43,8 @@ func resourceipmac() *schema.Resource {
 				Type:             schema.TypeString,
 				Description:      "The MAC Address o map with the IP address.",
 				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"), "Unsupported MAC address format."),
-				DiffSuppressFunc: resourcediffsuppresscase,
+				DiffSuppressFunc: resourcediffsuppressmac,
+				StateFunc:        resourcestatemac,
 				Required:         true,
 				ForceNew:         true,
 			},
@@ -60,7 +60,7 @@ func resourceipmacCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	parameters.Add("site_name", d.Get("space").(string))
 	parameters.Add("add_flag", "edit_only")
 	parameters.Add("hostaddr", d.Get("address").(string))
-	parameters.Add("mac_addr", strings.ToLower(d.Get("mac").(string)))
+	parameters.Add("mac_addr", macnormalize(d.Get("mac").(string)))
 	parameters.Add("keep_class_parameters", "1")
 
 	// Sending the creation request
@@ -140,7 +140,7 @@ func resourceipmacRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		// Checking the answer
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if ipMac, ipMacExist := buf[0]["mac_addr"].(string); ipMacExist {
-				if strings.ToLower(ipMac) == strings.ToLower(d.Get("mac").(string)) {
+				if macnormalize(ipMac) == macnormalize(d.Get("mac").(string)) {
 					return nil
 				}
 				// Log the error
@@ -7,6 +7,7 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
@@ -33,15 +34,26 @@ func resourceippool() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space into which creating the IP pool.",
-				Required:    true,
+				Description: "The name of the space into which creating the IP pool (Default: the provider's default_space).",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Description: "The name of the parent IP subnet into which creating the IP pool.",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Description:  "The name of the parent IP subnet into which creating the IP pool (Computed when subnet_id is set).",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
+			},
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Description:  "The oid of the parent IP subnet into which creating the IP pool, to use when several subnets share the same name within the space.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet", "subnet_id"},
 			},
 			"start": {
 				Type:         schema.TypeString,
@@ -63,6 +75,16 @@ func resourceippool() *schema.Resource {
 				ForceNew:    false,
 				Default:     false,
 			},
+			"exclusions": {
+				Type:        schema.TypeSet,
+				Description: "The set of IP addresses to exclude from the IP pool (registered with the 'excluded' name and class).",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPv4Address,
+				},
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Description: "The name of the IP pool to create.",
@@ -87,15 +109,29 @@ func resourceippool() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the IP pool.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the IP pool.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the IP pool's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(true),
+			resourcediffvalidateclass("ip_pool"),
+			resourcediffvalidatesubnetname(false),
+		),
 	}
 }
 
@@ -110,7 +146,7 @@ func resourceippoolCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	// Gather required ID(s) from provided subnet information
-	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+	subnetInfo, subnetErr := resourceipsubnetref(d, siteID, meta)
 	if subnetErr != nil {
 		// Reporting a failure
 		return diag.FromErr(subnetErr)
@@ -137,7 +173,7 @@ func resourceippoolCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		classParameters.Add("dhcprange", "0")
 	}
 
-	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+	for k, v := range resourceclassparams(d) {
 		classParameters.Add(k, v.(string))
 	}
 
@@ -154,11 +190,32 @@ func resourceippoolCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created IP pool (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIPPool)
 				d.SetId(oid)
 
 				d.Set("prefix", subnetInfo["start_addr"].(string)+"/"+strconv.Itoa(subnetInfo["prefix_length"].(int)))
 				d.Set("prefix_size", subnetInfo["prefix_length"].(int))
 
+				// Registering the excluded IP addresses, the pool is rolled back on failure
+				exclusions := toStringArray(d.Get("exclusions").(*schema.Set).List())
+				steps := []string{}
+
+				for _, exclusion := range exclusions {
+					steps = append(steps, "exclusion of "+exclusion)
+				}
+
+				createSteps := newcreatesteps("IP pool", d.Get("name").(string), steps...)
+
+				for i, exclusion := range exclusions {
+					if exclusionErr := ippoolexclusionadd(siteID, exclusion, meta); exclusionErr != nil {
+						return createSteps.fail(ctx, d, steps[i], exclusionErr, func() error {
+							return objectdelete("IP pool", "rest/ip_pool_delete", "pool_id", oid, meta)
+						})
+					}
+
+					createSteps.done(steps[i])
+				}
+
 				return nil
 			}
 		}
@@ -180,6 +237,29 @@ func resourceippoolCreate(ctx context.Context, d *schema.ResourceData, meta inte
 func resourceippoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Updating the excluded IP addresses
+	if d.HasChange("exclusions") {
+		siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+		if siteErr != nil {
+			// Reporting a failure
+			return diag.FromErr(siteErr)
+		}
+
+		oldExclusions, newExclusions := d.GetChange("exclusions")
+
+		for _, exclusion := range oldExclusions.(*schema.Set).Difference(newExclusions.(*schema.Set)).List() {
+			if exclusionErr := ippoolexclusiondelete(siteID, exclusion.(string), meta); exclusionErr != nil {
+				return diag.FromErr(exclusionErr)
+			}
+		}
+
+		for _, exclusion := range newExclusions.(*schema.Set).Difference(oldExclusions.(*schema.Set)).List() {
+			if exclusionErr := ippoolexclusionadd(siteID, exclusion.(string), meta); exclusionErr != nil {
+				return diag.FromErr(exclusionErr)
+			}
+		}
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("pool_id", d.Id())
@@ -199,7 +279,7 @@ func resourceippoolUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		classParameters.Add("dhcprange", "0")
 	}
 
-	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+	for k, v := range resourceclassparams(d) {
 		classParameters.Add(k, v.(string))
 	}
 
@@ -216,6 +296,7 @@ func resourceippoolUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP pool (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIPPool)
 				d.SetId(oid)
 				return nil
 			}
@@ -238,6 +319,21 @@ func resourceippoolUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 func resourceippoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Removing the excluded IP addresses first
+	if d.Get("exclusions").(*schema.Set).Len() > 0 {
+		siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+		if siteErr != nil {
+			// Reporting a failure
+			return diag.FromErr(siteErr)
+		}
+
+		for _, exclusion := range d.Get("exclusions").(*schema.Set).List() {
+			if exclusionErr := ippoolexclusiondelete(siteID, exclusion.(string), meta); exclusionErr != nil {
+				return diag.FromErr(exclusionErr)
+			}
+		}
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("pool_id", d.Id())
@@ -251,6 +347,14 @@ func resourceippoolDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 		// Checking the answer
 		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// An IP pool already deleted (ex: along with its subnet) is not a failure
+			if objectmissing(resp.StatusCode, buf) {
+				tflog.Debug(ctx, fmt.Sprintf("IP pool already deleted (oid): %s\n", d.Id()))
+				lookupcacheinvalidate(meta, cacheKindIPPool)
+				d.SetId("")
+				return nil
+			}
+
 			// Reporting a failure
 			if len(buf) > 0 {
 				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
@@ -263,6 +367,7 @@ func resourceippoolDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted IP pool (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindIPPool)
 
 		// Unset local ID
 		d.SetId("")
@@ -294,6 +399,26 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 			d.Set("name", buf[0]["pool_name"].(string))
 			d.Set("class", buf[0]["pool_class_name"].(string))
 
+			// Updating the location and boundaries of the pool, keeping the local case of the names
+			if siteName, siteNameExist := buf[0]["site_name"].(string); siteNameExist && !strings.EqualFold(siteName, d.Get("space").(string)) {
+				d.Set("space", siteName)
+			}
+
+			if subnetName, subnetNameExist := buf[0]["subnet_name"].(string); subnetNameExist && !strings.EqualFold(subnetName, d.Get("subnet").(string)) {
+				d.Set("subnet", subnetName)
+			}
+
+			d.Set("subnet_id", infostring(buf[0], "subnet_id"))
+
+			if startAddr, startAddrExist := buf[0]["start_ip_addr"].(string); startAddrExist {
+				d.Set("start", hexiptoip(startAddr))
+			}
+
+			if poolSize, poolSizeExist := buf[0]["pool_size"].(string); poolSizeExist {
+				size, _ := strconv.Atoi(poolSize)
+				d.Set("size", size)
+			}
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool_class_parameters"].(string))
@@ -308,6 +433,12 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -317,6 +448,34 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Updating local exclusions
+			exclusions, exclusionsErr := ippoolexclusionlist(d.Id(), meta)
+			if exclusionsErr != nil {
+				// Reporting a failure
+				return diag.FromErr(exclusionsErr)
+			}
+
+			d.Set("exclusions", exclusions)
+
+			// Reconstructing the prefix of the parent subnet (ex: after an import)
+			if d.Get("prefix").(string) == "" && d.Get("space").(string) != "" && d.Get("subnet").(string) != "" {
+				var subnetInfo map[string]interface{}
+				var subnetErr error
+
+				if d.Get("subnet_id").(string) != "" {
+					subnetInfo, subnetErr = ipsubnetinfobyid(d.Get("subnet_id").(string), meta)
+				} else if siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta); siteErr == nil {
+					subnetInfo, subnetErr = ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+				} else {
+					subnetErr = siteErr
+				}
+
+				if subnetErr == nil {
+					d.Set("prefix", subnetInfo["start_addr"].(string)+"/"+strconv.Itoa(subnetInfo["prefix_length"].(int)))
+					d.Set("prefix_size", subnetInfo["prefix_length"].(int))
+				}
+			}
+
 			return nil
 		}
 
@@ -343,6 +502,31 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	s := meta.(*SOLIDserver)
 
+	// Resolve composite import ID (space/subnet/pool or space:subnet:pool), fallback to the pool oid otherwise
+	if spaceName, subnetName, poolName, composite := poolimportidsplit(d.Id()); composite {
+		siteID, siteErr := ipsiteidbyname(spaceName, meta)
+		if siteErr != nil {
+			// Reporting a failure
+			return nil, siteErr
+		}
+
+		poolInfo, poolErr := ippoolinfobyname(siteID, poolName, subnetName, meta)
+		if poolErr != nil {
+			// Reporting a failure
+			return nil, poolErr
+		}
+
+		poolID, poolIDExist := poolInfo["id"].(string)
+		if !poolIDExist {
+			// Reporting a failure
+			return nil, fmt.Errorf("SOLIDServer - Unable to find and import IP pool: %s\n", d.Id())
+		}
+
+		d.SetId(poolID)
+		d.Set("space", spaceName)
+		d.Set("subnet", subnetName)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("pool_id", d.Id())
@@ -359,6 +543,25 @@ func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta
 			d.Set("name", buf[0]["pool_name"].(string))
 			d.Set("class", buf[0]["pool_class_name"].(string))
 
+			if siteName, siteNameExist := buf[0]["site_name"].(string); siteNameExist {
+				d.Set("space", siteName)
+			}
+
+			if subnetName, subnetNameExist := buf[0]["subnet_name"].(string); subnetNameExist {
+				d.Set("subnet", subnetName)
+			}
+
+			d.Set("subnet_id", infostring(buf[0], "subnet_id"))
+
+			if startAddr, startAddrExist := buf[0]["start_ip_addr"].(string); startAddrExist {
+				d.Set("start", hexiptoip(startAddr))
+			}
+
+			if poolSize, poolSizeExist := buf[0]["pool_size"].(string); poolSizeExist {
+				size, _ := strconv.Atoi(poolSize)
+				d.Set("size", size)
+			}
+
 			// Setting local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool_class_parameters"].(string))
@@ -373,6 +576,12 @@ func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -382,6 +591,15 @@ func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Setting local exclusions
+			exclusions, exclusionsErr := ippoolexclusionlist(d.Id(), meta)
+			if exclusionsErr != nil {
+				// Reporting a failure
+				return nil, exclusionsErr
+			}
+
+			d.Set("exclusions", exclusions)
+
 			return []*schema.ResourceData{d}, nil
 		}
 
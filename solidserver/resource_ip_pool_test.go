@@ -0,0 +1,113 @@
+//go:build all || ip_pool
+// +build all ip_pool
+
+// to test only these features: -tags ip_pool -run="ippool_XX"
+
+package solidserver
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+// create pool and import it using its space:subnet:pool composite key
+func TestAccippool_01(t *testing.T) {
+	spacename := testAccName("01-space")
+	subnetname := testAccName("01-subnet")
+	poolname := testAccName("01-pool")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: Config_TestAccippool_01(spacename, subnetname, poolname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("solidserver_ip_pool.pool", "id"),
+					resource.TestCheckResourceAttr("solidserver_ip_pool.pool", "prefix", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("solidserver_ip_pool.pool", "prefix_size", "24"),
+				),
+			},
+			{
+				ResourceName: "solidserver_ip_pool.pool",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s:%s:%s", spacename, subnetname, poolname), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"dhcp_range"},
+			},
+			{
+				Config:             Config_TestAccippool_01(spacename, subnetname, poolname),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+// create pool and import it using its space/subnet/pool composite key then its oid, the following plan being empty
+func TestAccippool_02(t *testing.T) {
+	spacename := testAccName("02-space")
+	subnetname := testAccName("02-subnet")
+	poolname := testAccName("02-pool")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: Config_TestAccippool_01(spacename, subnetname, poolname),
+			},
+			{
+				ResourceName: "solidserver_ip_pool.pool",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s/%s/%s", spacename, subnetname, poolname), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"dhcp_range"},
+			},
+			{
+				ResourceName:       "solidserver_ip_pool.pool",
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				Config:             Config_TestAccippool_01(spacename, subnetname, poolname),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func Config_TestAccippool_01(spacename string, subnetname string, poolname string) string {
+	return fmt.Sprintf(`
+    %s
+
+    resource "solidserver_ip_subnet" "subnet" {
+      space            = "${solidserver_ip_space.space.name}"
+      request_ip       = "10.0.0.0"
+      prefix_size      = 24
+      name             = "%s"
+      terminal         = true
+    }
+
+    resource "solidserver_ip_pool" "pool" {
+      space            = "${solidserver_ip_space.space.name}"
+      subnet           = "${solidserver_ip_subnet.subnet.name}"
+      start            = "10.0.0.16"
+      size             = 16
+      name             = "%s"
+    }
+`, Config_CreateSpace(spacename),
+		subnetname,
+		poolname)
+}
@@ -42,15 +42,25 @@ func resourceipspace() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to IP space.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to IP space.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the IP space's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: resourcediffvalidateclass("ip_site"),
 	}
 }
 
@@ -62,7 +72,7 @@ func resourceipspaceCreate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("add_flag", "new_only")
 	parameters.Add("site_name", d.Get("name").(string))
 	parameters.Add("site_class_name", d.Get("class").(string))
-	parameters.Add("site_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("site_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/ip_site_add", &parameters)
@@ -76,6 +86,7 @@ func resourceipspaceCreate(ctx context.Context, d *schema.ResourceData, meta int
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				//MIGRATION SDKV2 - tflog.Debug("Created IP space (oid): %s\n", oid)
 				tflog.Debug(ctx, fmt.Sprintf("Created IP space (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIPSpace)
 				d.SetId(oid)
 				return nil
 			}
@@ -104,7 +115,7 @@ func resourceipspaceUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("add_flag", "edit_only")
 	parameters.Add("site_name", d.Get("name").(string))
 	parameters.Add("site_class_name", d.Get("class").(string))
-	parameters.Add("site_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("site_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip_site_add", &parameters)
@@ -117,6 +128,7 @@ func resourceipspaceUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP space (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIPSpace)
 				d.SetId(oid)
 				return nil
 			}
@@ -164,6 +176,7 @@ func resourceipspaceDelete(ctx context.Context, d *schema.ResourceData, meta int
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted IP space (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindIPSpace, cacheKindIPSubnet, cacheKindIPPool, cacheKindIP6Subnet, cacheKindIP6Pool)
 
 		// Unset local ID
 		d.SetId("")
@@ -201,6 +214,12 @@ func resourceipspaceRead(ctx context.Context, d *schema.ResourceData, meta inter
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -258,6 +277,12 @@ func resourceipspaceImportState(ctx context.Context, d *schema.ResourceData, met
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -7,16 +7,21 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"math/bits"
 	"math/rand"
+	"net/http"
+	"net/netip"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
 func resourceipsubnet() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceipsubnetCreate,
 		ReadContext:   resourceipsubnetRead,
 		UpdateContext: resourceipsubnetUpdate,
@@ -34,8 +39,9 @@ func resourceipsubnet() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space into which creating the subnet.",
-				Required:    true,
+				Description: "The name of the space into which creating the subnet (Default: the provider's default_space).",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"block": {
@@ -54,10 +60,37 @@ func resourceipsubnet() *schema.Resource {
 				Default:      "",
 			},
 			"prefix_size": {
-				Type:        schema.TypeInt,
-				Description: "The expected IP subnet's prefix length (ex: 24 for a '/24').",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeInt,
+				Description:  "The expected IP subnet's prefix length (ex: 24 for a '/24'), computed from the cidr if specified.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"prefix_size", "cidr"},
+			},
+			"cidr": {
+				Type:             schema.TypeString,
+				Description:      "The requested IP subnet in CIDR notation (ex: 10.4.12.0/22), instead of request_ip and prefix_size; computed from the provisionned IP subnet otherwise.",
+				ValidateFunc:     validatenetworkprefix(false),
+				DiffSuppressFunc: resourcediffsuppressprefix,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"request_ip"},
+				ExactlyOneOf:     []string{"prefix_size", "cidr"},
+			},
+			"max_allocation_size": {
+				Type:         schema.TypeInt,
+				Description:  "The shortest prefix length allowed for the allocated IP subnet (ex: 24 forbids allocations larger than a '/24'; Default: 0, no constraint).",
+				ValidateFunc: validation.IntBetween(0, 32),
+				Optional:     true,
+				Default:      0,
+			},
+			"min_allocation_size": {
+				Type:         schema.TypeInt,
+				Description:  "The longest prefix length allowed for the allocated IP subnet (ex: 28 forbids allocations smaller than a '/28'; Default: 0, no constraint).",
+				ValidateFunc: validation.IntBetween(0, 32),
+				Optional:     true,
+				Default:      0,
 			},
 			"prefix": {
 				Type:        schema.TypeString,
@@ -91,10 +124,17 @@ func resourceipsubnet() *schema.Resource {
 			},
 			"name": {
 				Type:        schema.TypeString,
-				Description: "The name of the IP subnet to create.",
-				Required:    true,
+				Description: "The name of the IP subnet to create, computed from the allocated prefix if empty and auto_name_from_cidr is enabled.",
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    false,
 			},
+			"auto_name_from_cidr": {
+				Type:        schema.TypeBool,
+				Description: "Name the IP subnet after its allocated prefix (ex: 10.1.0.0_24) if no name is specified (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
 			"terminal": {
 				Type:        schema.TypeBool,
 				Description: "The terminal property of the IP subnet.",
@@ -102,6 +142,23 @@ func resourceipsubnet() *schema.Resource {
 				ForceNew:    true,
 				Default:     true,
 			},
+			"split_into": {
+				Type:          schema.TypeInt,
+				Description:   "Split the allocated prefix into 2, 4, 8 or 16 sibling non-terminal IP subnets created within the block instead of a single IP subnet. The children are named after the IP subnet name with a numeric suffix (ex: lan-1), or after their prefix if auto_name_from_cidr is enabled.",
+				ValidateFunc:  validation.IntInSlice([]int{2, 4, 8, 16}),
+				Optional:      true,
+				ForceNew:      true,
+				RequiredWith:  []string{"block"},
+				ConflictsWith: []string{"gateway_offset"},
+			},
+			"child_subnet_ids": {
+				Type:        schema.TypeList,
+				Description: "The IDs of the child IP subnets created when split_into is set.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"vlan_domain": {
 				Type:        schema.TypeString,
 				Description: "The VLAN Domain associated to the IP subnet.",
@@ -116,6 +173,13 @@ func resourceipsubnet() *schema.Resource {
 				ForceNew:    false,
 				Default:     0,
 			},
+			"lock": {
+				Type:          schema.TypeBool,
+				Description:   "Mark the IP subnet as locked through the __eip_lock class parameter, an advisory flag for GUI users that SOLIDserver does not enforce; Terraform unlocks it before destroying it (Supported from SOLIDserver 8.2; Default: false).",
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"split_into"},
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP subnet.",
@@ -124,8 +188,26 @@ func resourceipsubnet() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the IP subnet.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the IP subnet.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the IP subnet's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"inherit_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters keys whose values are inherited from the parent IP block/subnet.",
 				Optional:    true,
 				ForceNew:    false,
 				Elem: &schema.Schema{
@@ -133,7 +215,211 @@ func resourceipsubnet() *schema.Resource {
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(true),
+			resourcediffvalidateclass("ip_subnet"),
+			customdiff.IfValueChange("cidr", func(ctx context.Context, old, new, meta interface{}) bool {
+				return new.(string) != "" && old.(string) != new.(string)
+			}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				prefix, prefixErr := netip.ParsePrefix(d.Get("cidr").(string))
+
+				if prefixErr != nil {
+					return fmt.Errorf("Invalid IP subnet cidr: %s", d.Get("cidr").(string))
+				}
+
+				// Map the cidr onto the prefix_size to plan and validate the subnet size
+				return d.SetNew("prefix_size", prefix.Bits())
+			}),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if d.Id() != "" || d.Get("auto_name_from_cidr").(bool) {
+					return nil
+				}
+
+				// The name can only be computed from the allocated prefix
+				if diffnamemissing(d) {
+					return fmt.Errorf("Can't create an IP subnet without name unless auto_name_from_cidr is enabled")
+				}
+
+				return nil
+			},
+			customdiff.IfValue("split_into", func(ctx context.Context, value, meta interface{}) bool {
+				return value.(int) > 0
+			}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if d.Get("terminal").(bool) {
+					return fmt.Errorf("split_into can only be used with a non-terminal IP subnet (terminal = false)")
+				}
+
+				if childPrefixSize := d.Get("prefix_size").(int) + bits.Len(uint(d.Get("split_into").(int))) - 1; childPrefixSize > 32 {
+					return fmt.Errorf("Unable to split a /%d IP subnet into %d IP subnets", d.Get("prefix_size").(int), d.Get("split_into").(int))
+				}
+
+				return nil
+			}),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				maxAllocationSize := d.Get("max_allocation_size").(int)
+				minAllocationSize := d.Get("min_allocation_size").(int)
+
+				if maxAllocationSize > 0 && minAllocationSize > 0 && maxAllocationSize > minAllocationSize {
+					return fmt.Errorf("max_allocation_size (/%d) can't be longer than min_allocation_size (/%d)", maxAllocationSize, minAllocationSize)
+				}
+
+				// The allocation sizes only apply when allocating a new subnet
+				if d.Id() == "" && !ipsubnetallocationsizeallowed(d.Get("prefix_size").(int), maxAllocationSize, minAllocationSize) {
+					return fmt.Errorf("prefix_size (/%d) is out of the allowed allocation sizes", d.Get("prefix_size").(int))
+				}
+
+				return nil
+			},
+		),
+	}
+
+	// Resources created by older provider versions may not be identified by their oid
+	r.SchemaVersion = stateVersionOID
+	r.StateUpgraders = []schema.StateUpgrader{
+		stateupgraderoid("IP subnet", r, "rest/ip_block_subnet_info", "subnet_id", resourceipsubnetstatelookup, resourceipsubnetstatematch),
+	}
+
+	return r
+}
+
+// Return the oid of an IP subnet from the space and prefix stored in a raw state
+// Or an empty string if not found
+func resourceipsubnetstatelookup(rawState map[string]interface{}, meta interface{}) (string, error) {
+	prefix := strings.Split(statestring(rawState, "prefix"), "/")
+	if len(prefix) != 2 {
+		return "", nil
+	}
+
+	prefixLength, prefixErr := strconv.Atoi(prefix[1])
+	if prefixErr != nil || prefixLength < 0 || prefixLength > 32 {
+		return "", nil
+	}
+
+	subnets, subnetsErr := listall("rest/ip_block_subnet_list",
+		"site_name='"+whereescape(statestring(rawState, "space"))+"' AND start_ip_addr='"+iptohexip(prefix[0])+"' AND subnet_size='"+strconv.Itoa(prefixlengthtosize(prefixLength))+"'", meta)
+	if subnetsErr != nil || len(subnets) == 0 {
+		return "", subnetsErr
+	}
+
+	// A terminal subnet and its parent block may share the same prefix
+	terminal := "1"
+	if isTerminal, isTerminalExist := rawState["terminal"].(bool); isTerminalExist && !isTerminal {
+		terminal = "0"
+	}
+
+	for _, subnet := range subnets {
+		if infostring(subnet, "is_terminal") == terminal {
+			return infostring(subnet, "subnet_id"), nil
+		}
+	}
+
+	return "", nil
+}
+
+// Return true if an IP subnet matches the space and prefix stored in a raw state
+func resourceipsubnetstatematch(rawState map[string]interface{}, info map[string]interface{}) bool {
+	prefix := strings.Split(statestring(rawState, "prefix"), "/")
+	if len(prefix) != 2 {
+		return false
+	}
+
+	prefixLength, prefixErr := strconv.Atoi(prefix[1])
+	if prefixErr != nil || prefixLength < 0 || prefixLength > 32 {
+		return false
+	}
+
+	return strings.EqualFold(infostring(info, "site_name"), statestring(rawState, "space")) &&
+		infostring(info, "start_ip_addr") == iptohexip(prefix[0]) &&
+		infostring(info, "subnet_size") == strconv.Itoa(prefixlengthtosize(prefixLength))
+}
+
+// Return the name of an IP subnet computed from its prefix (ex: 10.1.0.0_24)
+func ipsubnetnamefromcidr(address string, prefixSize int) string {
+	return address + "_" + strconv.Itoa(prefixSize)
+}
+
+// Add the class parameters inherited from the parent IP block/subnet
+func resourceipsubnetinheritclassparams(d *schema.ResourceData, parentID string, classParameters url.Values, meta interface{}) error {
+	inheritedKeys := toStringArray(d.Get("inherit_class_parameters").([]interface{}))
+
+	if len(inheritedKeys) == 0 || parentID == "" {
+		return nil
+	}
+
+	parentClassParameters, parentErr := ipsubnetclassparams(parentID, meta)
+
+	if parentErr != nil {
+		return parentErr
+	}
+
+	for _, key := range inheritedKeys {
+		if value, valueExist := parentClassParameters[key]; valueExist {
+			classParameters.Set(key, value[0])
+		}
+	}
+
+	return nil
+}
+
+// Return the information of the terminal IP subnet referenced by a resource, either by subnet_id or by name
+// And record both the subnet name and oid locally
+func resourceipsubnetref(d *schema.ResourceData, siteID string, meta interface{}) (map[string]interface{}, error) {
+	var subnetInfo map[string]interface{}
+	var subnetErr error
+
+	if subnetID := d.Get("subnet_id").(string); subnetID != "" {
+		subnetInfo, subnetErr = ipsubnetinfobyid(subnetID, meta)
+
+		if subnetErr != nil {
+			return nil, subnetErr
+		}
+
+		if infostring(subnetInfo, "site_id") != siteID || infostring(subnetInfo, "terminal") != "1" {
+			return nil, fmt.Errorf("SOLIDServer - Unable to find IP subnet (oid): %s in space: %s\n", subnetID, d.Get("space").(string))
+		}
+	} else {
+		subnetInfo, subnetErr = ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+		if subnetErr != nil {
+			return nil, subnetErr
+		}
 	}
+
+	d.Set("subnet", infostring(subnetInfo, "name"))
+	d.Set("subnet_id", infostring(subnetInfo, "id"))
+
+	return subnetInfo, nil
+}
+
+// Refuse at plan time a subnet name shared by several terminal subnets of the same space
+// Pointing out the matching subnets so that one of them can be selected through subnet_id
+func resourcediffvalidatesubnetname(ip6 bool) schema.CustomizeDiffFunc {
+	return customdiff.IfValue("subnet", func(ctx context.Context, value, meta interface{}) bool {
+		return value.(string) != "" && meta != nil && !meta.(*SOLIDserver).DisablePlanValidation
+	}, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" || !d.NewValueKnown("subnet") || !d.NewValueKnown("space") || d.Get("space").(string) == "" {
+			return nil
+		}
+
+		var subnets []string
+		var subnetsErr error
+
+		if ip6 {
+			subnets, subnetsErr = ip6subnetsbyname(d.Get("space").(string), d.Get("subnet").(string), meta)
+		} else {
+			subnets, subnetsErr = ipsubnetsbyname(d.Get("space").(string), d.Get("subnet").(string), meta)
+		}
+
+		if subnetsErr != nil {
+			return fmt.Errorf("Unable to look up subnet name '%s' in space '%s' (%s)", d.Get("subnet").(string), d.Get("space").(string), subnetsErr)
+		}
+
+		if len(subnets) < 2 {
+			return nil
+		}
+
+		return fmt.Errorf("Subnet name '%s' matches %d subnets in space '%s': %s; use subnet_id to select one of them", d.Get("subnet").(string), len(subnets), d.Get("space").(string), strings.Join(subnets, ", "))
+	})
 }
 
 func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -186,19 +472,46 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	subnetAddresses, subnetErr := ipsubnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), meta)
+	requestedIP := d.Get("request_ip").(string)
+
+	// Use the network address and prefix length of the cidr if any
+	if len(d.Get("cidr").(string)) > 0 {
+		prefix, prefixErr := netip.ParsePrefix(d.Get("cidr").(string))
+
+		if prefixErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create IP subnet: %s, invalid cidr: %s\n", d.Get("name").(string), d.Get("cidr").(string))
+		}
+
+		requestedIP = prefix.Masked().Addr().String()
+		d.Set("prefix_size", prefix.Bits())
+	}
+
+	subnetAddresses, subnetErr := ipsubnetfindbysize(siteID, blockInfo["id"].(string), requestedIP, d.Get("prefix_size").(int), d.Get("max_allocation_size").(int), d.Get("min_allocation_size").(int), meta)
 
 	if subnetErr != nil {
 		// Reporting a failure
 		return diag.FromErr(subnetErr)
 	}
 
+	// Create the sibling IP subnets splitting the allocated prefix
+	if d.Get("split_into").(int) > 0 {
+		return resourceipsubnetsplitintoCreate(ctx, d, siteID, blockInfo, vlmVlanID, subnetAddresses, meta)
+	}
+
 	for i := 0; i < len(subnetAddresses); i++ {
+		subnetName := d.Get("name").(string)
+
+		// Name the subnet after the allocated prefix
+		if subnetName == "" && d.Get("auto_name_from_cidr").(bool) {
+			subnetName = ipsubnetnamefromcidr(hexiptoip(subnetAddresses[i]), d.Get("prefix_size").(int))
+		}
+
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("site_id", siteID)
 		parameters.Add("add_flag", "new_only")
-		parameters.Add("subnet_name", d.Get("name").(string))
+		parameters.Add("subnet_name", subnetName)
 		parameters.Add("subnet_addr", hexiptoip(subnetAddresses[i]))
 		parameters.Add("subnet_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
 		parameters.Add("subnet_class_name", d.Get("class").(string))
@@ -241,8 +554,18 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 			tflog.Debug(ctx, fmt.Sprintf("Subnet computed gateway: %s\n", gateway))
 		}
 
-		for k, v := range d.Get("class_parameters").(map[string]interface{}) {
-			classParameters.Add(k, v.(string))
+		if inheritErr := resourceipsubnetinheritclassparams(d, blockInfo["id"].(string), classParameters, meta); inheritErr != nil {
+			// Reporting a failure
+			return diag.FromErr(inheritErr)
+		}
+
+		for k, v := range resourceclassparams(d) {
+			classParameters.Set(k, v.(string))
+		}
+
+		if lockErr := classparamsetlock(d, classParameters, meta); lockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(lockErr)
 		}
 
 		parameters.Add("subnet_class_parameters", classParameters.Encode())
@@ -263,10 +586,14 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", oid))
+					lookupcacheinvalidate(meta, cacheKindIPSubnet)
 					d.SetId(oid)
+					d.Set("name", subnetName)
 					d.Set("prefix", prefix)
+					d.Set("cidr", prefix)
 					d.Set("address", hexiptoip(subnetAddresses[i]))
 					d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
+					d.Set("child_subnet_ids", []string{})
 					if goffset != 0 {
 						d.Set("gateway", gateway)
 					}
@@ -275,12 +602,12 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 			} else {
 				if len(buf) > 0 {
 					if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-						tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s (%s)\n", d.Get("name").(string), prefix, errMsg))
+						tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s (%s)\n", subnetName, prefix, errMsg))
 					} else {
-						tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", d.Get("name").(string), prefix))
+						tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", subnetName, prefix))
 					}
 				} else {
-					tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", d.Get("name").(string), prefix))
+					tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", subnetName, prefix))
 				}
 			}
 		} else {
@@ -343,11 +670,44 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		tflog.Debug(ctx, fmt.Sprintf("Subnet updated gateway: %s\n", d.Get("gateway").(string)))
 	}
 
-	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
-		classParameters.Add(k, v.(string))
+	// Retrieve the inherited class parameters from the parent block/subnet
+	if len(d.Get("inherit_class_parameters").([]interface{})) > 0 && len(d.Get("block").(string)) > 0 {
+		siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+		if siteErr != nil {
+			// Reporting a failure
+			return diag.FromErr(siteErr)
+		}
+
+		blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+		if blockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(blockErr)
+		}
+
+		if inheritErr := resourceipsubnetinheritclassparams(d, blockInfo["id"].(string), classParameters, meta); inheritErr != nil {
+			// Reporting a failure
+			return diag.FromErr(inheritErr)
+		}
+	}
+
+	for k, v := range resourceclassparams(d) {
+		classParameters.Set(k, v.(string))
 	}
+
+	if lockErr := classparamsetlock(d, classParameters, meta); lockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(lockErr)
+	}
+
 	parameters.Add("subnet_class_parameters", classParameters.Encode())
 
+	// Apply the update to every child IP subnet of a split IP subnet
+	if d.Get("split_into").(int) > 0 {
+		return resourceipsubnetsplitintoUpdate(ctx, d, parameters, meta)
+	}
+
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip_subnet_add", &parameters)
 
@@ -359,6 +719,7 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP subnet (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIPSubnet)
 				d.SetId(oid)
 				return nil
 			}
@@ -424,6 +785,19 @@ func resourceipsubnetgatewayDelete(ctx context.Context, d *schema.ResourceData,
 func resourceipsubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// The ID of a split IP subnet is the one of its block, only delete the children
+	if d.Get("split_into").(int) > 0 {
+		return resourceipsubnetsplitintoDelete(ctx, d, meta)
+	}
+
+	// Unlock the IP subnet, the lock only protecting it from the GUI
+	if d.Get("lock").(bool) {
+		if unlockErr := objectunlock("rest/ip_subnet_add", "subnet_id", d.Id(), "subnet_class_parameters", meta); unlockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(unlockErr)
+		}
+	}
+
 	// Delete related resources such as the Gateway
 	if d.Get("gateway_offset") != 0 {
 		resourceipsubnetgatewayDelete(ctx, d, meta)
@@ -454,6 +828,7 @@ func resourceipsubnetDelete(ctx context.Context, d *schema.ResourceData, meta in
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted IP subnet (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindIPSubnet, cacheKindIPPool)
 
 		// Unset local ID
 		d.SetId("")
@@ -469,6 +844,11 @@ func resourceipsubnetDelete(ctx context.Context, d *schema.ResourceData, meta in
 func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// The ID of a split IP subnet is the one of its first child, only read the children
+	if d.Get("split_into").(int) > 0 {
+		return resourceipsubnetsplitintoRead(ctx, d, meta)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("subnet_id", d.Id())
@@ -486,6 +866,7 @@ func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta inte
 			d.Set("block", buf[0]["parent_subnet_name"].(string))
 			d.Set("name", buf[0]["subnet_name"].(string))
 			d.Set("class", buf[0]["subnet_class_name"].(string))
+			d.Set("child_subnet_ids", []string{})
 
 			if buf[0]["is_terminal"].(string) == "1" {
 				d.Set("terminal", true)
@@ -507,11 +888,19 @@ func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta inte
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet_class_parameters"].(string))
 			computedClassParameters := map[string]string{}
 
+			d.Set("lock", classparamlocked(retrievedClassParameters))
+
 			if gateway, gatewayExist := retrievedClassParameters["gateway"]; gatewayExist {
 				d.Set("gateway", gateway[0])
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -521,6 +910,28 @@ func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Only keep the inherited keys whose values still match the parent ones
+			inheritedKeys := toStringArray(d.Get("inherit_class_parameters").([]interface{}))
+
+			if len(inheritedKeys) > 0 {
+				computedInheritedKeys := []string{}
+				parentID, _ := buf[0]["parent_subnet_id"].(string)
+				parentClassParameters, parentErr := ipsubnetclassparams(parentID, meta)
+
+				if parentErr != nil {
+					tflog.Debug(ctx, fmt.Sprintf("Unable to read inherited class parameters of IP subnet: %s\n", d.Get("name").(string)))
+					parentClassParameters = url.Values{}
+				}
+
+				for _, key := range inheritedKeys {
+					if rv, rvExist := retrievedClassParameters[key]; rvExist && parentClassParameters.Get(key) == rv[0] {
+						computedInheritedKeys = append(computedInheritedKeys, key)
+					}
+				}
+
+				d.Set("inherit_class_parameters", computedInheritedKeys)
+			}
+
 			return nil
 		}
 
@@ -572,6 +983,7 @@ func resourceipsubnetImportState(ctx context.Context, d *schema.ResourceData, me
 
 			d.Set("address", address)
 			d.Set("prefix", prefix)
+			d.Set("cidr", prefix)
 			d.Set("prefix_size", prefix_length)
 			d.Set("netmask", prefixlengthtohexip(prefix_length))
 			d.Set("gateway_offset", 0)
@@ -598,11 +1010,19 @@ func resourceipsubnetImportState(ctx context.Context, d *schema.ResourceData, me
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet_class_parameters"].(string))
 			computedClassParameters := map[string]string{}
 
+			d.Set("lock", classparamlocked(retrievedClassParameters))
+
 			if gateway, gatewayExist := retrievedClassParameters["gateway"]; gatewayExist {
 				d.Set("gateway", gateway[0])
 			}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -632,3 +1052,341 @@ func resourceipsubnetImportState(ctx context.Context, d *schema.ResourceData, me
 	// Reporting a failure
 	return nil, err
 }
+
+// Return the addresses and the names of the child IP subnets splitting the prefix starting at the provided address
+func resourceipsubnetsplitintochildren(d *schema.ResourceData, address string) ([]string, []string, error) {
+	prefixSize := d.Get("prefix_size").(int)
+	childPrefixSize := prefixSize + bits.Len(uint(d.Get("split_into").(int))) - 1
+
+	addresses, addressesErr := ipsubnetsplitaddresses(address, prefixSize, childPrefixSize, d.Get("split_into").(int))
+	if addressesErr != nil {
+		return nil, nil, addressesErr
+	}
+
+	names := []string{}
+
+	for i, childAddress := range addresses {
+		if len(d.Get("name").(string)) == 0 {
+			names = append(names, ipsubnetnamefromcidr(childAddress, childPrefixSize))
+		} else {
+			names = append(names, d.Get("name").(string)+"-"+strconv.Itoa(i+1))
+		}
+	}
+
+	return addresses, names, nil
+}
+
+func resourceipsubnetsplitintoCreate(ctx context.Context, d *schema.ResourceData, siteID string, blockInfo map[string]interface{}, vlmVlanID string, subnetAddresses []string, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	subnetLevel, _ := strconv.Atoi(blockInfo["level"].(string))
+	childPrefixSize := d.Get("prefix_size").(int) + bits.Len(uint(d.Get("split_into").(int))) - 1
+
+	// Building class_parameters
+	classParameters := url.Values{}
+
+	if inheritErr := resourceipsubnetinheritclassparams(d, blockInfo["id"].(string), classParameters, meta); inheritErr != nil {
+		// Reporting a failure
+		return diag.FromErr(inheritErr)
+	}
+
+	for k, v := range resourceclassparams(d) {
+		classParameters.Set(k, v.(string))
+	}
+
+	for i := 0; i < len(subnetAddresses); i++ {
+		address := hexiptoip(subnetAddresses[i])
+		childAddresses, childNames, childErr := resourceipsubnetsplitintochildren(d, address)
+
+		if childErr != nil {
+			// Reporting a failure
+			return diag.FromErr(childErr)
+		}
+
+		ids := []string{}
+		var err error = nil
+
+		steps := []string{}
+
+		for _, childName := range childNames {
+			steps = append(steps, "creation of child IP subnet "+childName)
+		}
+
+		createSteps := newcreatesteps("IP subnet", d.Get("name").(string), steps...)
+
+		for j, childAddress := range childAddresses {
+			// Building parameters
+			parameters := url.Values{}
+			parameters.Add("site_id", siteID)
+			parameters.Add("add_flag", "new_only")
+			parameters.Add("subnet_name", childNames[j])
+			parameters.Add("subnet_addr", childAddress)
+			parameters.Add("subnet_prefix", strconv.Itoa(childPrefixSize))
+			parameters.Add("subnet_class_name", d.Get("class").(string))
+			parameters.Add("subnet_level", strconv.Itoa(subnetLevel+1))
+			parameters.Add("is_terminal", "0")
+			parameters.Add("subnet_class_parameters", classParameters.Encode())
+
+			// Specify the VLAN if applicable
+			if len(vlmVlanID) > 0 {
+				parameters.Add("vlmvlan_id", vlmVlanID)
+			}
+
+			// Sending the creation request
+			var resp *http.Response
+			var body string
+
+			resp, body, err = s.Request("post", "rest/ip_subnet_add", &parameters)
+
+			if err != nil {
+				break
+			}
+
+			var buf [](map[string]interface{})
+			json.Unmarshal([]byte(body), &buf)
+
+			prefix := childAddress + "/" + strconv.Itoa(childPrefixSize)
+
+			// Checking the answer
+			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+					tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", oid))
+					lookupcacheinvalidate(meta, cacheKindIPSubnet)
+					ids = append(ids, oid)
+					createSteps.done(steps[j])
+					continue
+				}
+			}
+
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s (%s)\n", childNames[j], prefix, errMsg))
+					break
+				}
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", childNames[j], prefix))
+			break
+		}
+
+		if len(ids) == len(childAddresses) {
+			prefix := address + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+
+			d.SetId(ids[0])
+			d.Set("child_subnet_ids", ids)
+			d.Set("prefix", prefix)
+			d.Set("cidr", prefix)
+			d.Set("address", address)
+			d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
+			return nil
+		}
+
+		// Rolling back the child IP subnets already created
+		remainingIDs := []string{}
+		var rollbackErr error = nil
+
+		for _, oid := range ids {
+			if deleteErr := ipsubnetdelete(oid, meta); deleteErr != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Unable to roll back IP subnet (oid): %s (%s)\n", oid, deleteErr))
+				remainingIDs = append(remainingIDs, oid)
+				rollbackErr = deleteErr
+			}
+		}
+
+		// Keeping track of the child IP subnets left, the next apply deletes them before splitting again
+		if rollbackErr != nil {
+			prefix := address + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+
+			d.SetId(remainingIDs[0])
+			d.Set("child_subnet_ids", remainingIDs)
+			d.Set("prefix", prefix)
+			d.Set("cidr", prefix)
+			d.Set("address", address)
+			d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
+
+			stepErr := err
+			if stepErr == nil {
+				stepErr = fmt.Errorf("SOLIDServer - Unable to create IP subnet: %s\n", childNames[len(ids)])
+			}
+
+			return createSteps.failed(steps[len(ids)], stepErr, rollbackErr)
+		}
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+	}
+
+	// Reporting a failure
+	return diag.Errorf("Unable to create IP subnet: %s, unable to find a suitable prefix\n", d.Get("name").(string))
+}
+
+func resourceipsubnetsplitintoUpdate(ctx context.Context, d *schema.ResourceData, parameters url.Values, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	childAddresses, childNames, childErr := resourceipsubnetsplitintochildren(d, d.Get("address").(string))
+	if childErr != nil {
+		// Reporting a failure
+		return diag.FromErr(childErr)
+	}
+
+	for _, oid := range toStringArray(d.Get("child_subnet_ids").([]interface{})) {
+		// Naming each child after its position within the split prefix, some children may be missing
+		childInfo, childInfoErr := ipsubnetinfobyid(oid, meta)
+		if childInfoErr != nil {
+			// Reporting a failure
+			return diag.FromErr(childInfoErr)
+		}
+
+		i := stringOffsetInSlice(infostring(childInfo, "start_addr"), childAddresses)
+		if i < 0 {
+			continue
+		}
+
+		parameters.Set("subnet_id", oid)
+		parameters.Set("subnet_name", childNames[i])
+
+		// Sending the update request
+		resp, body, err := s.Request("put", "rest/ip_subnet_add", &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Updated IP subnet (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindIPSubnet)
+				continue
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to update IP subnet: %s (%s)", childNames[i], errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to update IP subnet: %s\n", childNames[i])
+	}
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipsubnetsplitintoDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	remainingIDs := []string{}
+
+	for _, oid := range toStringArray(d.Get("child_subnet_ids").([]interface{})) {
+		if deleteErr := ipsubnetdelete(oid, meta); deleteErr != nil {
+			diags = append(diags, diag.FromErr(deleteErr)...)
+			remainingIDs = append(remainingIDs, oid)
+			continue
+		}
+
+		// Log deletion
+		tflog.Debug(ctx, fmt.Sprintf("Deleted IP subnet (oid): %s\n", oid))
+	}
+
+	if diags.HasError() {
+		// Only keep track of the child IP subnets left
+		d.Set("child_subnet_ids", remainingIDs)
+
+		// Reporting a failure
+		return diags
+	}
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipsubnetsplitintoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	childIDs := []string{}
+
+	for _, oid := range toStringArray(d.Get("child_subnet_ids").([]interface{})) {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("subnet_id", oid)
+
+		// Sending the read request
+		resp, body, err := s.Request("get", "rest/ip_block_subnet_info", &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			// The children share the space, block and class of the split IP subnet
+			if len(childIDs) == 0 {
+				d.Set("space", infostring(buf[0], "site_name"))
+				d.Set("block", infostring(buf[0], "parent_subnet_name"))
+				d.Set("class", infostring(buf[0], "subnet_class_name"))
+
+				// Updating local class_parameters
+				currentClassParameters := d.Get("class_parameters").(map[string]interface{})
+				retrievedClassParameters, _ := url.ParseQuery(infostring(buf[0], "subnet_class_parameters"))
+				computedClassParameters := map[string]string{}
+
+				for ck := range currentClassParameters {
+					// Keeping the local value of the class parameters maintained by SOLIDserver
+					if classparamignored(d, ck) {
+						computedClassParameters[ck] = currentClassParameters[ck].(string)
+						continue
+					}
+
+					if rv, rvExist := retrievedClassParameters[ck]; rvExist {
+						computedClassParameters[ck] = rv[0]
+					} else {
+						computedClassParameters[ck] = ""
+					}
+				}
+
+				d.Set("class_parameters", computedClassParameters)
+			}
+
+			childIDs = append(childIDs, oid)
+			continue
+		}
+
+		// Dropping the children deleted outside of Terraform
+		if resp.StatusCode == 204 || objectmissing(resp.StatusCode, buf) {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find child IP subnet (oid): %s\n", oid))
+			continue
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to read child IP subnet (oid): %s (%s)", oid, errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to read child IP subnet (oid): %s\n", oid)
+	}
+
+	// Unset local ID once every child is gone
+	if len(childIDs) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("child_subnet_ids", childIDs)
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateNetworkPrefix(t *testing.T) {
+	cases := []struct {
+		ipv6  bool
+		value string
+		valid bool
+	}{
+		{false, "10.4.12.0/22", true},
+		{false, "10.4.13.0/22", false},
+		{false, "10.4.12.0", false},
+		{false, "2001:db8:1::/48", false},
+		{true, "2001:db8:1::/48", true},
+		{true, "2001:db8:1::1/48", false},
+		{true, "10.4.12.0/22", false},
+	}
+
+	for _, c := range cases {
+		_, errs := validatenetworkprefix(c.ipv6)(c.value, "cidr")
+
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("validatenetworkprefix(%t)(%q): expected valid=%t, got %v", c.ipv6, c.value, c.valid, errs)
+		}
+	}
+}
+
+func TestIPSubnetCIDR(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentAddr := ""
+	sentPrefix := ""
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_subnet_add", func(w http.ResponseWriter, r *http.Request) {
+		sentAddr = r.URL.Query().Get("subnet_addr")
+		sentPrefix = r.URL.Query().Get("subnet_prefix")
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	r := resourceipsubnet()
+
+	// cidr is mutually exclusive with request_ip and prefix_size
+	for _, cfg := range []map[string]interface{}{
+		{"space": "space01", "name": "subnet01", "terminal": false, "cidr": "10.4.12.0/22", "request_ip": "10.4.12.0"},
+		{"space": "space01", "name": "subnet01", "terminal": false, "cidr": "10.4.12.0/22", "prefix_size": 22},
+		{"space": "space01", "name": "subnet01", "terminal": false},
+	} {
+		if diags := r.Validate(terraform.NewResourceConfigRaw(cfg)); !diags.HasError() {
+			t.Errorf("expected a validation error for %v", cfg)
+		}
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":    "space01",
+		"name":     "subnet01",
+		"terminal": false,
+		"cidr":     "10.4.12.0/22",
+	})
+
+	if diags := r.Validate(config); diags.HasError() {
+		t.Fatalf("unexpected validation error: %v", diags)
+	}
+
+	diff, err := r.Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The prefix_size is planned from the cidr
+	if attr, attrExist := diff.Attributes["prefix_size"]; !attrExist || attr.New != "22" {
+		t.Errorf("expected prefix_size to be planned as 22, got %v", attr)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourceipsubnetCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sentAddr != "10.4.12.0" || sentPrefix != "22" {
+		t.Errorf("unexpected subnet created: %s/%s", sentAddr, sentPrefix)
+	}
+
+	if d.Get("cidr").(string) != "10.4.12.0/22" || d.Get("prefix").(string) != "10.4.12.0/22" {
+		t.Errorf("unexpected cidr: %s (prefix: %s)", d.Get("cidr").(string), d.Get("prefix").(string))
+	}
+}
+
+func TestIPSubnetAutoNameFromCIDR(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentName := ""
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_subnet_add", func(w http.ResponseWriter, r *http.Request) {
+		sentName = r.URL.Query().Get("subnet_name")
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	r := resourceipsubnet()
+
+	// A name is required at plan time unless computed from the allocated prefix
+	unnamed := map[string]interface{}{
+		"space":    "space01",
+		"terminal": false,
+		"cidr":     "10.1.0.0/24",
+	}
+
+	if _, err := r.Diff(context.Background(), &terraform.InstanceState{RawConfig: mockRawConfig(r, unnamed)}, terraform.NewResourceConfigRaw(unnamed), nil); err == nil || !strings.Contains(err.Error(), "auto_name_from_cidr") {
+		t.Errorf("expected an error without name nor auto_name_from_cidr, got: %v", err)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":               "space01",
+		"terminal":            false,
+		"cidr":                "10.1.0.0/24",
+		"auto_name_from_cidr": true,
+	})
+
+	diff, err := r.Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourceipsubnetCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sentName != "10.1.0.0_24" || d.Get("name").(string) != "10.1.0.0_24" {
+		t.Errorf("unexpected subnet name: %q (state: %q)", sentName, d.Get("name").(string))
+	}
+
+	// The computed name is kept on subsequent plans
+	diff, err = r.Diff(context.Background(), d.State(), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
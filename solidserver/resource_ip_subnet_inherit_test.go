@@ -0,0 +1,84 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIPSubnetInheritClassParameters(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentClassParameters := url.Values{}
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id":     "10",
+			"subnet_name":   "block01",
+			"subnet_size":   "65536",
+			"start_ip_addr": "0a000000",
+			"end_ip_addr":   "0a00ffff",
+			"is_terminal":   "0",
+			"subnet_level":  "0",
+		}})
+	})
+
+	m.handle("/rpc/ip_find_free_subnet", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"start_ip_addr": "0a000100"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_info", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("subnet_id") {
+		case "10":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"subnet_class_parameters": "site=paris&owner=netops&vrf=blue"}})
+		default:
+			mockReply(w, http.StatusOK, []map[string]interface{}{{
+				"site_name":               "space01",
+				"parent_subnet_name":      "block01",
+				"parent_subnet_id":        "10",
+				"subnet_name":             "subnet01",
+				"subnet_class_name":       "",
+				"subnet_class_parameters": sentClassParameters.Encode(),
+				"is_terminal":             "1",
+				"vlmdomain_name":          "#",
+			}})
+		}
+	})
+
+	m.handle("/rest/ip_subnet_add", func(w http.ResponseWriter, r *http.Request) {
+		sentClassParameters, _ = url.ParseQuery(r.URL.Query().Get("subnet_class_parameters"))
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceipsubnet().Schema, map[string]interface{}{
+		"space":                    "space01",
+		"block":                    "block01",
+		"prefix_size":              24,
+		"name":                     "subnet01",
+		"class_parameters":         map[string]interface{}{"owner": "sysops"},
+		"inherit_class_parameters": []interface{}{"site", "owner", "missing"},
+	})
+
+	if diags := resourceipsubnetCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sentClassParameters.Get("site") != "paris" || sentClassParameters.Get("owner") != "sysops" || sentClassParameters.Has("vrf") {
+		t.Errorf("unexpected class parameters: %s", sentClassParameters.Encode())
+	}
+
+	if diags := resourceipsubnetRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// owner is overridden locally and missing is not defined on the parent, only site is inherited
+	if inherited := d.Get("inherit_class_parameters").([]interface{}); len(inherited) != 1 || inherited[0] != "site" {
+		t.Errorf("unexpected inherited keys: %v", inherited)
+	}
+}
@@ -0,0 +1,312 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func resourceipsubnetsplit() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipsubnetsplitCreate,
+		ReadContext:   resourceipsubnetsplitRead,
+		DeleteContext: resourceipsubnetsplitDelete,
+
+		Description: heredoc.Doc(`
+			IP subnet split resource allows to carve a parent IP block or subnet into several contiguous child subnets of the same size.
+			The child subnets are reserved in ascending order starting at the parent's network address, if any of them can't be
+			created the ones already created are deleted.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:         schema.TypeString,
+				Description:  "The name of the space of the parent IP block or subnet.",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"parent_subnet"},
+			},
+			"parent_subnet": {
+				Type:         schema.TypeString,
+				Description:  "The name of the parent IP block or subnet to split.",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"space"},
+				ExactlyOneOf: []string{"parent_subnet", "parent_subnet_id"},
+			},
+			"parent_subnet_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the parent IP block or subnet to split.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"parent_subnet", "parent_subnet_id"},
+			},
+			"child_prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The prefix length of the child subnets (ex: 24 for a /24).",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"child_count": {
+				Type:         schema.TypeInt,
+				Description:  "The number of child subnets to create, named after their prefix (ex: 10.0.1.0_24).",
+				ValidateFunc: validation.IntAtLeast(1),
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"child_count", "names"},
+			},
+			"names": {
+				Type:         schema.TypeList,
+				Description:  "The names of the child subnets to create, one child subnet being created per name.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"child_count", "names"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"terminal": {
+				Type:        schema.TypeBool,
+				Description: "The terminal property of the child subnets (Default: true).",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"cidrs": {
+				Type:        schema.TypeList,
+				Description: "The prefixes of the child subnets.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Description: "The IDs of the child subnets.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceipsubnetsplitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+	var parentInfo map[string]interface{}
+	var parentErr error
+	siteID := ""
+
+	// Gather the parent information from the provided name or ID
+	if len(d.Get("parent_subnet").(string)) > 0 {
+		var siteErr error
+
+		siteID, siteErr = ipsiteidbyname(d.Get("space").(string), meta)
+		if siteErr != nil {
+			// Reporting a failure
+			return diag.FromErr(siteErr)
+		}
+
+		parentInfo, parentErr = ipsubnetinfobyname(siteID, d.Get("parent_subnet").(string), false, meta)
+	} else {
+		parentInfo, parentErr = ipsubnetinfobyid(d.Get("parent_subnet_id").(string), meta)
+		siteID = infostring(parentInfo, "site_id")
+	}
+
+	if parentErr != nil {
+		// Reporting a failure
+		return diag.FromErr(parentErr)
+	}
+
+	parentPrefixLength, parentPrefixLengthExist := parentInfo["prefix_length"].(int)
+
+	if parentInfo == nil || !parentPrefixLengthExist || infostring(parentInfo, "start_addr") == "" || siteID == "" {
+		// Reporting a failure
+		return diag.Errorf("Unable to split IP subnet: %s%s, unable to retrieve its network address\n", d.Get("parent_subnet").(string), d.Get("parent_subnet_id").(string))
+	}
+
+	childPrefixSize := d.Get("child_prefix_size").(int)
+	names := toStringArray(d.Get("names").([]interface{}))
+	count := d.Get("child_count").(int)
+
+	if len(names) > 0 {
+		count = len(names)
+	}
+
+	// Compute the contiguous child subnets from the parent network address
+	addresses, addressesErr := ipsubnetsplitaddresses(infostring(parentInfo, "start_addr"), parentPrefixLength, childPrefixSize, count)
+	if addressesErr != nil {
+		// Reporting a failure
+		return diag.FromErr(addressesErr)
+	}
+
+	parentLevel, _ := strconv.Atoi(infostring(parentInfo, "level"))
+	ids := []string{}
+	cidrs := []string{}
+	steps := []string{}
+
+	for i, address := range addresses {
+		if len(names) < count {
+			names = append(names, ipsubnetnamefromcidr(address, childPrefixSize))
+		}
+
+		steps = append(steps, "creation of child IP subnet "+names[i])
+	}
+
+	createSteps := newcreatesteps("IP subnet split", infostring(parentInfo, "name"), steps...)
+
+	for i, address := range addresses {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_id", siteID)
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("subnet_name", names[i])
+		parameters.Add("subnet_addr", address)
+		parameters.Add("subnet_prefix", strconv.Itoa(childPrefixSize))
+		parameters.Add("subnet_level", strconv.Itoa(parentLevel+1))
+
+		// Specify if subnet is terminal
+		if d.Get("terminal").(bool) {
+			parameters.Add("is_terminal", "1")
+		} else {
+			parameters.Add("is_terminal", "0")
+		}
+
+		// Sending the creation request
+		resp, body, err := s.Request("post", "rest/ip_subnet_add", &parameters)
+		prefix := address + "/" + strconv.Itoa(childPrefixSize)
+		errMsg := ""
+
+		if err == nil {
+			var buf [](map[string]interface{})
+			json.Unmarshal([]byte(body), &buf)
+
+			// Checking the answer
+			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+					tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", oid))
+					lookupcacheinvalidate(meta, cacheKindIPSubnet)
+					ids = append(ids, oid)
+					cidrs = append(cidrs, prefix)
+					createSteps.done(steps[i])
+					continue
+				}
+			}
+
+			if len(buf) > 0 {
+				errMsg, _ = buf[0]["errmsg"].(string)
+			}
+		} else {
+			errMsg = err.Error()
+		}
+
+		// Rolling back the child subnets already created
+		remainingIDs := []string{}
+		remainingNames := []string{}
+		remainingCidrs := []string{}
+		var rollbackErr error = nil
+
+		for j, oid := range ids {
+			if deleteErr := ipsubnetdelete(oid, meta); deleteErr != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Unable to roll back IP subnet (oid): %s (%s)\n", oid, deleteErr))
+				remainingIDs = append(remainingIDs, oid)
+				remainingNames = append(remainingNames, names[j])
+				remainingCidrs = append(remainingCidrs, cidrs[j])
+				rollbackErr = deleteErr
+			}
+		}
+
+		// Keeping track of the child subnets left, the next apply deletes them before splitting again
+		if rollbackErr != nil {
+			d.SetId(infostring(parentInfo, "id") + ":" + strconv.Itoa(childPrefixSize))
+			d.Set("parent_subnet_id", infostring(parentInfo, "id"))
+			d.Set("child_count", count)
+			d.Set("names", remainingNames)
+			d.Set("cidrs", remainingCidrs)
+			d.Set("ids", remainingIDs)
+
+			return createSteps.failed(steps[i], fmt.Errorf("%s", errMsg), rollbackErr)
+		}
+
+		// Reporting a failure
+		return diag.Errorf("Unable to create IP subnet: %s with prefix: %s, %d child subnet(s) rolled back (%s)", names[i], prefix, len(ids), errMsg)
+	}
+
+	d.SetId(infostring(parentInfo, "id") + ":" + strconv.Itoa(childPrefixSize))
+	d.Set("parent_subnet_id", infostring(parentInfo, "id"))
+	d.Set("child_count", count)
+	d.Set("names", names)
+	d.Set("cidrs", cidrs)
+	d.Set("ids", ids)
+
+	return nil
+}
+
+func resourceipsubnetsplitDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	remainingIDs := []string{}
+
+	for _, oid := range toStringArray(d.Get("ids").([]interface{})) {
+		if deleteErr := ipsubnetdelete(oid, meta); deleteErr != nil {
+			diags = append(diags, diag.FromErr(deleteErr)...)
+			remainingIDs = append(remainingIDs, oid)
+			continue
+		}
+
+		// Log deletion
+		tflog.Debug(ctx, fmt.Sprintf("Deleted IP subnet (oid): %s\n", oid))
+	}
+
+	if diags.HasError() {
+		// Only keep track of the child subnets left
+		d.Set("ids", remainingIDs)
+
+		// Reporting a failure
+		return diags
+	}
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipsubnetsplitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	names := []string{}
+	cidrs := []string{}
+
+	for _, oid := range toStringArray(d.Get("ids").([]interface{})) {
+		subnetInfo, subnetErr := ipsubnetinfobyid(oid, meta)
+
+		if subnetErr != nil {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find IP subnet (oid): %s\n", oid))
+
+			// Do not unset the local ID to avoid inconsistency
+
+			// Reporting a failure
+			return diag.Errorf("Unable to find child IP subnet (oid): %s\n", oid)
+		}
+
+		names = append(names, subnetInfo["name"].(string))
+		cidrs = append(cidrs, subnetInfo["start_addr"].(string)+"/"+strconv.Itoa(subnetInfo["prefix_length"].(int)))
+	}
+
+	d.Set("names", names)
+	d.Set("cidrs", cidrs)
+
+	return nil
+}
@@ -0,0 +1,352 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIPSubnetSplitAddresses(t *testing.T) {
+	addresses, err := ipsubnetsplitaddresses("10.0.0.0", 22, 24, 4)
+	if err != nil || strings.Join(addresses, ",") != "10.0.0.0,10.0.1.0,10.0.2.0,10.0.3.0" {
+		t.Errorf("unexpected /24 children of 10.0.0.0/22: %v (%v)", addresses, err)
+	}
+
+	addresses, err = ipsubnetsplitaddresses("192.168.10.128", 25, 27, 3)
+	if err != nil || strings.Join(addresses, ",") != "192.168.10.128,192.168.10.160,192.168.10.192" {
+		t.Errorf("unexpected /27 children of 192.168.10.128/25: %v (%v)", addresses, err)
+	}
+
+	if _, err = ipsubnetsplitaddresses("10.0.0.0", 22, 24, 5); err == nil {
+		t.Errorf("expected an error when the children don't fit within the parent")
+	}
+
+	if _, err = ipsubnetsplitaddresses("10.0.0.0", 24, 22, 1); err == nil {
+		t.Errorf("expected an error with children larger than the parent")
+	}
+}
+
+func TestIPSubnetSplit(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mutex := sync.Mutex{}
+	subnets := map[string]string{}
+	conflict := ""
+	nextID := 100
+	failDelete := false
+
+	if err := resourceipsubnetsplit().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+
+	m.handle("/rest/ip_block_subnet_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id":     r.URL.Query().Get("subnet_id"),
+			"subnet_name":   "block01",
+			"site_id":       "2",
+			"site_name":     "local",
+			"subnet_level":  "0",
+			"is_terminal":   "0",
+			"subnet_size":   "1024",
+			"start_ip_addr": "0a000000",
+		}})
+	})
+
+	m.handle("/rest/ip_subnet_add", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Query().Get("subnet_addr") == conflict {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "overlapping subnet"}})
+			return
+		}
+
+		if r.URL.Query().Get("subnet_level") != "1" || r.URL.Query().Get("subnet_prefix") != "24" || r.URL.Query().Get("site_id") != "2" {
+			t.Errorf("unexpected creation parameters: %v", r.URL.Query())
+		}
+
+		nextID++
+		subnets[strconv.Itoa(nextID)] = r.URL.Query().Get("subnet_addr")
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": strconv.Itoa(nextID)}})
+	})
+
+	m.handle("/rest/ip_subnet_delete", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if failDelete {
+			mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errmsg": "permission denied"}})
+			return
+		}
+
+		delete(subnets, r.URL.Query().Get("subnet_id"))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": r.URL.Query().Get("subnet_id")}})
+	})
+
+	config := map[string]interface{}{
+		"parent_subnet_id":  "5",
+		"child_prefix_size": 24,
+		"child_count":       4,
+	}
+
+	// The children already created are deleted on conflict
+	conflict = "10.0.2.0"
+	d := schema.TestResourceDataRaw(t, resourceipsubnetsplit().Schema, config)
+
+	if diags := resourceipsubnetsplitCreate(context.Background(), d, s); len(diags) != 1 || !strings.Contains(diags[0].Summary, "2 child subnet(s) rolled back (overlapping subnet)") {
+		t.Fatalf("expected a single creation error, got %v", diags)
+	}
+
+	if len(subnets) != 0 || d.Id() != "" {
+		t.Fatalf("expected the child subnets to be rolled back, remaining subnets: %v", subnets)
+	}
+
+	// The children left by a failed rollback are kept in the state and listed in the diagnostic
+	failDelete = true
+	d = schema.TestResourceDataRaw(t, resourceipsubnetsplit().Schema, config)
+	diags := resourceipsubnetsplitCreate(context.Background(), d, s)
+
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "creation of child IP subnet 10.0.2.0_24 failed (overlapping subnet)") ||
+		!strings.Contains(diags[0].Detail, "Completed: creation of child IP subnet 10.0.0.0_24, creation of child IP subnet 10.0.1.0_24\nMissing: creation of child IP subnet 10.0.2.0_24, creation of child IP subnet 10.0.3.0_24") {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if ids := toStringArray(d.Get("ids").([]interface{})); d.Id() != "5:24" || len(ids) != 2 || len(subnets) != 2 || d.Get("cidrs").([]interface{})[1] != "10.0.1.0/24" {
+		t.Fatalf("expected the remaining child subnets to be kept: %v (subnets: %v)", d.State().Attributes, subnets)
+	}
+
+	failDelete = false
+
+	if diags := resourceipsubnetsplitDelete(context.Background(), d, s); diags.HasError() || len(subnets) != 0 {
+		t.Fatalf("expected the remaining child subnets to be deleted: %v (subnets: %v)", diags, subnets)
+	}
+
+	// The children are created contiguously
+	conflict = ""
+	d = schema.TestResourceDataRaw(t, resourceipsubnetsplit().Schema, config)
+
+	if diags := resourceipsubnetsplitCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	cidrs := toStringArray(d.Get("cidrs").([]interface{}))
+	names := toStringArray(d.Get("names").([]interface{}))
+	ids := toStringArray(d.Get("ids").([]interface{}))
+
+	if d.Id() != "5:24" || strings.Join(cidrs, ",") != "10.0.0.0/24,10.0.1.0/24,10.0.2.0/24,10.0.3.0/24" || names[3] != "10.0.3.0_24" || len(ids) != 4 || len(subnets) != 4 {
+		t.Fatalf("unexpected IP subnet split: %v", d.State().Attributes)
+	}
+
+	if diags := resourceipsubnetsplitDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(subnets) != 0 {
+		t.Errorf("expected the child subnets to be deleted, remaining subnets: %v", subnets)
+	}
+
+	// A parent found by name without its level is considered a top level subnet
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id":     "5",
+			"subnet_name":   "block01",
+			"subnet_size":   "1024",
+			"start_ip_addr": "0a000000",
+		}})
+	})
+
+	d = schema.TestResourceDataRaw(t, resourceipsubnetsplit().Schema, map[string]interface{}{
+		"space":             "local",
+		"parent_subnet":     "block01",
+		"child_prefix_size": 24,
+		"child_count":       2,
+	})
+
+	if diags := resourceipsubnetsplitCreate(context.Background(), d, s); diags.HasError() || d.Id() != "5:24" || len(subnets) != 2 {
+		t.Fatalf("unexpected IP subnet split: %v (%v)", d.State().Attributes, diags)
+	}
+}
+
+func TestIPSubnetSplitInto(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mutex := sync.Mutex{}
+	subnets := map[string]string{}
+	nextID := 100
+	failName := ""
+	failDelete := false
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id":     "5",
+			"subnet_name":   "block01",
+			"subnet_size":   "65536",
+			"subnet_level":  "0",
+			"is_terminal":   "0",
+			"start_ip_addr": "0a000000",
+			"end_ip_addr":   "0a00ffff",
+		}})
+	})
+
+	m.handle("/rest/ip_subnet_add", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Query().Get("subnet_level") != "1" || r.URL.Query().Get("subnet_prefix") != "24" || r.URL.Query().Get("is_terminal") != "0" {
+			t.Errorf("unexpected creation parameters: %v", r.URL.Query())
+		}
+
+		if r.URL.Query().Get("subnet_name") == failName {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "quota exceeded"}})
+			return
+		}
+
+		nextID++
+		subnets[strconv.Itoa(nextID)] = r.URL.Query().Get("subnet_name")
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": strconv.Itoa(nextID)}})
+	})
+
+	m.handle("/rest/ip_block_subnet_info", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		name, exist := subnets[r.URL.Query().Get("subnet_id")]
+		if !exist {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "Subnet not found"}})
+			return
+		}
+
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"subnet_id": r.URL.Query().Get("subnet_id"), "subnet_name": name, "site_name": "space01", "parent_subnet_name": "block01",
+			"subnet_class_name": "lan_class", "subnet_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/ip_subnet_delete", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Query().Get("subnet_id") == "5" {
+			t.Errorf("the parent IP block must not be deleted")
+		}
+
+		if failDelete {
+			mockReply(w, http.StatusServiceUnavailable, nil)
+			return
+		}
+
+		delete(subnets, r.URL.Query().Get("subnet_id"))
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": r.URL.Query().Get("subnet_id")}})
+	})
+
+	r := resourceipsubnet()
+
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+
+	// Only non-terminal IP subnets can be split
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":       "space01",
+		"block":       "block01",
+		"name":        "lan",
+		"prefix_size": 22,
+		"split_into":  4,
+	}), nil); err == nil {
+		t.Errorf("expected an error when splitting a terminal IP subnet")
+	}
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"space":      "space01",
+		"block":      "block01",
+		"name":       "lan",
+		"cidr":       "10.0.4.0/22",
+		"terminal":   false,
+		"split_into": 4,
+	}), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourceipsubnetCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	ids := toStringArray(d.Get("child_subnet_ids").([]interface{}))
+
+	if d.Id() != ids[0] || len(ids) != 4 || len(subnets) != 4 || subnets[ids[3]] != "lan-4" || d.Get("prefix").(string) != "10.0.4.0/22" {
+		t.Fatalf("unexpected IP subnet split: %v (subnets: %v)", d.State().Attributes, subnets)
+	}
+
+	// Children deleted outside of Terraform are dropped from the state
+	delete(subnets, ids[1])
+
+	if diags := resourceipsubnetRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if remaining := toStringArray(d.Get("child_subnet_ids").([]interface{})); len(remaining) != 3 || d.Id() != ids[0] || d.Get("class").(string) != "lan_class" {
+		t.Fatalf("unexpected IP subnet split: %v", d.State().Attributes)
+	}
+
+	if diags := resourceipsubnetDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(subnets) != 0 || d.Id() != "" {
+		t.Errorf("expected the child subnets to be deleted, remaining subnets: %v", subnets)
+	}
+
+	// A failed child subnet is rolled back along with the children already created
+	failName = "lan-3"
+	d, _ = schema.InternalMap(r.Schema).Data(nil, diff)
+
+	if diags := resourceipsubnetCreate(context.Background(), d, s); !diags.HasError() || d.Id() != "" || len(subnets) != 0 {
+		t.Fatalf("expected the IP subnet split to be rolled back: %v (subnets: %v)", diags, subnets)
+	}
+
+	// The children left by a failed rollback are kept in the state and listed in the diagnostic
+	failDelete = true
+	d, _ = schema.InternalMap(r.Schema).Data(nil, diff)
+	diags := resourceipsubnetCreate(context.Background(), d, s)
+
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "creation of child IP subnet lan-3 failed") ||
+		!strings.Contains(diags[0].Detail, "Completed: creation of child IP subnet lan-1, creation of child IP subnet lan-2\nMissing: creation of child IP subnet lan-3, creation of child IP subnet lan-4") {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if ids := toStringArray(d.Get("child_subnet_ids").([]interface{})); d.Id() != ids[0] || len(ids) != 2 || len(subnets) != 2 {
+		t.Fatalf("expected the remaining child subnets to be kept: %v (subnets: %v)", d.State().Attributes, subnets)
+	}
+
+	// The next apply deletes them
+	failDelete = false
+
+	if diags := resourceipsubnetDelete(context.Background(), d, s); diags.HasError() || len(subnets) != 0 {
+		t.Errorf("expected the remaining child subnets to be deleted: %v (subnets: %v)", diags, subnets)
+	}
+
+	// The split IP subnet is gone along with its last child
+	d.SetId("101")
+
+	if diags := resourceipsubnetRead(context.Background(), d, s); diags.HasError() || d.Id() != "" {
+		t.Errorf("expected the split IP subnet to be gone: %v (%v)", d.State(), diags)
+	}
+}
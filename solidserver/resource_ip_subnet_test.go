@@ -8,7 +8,6 @@ package solidserver
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/satori/go.uuid"
 	"testing"
 )
 
@@ -22,8 +21,8 @@ import (
 
 // create non terminal subnet
 func TestAccipsubnet_01(t *testing.T) {
-	spacename := fmt.Sprintf("01-space-%s", uuid.Must(uuid.NewV4()))
-	blockname := fmt.Sprintf("01-block-%s", uuid.Must(uuid.NewV4()))
+	spacename := testAccName("01-space")
+	blockname := testAccName("01-block")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -66,9 +65,9 @@ func Config_TestAccipsubnet_01(spacename string, blockname string) string {
 // create non terminal subnet
 // + terminal subnet
 func TestAccipsubnet_02(t *testing.T) {
-	spacename := fmt.Sprintf("02-space-%s", uuid.Must(uuid.NewV4()))
-	blockname1 := fmt.Sprintf("02-b1-%s", uuid.Must(uuid.NewV4()))
-	blockname2 := fmt.Sprintf("02-b2-%s", uuid.Must(uuid.NewV4()))
+	spacename := testAccName("02-space")
+	blockname1 := testAccName("02-b1")
+	blockname2 := testAccName("02-b2")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -117,10 +116,10 @@ func Config_TestAccipsubnet_02(spacename string, blockname1 string, blockname2 s
 // + non terminal subnet
 // + terminal subnet
 func TestAccipsubnet_03(t *testing.T) {
-	spacename := fmt.Sprintf("03-space-%s", uuid.Must(uuid.NewV4()))
-	blockname1 := fmt.Sprintf("03-b1-%s", uuid.Must(uuid.NewV4()))
-	blockname2 := fmt.Sprintf("03-b2-%s", uuid.Must(uuid.NewV4()))
-	blockname3 := fmt.Sprintf("03-b3-%s", uuid.Must(uuid.NewV4()))
+	spacename := testAccName("03-space")
+	blockname1 := testAccName("03-b1")
+	blockname2 := testAccName("03-b2")
+	blockname3 := testAccName("03-b3")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
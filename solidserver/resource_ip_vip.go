@@ -0,0 +1,476 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strings"
+)
+
+// Class parameter holding the members of a VIP, owned by the provider
+const vipMembersClassParameter = "__eip_vip_members"
+
+// A member of a VIP, as serialized within its class parameters
+// The space is omitted when it is the one of the VIP
+type ipVipMember struct {
+	Address string `json:"address"`
+	Space   string `json:"space,omitempty"`
+}
+
+func resourceipvip() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipvipCreate,
+		ReadContext:   resourceipvipRead,
+		UpdateContext: resourceipvipUpdate,
+		DeleteContext: resourceipvipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceipvipImportState,
+		},
+
+		Description: heredoc.Doc(`
+			IP VIP resource allows to register a virtual IP address (ex: anycast service, load balancer VIP)
+			along with the addresses of its members, documented within its class parameters.
+			The members are expected to be registered within the IPAM, the unregistered ones are reported as warnings.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which registering the VIP (Default: the provider's default_space).",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Description:  "The IP address of the VIP.",
+				ValidateFunc: validation.IsIPv4Address,
+				Required:     true,
+				ForceNew:     true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the VIP.",
+				Required:    true,
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Description: "The addresses of the members of the VIP, in their order.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:         schema.TypeString,
+							Description:  "The IP address of the member (ex: the address of a solidserver_ip_address).",
+							ValidateFunc: validation.IsIPv4Address,
+							Required:     true,
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The name of the space of the member (Default: the space of the VIP).",
+							Optional:    true,
+							Default:     "",
+						},
+					},
+				},
+			},
+			"unregistered_members": {
+				Type:        schema.TypeList,
+				Description: "The members of the VIP not registered within the IPAM, as space/address.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the VIP.",
+				Optional:    true,
+				Default:     "",
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the VIP.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the VIP's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			resourcediffdefaultspace(true),
+			resourceipvipdiffmembers,
+		),
+	}
+}
+
+// Refuse the class parameter owned by the provider, and the members listed twice
+func resourceipvipdiffmembers(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if _, reserved := d.Get("class_parameters").(map[string]interface{})[vipMembersClassParameter]; reserved {
+		return fmt.Errorf("The class parameter %s is managed through members", vipMembersClassParameter)
+	}
+
+	if !d.NewValueKnown("members") {
+		return nil
+	}
+
+	listed := map[string]bool{}
+
+	for _, member := range ipvipmembers(d.Get("members").([]interface{})) {
+		key := member.Space + "/" + member.Address
+
+		if listed[key] {
+			return fmt.Errorf("The member %s of the VIP is listed twice", strings.TrimPrefix(key, "/"))
+		}
+
+		listed[key] = true
+	}
+
+	return nil
+}
+
+// Return the members of a VIP from its schema value
+func ipvipmembers(members []interface{}) []ipVipMember {
+	result := make([]ipVipMember, 0, len(members))
+
+	for _, member := range members {
+		if member == nil {
+			continue
+		}
+
+		result = append(result, ipVipMember{
+			Address: member.(map[string]interface{})["address"].(string),
+			Space:   member.(map[string]interface{})["space"].(string),
+		})
+	}
+
+	return result
+}
+
+// Return the members of a VIP serialized as a class parameter value
+func ipvipmembersencode(members []ipVipMember) string {
+	value, _ := json.Marshal(members)
+	return string(value)
+}
+
+// Return the members of a VIP from their class parameter value, as a schema value
+// Or an error if the value is not valid (ex: edited outside of Terraform)
+func ipvipmembersdecode(value string) ([]interface{}, error) {
+	members := []ipVipMember{}
+	result := []interface{}{}
+
+	if value == "" {
+		return result, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &members); err != nil {
+		return nil, fmt.Errorf("SOLIDServer - Invalid VIP members: %s (%s)\n", value, err)
+	}
+
+	for _, member := range members {
+		result = append(result, map[string]interface{}{"address": member.Address, "space": member.Space})
+	}
+
+	return result, nil
+}
+
+// Return the class parameters of a VIP, including its members
+func resourceipvipclassparams(d *schema.ResourceData) url.Values {
+	classParameters := urlfromclassparams(resourceclassparams(d))
+	classParameters.Set(vipMembersClassParameter, ipvipmembersencode(ipvipmembers(d.Get("members").([]interface{}))))
+
+	return classParameters
+}
+
+// Update the unregistered members of a VIP, looking each member up within the IPAM
+// Return a warning listing the unregistered members, if any
+func resourceipvipverifymembers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	unregistered := []string{}
+
+	for _, member := range ipvipmembers(d.Get("members").([]interface{})) {
+		spaceName := member.Space
+
+		if spaceName == "" {
+			spaceName = d.Get("space").(string)
+		}
+
+		siteID, siteErr := ipsiteidbyname(spaceName, meta)
+		addressID := ""
+
+		if siteErr == nil && siteID != "" {
+			addressID, siteErr = ipaddressidbyip(siteID, member.Address, meta)
+		}
+
+		if siteErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to look up member %s of VIP: %s (%s)\n", member.Address, d.Get("name").(string), siteErr)
+		}
+
+		if addressID == "" {
+			unregistered = append(unregistered, spaceName+"/"+member.Address)
+		}
+	}
+
+	d.Set("unregistered_members", unregistered)
+
+	if len(unregistered) == 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Unregistered members of VIP: %s (%s)\n", d.Get("name").(string), strings.Join(unregistered, ", ")))
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Unregistered members of VIP: %s", d.Get("name").(string)),
+		Detail:   fmt.Sprintf("The following members are not registered within the IPAM: %s", strings.Join(unregistered, ", ")),
+	}}
+}
+
+func resourceipvipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	if siteID == "" {
+		return diag.Errorf("Unable to create VIP: %s, unable to find space: %s\n", d.Get("name").(string), d.Get("space").(string))
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("ip_name", d.Get("name").(string))
+	parameters.Add("hostaddr", d.Get("address").(string))
+	parameters.Add("ip_class_name", d.Get("class").(string))
+	parameters.Add("ip_class_parameters", resourceipvipclassparams(d).Encode())
+
+	// Sending the creation request
+	resp, body, err := s.Request("post", "rest/ip_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Created VIP (oid): %s\n", oid))
+				d.SetId(oid)
+
+				return resourceipvipverifymembers(ctx, d, meta)
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to create VIP: %s (%s)", d.Get("name").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to create VIP: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceipvipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Nothing to update on SOLIDserver (ex: ignore_class_parameters changed)
+	if !d.HasChanges("name", "members", "class", "class_parameters") {
+		return nil
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", d.Id())
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("ip_name", d.Get("name").(string))
+	parameters.Add("ip_class_name", d.Get("class").(string))
+	parameters.Add("ip_class_parameters", resourceipvipclassparams(d).Encode())
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/ip_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Updated VIP (oid): %s\n", oid))
+				d.SetId(oid)
+
+				return resourceipvipverifymembers(ctx, d, meta)
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to update VIP: %s (%s)", d.Get("name").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to update VIP: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceipvipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", d.Id())
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/ip_delete", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// A VIP already deleted (ex: along with its subnet) is not a failure
+			if objectmissing(resp.StatusCode, buf) {
+				tflog.Debug(ctx, fmt.Sprintf("VIP already deleted (oid): %s\n", d.Id()))
+				d.SetId("")
+				return nil
+			}
+
+			// Reporting a failure
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to delete VIP: %s (%s)", d.Get("name").(string), errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to delete VIP: %s", d.Get("name").(string))
+		}
+
+		// Log deletion
+		tflog.Debug(ctx, fmt.Sprintf("Deleted VIP (oid): %s\n", d.Id()))
+
+		// Unset local ID
+		d.SetId("")
+
+		// Reporting a success
+		return nil
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceipvipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_address_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			d.Set("space", infostring(buf[0], "site_name"))
+			d.Set("address", hexiptoip(infostring(buf[0], "ip_addr")))
+			d.Set("name", infostring(buf[0], "name"))
+			d.Set("class", infostring(buf[0], "ip_class_name"))
+
+			// Updating local class_parameters
+			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
+			retrievedClassParameters, _ := url.ParseQuery(infostring(buf[0], "ip_class_parameters"))
+			computedClassParameters := map[string]string{}
+
+			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
+				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
+					computedClassParameters[ck] = rv[0]
+				} else {
+					computedClassParameters[ck] = ""
+				}
+			}
+
+			d.Set("class_parameters", computedClassParameters)
+
+			// Updating the members, reported as a drift when altered outside of Terraform
+			members, membersErr := ipvipmembersdecode(retrievedClassParameters.Get(vipMembersClassParameter))
+
+			if membersErr != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Unable to read the members of VIP: %s (%s)\n", d.Get("name").(string), membersErr))
+				members = []interface{}{}
+			}
+
+			d.Set("members", members)
+
+			return resourceipvipverifymembers(ctx, d, meta)
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find VIP: %s (%s)\n", d.Get("name"), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find VIP (oid): %s\n", d.Id()))
+		}
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find VIP: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceipvipImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if diags := resourceipvipRead(ctx, d, meta); diags.HasError() {
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import VIP (oid): %s\n", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,187 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIPVipMembersEncoding(t *testing.T) {
+	members := []ipVipMember{{Address: "10.0.0.1"}, {Address: "10.1.0.10", Space: "other"}}
+	value := ipvipmembersencode(members)
+
+	if value != `[{"address":"10.0.0.1"},{"address":"10.1.0.10","space":"other"}]` {
+		t.Errorf("unexpected encoding: %s", value)
+	}
+
+	decoded, err := ipvipmembersdecode(value)
+
+	if err != nil || len(decoded) != 2 || decoded[1].(map[string]interface{})["space"] != "other" || decoded[0].(map[string]interface{})["space"] != "" {
+		t.Errorf("unexpected decoding: %v (%v)", decoded, err)
+	}
+
+	if decoded, err := ipvipmembersdecode(""); err != nil || len(decoded) != 0 {
+		t.Errorf("expected no member, got %v (%v)", decoded, err)
+	}
+
+	if _, err := ipvipmembersdecode("10.0.0.1,10.0.0.2"); err == nil {
+		t.Errorf("expected an error on invalid members")
+	}
+}
+
+func TestIPVip(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	r := resourceipvip()
+	sent := url.Values{}
+	stored := url.Values{}
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("WHERE") {
+		case "site_name='myspace'":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+		case "site_name='other'":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "3"}})
+		default:
+			mockReply(w, http.StatusNoContent, nil)
+		}
+	})
+
+	// Only 10.0.0.1 is registered, within mySpace
+	m.handle("/rest/ip_address_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") == "site_id='2' AND ip_addr='0a000001'" {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"ip_id": "11"}})
+			return
+		}
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	m.handle("/rest/ip_add", func(w http.ResponseWriter, r *http.Request) {
+		sent = r.URL.Query()
+		stored, _ = url.ParseQuery(sent.Get("ip_class_parameters"))
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "42"}})
+	})
+
+	m.handle("/rest/ip_address_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_name":           "mySpace",
+			"ip_addr":             "0a000064",
+			"name":                sent.Get("ip_name"),
+			"ip_class_name":       "",
+			"ip_class_parameters": stored.Encode(),
+		}})
+	})
+
+	config := map[string]interface{}{
+		"space":   "mySpace",
+		"address": "10.0.0.100",
+		"name":    "vip01",
+		"members": []interface{}{
+			map[string]interface{}{"address": "10.0.0.1"},
+			map[string]interface{}{"address": "10.1.0.10", "space": "other"},
+			map[string]interface{}{"address": "10.2.0.10", "space": "missing"},
+		},
+		"class_parameters": map[string]interface{}{"owner": "netops"},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := resourceipvipCreate(context.Background(), d, s)
+
+	if diags.HasError() || d.Id() != "42" {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "other/10.1.0.10, missing/10.2.0.10") {
+		t.Errorf("expected a warning listing the unregistered members, got %v", diags)
+	}
+
+	if strings.Join(toStringArray(d.Get("unregistered_members").([]interface{})), ",") != "other/10.1.0.10,missing/10.2.0.10" {
+		t.Errorf("unexpected unregistered members: %v", d.Get("unregistered_members"))
+	}
+
+	if stored.Get("owner") != "netops" || stored.Get(vipMembersClassParameter) != `[{"address":"10.0.0.1"},{"address":"10.1.0.10","space":"other"},{"address":"10.2.0.10","space":"missing"}]` {
+		t.Errorf("unexpected class parameters: %v", stored)
+	}
+
+	// A member removed outside of Terraform is reported as a drift
+	stored.Set(vipMembersClassParameter, `[{"address":"10.0.0.1"}]`)
+
+	if diags := resourceipvipRead(context.Background(), d, s); diags.HasError() || len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(d.Get("members").([]interface{})) != 1 || len(d.Get("class_parameters").(map[string]interface{})) != 1 {
+		t.Fatalf("unexpected state: %v", d.State().Attributes)
+	}
+
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff == nil || diff.Attributes["members.#"] == nil || diff.RequiresNew() {
+		t.Fatalf("expected an in-place update of the members, got %v", diff)
+	}
+
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diags := resourceipvipUpdate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if sent.Get("ip_id") != "42" || sent.Get("add_flag") != "edit_only" || !strings.Contains(stored.Get(vipMembersClassParameter), "10.2.0.10") {
+		t.Errorf("unexpected update: %v", sent)
+	}
+
+	// Members altered beyond recognition are reported as removed
+	stored.Set(vipMembersClassParameter, "10.0.0.1")
+
+	if diags := resourceipvipRead(context.Background(), d, s); diags.HasError() || len(d.Get("members").([]interface{})) != 0 {
+		t.Errorf("expected no member, got %v (%v)", d.Get("members"), diags)
+	}
+}
+
+func TestIPVipDiff(t *testing.T) {
+	r := resourceipvip()
+
+	cases := []struct {
+		config map[string]interface{}
+		err    string
+	}{
+		{
+			config: map[string]interface{}{
+				"space": "mySpace", "address": "10.0.0.100", "name": "vip01",
+				"class_parameters": map[string]interface{}{vipMembersClassParameter: "[]"},
+			},
+			err: "managed through members",
+		},
+		{
+			config: map[string]interface{}{
+				"space": "mySpace", "address": "10.0.0.100", "name": "vip01",
+				"members": []interface{}{
+					map[string]interface{}{"address": "10.0.0.1"},
+					map[string]interface{}{"address": "10.0.0.1", "space": "other"},
+					map[string]interface{}{"address": "10.0.0.1"},
+				},
+			},
+			err: "member 10.0.0.1 of the VIP is listed twice",
+		},
+	}
+
+	for _, c := range cases {
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(c.config), &SOLIDserver{})
+
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("expected error %q, got %v", c.err, err)
+		}
+	}
+}
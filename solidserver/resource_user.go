@@ -8,7 +8,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
+	"strings"
 	// "strconv"
 )
 
@@ -35,10 +37,27 @@ func resourceuser() *schema.Resource {
 				ForceNew:    false,
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Description: "The password of the user",
-				Required:    true,
-				ForceNew:    false,
+				Type:             schema.TypeString,
+				Description:      "The password of the user",
+				DiffSuppressFunc: resourceuserdiffsuppresspassword,
+				Required:         true,
+				Sensitive:        true,
+				ForceNew:         false,
+			},
+			"password_version": {
+				Type:         schema.TypeInt,
+				Description:  "An arbitrary version number of the password. When set, the password is only sent to SOLIDserver on creation and when password_version is increased, other changes to the password being ignored. Setting it on an imported user allows to manage it without resetting its password (Default: 0, the password is sent whenever it changes).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"account_type": {
+				Type:         schema.TypeString,
+				Description:  "The authentication mode of the user, either local or ldap (computed by SOLIDserver if not set). The password of an LDAP user is not used by SOLIDserver.",
+				ValidateFunc: validation.StringInSlice([]string{"local", "ldap"}, false),
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
 			},
 			"groups": {
 				Type:        schema.TypeSet,
@@ -79,10 +98,19 @@ func resourceuser() *schema.Resource {
 				ForceNew:    false,
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to the user.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to the user.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the user's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -91,6 +119,40 @@ func resourceuser() *schema.Resource {
 	}
 }
 
+// Return a warning when a password is managed for an LDAP user
+func resourceuserpasswordwarning(d *schema.ResourceData) diag.Diagnostics {
+	if d.Get("account_type").(string) == "ldap" && d.Get("password").(string) != "" {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The password of LDAP user %s has no effect on SOLIDserver", d.Get("login").(string)),
+			Detail:   "LDAP users authenticate against the directory, the password is only kept in the Terraform state.",
+		}}
+	}
+
+	return nil
+}
+
+// Return true when the password of the user must be sent to SOLIDserver
+// When password_version is used, only its increase triggers it (setting it, ex: after an import, does not)
+func resourceuserpasswordchanged(d *schema.ResourceData) bool {
+	oldVersion, newVersion := d.GetChange("password_version")
+
+	if newVersion.(int) > 0 {
+		return oldVersion.(int) > 0 && newVersion.(int) > oldVersion.(int)
+	}
+
+	return d.HasChange("password")
+}
+
+// Ignore the password changes not sent to SOLIDserver when password_version is used
+func resourceuserdiffsuppresspassword(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+
+	return d.Get("password_version").(int) > 0 && !resourceuserpasswordchanged(d)
+}
+
 func _addUserToGroup(ctx context.Context, d *schema.ResourceData, meta interface{}, group string) error {
 	s := meta.(*SOLIDserver)
 
@@ -186,6 +248,10 @@ func resourceuserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	parameters.Add("usr_login", d.Get("login").(string))
 	parameters.Add("usr_password", d.Get("password").(string))
 
+	if accountType, accountTypeExist := d.GetOk("account_type"); accountTypeExist {
+		parameters.Add("usr_auth_mode", accountType.(string))
+	}
+
 	if len(d.Get("description").(string)) > 0 {
 		parameters.Add("usr_description", d.Get("description").(string))
 	}
@@ -231,7 +297,14 @@ func resourceuserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 	}
 
-	return nil
+	// Reading the authentication mode computed by SOLIDserver
+	if userInfo, userErr := _readUserId(ctx, d, meta); userErr == nil {
+		if accountType, accountTypeExist := userInfo["usr_auth_mode"].(string); accountTypeExist {
+			d.Set("account_type", strings.ToLower(accountType))
+		}
+	}
+
+	return resourceuserpasswordwarning(d)
 }
 
 func resourceuserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -251,7 +324,6 @@ func resourceuserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		"email":       "usr_email",
 		"last_name":   "usr_lname",
 		"first_name":  "usr_fname",
-		"password":    "usr_password",
 	}
 
 	for k, v := range aVars {
@@ -262,6 +334,11 @@ func resourceuserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 	}
 
+	if resourceuserpasswordchanged(d) {
+		bChange = true
+		parameters.Add("usr_password", d.Get("password").(string))
+	}
+
 	if bChange {
 		// Sending the update request
 		resp, body, err := s.Request("put", "rest/user_add", &parameters)
@@ -329,6 +406,10 @@ func resourceuserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 	}
 
+	if resourceuserpasswordchanged(d) {
+		return resourceuserpasswordwarning(d)
+	}
+
 	return nil
 }
 
@@ -368,27 +449,30 @@ func resourceuserDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	return diag.FromErr(err)
 }
 
-func resourceuserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s := meta.(*SOLIDserver)
-
-	buf, err := _readUserId(ctx, d, meta)
+// Update the local information of a user from the one returned by SOLIDserver
+func resourceuserset(d *schema.ResourceData, info map[string]interface{}) {
+	d.Set("login", infostring(info, "usr_login"))
+	d.Set("description", infostring(info, "usr_description"))
+	d.Set("first_name", infostring(info, "usr_fname"))
+	d.Set("last_name", infostring(info, "usr_lname"))
+	d.Set("email", infostring(info, "usr_email"))
 
-	if err != nil {
-		return diag.Errorf("Unable to find user: %s\n", d.Get("login").(string))
+	if accountType, accountTypeExist := info["usr_auth_mode"].(string); accountTypeExist {
+		d.Set("account_type", strings.ToLower(accountType))
 	}
 
-	d.Set("login", buf["usr_login"].(string))
-	d.Set("description", buf["usr_description"].(string))
-	d.Set("first_name", buf["usr_fname"].(string))
-	d.Set("last_name", buf["usr_lname"].(string))
-	d.Set("email", buf["usr_email"].(string))
-
 	// Updating local class_parameters
 	currentClassParameters := d.Get("class_parameters").(map[string]interface{})
-	retrievedClassParameters, _ := url.ParseQuery(buf["usr_class_parameters"].(string))
+	retrievedClassParameters, _ := url.ParseQuery(infostring(info, "usr_class_parameters"))
 	computedClassParameters := map[string]string{}
 
 	for ck := range currentClassParameters {
+		// Keeping the local value of the class parameters maintained by SOLIDserver
+		if classparamignored(d, ck) {
+			computedClassParameters[ck] = currentClassParameters[ck].(string)
+			continue
+		}
+
 		if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 			computedClassParameters[ck] = rv[0]
 		} else {
@@ -397,97 +481,91 @@ func resourceuserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	}
 
 	d.Set("class_parameters", computedClassParameters)
+}
+
+// Return the names of the groups a user belongs to
+// Or an error in case of failure
+func usergroupsbyid(userID string, meta interface{}) ([]string, error) {
+	s := meta.(*SOLIDserver)
 
-	// get group for this user id
+	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("usr_id", d.Id())
+	parameters.Add("usr_id", userID)
 	parameters.Add("ORDERBY", "grp_name")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/user_admin_group_list", &parameters)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, err
 	}
 
-	var bufg [](map[string]interface{})
-	json.Unmarshal([]byte(body), &bufg)
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
 
 	// Checking the answer
 	if resp.StatusCode == 200 || resp.StatusCode == 204 {
-		if len(bufg) > 0 {
-			var groups []string
+		groups := []string{}
 
-			for _, elem := range bufg {
-				//log.Printf("[DEBUG] resourceuserRead grp = %s\n", elem["grp_name"])
-				groups = append(groups, elem["grp_name"].(string))
+		for _, elem := range buf {
+			if groupName := infostring(elem, "grp_name"); groupName != "" {
+				groups = append(groups, groupName)
 			}
-			//log.Printf("[DEBUG] resourceuserRead set grp = %s\n", groups)
-
-			d.Set("groups", groups)
-
-			return nil
 		}
 
-		return nil
+		return groups, nil
 	}
 
-	return diag.Errorf("Unable to find group for user: %s\n",
-		d.Get("login").(string))
+	return nil, fmt.Errorf("SOLIDServer - Unable to find the groups of user (oid): %s\n", userID)
 }
 
-func resourceuserImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	s := meta.(*SOLIDserver)
+func resourceuserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	buf, err := _readUserId(ctx, d, meta)
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("usr_id", d.Id())
+	if err != nil || buf == nil {
+		return diag.Errorf("Unable to find user: %s\n", d.Get("login").(string))
+	}
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/user_info", &parameters)
+	resourceuserset(d, buf)
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	groups, groupsErr := usergroupsbyid(d.Id(), meta)
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			d.Set("login", buf[0]["usr_login"].(string))
-			d.Set("description", buf[0]["usr_description"].(string))
-			d.Set("first_name", buf[0]["usr_fname"].(string))
-			d.Set("last_name", buf[0]["usr_lname"].(string))
-			d.Set("email", buf[0]["usr_email"].(string))
-
-			// Updating local class_parameters
-			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
-			retrievedClassParameters, _ := url.ParseQuery(buf[0]["usr_class_parameters"].(string))
-			computedClassParameters := map[string]string{}
-
-			for ck := range currentClassParameters {
-				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
-					computedClassParameters[ck] = rv[0]
-				} else {
-					computedClassParameters[ck] = ""
-				}
-			}
+	if groupsErr != nil {
+		return diag.Errorf("Unable to find group for user: %s\n",
+			d.Get("login").(string))
+	}
 
-			d.Set("class_parameters", computedClassParameters)
+	if len(groups) > 0 {
+		d.Set("groups", groups)
+	}
 
-			return []*schema.ResourceData{d}, nil
-		}
+	return nil
+}
 
-		if len(buf) > 0 {
-			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				tflog.Debug(ctx, fmt.Sprintf("Unable to import user (oid): %s (%s)\n", d.Id(), errMsg))
-			}
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import user (oid): %s\n", d.Id()))
-		}
+func resourceuserImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	buf, err := _readUserId(ctx, d, meta)
+
+	if err != nil || buf == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find and import user (oid): %s (%s)\n", d.Id(), err))
 
 		// Reporting a failure
 		return nil, fmt.Errorf("SOLIDServer - Unable to find and import user (oid): %s\n", d.Id())
 	}
 
-	// Reporting a failure
-	return nil, err
+	resourceuserset(d, buf)
+
+	// Importing the groups to avoid removing the user from all of them on the next apply
+	groups, groupsErr := usergroupsbyid(d.Id(), meta)
+
+	if groupsErr != nil {
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to import the groups of user: %s (%s)\n", d.Get("login").(string), groupsErr)
+	}
+
+	d.Set("groups", groups)
+
+	// The password of the user remains unknown until changed
+	d.Set("password_version", 0)
+
+	return []*schema.ResourceData{d}, nil
 }
@@ -0,0 +1,65 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestUserAccountType(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentAuthMode := ""
+
+	m.handle("/rest/user_add", func(w http.ResponseWriter, r *http.Request) {
+		sentAuthMode = r.URL.Query().Get("usr_auth_mode")
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "9"}})
+	})
+
+	m.handle("/rest/user_admin_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"usr_login":            "jdoe",
+			"usr_description":      "",
+			"usr_fname":            "",
+			"usr_lname":            "",
+			"usr_email":            "",
+			"usr_auth_mode":        "LDAP",
+			"usr_class_parameters": "",
+		}})
+	})
+
+	// The authentication mode is sent when set and LDAP passwords are reported
+	d := schema.TestResourceDataRaw(t, resourceuser().Schema, map[string]interface{}{
+		"login":        "jdoe",
+		"password":     "secret",
+		"account_type": "ldap",
+		"groups":       []interface{}{},
+	})
+
+	diags := resourceuserCreate(context.Background(), d, s)
+
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+
+	if sentAuthMode != "ldap" || d.Get("account_type").(string) != "ldap" {
+		t.Errorf("unexpected authentication mode: sent %q, got %q", sentAuthMode, d.Get("account_type").(string))
+	}
+
+	// The authentication mode is computed by SOLIDserver otherwise
+	d = schema.TestResourceDataRaw(t, resourceuser().Schema, map[string]interface{}{
+		"login":    "jdoe",
+		"password": "secret",
+		"groups":   []interface{}{},
+	})
+
+	if diags := resourceuserCreate(context.Background(), d, s); len(diags) != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+
+	if sentAuthMode != "" || d.Get("account_type").(string) != "ldap" {
+		t.Errorf("unexpected authentication mode: sent %q, got %q", sentAuthMode, d.Get("account_type").(string))
+	}
+}
@@ -0,0 +1,113 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUserPasswordVersion(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	sentPassword := ""
+
+	m.handle("/rest/user_add", func(w http.ResponseWriter, r *http.Request) {
+		sentPassword = r.URL.Query().Get("usr_password")
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "9"}})
+	})
+
+	r := resourceuser()
+
+	update := func(state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff != nil {
+			d, err := schema.InternalMap(r.Schema).Data(state, diff)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diags := resourceuserUpdate(context.Background(), d, s); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+		}
+
+		return diff
+	}
+
+	// The password of an imported user is unknown, setting password_version doesn't reset it
+	imported := &terraform.InstanceState{
+		ID: "9",
+		Attributes: map[string]string{
+			"id":           "9",
+			"login":        "jdoe",
+			"account_type": "local",
+			"groups.#":     "0",
+		},
+	}
+
+	diff := update(imported, map[string]interface{}{
+		"login":            "jdoe",
+		"password":         "secret",
+		"password_version": 1,
+		"groups":           []interface{}{},
+	})
+
+	if _, passwordChange := diff.Attributes["password"]; passwordChange || m.count("/rest/user_add") != 0 {
+		t.Errorf("unexpected password change: %v", diff)
+	}
+
+	// Increasing password_version sends the password
+	managed := &terraform.InstanceState{
+		ID: "9",
+		Attributes: map[string]string{
+			"id":               "9",
+			"login":            "jdoe",
+			"password":         "secret",
+			"password_version": "1",
+			"account_type":     "local",
+			"groups.#":         "0",
+		},
+	}
+
+	diff = update(managed, map[string]interface{}{
+		"login":            "jdoe",
+		"password":         "changed",
+		"password_version": 1,
+		"groups":           []interface{}{},
+	})
+
+	if diff != nil || m.count("/rest/user_add") != 0 {
+		t.Errorf("unexpected password change: %v", diff)
+	}
+
+	update(managed, map[string]interface{}{
+		"login":            "jdoe",
+		"password":         "changed",
+		"password_version": 2,
+		"groups":           []interface{}{},
+	})
+
+	if sentPassword != "changed" {
+		t.Errorf("expected the password to be sent, got: %q", sentPassword)
+	}
+
+	// Without password_version, the password is sent whenever it changes
+	managed.Attributes["password_version"] = "0"
+	sentPassword = ""
+
+	update(managed, map[string]interface{}{
+		"login":    "jdoe",
+		"password": "changed again",
+		"groups":   []interface{}{},
+	})
+
+	if sentPassword != "changed again" {
+		t.Errorf("expected the password to be sent, got: %q", sentPassword)
+	}
+}
@@ -6,14 +6,10 @@
 package solidserver
 
 import (
-	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/satori/go.uuid"
+	"log"
 	"regexp"
 	"sort"
 	"testing"
@@ -22,7 +18,7 @@ import (
 var t_user_name string
 
 func TestAccUser_ChangeUserGroup(t *testing.T) {
-	username := fmt.Sprintf("user-%s", uuid.Must(uuid.NewV4()))
+	username := testAccName("user")
 	var groupsid_01 []string
 	var groupsid_02 []string
 
@@ -99,8 +95,8 @@ func TestAccUser_CreateUser01(t *testing.T) {
 
 // create user and change parameters at each steps
 func TestAccUser_ModifyUserParams(t *testing.T) {
-	username := fmt.Sprintf("user-%s", uuid.Must(uuid.NewV4()))
-	username_02 := fmt.Sprintf("user-%s", uuid.Must(uuid.NewV4()))
+	username := testAccName("user")
+	username_02 := testAccName("user")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -271,7 +267,7 @@ func Config_TestAccUser_GetGroupAdmin() string {
 }
 
 func Config_TestAccUser_CreateUser01() string {
-	t_user_name = fmt.Sprintf("user-%s", uuid.Must(uuid.NewV4()))
+	t_user_name = testAccName("user")
 	// log.Printf("[DEBUG] - user name: %s\n", t_user_name)
 
 	return fmt.Sprintf(`
@@ -315,7 +311,7 @@ func Config_TestAccUser_ModifyUserParams(username string,
 }
 
 func Config_TestAccUser_ChangeUserGroup01(username string) string {
-	gr01 := fmt.Sprintf("group-%s", uuid.Must(uuid.NewV4()))
+	gr01 := testAccName("group")
 
 	return fmt.Sprintf(`
     resource "solidserver_usergroup" "gr01" {
@@ -336,8 +332,8 @@ func Config_TestAccUser_ChangeUserGroup01(username string) string {
 
 func Config_TestAccUser_ChangeUserGroup02(username string) string {
 	// log.Printf("[DEBUG] - Config_TestAccUser_ChangeUserGroup02\n")
-	gr01 := fmt.Sprintf("group-%s", uuid.Must(uuid.NewV4()))
-	gr02 := fmt.Sprintf("group-%s", uuid.Must(uuid.NewV4()))
+	gr01 := testAccName("group")
+	gr02 := testAccName("group")
 
 	return fmt.Sprintf(`
     resource "solidserver_usergroup" "gr01" {
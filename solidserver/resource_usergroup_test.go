@@ -6,12 +6,11 @@ package solidserver
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/satori/go.uuid"
 	"testing"
 )
 
 func TestAccUserGroup_Create01(t *testing.T) {
-	groupname := fmt.Sprintf("group-%s", uuid.Must(uuid.NewV4()))
+	groupname := testAccName("group")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -30,8 +29,8 @@ func TestAccUserGroup_Create01(t *testing.T) {
 }
 
 func TestAccUserGroup_ModifyUserParams(t *testing.T) {
-	groupname := fmt.Sprintf("group-%s", uuid.Must(uuid.NewV4()))
-	groupname_02 := fmt.Sprintf("group-%s", uuid.Must(uuid.NewV4()))
+	groupname := testAccName("group")
+	groupname_02 := testAccName("group")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
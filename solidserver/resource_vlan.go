@@ -7,7 +7,9 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net/url"
 	"strconv"
 )
@@ -35,12 +37,11 @@ func resourcevlan() *schema.Resource {
 			},
 			"vlan_range": {
 				Type:        schema.TypeString,
-				Description: "The name of the vlan Range.",
-				//DiffSuppressFunc:      resourcediffsuppressnull,
-				Required: false,
-				Optional: true,
-				ForceNew: true,
-				Default:  "",
+				Description: "The name of the vlan Range, computed from the vlan ID when not set (empty when outside of any range).",
+				Required:    false,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"request_id": {
 				Type:        schema.TypeInt,
@@ -49,6 +50,22 @@ func resourcevlan() *schema.Resource {
 				ForceNew:    true,
 				Default:     0,
 			},
+			"id_min": {
+				Type:         schema.TypeInt,
+				Description:  "The lowest vlan ID to pick from when no vlan ID is requested (Default: no lower bound).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+			},
+			"id_max": {
+				Type:         schema.TypeInt,
+				Description:  "The highest vlan ID to pick from when no vlan ID is requested (Default: no upper bound).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+			},
 			"vlan_id": {
 				Type:        schema.TypeInt,
 				Description: "The vlan ID.",
@@ -61,6 +78,19 @@ func resourcevlan() *schema.Resource {
 				Required:    true,
 				ForceNew:    false,
 			},
+			"space_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of subnets associated to the vlan.",
+				Computed:    true,
+			},
+			"associated_subnets": {
+				Type:        schema.TypeList,
+				Description: "The names of the subnets associated to the vlan.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the vlan.",
@@ -69,15 +99,41 @@ func resourcevlan() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to vlan.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to vlan.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the VLAN's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffvalidateclass("vlm_vlan"),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				idMin := d.Get("id_min").(int)
+				idMax := d.Get("id_max").(int)
+
+				if idMax > 0 && idMin > idMax {
+					return fmt.Errorf("The id_min (%d) of the vlan must be lower than or equal to its id_max (%d)", idMin, idMax)
+				}
+
+				if requestID := d.Get("request_id").(int); requestID > 0 && (requestID < idMin || (idMax > 0 && requestID > idMax)) {
+					return fmt.Errorf("The requested vlan ID (%d) is outside of the id_min/id_max window", requestID)
+				}
+
+				return nil
+			},
+		),
 	}
 }
 
@@ -92,7 +148,7 @@ func resourcevlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	} else {
 		var vlanErr error = nil
 
-		vlanIDs, vlanErr = vlanidfindfree(d.Get("vlan_domain").(string), meta)
+		vlanIDs, vlanErr = vlanidfindfree(d.Get("vlan_domain").(string), d.Get("id_min").(int), d.Get("id_max").(int), meta)
 
 		if vlanErr != nil {
 			// Reporting a failure
@@ -114,10 +170,10 @@ func resourcevlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		parameters.Add("vlmvlan_name", d.Get("name").(string))
 
 		if s.Version < 730 {
-			tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+			tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 		} else {
 			parameters.Add("vlmvlan_class_name", d.Get("class").(string))
-			parameters.Add("vlmvlan_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+			parameters.Add("vlmvlan_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 		}
 
 		// Sending creation request
@@ -131,11 +187,21 @@ func resourcevlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created vlan (oid): %s\n", oid))
+					lookupcacheinvalidate(meta, cacheKindVlan)
 
 					vnid, _ := strconv.Atoi(vlanIDs[i])
 					d.Set("vlan_id", vnid)
+					d.Set("space_count", 0)
+					d.Set("associated_subnets", []string{})
 					d.SetId(oid)
 
+					// Reporting the vlan range the vlan ID landed in
+					if rangeName, rangeErr := vlanrangenamebyid(oid, meta); rangeErr == nil {
+						d.Set("vlan_range", rangeName)
+					} else {
+						tflog.Debug(ctx, fmt.Sprintf("Unable to retrieve the vlan range of vlan: %s\n", d.Get("name").(string)))
+					}
+
 					return nil
 				}
 			} else {
@@ -169,10 +235,10 @@ func resourcevlanUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 	parameters.Add("vlmvlan_name", d.Get("name").(string))
 
 	if s.Version < 730 {
-		tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+		tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 	} else {
 		parameters.Add("vlmvlan_class_name", d.Get("class").(string))
-		parameters.Add("vlmvlan_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+		parameters.Add("vlmvlan_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 	}
 
 	// Sending the update request
@@ -186,6 +252,7 @@ func resourcevlanUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated vlan (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindVlan)
 				d.SetId(oid)
 				return nil
 			}
@@ -221,6 +288,14 @@ func resourcevlanDelete(ctx context.Context, d *schema.ResourceData, meta interf
 
 		// Checking the answer
 		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// A vlan already deleted (ex: along with its domain) is not a failure
+			if objectmissing(resp.StatusCode, buf) {
+				tflog.Debug(ctx, fmt.Sprintf("vlan already deleted (oid): %s\n", d.Id()))
+				lookupcacheinvalidate(meta, cacheKindVlan)
+				d.SetId("")
+				return nil
+			}
+
 			// Reporting a failure
 			if len(buf) > 0 {
 				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
@@ -233,6 +308,7 @@ func resourcevlanDelete(ctx context.Context, d *schema.ResourceData, meta interf
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted vlan (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindVlan)
 
 		// Unset local ID
 		d.SetId("")
@@ -264,19 +340,26 @@ func resourcevlanRead(ctx context.Context, d *schema.ResourceData, meta interfac
 			vnid, _ := strconv.Atoi(buf[0]["vlmvlan_vlan_id"].(string))
 
 			d.Set("name", buf[0]["vlmvlan_name"].(string))
-			/* Do not read vlan_domain nor vlan_range as their names may change
+			/* Do not read vlan_domain as its name may change
 			// At least until suitable option is found to ignore the change
 			d.Set("vlan_domain", buf[0]["vlmdomain_name"].(string))
-			if buf[0]["vlmrange_name"].(string) != "#" {
-				d.Set("vlan_range", buf[0]["vlmrange_name"].(string))
-			} else {
-				d.Set("vlan_range", "")
-			}
 			*/
 			d.Set("vlan_id", vnid)
+			d.Set("vlan_range", vlanrangename(buf[0]))
+
+			// Updating the associated subnets
+			subnets, subnetsErr := vlansubnetsbyid(d.Get("vlan_domain").(string), vnid, meta)
+
+			if subnetsErr != nil {
+				// Reporting a failure
+				return diag.Errorf("Unable to retrieve the subnets associated to vlan: %s (%s)\n", d.Get("name").(string), subnetsErr)
+			}
+
+			d.Set("space_count", len(subnets))
+			d.Set("associated_subnets", subnets)
 
 			if s.Version < 730 {
-				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 			} else {
 				d.Set("class", buf[0]["vlmvlan_class_name"].(string))
 
@@ -286,6 +369,12 @@ func resourcevlanRead(ctx context.Context, d *schema.ResourceData, meta interfac
 				computedClassParameters := map[string]string{}
 
 				for ck := range currentClassParameters {
+					// Keeping the local value of the class parameters maintained by SOLIDserver
+					if classparamignored(d, ck) {
+						computedClassParameters[ck] = currentClassParameters[ck].(string)
+						continue
+					}
+
 					if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 						computedClassParameters[ck] = rv[0]
 					} else {
@@ -339,11 +428,22 @@ func resourcevlanImportState(ctx context.Context, d *schema.ResourceData, meta i
 
 			d.Set("name", buf[0]["vlmvlan_name"].(string))
 			d.Set("vlan_domain", buf[0]["vlmdomain_name"].(string))
-			d.Set("vlan_range", buf[0]["vlmrange_name"].(string))
+			d.Set("vlan_range", vlanrangename(buf[0]))
 			d.Set("vlan_id", vnid)
 
+			// Updating the associated subnets
+			subnets, subnetsErr := vlansubnetsbyid(d.Get("vlan_domain").(string), vnid, meta)
+
+			if subnetsErr != nil {
+				// Reporting a failure
+				return nil, fmt.Errorf("SOLIDServer - Unable to retrieve the subnets associated to vlan: %s (%s)\n", d.Get("name").(string), subnetsErr)
+			}
+
+			d.Set("space_count", len(subnets))
+			d.Set("associated_subnets", subnets)
+
 			if s.Version < 730 {
-				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 			} else {
 				d.Set("class", buf[0]["vlmvlan_class_name"].(string))
 
@@ -353,6 +453,12 @@ func resourcevlanImportState(ctx context.Context, d *schema.ResourceData, meta i
 				computedClassParameters := map[string]string{}
 
 				for ck := range currentClassParameters {
+					// Keeping the local value of the class parameters maintained by SOLIDserver
+					if classparamignored(d, ck) {
+						computedClassParameters[ck] = currentClassParameters[ck].(string)
+						continue
+					}
+
 					if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 						computedClassParameters[ck] = rv[0]
 					} else {
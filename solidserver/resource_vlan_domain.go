@@ -7,9 +7,12 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 func resourcevlandomain() *schema.Resource {
@@ -48,16 +51,299 @@ func resourcevlandomain() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to VLAN Domain.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to VLAN Domain.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vlan_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of VLANs used within the VLAN Domain.",
+				Computed:    true,
+			},
+			"free_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of free VLANs within the VLAN Domain.",
+				Computed:    true,
+			},
+			"total_capacity": {
+				Type:        schema.TypeInt,
+				Description: "The number of VLAN IDs within the range of the VLAN Domain.",
+				Computed:    true,
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the VLAN domain's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"ranges": {
+				Type:        schema.TypeSet,
+				Description: "The VLAN ranges managed along with the VLAN Domain, identified by their name. The ranges created otherwise (ex: through solidserver_vlan_range) are left untouched. Swapped ranges are deleted and created again.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the VLAN Range.",
+							Required:    true,
+						},
+						"start": {
+							Type:        schema.TypeInt,
+							Description: "The VLAN Range's lower VLAN ID.",
+							Required:    true,
+						},
+						"end": {
+							Type:        schema.TypeInt,
+							Description: "The VLAN Range's higher VLAN ID.",
+							Required:    true,
+						},
+					},
+				},
+			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourcediffvalidateclass("vlm_domain"),
+			resourcevlandomaindiffranges,
+		),
+	}
+}
+
+// A VLAN range, as bounds
+type vlanRangeBounds [2]int
+
+// An operation on the VLAN ranges of a domain (delete, edit or add)
+type vlanRangeOperation struct {
+	action string
+	name   string
+	bounds vlanRangeBounds
+}
+
+// Refuse the ranges whose bounds are reversed or overlap each other
+func resourcevlandomaindiffranges(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("ranges") {
+		return nil
+	}
+
+	ranges := resourcevlandomainranges(d.Get("ranges").(*schema.Set))
+	names := resourcevlandomainrangenames(ranges)
+
+	for i, name := range names {
+		if ranges[name][0] > ranges[name][1] {
+			return fmt.Errorf("The start of VLAN Range '%s' must be lower than or equal to its end", name)
+		}
+
+		for _, other := range names[i+1:] {
+			if vlanrangesoverlap(ranges[name], ranges[other]) {
+				return fmt.Errorf("VLAN Ranges '%s' and '%s' overlap", name, other)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Return the bounds of the VLAN ranges of a set by name
+func resourcevlandomainranges(set *schema.Set) map[string]vlanRangeBounds {
+	result := map[string]vlanRangeBounds{}
+
+	for _, item := range set.List() {
+		vlanRange := item.(map[string]interface{})
+		result[vlanRange["name"].(string)] = vlanRangeBounds{vlanRange["start"].(int), vlanRange["end"].(int)}
+	}
+
+	return result
+}
+
+// Return the sorted names of VLAN ranges
+func resourcevlandomainrangenames(ranges map[string]vlanRangeBounds) []string {
+	names := make([]string, 0, len(ranges))
+
+	for name := range ranges {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Return true if two VLAN ranges share VLAN IDs
+func vlanrangesoverlap(a vlanRangeBounds, b vlanRangeBounds) bool {
+	return a[0] <= b[1] && b[0] <= a[1]
+}
+
+// Return the operations turning the VLAN ranges of a domain from current into target
+// The operations are ordered so that the ranges never overlap in between, SOLIDserver rejecting it:
+// deletions first, then the edits and additions not overlapping the ranges in place, shrinking the
+// blocked ranges to the part of their target they already hold. Ranges blocking each other
+// (ex: swapped) are left only by deleting one of them, created again once the others are in place
+func resourcevlandomainrangesplan(current map[string]vlanRangeBounds, target map[string]vlanRangeBounds) []vlanRangeOperation {
+	operations := []vlanRangeOperation{}
+	state := map[string]vlanRangeBounds{}
+
+	for name, bounds := range current {
+		state[name] = bounds
+	}
+
+	apply := func(action string, name string, bounds vlanRangeBounds) {
+		operations = append(operations, vlanRangeOperation{action: action, name: name, bounds: bounds})
+
+		if action == "delete" {
+			delete(state, name)
+		} else {
+			state[name] = bounds
+		}
+	}
+
+	// Return true if bounds overlap the ranges in place, other than the named one
+	blocked := func(name string, bounds vlanRangeBounds) bool {
+		for other, otherBounds := range state {
+			if other != name && vlanrangesoverlap(bounds, otherBounds) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, name := range resourcevlandomainrangenames(current) {
+		if _, exist := target[name]; !exist {
+			apply("delete", name, current[name])
+		}
+	}
+
+	pending := []string{}
+
+	for _, name := range resourcevlandomainrangenames(target) {
+		if bounds, exist := state[name]; !exist || bounds != target[name] {
+			pending = append(pending, name)
+		}
+	}
+
+	for len(pending) > 0 {
+		remaining := []string{}
+
+		for _, name := range pending {
+			if blocked(name, target[name]) {
+				remaining = append(remaining, name)
+				continue
+			}
+
+			if _, exist := state[name]; exist {
+				apply("edit", name, target[name])
+			} else {
+				apply("add", name, target[name])
+			}
+		}
+
+		if len(remaining) < len(pending) {
+			pending = remaining
+			continue
+		}
+
+		// Shrinking the blocked ranges to the part of their target they already hold
+		shrunk := false
+
+		for _, name := range pending {
+			if bounds, exist := state[name]; exist {
+				shrink := vlanRangeBounds{max(bounds[0], target[name][0]), min(bounds[1], target[name][1])}
+
+				if shrink[0] <= shrink[1] && shrink != bounds {
+					apply("edit", name, shrink)
+					shrunk = true
+				}
+			}
+		}
+
+		if shrunk {
+			continue
+		}
+
+		// Deleting a blocked range, created again once the others are in place
+		deleted := false
+
+		for _, name := range pending {
+			if bounds, exist := state[name]; exist {
+				apply("delete", name, bounds)
+				deleted = true
+				break
+			}
+		}
+
+		// The targets overlap each other, leaving SOLIDserver report it
+		if !deleted {
+			for _, name := range pending {
+				apply("add", name, target[name])
+			}
+			break
+		}
+	}
+
+	return operations
+}
+
+// Apply the changes of the VLAN ranges of a domain, the ranges in place being updated along
+// Return an error in case of failure
+func resourcevlandomainrangesreconcile(ctx context.Context, domainID string, current map[string]vlanRangeBounds, target map[string]vlanRangeBounds, meta interface{}) error {
+	operations := resourcevlandomainrangesplan(current, target)
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	// Resolving the oid of the ranges in place
+	rangeIDs := map[string]string{}
+	ranges, rangesErr := vlandomainranges(domainID, meta)
+
+	if rangesErr != nil {
+		return rangesErr
+	}
+
+	for _, vlanRange := range ranges {
+		rangeIDs[strings.ToLower(infostring(vlanRange, "vlmrange_name"))] = infostring(vlanRange, "vlmrange_id")
+	}
+
+	for _, operation := range operations {
+		switch operation.action {
+		case "delete":
+			if err := objectdelete("VLAN Range", "rest/vlm_range_delete", "vlmrange_id", rangeIDs[strings.ToLower(operation.name)], meta); err != nil {
+				return err
+			}
+
+			delete(rangeIDs, strings.ToLower(operation.name))
+			delete(current, operation.name)
+		default:
+			oid, err := vlanrangeset(domainID, rangeIDs[strings.ToLower(operation.name)], operation.name, operation.bounds[0], operation.bounds[1], meta)
+
+			if err != nil {
+				return err
+			}
+
+			rangeIDs[strings.ToLower(operation.name)] = oid
+			current[operation.name] = operation.bounds
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Applied %s of VLAN Range: %s (%d-%d)\n", operation.action, operation.name, operation.bounds[0], operation.bounds[1]))
 	}
+
+	return nil
+}
+
+// Set the VLAN ranges of the domain in its state
+func resourcevlandomainsetranges(d *schema.ResourceData, ranges map[string]vlanRangeBounds) {
+	items := []interface{}{}
+
+	for _, name := range resourcevlandomainrangenames(ranges) {
+		items = append(items, map[string]interface{}{"name": name, "start": ranges[name][0], "end": ranges[name][1]})
+	}
+
+	d.Set("ranges", items)
 }
 
 func resourcevlandomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -68,10 +354,10 @@ func resourcevlandomainCreate(ctx context.Context, d *schema.ResourceData, meta
 	parameters.Add("add_flag", "new_only")
 	parameters.Add("vlmdomain_name", d.Get("name").(string))
 	parameters.Add("vlmdomain_class_name", d.Get("class").(string))
-	parameters.Add("vlmdomain_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("vlmdomain_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	if d.Get("vxlan").(bool) {
-		if s.Version < 700 {
+		if s.Version < versionVXLAN {
 			return diag.Errorf("VXLAN Domain are not supported in this SOLIDserver version %d\n", s.Version)
 		}
 
@@ -89,7 +375,33 @@ func resourcevlandomainCreate(ctx context.Context, d *schema.ResourceData, meta
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created VLAN Domain (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindVlanDomain)
 				d.SetId(oid)
+
+				// Creating the ranges, the domain is rolled back on failure
+				target := resourcevlandomainranges(d.Get("ranges").(*schema.Set))
+				current := map[string]vlanRangeBounds{}
+				steps := []string{}
+
+				for _, name := range resourcevlandomainrangenames(target) {
+					steps = append(steps, "creation of VLAN Range "+name)
+				}
+
+				if err := resourcevlandomainrangesreconcile(ctx, oid, current, target, meta); err != nil {
+					createSteps := newcreatesteps("VLAN Domain", d.Get("name").(string), steps...)
+
+					for _, name := range resourcevlandomainrangenames(current) {
+						createSteps.done("creation of VLAN Range " + name)
+					}
+
+					resourcevlandomainsetranges(d, current)
+					_, missing := createSteps.status()
+
+					return createSteps.fail(ctx, d, missing[0], err, func() error {
+						return objectdelete("VLAN Domain", "rest/vlm_domain_delete", "vlmdomain_id", oid, meta)
+					})
+				}
+
 				return nil
 			}
 		}
@@ -111,16 +423,21 @@ func resourcevlandomainCreate(ctx context.Context, d *schema.ResourceData, meta
 func resourcevlandomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Updating the ranges without touching the domain
+	if !d.HasChangesExcept("ranges") {
+		return resourcevlandomainupdateranges(ctx, d, meta)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("vlmdomain_id", d.Id())
 	parameters.Add("add_flag", "edit_only")
 	parameters.Add("vlmdomain_name", d.Get("name").(string))
 	parameters.Add("vlmdomain_class_name", d.Get("class").(string))
-	parameters.Add("vlmdomain_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("vlmdomain_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	if d.Get("vxlan").(bool) {
-		if s.Version < 700 {
+		if s.Version < versionVXLAN {
 			return diag.Errorf("VXLAN Domain are not supported in this SOLIDserver version %d\n", s.Version)
 		}
 		parameters.Add("support_vxlan", "1")
@@ -137,8 +454,9 @@ func resourcevlandomainUpdate(ctx context.Context, d *schema.ResourceData, meta
 		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated VLAN Domain (oid): %s\n", oid))
+				lookupcacheinvalidate(meta, cacheKindVlanDomain)
 				d.SetId(oid)
-				return nil
+				return resourcevlandomainupdateranges(ctx, d, meta)
 			}
 		}
 
@@ -156,6 +474,24 @@ func resourcevlandomainUpdate(ctx context.Context, d *schema.ResourceData, meta
 	return diag.FromErr(err)
 }
 
+// Apply the changes of the VLAN ranges of the domain
+// On failure, the ranges in place are kept in the state for the next apply to converge
+func resourcevlandomainupdateranges(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("ranges") {
+		return nil
+	}
+
+	old, new := d.GetChange("ranges")
+	current := resourcevlandomainranges(old.(*schema.Set))
+
+	if err := resourcevlandomainrangesreconcile(ctx, d.Id(), current, resourcevlandomainranges(new.(*schema.Set)), meta); err != nil {
+		resourcevlandomainsetranges(d, current)
+		return diag.Errorf("Unable to update the VLAN Ranges of VLAN Domain: %s (%s)", d.Get("name").(string), strings.TrimSpace(err.Error()))
+	}
+
+	return nil
+}
+
 func resourcevlandomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
@@ -184,6 +520,7 @@ func resourcevlandomainDelete(ctx context.Context, d *schema.ResourceData, meta
 
 		// Log deletion
 		tflog.Debug(ctx, fmt.Sprintf("Deleted VLAN Domain (oid): %s\n", d.Id()))
+		lookupcacheinvalidate(meta, cacheKindVlanDomain, cacheKindVlan)
 
 		// Unset local ID
 		d.SetId("")
@@ -228,6 +565,12 @@ func resourcevlandomainRead(ctx context.Context, d *schema.ResourceData, meta in
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -237,6 +580,39 @@ func resourcevlandomainRead(ctx context.Context, d *schema.ResourceData, meta in
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Updating the utilization of the domain
+			resourcevlandomainsetcounts(ctx, d, buf[0], meta)
+
+			// Updating the ranges managed along with the domain, the others are ignored
+			if tracked := resourcevlandomainranges(d.Get("ranges").(*schema.Set)); len(tracked) > 0 {
+				trackedNames := map[string]string{}
+
+				for name := range tracked {
+					trackedNames[strings.ToLower(name)] = name
+				}
+
+				ranges, rangesErr := vlandomainranges(d.Id(), meta)
+
+				if rangesErr != nil {
+					// Reporting a failure
+					return diag.Errorf("Unable to list the VLAN Ranges of VLAN Domain: %s (%s)\n", d.Get("name").(string), rangesErr)
+				}
+
+				current := map[string]vlanRangeBounds{}
+
+				for _, vlanRange := range ranges {
+					name, trackedExist := trackedNames[strings.ToLower(infostring(vlanRange, "vlmrange_name"))]
+					start, startErr := strconv.Atoi(infostring(vlanRange, "vlmrange_start_vlan_id"))
+					end, endErr := strconv.Atoi(infostring(vlanRange, "vlmrange_end_vlan_id"))
+
+					if trackedExist && startErr == nil && endErr == nil {
+						current[name] = vlanRangeBounds{start, end}
+					}
+				}
+
+				resourcevlandomainsetranges(d, current)
+			}
+
 			return nil
 		}
 
@@ -260,6 +636,20 @@ func resourcevlandomainRead(ctx context.Context, d *schema.ResourceData, meta in
 	return diag.FromErr(err)
 }
 
+// Update the utilization of a VLAN Domain, keeping the current values in case of failure
+func resourcevlandomainsetcounts(ctx context.Context, d *schema.ResourceData, domain map[string]interface{}, meta interface{}) {
+	free, used, countErr := vlandomaincounts(d.Id(), meta)
+
+	if countErr != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to retrieve the utilization of VLAN Domain: %s (%s)\n", d.Get("name").(string), countErr))
+		return
+	}
+
+	d.Set("vlan_count", used)
+	d.Set("free_count", free)
+	d.Set("total_capacity", vlandomaincapacity(domain, d.Get("vxlan").(bool)))
+}
+
 func resourcevlandomainImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	s := meta.(*SOLIDserver)
 
@@ -292,6 +682,12 @@ func resourcevlandomainImportState(ctx context.Context, d *schema.ResourceData,
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -0,0 +1,271 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestVlanDomainRangesPlan(t *testing.T) {
+	cases := []struct {
+		name    string
+		current map[string]vlanRangeBounds
+		target  map[string]vlanRangeBounds
+		deletes int
+	}{
+		{
+			name:    "swap",
+			current: map[string]vlanRangeBounds{"a": {1, 10}, "b": {11, 20}},
+			target:  map[string]vlanRangeBounds{"a": {11, 20}, "b": {1, 10}},
+			deletes: 1,
+		},
+		{
+			name:    "boundary move",
+			current: map[string]vlanRangeBounds{"a": {1, 10}, "b": {11, 20}},
+			target:  map[string]vlanRangeBounds{"a": {1, 5}, "b": {6, 20}},
+		},
+		{
+			name:    "boundary move, grown range first",
+			current: map[string]vlanRangeBounds{"a": {1, 10}, "b": {11, 20}},
+			target:  map[string]vlanRangeBounds{"a": {1, 15}, "b": {16, 20}},
+		},
+		{
+			name:    "shift",
+			current: map[string]vlanRangeBounds{"a": {1, 10}, "b": {11, 20}},
+			target:  map[string]vlanRangeBounds{"a": {5, 15}, "b": {16, 25}},
+		},
+		{
+			name:    "rotation",
+			current: map[string]vlanRangeBounds{"a": {1, 10}, "b": {11, 20}, "c": {21, 30}},
+			target:  map[string]vlanRangeBounds{"a": {11, 20}, "b": {21, 30}, "c": {1, 10}},
+			deletes: 1,
+		},
+		{
+			name:    "replacement",
+			current: map[string]vlanRangeBounds{"a": {1, 10}, "b": {11, 20}},
+			target:  map[string]vlanRangeBounds{"b": {11, 20}, "c": {1, 10}},
+			deletes: 1,
+		},
+	}
+
+	for _, c := range cases {
+		state := map[string]vlanRangeBounds{}
+		deletes := 0
+
+		for name, bounds := range c.current {
+			state[name] = bounds
+		}
+
+		for _, operation := range resourcevlandomainrangesplan(c.current, c.target) {
+			if operation.action == "delete" {
+				delete(state, operation.name)
+				deletes++
+				continue
+			}
+
+			state[operation.name] = operation.bounds
+
+			for other, bounds := range state {
+				if other != operation.name && vlanrangesoverlap(operation.bounds, bounds) {
+					t.Errorf("%s: transient overlap of %s %v with %s %v", c.name, operation.name, operation.bounds, other, bounds)
+				}
+			}
+		}
+
+		if fmt.Sprint(state) != fmt.Sprint(c.target) {
+			t.Errorf("%s: unexpected ranges: %v", c.name, state)
+		}
+
+		if deletes != c.deletes {
+			t.Errorf("%s: expected %d deletions, got %d", c.name, c.deletes, deletes)
+		}
+	}
+}
+
+func TestVlanDomainRanges(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	r := resourcevlandomain()
+	ranges := map[string]map[string]interface{}{"7": {"vlmrange_id": "7", "vlmrange_name": "external", "vlmrange_start_vlan_id": "90", "vlmrange_end_vlan_id": "99"}}
+	nextID := 10
+	failDelete := false
+
+	m.handle("/rest/vlm_domain_add", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "4"}})
+	})
+
+	m.handle("/rest/vlmdomain_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"vlmdomain_id":               "4",
+			"vlmdomain_name":             "domain01",
+			"vlmdomain_start_vlan_id":    "1",
+			"vlmdomain_end_vlan_id":      "100",
+			"vlmdomain_class_name":       "",
+			"vlmdomain_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/vlmvlan_count", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "0"}})
+	})
+
+	m.handle("/rest/vlmrange_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "vlmdomain_id='4'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		rows := []map[string]interface{}{}
+		for _, row := range ranges {
+			rows = append(rows, row)
+		}
+		mockReply(w, http.StatusOK, rows)
+	})
+
+	// SOLIDserver refuses overlapping ranges
+	m.handle("/rest/vlm_range_add", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		start, _ := strconv.Atoi(query.Get("vlmrange_start_vlan_id"))
+		end, _ := strconv.Atoi(query.Get("vlmrange_end_vlan_id"))
+
+		for id, row := range ranges {
+			rowStart, _ := strconv.Atoi(row["vlmrange_start_vlan_id"].(string))
+			rowEnd, _ := strconv.Atoi(row["vlmrange_end_vlan_id"].(string))
+
+			if id != query.Get("vlmrange_id") && start <= rowEnd && rowStart <= end {
+				t.Errorf("overlapping VLAN Range: %s (%d-%d) with %s", query.Get("vlmrange_name"), start, end, row["vlmrange_name"])
+				mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "Range overlap"}})
+				return
+			}
+		}
+
+		// Edits of unknown ranges are refused
+		id := query.Get("vlmrange_id")
+		if _, exist := ranges[id]; r.Method != http.MethodPost && !exist {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "VLAN Range not found"}})
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			id = strconv.Itoa(nextID)
+			nextID++
+		}
+
+		ranges[id] = map[string]interface{}{
+			"vlmrange_id":            id,
+			"vlmrange_name":          query.Get("vlmrange_name"),
+			"vlmrange_start_vlan_id": query.Get("vlmrange_start_vlan_id"),
+			"vlmrange_end_vlan_id":   query.Get("vlmrange_end_vlan_id"),
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": id}})
+	})
+
+	m.handle("/rest/vlm_range_delete", func(w http.ResponseWriter, r *http.Request) {
+		if failDelete {
+			mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errno": "1", "errmsg": "Forbidden"}})
+			return
+		}
+		delete(ranges, r.URL.Query().Get("vlmrange_id"))
+		mockReply(w, http.StatusOK, nil)
+	})
+
+	// Return the ranges of the mock as name:start-end, sorted by start
+	serverRanges := func() string {
+		result := make([]string, 101)
+		for _, row := range ranges {
+			start, _ := strconv.Atoi(row["vlmrange_start_vlan_id"].(string))
+			result[start] = fmt.Sprintf("%s:%s-%s", row["vlmrange_name"], row["vlmrange_start_vlan_id"], row["vlmrange_end_vlan_id"])
+		}
+		return strings.Join(strings.Fields(strings.Join(result, " ")), " ")
+	}
+
+	// Applying a new configuration of the ranges
+	apply := func(d *schema.ResourceData, config map[string]interface{}) (*schema.ResourceData, bool) {
+		state := d.State()
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff.RequiresNew() {
+			t.Fatalf("unexpected replacement of the VLAN Domain: %v", diff.Attributes)
+		}
+
+		d, err = schema.InternalMap(r.Schema).Data(state, diff)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return d, resourcevlandomainUpdate(context.Background(), d, s).HasError()
+	}
+
+	config := func(bounds ...interface{}) map[string]interface{} {
+		items := []interface{}{}
+		for i := 0; i < len(bounds); i += 3 {
+			items = append(items, map[string]interface{}{"name": bounds[i], "start": bounds[i+1], "end": bounds[i+2]})
+		}
+		return map[string]interface{}{"name": "domain01", "ranges": items}
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config("a", 1, 10, "b", 11, 20))
+
+	if diags := resourcevlandomainCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if serverRanges() != "a:1-10 b:11-20 external:90-99" {
+		t.Fatalf("unexpected VLAN Ranges: %s", serverRanges())
+	}
+
+	// The boundary moves without overlapping in between
+	d, failed := apply(d, config("a", 1, 15, "b", 16, 20))
+
+	if failed || serverRanges() != "a:1-15 b:16-20 external:90-99" || m.count("/rest/vlm_domain_add") != 1 {
+		t.Fatalf("unexpected VLAN Ranges: %s", serverRanges())
+	}
+
+	// The swapped ranges are deleted and created again
+	d, failed = apply(d, config("a", 16, 20, "b", 1, 15))
+
+	if failed || serverRanges() != "b:1-15 a:16-20 external:90-99" {
+		t.Fatalf("unexpected VLAN Ranges: %s", serverRanges())
+	}
+
+	// The changes applied before a failure are kept in the state
+	failDelete = true
+	d, failed = apply(d, config("a", 16, 25, "b", 1, 15))
+
+	if failed {
+		t.Fatalf("unexpected failure of an edit")
+	}
+
+	d, failed = apply(d, config("a", 1, 15, "b", 16, 25))
+
+	if !failed || serverRanges() != "b:1-15 a:16-25 external:90-99" {
+		t.Fatalf("expected a failure, got VLAN Ranges: %s", serverRanges())
+	}
+
+	// Nothing changed, as the first range is deleted to be swapped
+	if !strings.Contains(fmt.Sprint(d.Get("ranges").(*schema.Set).List()), "end:25 name:a start:16") {
+		t.Errorf("unexpected state: %v", d.Get("ranges").(*schema.Set).List())
+	}
+
+	// Drifts of the tracked ranges are reported, the others ignored
+	failDelete = false
+	ranges["11"]["vlmrange_end_vlan_id"] = "12"
+
+	if diags := resourcevlandomainRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if fmt.Sprint(resourcevlandomainranges(d.Get("ranges").(*schema.Set))) != "map[a:[16 25] b:[1 12]]" {
+		t.Errorf("unexpected state: %v", d.Get("ranges").(*schema.Set).List())
+	}
+}
@@ -51,6 +51,21 @@ func resourcevlanrange() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"free_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of free VLANs within the VLAN Range.",
+				Computed:    true,
+			},
+			"used_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of used VLANs within the VLAN Range.",
+				Computed:    true,
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Description: "The total number of VLANs within the VLAN Range.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the VLAN Range.",
@@ -59,15 +74,25 @@ func resourcevlanrange() *schema.Resource {
 				Default:     "",
 			},
 			"class_parameters": {
-				Type:        schema.TypeMap,
-				Description: "The class parameters associated to VLAN Range.",
+				Type:             schema.TypeMap,
+				Description:      "The class parameters associated to VLAN Range.",
+				DiffSuppressFunc: resourcediffsuppressclassparams,
+				Optional:         true,
+				ForceNew:         false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "Keys or glob patterns (ex: audit_*) of the VLAN range's class parameters maintained by SOLIDserver. Matching keys are never sent nor reported as drift, even when listed in class_parameters (keys absent from class_parameters are always ignored).",
 				Optional:    true,
-				ForceNew:    false,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: resourcediffvalidateclass("vlm_range"),
 	}
 }
 
@@ -82,7 +107,7 @@ func resourcevlanrangeCreate(ctx context.Context, d *schema.ResourceData, meta i
 	parameters.Add("vlmrange_start_vlan_id", strconv.Itoa(d.Get("start").(int)))
 	parameters.Add("vlmrange_end_vlan_id", strconv.Itoa(d.Get("end").(int)))
 	parameters.Add("vlmrange_class_name", d.Get("class").(string))
-	parameters.Add("vlmrange_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("vlmrange_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/vlm_range_add", &parameters)
@@ -96,6 +121,9 @@ func resourcevlanrangeCreate(ctx context.Context, d *schema.ResourceData, meta i
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Created VLAN Range (oid): %s\n", oid))
 				d.SetId(oid)
+				d.Set("free_count", d.Get("end").(int)-d.Get("start").(int)+1)
+				d.Set("used_count", 0)
+				d.Set("total_count", d.Get("end").(int)-d.Get("start").(int)+1)
 				return nil
 			}
 		}
@@ -123,7 +151,7 @@ func resourcevlanrangeUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	parameters.Add("add_flag", "edit_only")
 	parameters.Add("vlmrange_name", d.Get("name").(string))
 	parameters.Add("vlmrange_class_name", d.Get("class").(string))
-	parameters.Add("vlmrange_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("vlmrange_class_parameters", urlfromclassparams(resourceclassparams(d)).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/vlm_range_add", &parameters)
@@ -221,12 +249,30 @@ func resourcevlanrangeRead(ctx context.Context, d *schema.ResourceData, meta int
 			d.Set("support_vxlan", vxlanSupport)
 			d.Set("class", buf[0]["vlmrange_class_name"].(string))
 
+			// Updating the utilization of the range
+			free, used, total, countErr := vlanrangecounts(d.Id(), meta)
+
+			if countErr != nil {
+				// Reporting a failure
+				return diag.Errorf("Unable to retrieve the utilization of VLAN Range: %s (%s)\n", d.Get("name").(string), countErr)
+			}
+
+			d.Set("free_count", free)
+			d.Set("used_count", used)
+			d.Set("total_count", total)
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["vlmrange_class_parameters"].(string))
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -285,12 +331,30 @@ func resourcevlanrangeImportState(ctx context.Context, d *schema.ResourceData, m
 			d.Set("support_vxlan", vxlanSupport)
 			d.Set("class", buf[0]["vlmrange_class_name"].(string))
 
+			// Updating the utilization of the range
+			free, used, total, countErr := vlanrangecounts(d.Id(), meta)
+
+			if countErr != nil {
+				// Reporting a failure
+				return nil, fmt.Errorf("SOLIDServer - Unable to retrieve the utilization of VLAN Range: %s (%s)\n", d.Get("name").(string), countErr)
+			}
+
+			d.Set("free_count", free)
+			d.Set("used_count", used)
+			d.Set("total_count", total)
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["vlmrange_class_parameters"].(string))
 			computedClassParameters := map[string]string{}
 
 			for ck := range currentClassParameters {
+				// Keeping the local value of the class parameters maintained by SOLIDserver
+				if classparamignored(d, ck) {
+					computedClassParameters[ck] = currentClassParameters[ck].(string)
+					continue
+				}
+
 				if rv, rvExist := retrievedClassParameters[ck]; rvExist {
 					computedClassParameters[ck] = rv[0]
 				} else {
@@ -0,0 +1,227 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestVlanReadAssociatedSubnets(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	subnetWhere := ""
+
+	m.handle("/rest/vlmvlan_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"vlmvlan_vlan_id":          "100",
+			"vlmvlan_name":             "vlan100",
+			"vlmdomain_name":           "domain01",
+			"vlmrange_name":            "#",
+			"vlmvlan_class_name":       "",
+			"vlmvlan_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		subnetWhere = r.URL.Query().Get("WHERE")
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"subnet_name": "subnet01"},
+			{"subnet_name": "subnet02"},
+		})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcevlan().Schema, map[string]interface{}{
+		"vlan_domain": "domain01",
+		"name":        "vlan100",
+	})
+	d.SetId("5")
+
+	if diags := resourcevlanRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if subnetWhere != "vlmdomain_name='domain01' AND vlmvlan_vlan_id='100'" {
+		t.Errorf("unexpected subnet filter: %s", subnetWhere)
+	}
+
+	if d.Get("space_count").(int) != 2 || len(d.Get("associated_subnets").([]interface{})) != 2 {
+		t.Errorf("unexpected associated subnets: %d %v", d.Get("space_count").(int), d.Get("associated_subnets"))
+	}
+}
+
+func TestVlanRangeReadCounts(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/vlmrange_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"vlmrange_id":               "3",
+			"vlmrange_name":             "range01",
+			"vlmrange_start_vlan_id":    "100",
+			"vlmrange_end_vlan_id":      "199",
+			"vlmrange_class_name":       "",
+			"vlmrange_class_parameters": "",
+		}})
+	})
+
+	m.handle("/rest/vlmvlan_count", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("WHERE"), "type='free'") {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "97"}})
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "100"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourcevlanrange().Schema, map[string]interface{}{
+		"vlan_domain": "domain01",
+		"name":        "range01",
+	})
+
+	if diags := dataSourcevlanrangeRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("free_count").(int) != 97 || d.Get("used_count").(int) != 3 || d.Get("total_count").(int) != 100 {
+		t.Errorf("unexpected counts: free %d, used %d, total %d", d.Get("free_count").(int), d.Get("used_count").(int), d.Get("total_count").(int))
+	}
+}
+
+func TestVlanDomainReadCounts(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	domain := map[string]interface{}{
+		"vlmdomain_id":               "4",
+		"vlmdomain_name":             "domain01",
+		"vlmdomain_start_vlan_id":    "1",
+		"vlmdomain_end_vlan_id":      "100",
+		"vlmdomain_class_name":       "",
+		"vlmdomain_class_parameters": "",
+	}
+
+	m.handle("/rest/vlmdomain_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{domain})
+	})
+
+	m.handle("/rest/vlmdomain_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{domain})
+	})
+
+	m.handle("/rest/vlmvlan_count", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("WHERE"), "vlmdomain_id='4'") {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+
+		if strings.Contains(r.URL.Query().Get("WHERE"), "type='free'") {
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "8"}})
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "92"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourcevlandomain().Schema, map[string]interface{}{
+		"name": "domain01",
+	})
+
+	if diags := dataSourcevlandomainRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("vlan_count").(int) != 92 || d.Get("free_count").(int) != 8 || d.Get("total_capacity").(int) != 100 {
+		t.Errorf("unexpected counts: used %d, free %d, capacity %d", d.Get("vlan_count").(int), d.Get("free_count").(int), d.Get("total_capacity").(int))
+	}
+
+	// The whole VLAN ID space is used when the domain doesn't report its range
+	delete(domain, "vlmdomain_start_vlan_id")
+	d = resourcevlandomain().TestResourceData()
+	d.SetId("4")
+
+	if diags := resourcevlandomainRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("vlan_count").(int) != 92 || d.Get("free_count").(int) != 8 || d.Get("total_capacity").(int) != 4094 {
+		t.Errorf("unexpected counts: used %d, free %d, capacity %d", d.Get("vlan_count").(int), d.Get("free_count").(int), d.Get("total_capacity").(int))
+	}
+}
+
+func TestVlanIDWindow(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	addedIDs := []string{}
+
+	// Free ranges of the domain, the window spanning the first two of them
+	m.handle("/rest/vlmvlan_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "vlmdomain_name='domain01' AND type='free'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"free_start_vlan_id": "90", "free_end_vlan_id": "103"},
+			{"free_start_vlan_id": "480", "free_end_vlan_id": "600"},
+			{"free_start_vlan_id": "1000", "free_end_vlan_id": "1999"},
+		})
+	})
+
+	// The IDs of the first free range are taken concurrently
+	m.handle("/rest/vlm_vlan_add", func(w http.ResponseWriter, r *http.Request) {
+		addedIDs = append(addedIDs, r.URL.Query().Get("vlmvlan_vlan_id"))
+		if r.URL.Query().Get("vlmvlan_vlan_id") != "480" {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "VLAN ID already used"}})
+			return
+		}
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "9"}})
+	})
+
+	m.handle("/rest/vlmvlan_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"vlmvlan_vlan_id": "480", "vlmvlan_name": "vlan480", "vlmrange_name": "servers"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcevlan().Schema, map[string]interface{}{
+		"vlan_domain": "domain01",
+		"name":        "vlan480",
+		"id_min":      100,
+		"id_max":      499,
+	})
+
+	if diags := resourcevlanCreate(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if strings.Join(addedIDs, ",") != "100,101,102,480" {
+		t.Errorf("unexpected candidate vlan IDs: %v", addedIDs)
+	}
+
+	if d.Id() != "9" || d.Get("vlan_id").(int) != 480 || d.Get("vlan_range").(string) != "servers" {
+		t.Errorf("unexpected vlan: %s %d %s", d.Id(), d.Get("vlan_id").(int), d.Get("vlan_range").(string))
+	}
+
+	// No free vlan ID within the window
+	if _, err := vlanidfindfree("domain01", 2000, 2999, s); err == nil || !strings.Contains(err.Error(), "between 2000 and 2999") {
+		t.Errorf("expected a no free vlan ID error, got: %v", err)
+	}
+
+	// Prior to SOLIDserver 7.0, the free vlan IDs are listed one by one
+	s.Version = 650
+	m.handle("/rest/vlmvlan_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "vlmdomain_name='domain01' AND row_enabled='2'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"vlmvlan_vlan_id": "50"},
+			{"vlmvlan_vlan_id": "150"},
+			{"vlmvlan_vlan_id": "499"},
+			{"vlmvlan_vlan_id": "500"},
+		})
+	})
+
+	vlanIDs, err := vlanidfindfree("domain01", 100, 499, s)
+	if err != nil || strings.Join(vlanIDs, ",") != "150,499" {
+		t.Errorf("unexpected candidate vlan IDs: %v (%v)", vlanIDs, err)
+	}
+}
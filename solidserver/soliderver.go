@@ -9,13 +9,16 @@ import (
 	"fmt"
 	"golang.org/x/crypto/sha3"
 	"io/ioutil"
+	"log"
 	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
-	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -35,6 +38,33 @@ var httpRequestMethods = map[string]HttpRequestFunc{
 const regexpIPPort = `^!?(([0-9]{1,3})\.){3}[0-9]{1,3}:[0-9]{1,5}$`
 const regexpHostname = `^(([a-z0-9]|[a-z0-9][a-z0-9\-]*[a-z0-9])\.)*([a-z0-9]|[a-z0-9][a-z0-9\-]*[a-z0-9])$`
 const regexpNetworkAcl = `^(([0-9]{1,3}\.){3}[0-9]{1,3}(\/([0-9]|[1-2][0-9]|3[0-2]))?)|((([0-9a-fA-F]{1,4}:){7,7}[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,7}:|([0-9a-fA-F]{1,4}:){1,6}:[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,5}(:[0-9a-fA-F]{1,4}){1,2}|([0-9a-fA-F]{1,4}:){1,4}(:[0-9a-fA-F]{1,4}){1,3}|([0-9a-fA-F]{1,4}:){1,3}(:[0-9a-fA-F]{1,4}){1,4}|([0-9a-fA-F]{1,4}:){1,2}(:[0-9a-fA-F]{1,4}){1,5}|[0-9a-fA-F]{1,4}:((:[0-9a-fA-F]{1,4}){1,6})|:((:[0-9a-fA-F]{1,4}){1,7}|:)|fe80:(:[0-9a-fA-F]{0,4}){0,4}%[0-9a-zA-Z]{1,}|::(ffff(:0{1,4}){0,1}:){0,1}((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])|([0-9a-fA-F]{1,4}:){1,4}:((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9]))(/(1[012][0-9]|[1-9][0-9]|[0-9]))?)$`
+const regexpBasePath = `^(/[^/?#\s]+)*$`
+
+// Maximum number of idle connections kept per appliance, above Terraform's default parallelism (10)
+const httpMaxIdleConnsPerHost = 16
+
+// Delay after which an idle connection to the appliance is closed
+const httpIdleConnTimeout = 90 * time.Second
+
+// Minimum SOLIDserver versions of the version dependent features
+const (
+	versionVXLAN             = 700
+	versionGSLB              = 710
+	versionRRClassParameters = 800
+	versionAPIKey            = 840
+	versionLock              = 820
+	versionGroupResources    = 800
+)
+
+// Number of attempts of the version detection and initial delay between them (doubled after each attempt)
+var versionDetectMaxTry = 4
+var versionDetectRetryDelay = 2 * time.Second
+
+// HTTP statistics gathered over the lifetime of the provider process
+var httpStats struct {
+	requests int64
+	duration int64
+}
 
 type SOLIDserver struct {
 	Ctx                      context.Context
@@ -42,29 +72,65 @@ type SOLIDserver struct {
 	UseToken                 bool
 	Username                 string
 	Password                 string
+	APIKeyID                 string
+	APIKeySecret             string
 	BaseUrl                  string
 	SSLVerify                bool
 	AdditionalTrustCertsFile string
 	Timeout                  int
 	Version                  int
+	VersionString            string
 	Authenticated            bool
 	ProxyURL                 string
+	TLSServerName            string
+	ResolveOverrides         map[string]string
+	Cache                    *LookupCache
+	DisablePlanValidation    bool
+	AddressNameTemplate      string
+	VerifyDNSConflicts       string
+	DefaultSpace             string
+	RRConflicts              *RRConflictBatcher
+	Stats                    *RequestStats
+	Client                   *http.Client
+	clientOnce               sync.Once
+	clientErr                error
 }
 
-func NewSOLIDserver(ctx context.Context, host string, use_token bool, username string, password string, sslverify bool, certsfile string, timeout int, version string, proxyURL string) (*SOLIDserver, diag.Diagnostics) {
+func NewSOLIDserver(ctx context.Context, host string, use_token bool, username string, password string, apiKeyID string, apiKeySecret string, sslverify bool, certsfile string, timeout int, version string, proxyURL string, tlsServerName string, resolveOverrides map[string]string, disableLookupCache bool, disablePlanValidation bool, statsFile string, addressNameTemplate string, verifyDNSConflicts string, defaultSpace string, port int, basePath string) (*SOLIDserver, diag.Diagnostics) {
+	hostName, baseUrl, baseUrlErr := solidserverbaseurl(host, port, basePath)
+
+	if baseUrlErr != nil {
+		return nil, diag.FromErr(baseUrlErr)
+	}
+
 	s := &SOLIDserver{
 		Ctx:                      ctx,
-		Host:                     host,
+		Host:                     hostName,
 		UseToken:                 use_token,
 		Username:                 username,
 		Password:                 password,
-		BaseUrl:                  "https://" + host,
+		APIKeyID:                 apiKeyID,
+		APIKeySecret:             apiKeySecret,
+		BaseUrl:                  baseUrl,
 		SSLVerify:                sslverify,
 		AdditionalTrustCertsFile: certsfile,
 		Timeout:                  timeout,
 		Version:                  0,
 		Authenticated:            false,
 		ProxyURL:                 proxyURL,
+		TLSServerName:            tlsServerName,
+		ResolveOverrides:         resolveOverrides,
+		Cache:                    NewLookupCache(disableLookupCache),
+		DisablePlanValidation:    disablePlanValidation,
+		AddressNameTemplate:      addressNameTemplate,
+		VerifyDNSConflicts:       verifyDNSConflicts,
+		DefaultSpace:             defaultSpace,
+		RRConflicts:              NewRRConflictBatcher(),
+		Stats:                    NewRequestStats(statsFile),
+	}
+
+	if _, err := s.httpclient(); err != nil {
+		return nil, diag.FromErr(err)
 	}
 
 	if err := s.GetVersion(version); err != nil {
@@ -74,29 +140,69 @@ func NewSOLIDserver(ctx context.Context, host string, use_token bool, username s
 	return s, nil
 }
 
-func GenerateSignature(url string, method string, secret string, ts int64) [32]byte {
-	s := fmt.Sprintf("%s\n%d\n%s\n%s", secret, ts, strings.ToUpper(method), url)
-	buf := []byte(s)
-	return sha3.Sum256(buf)
-}
+// Return the host name and the base URL of the REST and RPC services from the host, the port and the base path
+// The host can also be given as an URL (ex: https://host:port/prefix), its path wins over the base path argument
+// Its port, or the one given along with the host (ex: host:8443), must match the port argument unless left to its default (443)
+// Or an error in case of failure
+func solidserverbaseurl(host string, port int, basePath string) (string, string, error) {
+	hostPort := ""
 
-func SubmitRequest(s *SOLIDserver, apiclient *gorequest.SuperAgent, method string, service string, parameters string) (*http.Response, string, error) {
-	var resp *http.Response = nil
-	var body string = ""
-	var errs []error = nil
-	var requestUrl string = ""
+	if strings.Contains(host, "://") {
+		hostURL, err := url.Parse(host)
 
-	var httpRequestTimings = map[string]struct {
-		msSweep  int
-		sTimeout int
-		maxTry   int
-	}{
-		"post":   {msSweep: 16, sTimeout: s.Timeout, maxTry: 1},
-		"put":    {msSweep: 16, sTimeout: s.Timeout, maxTry: 1},
-		"delete": {msSweep: 16, sTimeout: s.Timeout, maxTry: 1},
-		"get":    {msSweep: 16, sTimeout: s.Timeout, maxTry: 6},
+		if err != nil || hostURL.Hostname() == "" || hostURL.User != nil || hostURL.RawQuery != "" || hostURL.Fragment != "" {
+			return "", "", fmt.Errorf("SOLIDServer - Invalid host URL: %s\n", host)
+		}
+
+		if hostURL.Scheme != "https" {
+			return "", "", fmt.Errorf("SOLIDServer - Unsupported scheme of host URL: %s (only https is supported)\n", host)
+		}
+
+		if path := strings.TrimSuffix(hostURL.Path, "/"); path != "" {
+			basePath = path
+		}
+
+		host, hostPort = hostURL.Hostname(), hostURL.Port()
+	} else if hostName, hostNamePort, err := net.SplitHostPort(host); err == nil {
+		// Host given along with its port (ex: host:8443)
+		host, hostPort = hostName, hostNamePort
+	}
+
+	// Bracketed IPv6 address given without port (ex: [2001:db8::1])
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+	if hostPort != "" {
+		hostPortNumber, _ := strconv.Atoi(hostPort)
+
+		if port != 443 && port != hostPortNumber {
+			return "", "", fmt.Errorf("SOLIDServer - Conflicting ports: %s given along with the host, %d given as port\n", hostPort, port)
+		}
+
+		port = hostPortNumber
+	}
+
+	if port < 1 || port > 65535 {
+		return "", "", fmt.Errorf("SOLIDServer - Invalid port: %d\n", port)
+	}
+
+	if match, _ := regexp.MatchString(regexpBasePath, basePath); !match {
+		return "", "", fmt.Errorf("SOLIDServer - Invalid base path: %s (it must start with a '/' and have no trailing slash, ex: /prod-ipam)\n", basePath)
+	}
+
+	if port != 443 {
+		return host, "https://" + net.JoinHostPort(host, strconv.Itoa(port)) + basePath, nil
 	}
 
+	if strings.Contains(host, ":") {
+		return host, "https://[" + host + "]" + basePath, nil
+	}
+
+	return host, "https://" + host + basePath, nil
+}
+
+// Build the HTTP client shared by all the requests sent to the SOLIDserver
+// Return an error in case of failure
+func newhttpclient(s *SOLIDserver) (*http.Client, error) {
 	// Get the SystemCertPool, continue with an empty pool on error
 	rootCAs, x509err := x509.SystemCertPool()
 
@@ -110,7 +216,7 @@ func SubmitRequest(s *SOLIDserver, apiclient *gorequest.SuperAgent, method strin
 
 		if readErr != nil {
 			tflog.Error(s.Ctx, fmt.Sprintf("Failed to append %q to RootCAs: %v\n", s.AdditionalTrustCertsFile, readErr))
-			os.Exit(1)
+			return nil, fmt.Errorf("SOLIDServer - Unable to read certificates file: %s (%s)\n", s.AdditionalTrustCertsFile, readErr)
 		}
 
 		tflog.Debug(s.Ctx, fmt.Sprintf("Cert Subjects Before Append = %d\n", len(rootCAs.Subjects())))
@@ -122,11 +228,146 @@ func SubmitRequest(s *SOLIDserver, apiclient *gorequest.SuperAgent, method strin
 		tflog.Debug(s.Ctx, fmt.Sprintf("Cert Subjects After Append = %d\n", len(rootCAs.Subjects())))
 	}
 
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		DialContext: dialer.DialContext,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: !s.SSLVerify,
+			RootCAs:            rootCAs,
+			ServerName:         s.TLSServerName,
+			ClientSessionCache: tls.NewLRUClientSessionCache(0),
+		},
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        httpMaxIdleConnsPerHost,
+		MaxIdleConnsPerHost: httpMaxIdleConnsPerHost,
+		IdleConnTimeout:     httpIdleConnTimeout,
+	}
+
+	// Only the dialed address is overridden, the URL thus the Host header and the TLS verification name are kept
+	if len(s.ResolveOverrides) > 0 {
+		transport.DialContext = func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, resolveoverride(s.ResolveOverrides, addr))
+		}
+	}
+
+	if s.ProxyURL != "" {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Using proxy URL: %q\n", s.ProxyURL))
+
+		proxyURL, proxyErr := url.Parse(s.ProxyURL)
+		if proxyErr != nil {
+			return nil, fmt.Errorf("SOLIDServer - Invalid proxy URL: %s (%s)\n", s.ProxyURL, proxyErr)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(s.Timeout) * time.Second,
+	}, nil
+}
+
+// Return the address (host:port) to dial once the resolution overrides (host => IP) are applied
+func resolveoverride(overrides map[string]string, addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+
+	if err != nil {
+		return addr
+	}
+
+	for name, ip := range overrides {
+		if strings.EqualFold(name, host) {
+			return net.JoinHostPort(ip, port)
+		}
+	}
+
+	return addr
+}
+
+// Return the HTTP client of the SOLIDserver, building it on first use
+func (s *SOLIDserver) httpclient() (*http.Client, error) {
+	s.clientOnce.Do(func() {
+		if s.Client == nil {
+			s.Client, s.clientErr = newhttpclient(s)
+		}
+	})
+
+	return s.Client, s.clientErr
+}
+
+// Log the HTTP statistics gathered over the lifetime of the provider process
+func LogHTTPStats() {
+	requests := atomic.LoadInt64(&httpStats.requests)
+	duration := time.Duration(atomic.LoadInt64(&httpStats.duration))
+
+	log.Printf("[DEBUG] SOLIDserver HTTP statistics: %d request(s), %s spent in HTTP\n", requests, duration)
+}
+
+func GenerateSignature(url string, method string, secret string, ts int64) [32]byte {
+	s := fmt.Sprintf("%s\n%d\n%s\n%s", secret, ts, strings.ToUpper(method), url)
+	buf := []byte(s)
+	return sha3.Sum256(buf)
+}
+
+// Return the authentication headers of a request, based on the API key, the token or the username and password
+func requestauthheaders(s *SOLIDserver, method string, requestUrl string) map[string]string {
+	if s.APIKeyID != "" {
+		return map[string]string{
+			"X-IPM-Username": base64.StdEncoding.EncodeToString([]byte(s.APIKeyID)),
+			"X-IPM-Key":      base64.StdEncoding.EncodeToString([]byte(s.APIKeySecret)),
+		}
+	}
+
+	if s.UseToken == true {
+		timestamp := time.Now().Unix()
+		signature := GenerateSignature(requestUrl, method, s.Password, timestamp)
+
+		return map[string]string{
+			"X-SDS-TS":      fmt.Sprintf("%d", timestamp),
+			"Authorization": fmt.Sprintf("SDS %s:%x", s.Username, signature),
+		}
+	}
+
+	return map[string]string{
+		"X-IPM-Username": base64.StdEncoding.EncodeToString([]byte(s.Username)),
+		"X-IPM-Password": base64.StdEncoding.EncodeToString([]byte(s.Password)),
+	}
+}
+
+func SubmitRequest(s *SOLIDserver, apiclient *gorequest.SuperAgent, method string, service string, parameters string) (*http.Response, string, error) {
+	var resp *http.Response = nil
+	var body string = ""
+	var errs []error = nil
+	var requestUrl string = ""
+
+	var httpRequestTimings = map[string]struct {
+		msSweep  int
+		sTimeout int
+		maxTry   int
+	}{
+		"post":   {msSweep: 16, sTimeout: s.Timeout, maxTry: 1},
+		"put":    {msSweep: 16, sTimeout: s.Timeout, maxTry: 1},
+		"delete": {msSweep: 16, sTimeout: s.Timeout, maxTry: 1},
+		"get":    {msSweep: 16, sTimeout: s.Timeout, maxTry: 6},
+	}
+
 	t := httpRequestTimings[method]
 
 	tflog.Debug(s.Ctx, fmt.Sprintf("Timings for method '%s' : {%v}\n", method, t))
 
-	apiclient.Timeout(time.Duration(t.sTimeout) * time.Second)
+	client, clientErr := s.httpclient()
+	if clientErr != nil {
+		return nil, "", clientErr
+	}
+
+	// Sharing the transport (connection pool, TLS sessions) of the provider client
+	requestClient := *client
+	requestClient.Timeout = time.Duration(t.sTimeout) * time.Second
+	apiclient.Client = &requestClient
+	apiclient.Transport = client.Transport.(*http.Transport)
 
 	retryCount := 0
 
@@ -143,22 +384,14 @@ KeepTrying:
 		time.Sleep(time.Duration(rand.Intn(t.msSweep)) * time.Millisecond)
 
 		requestUrl = fmt.Sprintf("%s/%s?%s", s.BaseUrl, service, parameters)
-		if s.UseToken == true {
-			timestamp := time.Now().Unix()
-			signature := GenerateSignature(requestUrl, method, s.Password, timestamp)
-			resp, body, errs = httpFunc(apiclient, requestUrl).
-				TLSClientConfig(&tls.Config{InsecureSkipVerify: !s.SSLVerify, RootCAs: rootCAs}).
-				Set("X-SDS-TS", fmt.Sprintf("%d", timestamp)).
-				Set("Authorization", fmt.Sprintf("SDS %s:%x", s.Username, signature)).
-				End()
-
-		} else {
-			resp, body, errs = httpFunc(apiclient, requestUrl).
-				TLSClientConfig(&tls.Config{InsecureSkipVerify: !s.SSLVerify, RootCAs: rootCAs}).
-				Set("X-IPM-Username", base64.StdEncoding.EncodeToString([]byte(s.Username))).
-				Set("X-IPM-Password", base64.StdEncoding.EncodeToString([]byte(s.Password))).
-				End()
+		request := httpFunc(apiclient, requestUrl)
+
+		for header, value := range requestauthheaders(s, method, requestUrl) {
+			request = request.Set(header, value)
 		}
+
+		resp, body, errs = request.End()
+
 		if errs == nil {
 			return resp, body, nil
 		}
@@ -168,6 +401,7 @@ KeepTrying:
 		for _, err := range errs {
 			if err, ok := err.(net.Error); ok && err.Timeout() {
 				tflog.Debug(s.Ctx, fmt.Sprintf("Timeout Retry (%d/%d)\n", retryCount+1, t.maxTry))
+				s.Stats.Retry(service)
 				retryCount++
 				continue KeepTrying
 			}
@@ -179,68 +413,130 @@ KeepTrying:
 	return nil, "", fmt.Errorf("Error '%s' API request '%s' : timeout retry count exceeded (maxTry = %d) !\n", method, requestUrl, t.maxTry)
 }
 
-func (s *SOLIDserver) GetVersion(version string) diag.Diagnostics {
+// Compute the version number of a SOLIDserver from its version string (ex: 8.3.1 => 831)
+// The numeric form (ex: 831) is returned as is, suffixes (ex: 8.3.1-p1) are ignored
+// Return 0 in case of failure
+func parseversion(version string) int {
+	parts := strings.Split(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v"), ".")
 
-	apiclient := gorequest.New()
-	apiclient.Proxy(s.ProxyURL)
+	if len(parts) == 1 {
+		if num, numErr := strconv.Atoi(parts[0]); numErr == nil && num >= 100 {
+			return num
+		}
+	}
+
+	res := 0
+
+	for i := 0; i < 3; i++ {
+		num := 0
+
+		if i < len(parts) {
+			digits := 0
+			for digits < len(parts[i]) && '0' <= parts[i][digits] && parts[i][digits] <= '9' {
+				digits++
+			}
+
+			if digits == 0 && i == 0 {
+				return 0
+			}
+
+			num, _ = strconv.Atoi(parts[i][:digits])
+		}
+
+		res = res*10 + num
+	}
+
+	return res
+}
+
+// Return the version string reported by a SOLIDserver, either as a string or as a number
+func versionstring(version interface{}) string {
+	switch v := version.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return ""
+}
+
+func (s *SOLIDserver) GetVersion(version string) diag.Diagnostics {
+	var resp *http.Response = nil
+	var err error = nil
 
 	parameters := url.Values{}
 	parameters.Add("WHERE", "member_is_me='1'")
 
-	resp, body, err := SubmitRequest(s, apiclient, "get", "rest/member_list", parameters.Encode())
+	delay := versionDetectRetryDelay
 
-	if err == nil && resp.StatusCode == 200 {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	for try := 1; try <= versionDetectMaxTry; try++ {
+		if try > 1 {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Retrying SOLIDserver version detection in %s (%d/%d)\n", delay, try, versionDetectMaxTry))
+			time.Sleep(delay)
+			delay *= 2
+		}
 
-		if rversion, rversionExist := buf[0]["member_version"].(string); rversionExist {
-			//tflog.Debug(s.Ctx, fmt.Sprintf("Version: %s\n", rversion))
-			StrVersion := strings.Split(rversion, ".")
+		var body string
+		resp, body, err = SubmitRequest(s, gorequest.New(), "get", "rest/member_list", parameters.Encode())
 
-			// Computing version number
-			for i := 0; i < len(StrVersion) && i < 3; i++ {
-				num, numErr := strconv.Atoi(StrVersion[i])
-				if numErr == nil {
-					s.Version = s.Version*10 + num
-				} else {
-					s.Version = s.Version*10 + 0
-				}
-			}
+		if err == nil && resp.StatusCode == 200 {
+			var buf [](map[string]interface{})
+			json.Unmarshal([]byte(body), &buf)
+
+			if len(buf) > 0 {
+				rversion := versionstring(buf[0]["member_version"])
+
+				if num := parseversion(rversion); num > 0 {
+					s.Version = num
+					s.VersionString = rversion
 
-			// Handling new branch version
-			if s.Version < 100 {
-				s.Version = s.Version * 10
+					tflog.Debug(s.Ctx, fmt.Sprintf("SOLIDserver version retrieved from remote SOLIDserver: %d\n", s.Version))
+
+					return nil
+				}
 			}
 
-			tflog.Debug(s.Ctx, fmt.Sprintf("SOLIDserver version retrieved from remote SOLIDserver: %d\n", s.Version))
+			// The appliance may answer without any usable version while busy (ex: maintenance mode)
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to parse SOLIDserver version from answer: %s\n", body))
+			continue
+		}
 
-			return nil
+		// Client errors are not transient
+		if err == nil && (400 <= resp.StatusCode && resp.StatusCode < 500) {
+			break
 		}
 	}
 
-	if err == nil && (400 <= resp.StatusCode && resp.StatusCode < 500) {
-		if resp.StatusCode == 412 {
-			return diag.Errorf("Error retrieving SOLIDserver Version (Possible time drift). Consider investigating time drift issue.\n")
-		}
+	if err == nil && resp.StatusCode == 412 {
+		return diag.Errorf("Error retrieving SOLIDserver Version (Possible time drift). Consider investigating time drift issue.\n")
+	}
 
-		if version != "" {
-			StrVersion := strings.Split(version, ".")
+	// Appliances older than the API keys support reject the key headers
+	if err == nil && (resp.StatusCode == 401 || resp.StatusCode == 403) && s.APIKeyID != "" {
+		return diag.Errorf("Error retrieving SOLIDserver Version (API key rejected). Consider checking the API key or using username/password, API keys require SOLIDserver version %d or later.\n", versionAPIKey)
+	}
 
-			for i := 0; i < len(StrVersion) && i < 3; i++ {
-				num, numErr := strconv.Atoi(StrVersion[i])
-				if numErr == nil {
-					s.Version = s.Version*10 + num
-				} else {
-					s.Version = s.Version*10 + 0
-				}
-			}
-			tflog.Debug(s.Ctx, fmt.Sprintf("Error retrieving SOLIDserver Version (Insufficient Permissions)."))
-			tflog.Debug(s.Ctx, fmt.Sprintf("SOLIDserver version retrived from local provider parameter: %d\n", s.Version))
+	// Falling back to the version provided in the provider configuration
+	if version != "" {
+		if s.Version = parseversion(version); s.Version == 0 {
+			return diag.Errorf("Error retrieving SOLIDserver Version, unable to parse the provided version: %s\n", version)
+		}
+
+		s.VersionString = version
 
-			return nil
-		} else {
-			return diag.Errorf("Error retrieving SOLIDserver Version (Insufficient Permissions). Consider setting the SOLIDserver's version using the provider options.\n")
+		if s.APIKeyID != "" && s.Version < versionAPIKey {
+			return diag.Errorf("API keys are not supported in this SOLIDserver version %d, consider using username/password\n", s.Version)
 		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Error retrieving SOLIDserver Version."))
+		tflog.Debug(s.Ctx, fmt.Sprintf("SOLIDserver version retrived from local provider parameter: %d\n", s.Version))
+
+		return nil
+	}
+
+	if err == nil && (400 <= resp.StatusCode && resp.StatusCode < 500) {
+		return diag.Errorf("Error retrieving SOLIDserver Version (Insufficient Permissions). Consider setting the SOLIDserver's version using the provider options.\n")
 	}
 
 	if err != nil {
@@ -256,11 +552,6 @@ func (s *SOLIDserver) Request(method string, service string, parameters *url.Val
 	var err error = nil
 
 	apiclient := gorequest.New()
-	apiclient.Proxy(s.ProxyURL)
-
-	if s.ProxyURL != "" {
-		tflog.Debug(s.Ctx, fmt.Sprintf("Using proxy URL: %q\n", s.ProxyURL))
-	}
 
 	if s.Authenticated == false {
 		apiclient.Retry(3, time.Duration(rand.Intn(15)+1)*time.Second, http.StatusTooManyRequests, http.StatusInternalServerError)
@@ -268,7 +559,18 @@ func (s *SOLIDserver) Request(method string, service string, parameters *url.Val
 		apiclient.Retry(3, time.Duration(rand.Intn(15)+1)*time.Second, http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusUnauthorized)
 	}
 
+	start := time.Now()
 	resp, body, err = SubmitRequest(s, apiclient, method, service, parameters.Encode())
+	atomic.AddInt64(&httpStats.requests, 1)
+	atomic.AddInt64(&httpStats.duration, int64(time.Since(start)))
+
+	if s.Stats != nil {
+		retries := 0
+		if resp != nil {
+			retries, _ = strconv.Atoi(resp.Header.Get("Retry-Count"))
+		}
+		s.Stats.Record(service, time.Since(start), retries, err != nil || resp.StatusCode >= 400)
+	}
 
 	if err != nil {
 		return nil, "", fmt.Errorf("SOLIDServer - Error initiating API call (%q)\n", err)
@@ -0,0 +1,137 @@
+package solidserver
+
+import (
+	"strings"
+	"sync"
+)
+
+// Lookup kinds used as cache namespaces, one per object type resolved by name
+const (
+	cacheKindIPSpace    = "ip_space"
+	cacheKindIPSubnet   = "ip_subnet"
+	cacheKindIPPool     = "ip_pool"
+	cacheKindIP6Subnet  = "ip6_subnet"
+	cacheKindIP6Pool    = "ip6_pool"
+	cacheKindDevice     = "device"
+	cacheKindVlanDomain = "vlan_domain"
+	cacheKindVlan       = "vlan"
+	cacheKindCdb        = "cdb"
+	cacheKindClass      = "class"
+
+	cacheKindDNSServerParam = "dns_server_param"
+)
+
+// LookupCache is a read-through cache of name to ID (or info) lookups
+// Entries are valid for the lifetime of the provider instance and dropped
+// whenever the provider itself creates, renames or deletes an object of the same kind
+type LookupCache struct {
+	mutex    sync.RWMutex
+	disabled bool
+	entries  map[string]map[string]interface{}
+}
+
+func NewLookupCache(disabled bool) *LookupCache {
+	return &LookupCache{
+		disabled: disabled,
+		entries:  make(map[string]map[string]interface{}),
+	}
+}
+
+// Build the key of a cache entry from its lookup parameters
+// Only the key is case-insensitive, the lookups send the parameters as given
+func lookupcachekey(parts ...string) string {
+	return strings.ToLower(strings.Join(parts, "\x00"))
+}
+
+// Return a cached lookup result and true if found
+// Or nil and false if absent or if the cache is disabled
+func (c *LookupCache) Get(kind string, parts ...string) (interface{}, bool) {
+	if c == nil || c.disabled {
+		return nil, false
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if entries, entriesExist := c.entries[kind]; entriesExist {
+		if value, valueExist := entries[lookupcachekey(parts...)]; valueExist {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Store a successful lookup result
+func (c *LookupCache) Set(kind string, value interface{}, parts ...string) {
+	if c == nil || c.disabled {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, entriesExist := c.entries[kind]; !entriesExist {
+		c.entries[kind] = make(map[string]interface{})
+	}
+
+	c.entries[kind][lookupcachekey(parts...)] = value
+}
+
+// Drop every cached lookup result of the given kinds
+func (c *LookupCache) Invalidate(kinds ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, kind := range kinds {
+		delete(c.entries, kind)
+	}
+}
+
+// Return a cached string lookup result (ID) and true if found
+func lookupcachegetstring(meta interface{}, kind string, parts ...string) (string, bool) {
+	if value, valueExist := meta.(*SOLIDserver).Cache.Get(kind, parts...); valueExist {
+		return value.(string), true
+	}
+
+	return "", false
+}
+
+// Return a shallow copy of a map lookup result
+func lookupcachecopymap(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+
+	for k, v := range in {
+		out[k] = v
+	}
+
+	return out
+}
+
+// Return a copy of a cached map lookup result (info) and true if found
+func lookupcachegetmap(meta interface{}, kind string, parts ...string) (map[string]interface{}, bool) {
+	if value, valueExist := meta.(*SOLIDserver).Cache.Get(kind, parts...); valueExist {
+		return lookupcachecopymap(value.(map[string]interface{})), true
+	}
+
+	return nil, false
+}
+
+// Store a copy of a map lookup result (info)
+func lookupcachesetmap(meta interface{}, kind string, value map[string]interface{}, parts ...string) {
+	meta.(*SOLIDserver).Cache.Set(kind, lookupcachecopymap(value), parts...)
+}
+
+// Store a string lookup result (ID)
+func lookupcachesetstring(meta interface{}, kind string, value string, parts ...string) {
+	meta.(*SOLIDserver).Cache.Set(kind, value, parts...)
+}
+
+// Invalidate cached lookups of the given kinds following a change made by the provider
+func lookupcacheinvalidate(meta interface{}, kinds ...string) {
+	meta.(*SOLIDserver).Cache.Invalidate(kinds...)
+}
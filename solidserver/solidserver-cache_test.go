@@ -0,0 +1,146 @@
+package solidserver
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLookupCacheDisabled(t *testing.T) {
+	c := NewLookupCache(true)
+
+	c.Set(cacheKindIPSpace, "2", "space01")
+
+	if _, found := c.Get(cacheKindIPSpace, "space01"); found {
+		t.Errorf("disabled cache returned an entry")
+	}
+}
+
+func TestLookupCacheInvalidate(t *testing.T) {
+	c := NewLookupCache(false)
+
+	c.Set(cacheKindIPSpace, "2", "space01")
+	c.Set(cacheKindIPSubnet, "12", "2", "subnet01", "true")
+
+	if v, found := c.Get(cacheKindIPSpace, "SPACE01"); !found || v.(string) != "2" {
+		t.Errorf("expected cached space ID 2, got %v (found: %t)", v, found)
+	}
+
+	c.Invalidate(cacheKindIPSubnet)
+
+	if _, found := c.Get(cacheKindIPSubnet, "2", "subnet01", "true"); found {
+		t.Errorf("invalidated subnet entry still cached")
+	}
+
+	if _, found := c.Get(cacheKindIPSpace, "space01"); !found {
+		t.Errorf("space entry dropped by subnet invalidation")
+	}
+}
+
+func TestLookupCacheConcurrency(t *testing.T) {
+	c := NewLookupCache(false)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Set(cacheKindIPSpace, "2", "space01")
+			c.Get(cacheKindIPSpace, "space01")
+			c.Invalidate(cacheKindIPSpace)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestLookupCacheReadThrough(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "space01"}})
+	})
+
+	for i := 0; i < 3; i++ {
+		if siteID, err := ipsiteidbyname("space01", s); err != nil || siteID != "2" {
+			t.Fatalf("unexpected lookup result: %q (%v)", siteID, err)
+		}
+	}
+
+	if m.count("/rest/ip_site_list") != 1 {
+		t.Errorf("expected 1 API call, got %d", m.count("/rest/ip_site_list"))
+	}
+}
+
+func TestLookupCacheInvalidationOnCreate(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	subnets := []map[string]interface{}{}
+	mutex := sync.Mutex{}
+
+	m.handle("/rest/ip_block_subnet_list", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		res := []map[string]interface{}{}
+		for _, subnet := range subnets {
+			if strings.Contains(r.URL.Query().Get("WHERE"), "subnet_name='"+subnet["subnet_name"].(string)+"'") {
+				res = append(res, subnet)
+			}
+		}
+
+		mockReply(w, http.StatusOK, res)
+	})
+
+	m.handle("/rest/ip_subnet_add", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		subnets = append(subnets, map[string]interface{}{
+			"subnet_id":     "12",
+			"subnet_name":   r.URL.Query().Get("subnet_name"),
+			"subnet_size":   "256",
+			"start_ip_addr": "0a000000",
+			"end_ip_addr":   "0a0000ff",
+			"is_terminal":   "1",
+			"subnet_level":  "1",
+		})
+
+		mockReply(w, http.StatusCreated, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	// A first subnet lookup (before creation) must fail and not be cached
+	if info, _ := ipsubnetinfobyname("2", "subnet01", true, s); info != nil {
+		t.Fatalf("unexpected subnet found before creation: %v", info)
+	}
+
+	// Subnet created by the provider, then looked up twice
+	parameters := url.Values{}
+	parameters.Add("subnet_name", "subnet01")
+
+	if _, _, err := s.Request("post", "rest/ip_subnet_add", &parameters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lookupcacheinvalidate(s, cacheKindIPSubnet)
+
+	for i := 0; i < 2; i++ {
+		info, err := ipsubnetinfobyname("2", "subnet01", true, s)
+
+		if err != nil || info == nil || info["id"].(string) != "12" {
+			t.Fatalf("expected subnet 12 after creation, got %v (%v)", info, err)
+		}
+	}
+
+	if m.count("/rest/ip_block_subnet_list") != 2 {
+		t.Errorf("expected 2 API calls, got %d", m.count("/rest/ip_block_subnet_list"))
+	}
+
+	// The cached entry must be dropped once a subnet is created or renamed by the provider
+	lookupcacheinvalidate(s, cacheKindIPSubnet)
+	ipsubnetinfobyname("2", "subnet01", true, s)
+
+	if m.count("/rest/ip_block_subnet_list") != 3 {
+		t.Errorf("expected 3 API calls after invalidation, got %d", m.count("/rest/ip_block_subnet_list"))
+	}
+}
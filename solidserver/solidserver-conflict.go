@@ -0,0 +1,156 @@
+package solidserver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Modes of the plan time verification of the RR conflicting with the RR to create
+const (
+	conflictModeDisabled = "disabled"
+	conflictModeWarning  = "warning"
+	conflictModeError    = "error"
+)
+
+// Delay during which the conflict checks of a DNS server are gathered into a single request
+var rrConflictBatchDelay = 100 * time.Millisecond
+
+// Maximum number of RR names looked up within a single request
+const rrConflictBatchSize = 50
+
+// Batch of RR names looked up together on a DNS server
+type rrConflictBatch struct {
+	done    chan struct{}
+	names   map[string]string
+	records []map[string]interface{}
+	err     error
+}
+
+// RRConflictBatcher gathers the concurrent lookups of existing RR, issued while planning
+// the RR to create, into a single request per DNS server
+// The names looked up are remembered, the diff of a RR being computed more than once per plan
+type RRConflictBatcher struct {
+	mutex    sync.Mutex
+	pending  map[string]*rrConflictBatch
+	resolved map[string]*rrConflictBatch
+}
+
+func NewRRConflictBatcher() *RRConflictBatcher {
+	return &RRConflictBatcher{
+		pending:  make(map[string]*rrConflictBatch),
+		resolved: make(map[string]*rrConflictBatch),
+	}
+}
+
+// Return the RR of a DNS server (and view) having the given name and type, whatever their value
+// Or an error in case of failure
+func (b *RRConflictBatcher) Lookup(serverName string, viewName string, rrName string, rrType string, meta interface{}) ([]map[string]interface{}, error) {
+	serverKey := strings.ToLower(serverName)
+	nameKey := lookupcachekey(serverName, rrName)
+
+	b.mutex.Lock()
+
+	batch, batchExist := b.resolved[nameKey]
+
+	if !batchExist {
+		batch, batchExist = b.pending[serverKey]
+	}
+
+	if !batchExist {
+		batch = &rrConflictBatch{done: make(chan struct{}), names: map[string]string{}}
+		b.pending[serverKey] = batch
+
+		// The first lookup of a server waits for the others before sending the request
+		go func() {
+			time.Sleep(rrConflictBatchDelay)
+
+			b.mutex.Lock()
+			delete(b.pending, serverKey)
+			b.mutex.Unlock()
+
+			batch.records, batch.err = rrconflictlist(serverName, batch.names, meta)
+
+			if batch.err == nil {
+				b.mutex.Lock()
+				for name := range batch.names {
+					b.resolved[lookupcachekey(serverName, name)] = batch
+				}
+				b.mutex.Unlock()
+			}
+
+			close(batch.done)
+		}()
+	}
+
+	// Names are looked up as given, only their key is case-insensitive
+	batch.names[strings.ToLower(rrName)] = rrName
+
+	b.mutex.Unlock()
+
+	<-batch.done
+
+	if batch.err != nil {
+		return nil, batch.err
+	}
+
+	if viewName == "" {
+		viewName = "#"
+	}
+
+	result := []map[string]interface{}{}
+
+	for _, record := range batch.records {
+		if strings.EqualFold(rrnameunescape(infostring(record, "rr_full_name")), rrName) &&
+			strings.EqualFold(infostring(record, "rr_type"), rrType) &&
+			strings.EqualFold(infostring(record, "dnsview_name"), viewName) {
+			result = append(result, record)
+		}
+	}
+
+	return result, nil
+}
+
+// Return the RR of a DNS server having one of the given names
+// Or an error in case of failure
+func rrconflictlist(serverName string, names map[string]string, meta interface{}) ([]map[string]interface{}, error) {
+	result := []map[string]interface{}{}
+	conditions := make([]string, 0, len(names))
+
+	for _, name := range names {
+		conditions = append(conditions, rrnamewhere(name))
+	}
+
+	sort.Strings(conditions)
+
+	for start := 0; start < len(conditions); start += rrConflictBatchSize {
+		end := start + rrConflictBatchSize
+
+		if end > len(conditions) {
+			end = len(conditions)
+		}
+
+		records, err := listall("rest/dns_rr_list", "dns_name='"+whereescape(serverName)+"' AND ("+strings.Join(conditions[start:end], " OR ")+")", meta)
+
+		if err != nil {
+			return nil, fmt.Errorf("SOLIDServer - Unable to list the RR of DNS server: %s (%s)\n", serverName, err)
+		}
+
+		result = append(result, records...)
+	}
+
+	return result, nil
+}
+
+// Return a description of conflicting RR listing their values along with their zone and view
+func rrconflictdescription(records []map[string]interface{}) string {
+	descriptions := make([]string, 0, len(records))
+
+	for _, record := range records {
+		descriptions = append(descriptions, fmt.Sprintf("'%s' (zone: %s, view: %s)", infostring(record, "value1"), infostring(record, "dnszone_name"), infostring(record, "dnsview_name")))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
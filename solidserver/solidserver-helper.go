@@ -1,16 +1,22 @@
 package solidserver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"inet.af/netaddr"
+	"math"
 	"math/big"
+	"net/netip"
 	"net/url"
+	"path"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Integer Absolute value
@@ -58,6 +64,14 @@ func toStringArrayInterface(in []string) []interface{} {
 	return out
 }
 
+// Return the string value of a field from an object's information
+// Or an empty string if the field is missing or not a string (ex: not returned by this SOLIDserver version)
+func infostring(info map[string]interface{}, field string) string {
+	value, _ := info[field].(string)
+
+	return value
+}
+
 // Consistent merge of TypeList elements, maintaining entries position within the list
 // Workaround to TF Plugin SDK issue https://github.com/hashicorp/terraform-plugin-sdk/issues/477
 func typeListConsistentMerge(old []string, new []string) []interface{} {
@@ -124,32 +138,97 @@ func hexiptoip(hexip string) string {
 // Convert IP v4 address string into PTR record name
 // Return an empty string in case of failure
 func iptoptr(ip string) string {
-	a, b, c, d := 0, 0, 0, 0
+	addr, err := netaddr.ParseIP(ip)
 
-	count, _ := fmt.Sscanf(ip, "%03d.%03d.%03d.%03d", &a, &b, &c, &d)
-
-	if count == 4 {
-		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", d, c, b, a)
+	if err == nil && addr.Is4() {
+		a := addr.As4()
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", a[3], a[2], a[1], a[0])
 	}
 
 	return ""
 }
 
-// Convert IPv6 address string into PTR record name
+// Convert IPv6 address string (expanded or compressed) into PTR record name
 // Return an empty string in case of failure
 func ip6toptr(ip string) string {
-	buffer := strings.Split(ip, ":")
+	addr, err := netaddr.ParseIP(ip)
+
+	if err != nil || !addr.Is6() {
+		return ""
+	}
+
+	a := addr.As16()
 	res := ""
 
-	for i := len(buffer) - 1; i >= 0; i-- {
-		for j := len(buffer[i]) - 1; j >= 0; j-- {
-			res += string(buffer[i][j]) + "."
-		}
+	for i := len(a) - 1; i >= 0; i-- {
+		res += fmt.Sprintf("%x.%x.", a[i]&0x0f, a[i]>>4)
 	}
 
 	return res + "ip6.arpa"
 }
 
+// Convert an IPv4 or IPv6 address string into PTR record name
+// Return an empty string in case of failure
+func rrptrname(ip string) string {
+	if strings.Contains(ip, ":") {
+		return ip6toptr(ip)
+	}
+	return iptoptr(ip)
+}
+
+// Convert a RR name into the FQDN stored by SOLIDserver, the apex of the zone can be written "@"
+// Return an error when the apex is used without zone
+func rrnamenormalize(name string, zoneName string) (string, error) {
+	if name != "@" {
+		return rrnameunescape(name), nil
+	}
+
+	if zoneName == "" {
+		return "", fmt.Errorf("SOLIDServer - The apex RR name '@' requires the dnszone to be set\n")
+	}
+
+	return strings.ToLower(zoneName), nil
+}
+
+// Convert a RR name returned by SOLIDserver into its plain form, some appliances escape the wildcard label (ex: \*.example.com)
+func rrnameunescape(name string) string {
+	return strings.ReplaceAll(name, `\*`, "*")
+}
+
+// Return the canonical form of a DNS name returned by SOLIDserver (lower-cased, without trailing dot nor escaped wildcard label)
+// The case and the trailing dot of the names differ across SOLIDserver versions
+func dnsnamecanonical(name string) string {
+	return strings.TrimSuffix(strings.ToLower(rrnameunescape(name)), ".")
+}
+
+// Return the WHERE condition matching a RR name, including the escaped form of wildcard names
+func rrnamewhere(name string) string {
+	if !strings.Contains(name, "*") {
+		return "rr_full_name='" + whereescape(name) + "'"
+	}
+
+	return "(rr_full_name='" + whereescape(name) + "' OR rr_full_name='" + whereescape(strings.ReplaceAll(name, "*", `\*`)) + "')"
+}
+
+// Check that the wildcard label of a RR name is its leftmost label and the RR type supports it
+// Return an error otherwise
+func rrnamewildcardcheck(name string, rrType string) error {
+	if !strings.Contains(name, "*") {
+		return nil
+	}
+
+	if name != "*" && (!strings.HasPrefix(name, "*.") || strings.Contains(name[1:], "*")) {
+		return fmt.Errorf("SOLIDServer - Wildcard RR name '%s' must only use '*' as its leftmost label (ex: *.example.com)\n", name)
+	}
+
+	switch strings.ToUpper(rrType) {
+	case "NS", "DNAME", "PTR":
+		return fmt.Errorf("SOLIDServer - Wildcard RR name '%s' is not supported for RR of type %s\n", name, strings.ToUpper(rrType))
+	}
+
+	return nil
+}
+
 // Convert hexa IPv6 address string into standard IPv6 address string
 // Return an empty string in case of failure
 func hexip6toip6(hexip string) string {
@@ -277,6 +356,74 @@ func resourcediffsuppresscase(k, old, new string, d *schema.ResourceData) bool {
 	return false
 }
 
+// Convert a MAC address into its canonical lowercase and colon separated form
+// Return an empty string for the placeholder MAC addresses of the appliance (ex: EIP:...)
+func macnormalize(mac string) string {
+	mac = strings.TrimSpace(mac)
+
+	if strings.HasPrefix(strings.ToUpper(mac), "EIP:") {
+		return ""
+	}
+
+	return strings.ToLower(strings.ReplaceAll(mac, "-", ":"))
+}
+
+// Placeholders supported by the IP address name template
+var addressNameTemplatePlaceholders = []string{"{address}", "{subnet}", "{space}"}
+
+// Check that an IP address name template only holds supported placeholders
+// Return an error otherwise
+func addressnametemplatecheck(template string) error {
+	for _, placeholder := range regexp.MustCompile(`\{[^{}]*\}`).FindAllString(template, -1) {
+		if stringOffsetInSlice(placeholder, addressNameTemplatePlaceholders) == -1 {
+			return fmt.Errorf("SOLIDServer - Unknown placeholder %s in address name template '%s' (Supported: %s)\n", placeholder, template, strings.Join(addressNameTemplatePlaceholders, ", "))
+		}
+	}
+
+	return nil
+}
+
+// Render the name of an IP address from a name template once the address is allocated
+func addressnamefromtemplate(template string, address string, subnet string, space string) string {
+	return strings.NewReplacer("{address}", address, "{subnet}", subnet, "{space}", space).Replace(template)
+}
+
+// Return a CustomizeDiff function refusing an IP address to create without name unless the provider's name template is configured
+func resourcediffvalidateaddressname(objectName string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" || meta == nil || meta.(*SOLIDserver).AddressNameTemplate != "" {
+			return nil
+		}
+
+		if diffnamemissing(d) {
+			return fmt.Errorf("Can't create %s without name unless the provider's default_address_name_template is configured", objectName)
+		}
+
+		return nil
+	}
+}
+
+// Return true if the name of an object to create is known to be empty
+// The name is computed when not set, only the configuration tells a missing name apart from an unknown one
+func diffnamemissing(d *schema.ResourceDiff) bool {
+	if config := d.GetRawConfig(); !config.IsNull() {
+		name := config.GetAttr("name")
+		return name.IsKnown() && (name.IsNull() || name.AsString() == "")
+	}
+
+	return d.NewValueKnown("name") && d.Get("name").(string) == ""
+}
+
+// Store MAC addresses in their canonical form
+func resourcestatemac(v interface{}) string {
+	return macnormalize(v.(string))
+}
+
+// Ignore MAC Address Format (case and separator) When comparing remote and local value
+func resourcediffsuppressmac(k, old, new string, d *schema.ResourceData) bool {
+	return macnormalize(old) == macnormalize(new)
+}
+
 // Ignore Different IPv6 Format
 func resourcediffsuppressIPv6Format(k, old, new string, d *schema.ResourceData) bool {
 	oldipv6, _ := netaddr.ParseIP(old)
@@ -298,6 +445,62 @@ func resourcediffsuppressIPv6Format(k, old, new string, d *schema.ResourceData)
 	return false
 }
 
+// Ignore Different Prefix Format (ex: 2001:DB8::/48 and 2001:db8:0::/48)
+func resourcediffsuppressprefix(k, old, new string, d *schema.ResourceData) bool {
+	oldprefix, oldErr := netip.ParsePrefix(old)
+	newprefix, newErr := netip.ParsePrefix(new)
+
+	if oldErr == nil && newErr == nil {
+		return oldprefix == newprefix
+	}
+
+	return old == new
+}
+
+// Validate a network prefix in CIDR notation (ex: 10.4.12.0/22), rejecting host addresses (ex: 10.4.12.1/22)
+func validatenetworkprefix(ipv6 bool) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		prefix, prefixErr := netip.ParsePrefix(v)
+
+		if prefixErr != nil || prefix.Addr().Is6() != ipv6 || prefix.Addr().Is4In6() {
+			if ipv6 {
+				return nil, []error{fmt.Errorf("expected %s to be a valid IPv6 prefix in CIDR notation, got: %s", k, v)}
+			}
+			return nil, []error{fmt.Errorf("expected %s to be a valid IPv4 prefix in CIDR notation, got: %s", k, v)}
+		}
+
+		if prefix.Masked() != prefix {
+			return nil, []error{fmt.Errorf("expected %s to be a network address, got the host address: %s (network address: %s)", k, v, prefix.Masked().String())}
+		}
+
+		return nil, nil
+	}
+}
+
+// Convert a SOLIDserver timestamp (unix epoch or 'YYYY-MM-DD HH:MM:SS') into a RFC3339 date
+// Return an empty string if the timestamp can't be parsed
+func timestamptorfc3339(timestamp string) string {
+	if epoch, epochErr := strconv.ParseInt(timestamp, 10, 64); epochErr == nil {
+		if epoch <= 0 {
+			return ""
+		}
+
+		return time.Unix(epoch, 0).UTC().Format(time.RFC3339)
+	}
+
+	if date, dateErr := time.Parse("2006-01-02 15:04:05", timestamp); dateErr == nil {
+		return date.UTC().Format(time.RFC3339)
+	}
+
+	return ""
+}
+
 // Compute the prefix length from the size of a CIDR prefix
 // Return the prefix lenght
 func sizetoprefixlength(size int) int {
@@ -321,6 +524,28 @@ func prefixlengthtosize(length int) int {
 	return -1
 }
 
+// Compute the network addresses of count contiguous child subnets starting at the parent subnet address
+// Return an error if the children don't fit within the parent subnet
+func ipsubnetsplitaddresses(parentAddr string, parentPrefixSize int, childPrefixSize int, count int) ([]string, error) {
+	if childPrefixSize <= parentPrefixSize || childPrefixSize > 32 {
+		return nil, fmt.Errorf("SOLIDServer - Invalid child prefix size /%d for a /%d parent subnet\n", childPrefixSize, parentPrefixSize)
+	}
+
+	if count < 1 || (childPrefixSize-parentPrefixSize < 31 && count > 1<<(childPrefixSize-parentPrefixSize)) {
+		return nil, fmt.Errorf("SOLIDServer - Unable to fit %d /%d subnet(s) into a /%d parent subnet\n", count, childPrefixSize, parentPrefixSize)
+	}
+
+	res := []string{}
+	start := iptolong(parentAddr)
+	childSize := uint32(prefixlengthtosize(childPrefixSize))
+
+	for i := 0; i < count; i++ {
+		res = append(res, longtoip(start+uint32(i)*childSize))
+	}
+
+	return res, nil
+}
+
 // Compute the netmask of a CIDR prefix from its length
 // Return an empty string in case of failure
 func prefixlengthtohexip(length int) string {
@@ -334,14 +559,51 @@ func prefixlengthtohexip(length int) string {
 // Compute the actual size of an IPv6 CIDR prefix from its length
 // Return -1 in case of failure
 func prefix6lengthtosize(length int64) *big.Int {
-	sufix := big.NewInt(32 - (length / 4))
-	size := big.NewInt(16)
+	if length < 0 || length > 128 {
+		return big.NewInt(-1)
+	}
+
+	return new(big.Int).Lsh(big.NewInt(1), uint(128-length))
+}
+
+// Return true if an hexa IP address can be assigned within a subnet from its hexa boundaries and prefix length
+// Both boundaries are assignable in point-to-point (/31, /127) and host (/32, /128) subnets, excluded otherwise
+func iphexaddrinsubnet(startHexIP string, endHexIP string, hexIP string, prefixLength int, maxPrefixLength int) bool {
+	if len(hexIP) != len(startHexIP) || len(hexIP) != len(endHexIP) {
+		return false
+	}
+
+	if prefixLength >= maxPrefixLength-1 {
+		return strings.Compare(startHexIP, hexIP) <= 0 && strings.Compare(hexIP, endHexIP) <= 0
+	}
+
+	return strings.Compare(startHexIP, hexIP) == -1 && strings.Compare(hexIP, endHexIP) == -1
+}
 
-	size = size.Exp(size, sufix, nil)
+// Compute the usage of an IPv6 range from its hexa boundaries and its number of used addresses
+// Return the number of free addresses (capped to the largest int) and the utilization percentage
+func ip6rangeusage(startHexIP string, endHexIP string, used int) (int, float64) {
+	start, startOk := new(big.Int).SetString(startHexIP, 16)
+	end, endOk := new(big.Int).SetString(endHexIP, 16)
 
-	//size = size.Sub(size, big.NewInt(1))
+	if !startOk || !endOk || end.Cmp(start) < 0 {
+		return 0, 0
+	}
+
+	size := new(big.Int).Sub(end, start)
+	size.Add(size, big.NewInt(1))
+
+	free := new(big.Int).Sub(size, big.NewInt(int64(used)))
+	if free.Sign() < 0 {
+		free.SetInt64(0)
+	}
+	if !free.IsInt64() || free.Int64() > int64(math.MaxInt) {
+		free.SetInt64(int64(math.MaxInt))
+	}
+
+	utilization, _ := new(big.Float).Quo(new(big.Float).SetInt64(int64(used)*100), new(big.Float).SetInt(size)).Float64()
 
-	return size
+	return int(free.Int64()), utilization
 }
 
 // Build url value object from class parameters
@@ -356,14 +618,254 @@ func urlfromclassparams(parameters interface{}) url.Values {
 	return classParameters
 }
 
+// Return true if a class parameter key matches one of the patterns (glob syntax, ex: audit_*)
+func classparammatch(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if match, _ := path.Match(pattern, key); match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Class parameter marking an object as locked (see lock)
+// The flag is advisory only, SOLIDserver stores it as any other class parameter and does not refuse deletions on its own
+const lockClassParameter = "__eip_lock"
+
+// Add the lock class parameter of an object, only sent when locked or when unlocked by Terraform
+// Return an error if the object is locked while SOLIDserver does not support locking
+func classparamsetlock(d *schema.ResourceData, classParameters url.Values, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	if d.Get("lock").(bool) {
+		if s.Version < versionLock {
+			return fmt.Errorf("SOLIDServer - Locking is not supported in this SOLIDserver version %d\n", s.Version)
+		}
+
+		classParameters.Set(lockClassParameter, "1")
+	} else if d.HasChange("lock") && s.Version >= versionLock {
+		classParameters.Set(lockClassParameter, "0")
+	}
+
+	return nil
+}
+
+// Return true if the class parameters of an object hold the lock class parameter
+func classparamlocked(classParameters url.Values) bool {
+	return classParameters.Get(lockClassParameter) == "1"
+}
+
+// Unlock an object (see lock) prior to its deletion by Terraform
+// Return an error in case of failure
+func objectunlock(service string, idParameter string, id string, classParametersParameter string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	if s.Version < versionLock {
+		return nil
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(idParameter, id)
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add(classParametersParameter, url.Values{lockClassParameter: []string{"0"}}.Encode())
+
+	// Sending the update request
+	resp, body, err := s.Request("put", service, &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if _, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unlocked object (oid): %s\n", id))
+				return nil
+			}
+		}
+
+		// Nothing left to unlock, the deletion then succeeds as well
+		if objectmissing(resp.StatusCode, buf) {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Object already deleted (oid): %s\n", id))
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to unlock object (oid): %s (%s)\n", id, errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to unlock object (oid): %s\n", id)
+	}
+
+	return err
+}
+
+// Messages of SOLIDserver reporting a missing object
+var objectMissingMessages = []string{"not found", "does not exist", "doesn't exist", "no such"}
+
+// Return true if the answer to a request reports the object as missing (ex: already deleted along with its zone)
+// Other failures (ex: permission denied, dependency exists) are not
+func objectmissing(statusCode int, buf []map[string]interface{}) bool {
+	if statusCode == 404 {
+		return true
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			for _, message := range objectMissingMessages {
+				if strings.Contains(strings.ToLower(errMsg), message) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// Return true if a class parameter of a resource is maintained by SOLIDserver (see ignore_class_parameters)
+func classparamignored(d *schema.ResourceData, key string) bool {
+	return classparammatch(key, toStringArray(d.Get("ignore_class_parameters").([]interface{})))
+}
+
+// Return the class parameters of a resource to send to SOLIDserver, without the ones maintained by SOLIDserver
+func resourceclassparams(d *schema.ResourceData) map[string]interface{} {
+	res := map[string]interface{}{}
+
+	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+		if !classparamignored(d, k) {
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+// Suppress the diff of the class parameters maintained by SOLIDserver (see ignore_class_parameters)
+func resourcediffsuppressclassparams(k, old, new string, d *schema.ResourceData) bool {
+	key := strings.TrimPrefix(k, "class_parameters.")
+
+	// The number of class parameters only matters for the ones not maintained by SOLIDserver
+	if key == "%" {
+		oldClassParameters, newClassParameters := d.GetChange("class_parameters")
+		oldCount, newCount := 0, 0
+
+		for ok := range oldClassParameters.(map[string]interface{}) {
+			if !classparamignored(d, ok) {
+				oldCount++
+			}
+		}
+
+		for nk := range newClassParameters.(map[string]interface{}) {
+			if !classparamignored(d, nk) {
+				newCount++
+			}
+		}
+
+		return oldCount == newCount
+	}
+
+	return classparamignored(d, key)
+}
+
+// Escape a value to be used as a quoted string within a WHERE clause (ex: O'Brien)
+func whereescape(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// Number of objects retrieved per request by paginated list calls
+const listPageSize = 1000
+
+// Retrieve every object of a list service matching a WHERE clause, page by page
+// Return the list of objects (empty if none is matching)
+// Or nil and an error in case of failure
+func listall(service string, whereClause string, meta interface{}) ([]map[string]interface{}, error) {
+	result := []map[string]interface{}{}
+
+	err := listeach(service, whereClause, meta, func(object map[string]interface{}) bool {
+		result = append(result, object)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Call fn on every object of a list service matching a WHERE clause, page by page
+// Stop fetching pages as soon as fn returns false, keeping at most one page in memory
+// Return an error in case of failure
+func listeach(service string, whereClause string, meta interface{}, fn func(map[string]interface{}) bool) error {
+	s := meta.(*SOLIDserver)
+
+	for offset := 0; ; offset += listPageSize {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("limit", strconv.Itoa(listPageSize))
+		parameters.Add("offset", strconv.Itoa(offset))
+
+		if whereClause != "" {
+			parameters.Add("WHERE", whereClause)
+		}
+
+		// Sending the read request
+		resp, body, err := s.Request("get", service, &parameters)
+
+		if err != nil {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to list objects using: %s\n", service))
+			return err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 204 {
+			return nil
+		}
+
+		if resp.StatusCode != 200 || (len(buf) > 0 && buf[0]["errmsg"] != nil) {
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					tflog.Debug(s.Ctx, fmt.Sprintf("Unable to list objects using: %s (%s)\n", service, errMsg))
+				}
+			} else {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to list objects using: %s\n", service))
+			}
+
+			return fmt.Errorf("SOLIDServer - Unable to list objects using: %s\n", service)
+		}
+
+		for _, object := range buf {
+			if !fn(object) {
+				return nil
+			}
+		}
+
+		if len(buf) < listPageSize {
+			return nil
+		}
+	}
+}
+
 // Return the oid of a device from hostdev_name
 // Or an empty string in case of failure
 func hostdevidbyname(hostdevName string, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindDevice, hostdevName); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "hostdev_name='"+strings.ToLower(hostdevName)+"'")
+	parameters.Add("WHERE", "hostdev_name='"+whereescape(strings.ToLower(hostdevName))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/hostdev_list", &parameters)
@@ -375,6 +877,7 @@ func hostdevidbyname(hostdevName string, meta interface{}) (string, error) {
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if hostdevID, hostdevIDExist := buf[0]["hostdev_id"].(string); hostdevIDExist {
+				lookupcachesetstring(meta, cacheKindDevice, hostdevID, hostdevName)
 				return hostdevID, nil
 			}
 		}
@@ -425,6 +928,35 @@ func ipaddressfindfree(subnetID string, poolID string, meta interface{}) ([]stri
 	return []string{}, err
 }
 
+// Return the available IP addresses of a point-to-point (/31) or host (/32) subnet from its free ranges,
+// including the single address ones (free_start_ip_addr equal to free_end_ip_addr) the free address lookup skips
+// IPv4 only, IPv6 addresses of point-to-point (/127) and host (/128) subnets must be requested explicitly
+// Or an empty table of string in case of failure
+func ipaddressfindfreeinranges(subnetID string, meta interface{}) ([]string, error) {
+	buf, err := listall("rest/ip_address_list", "subnet_id='"+subnetID+"' AND type='free'", meta)
+
+	if err != nil {
+		return []string{}, err
+	}
+
+	addresses := []string{}
+
+	for _, row := range buf {
+		start := iptolong(hexiptoip(infostring(row, "free_start_ip_addr")))
+		end := iptolong(hexiptoip(infostring(row, "free_end_ip_addr")))
+
+		for addr := start; addr >= start && addr <= end && len(addresses) < 32; addr++ {
+			addresses = append(addresses, longtoip(addr))
+
+			if addr == end {
+				break
+			}
+		}
+	}
+
+	return addresses, nil
+}
+
 // Return an available IP addresses from site_id, block_id and expected subnet_size
 // Or an empty table of string in case of failure
 func ip6addressfindfree(subnetID string, poolID string, meta interface{}) ([]string, error) {
@@ -467,70 +999,152 @@ func ip6addressfindfree(subnetID string, poolID string, meta interface{}) ([]str
 
 // Return an available vlan from specified vlmdomain_name
 // Or an empty table strings in case of failure
-func vlanidfindfree(vlmdomainName string, meta interface{}) ([]string, error) {
+func vlanidfindfree(vlmdomainName string, idMin int, idMax int, meta interface{}) ([]string, error) {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("limit", "16")
+	whereClause := "vlmdomain_name='" + whereescape(strings.ToLower(vlmdomainName)) + "' AND type='free'"
 
 	if s.Version < 700 {
-		parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"' AND row_enabled='2'")
-	} else {
-		parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"' AND type='free'")
+		whereClause = "vlmdomain_name='" + whereescape(strings.ToLower(vlmdomainName)) + "' AND row_enabled='2'"
 	}
 
-	// Sending the creation request
-	resp, body, err := s.Request("get", "rest/vlmvlan_list", &parameters)
+	var buf [](map[string]interface{})
+	var err error = nil
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	if idMin > 0 || idMax > 0 {
+		// Browsing all the free vlan IDs (or ranges) of the domain, the window being applied client side
+		buf, err = listall("rest/vlmvlan_list", whereClause, meta)
+	} else {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("limit", "16")
+		parameters.Add("WHERE", whereClause)
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			vnIDs := []string{}
+		// Sending the creation request
+		resp, body, reqErr := s.Request("get", "rest/vlmvlan_list", &parameters)
 
-			for i := range buf {
-				if s.Version < 700 {
-					if vnID, vnIDExist := buf[i]["vlmvlan_vlan_id"].(string); vnIDExist {
-						tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %s\n", vnID))
-						vnIDs = append(vnIDs, vnID)
+		if err = reqErr; err == nil && resp.StatusCode == 200 {
+			json.Unmarshal([]byte(body), &buf)
+		}
+	}
+
+	if err == nil && len(buf) > 0 {
+		vnIDs := []string{}
+
+		for i := range buf {
+			if s.Version < 700 {
+				if vnID, vnIDExist := buf[i]["vlmvlan_vlan_id"].(string); vnIDExist {
+					if id, _ := strconv.Atoi(vnID); id < idMin || (idMax > 0 && id > idMax) {
+						continue
 					}
-				} else {
-					if startVlanID, startVlanIDExist := buf[i]["free_start_vlan_id"].(string); startVlanIDExist {
-						if endVlanID, endVlanIDExist := buf[i]["free_end_vlan_id"].(string); endVlanIDExist {
-							vnID, _ := strconv.Atoi(startVlanID)
-							maxVnID, _ := strconv.Atoi(endVlanID)
-
-							j := 0
-							for vnID < maxVnID && j < 8 {
-								tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %d\n", vnID))
-								vnIDs = append(vnIDs, strconv.Itoa(vnID))
-								vnID++
-								j++
-							}
+
+					tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %s\n", vnID))
+					vnIDs = append(vnIDs, vnID)
+				}
+			} else {
+				if startVlanID, startVlanIDExist := buf[i]["free_start_vlan_id"].(string); startVlanIDExist {
+					if endVlanID, endVlanIDExist := buf[i]["free_end_vlan_id"].(string); endVlanIDExist {
+						vnID, _ := strconv.Atoi(startVlanID)
+						maxVnID, _ := strconv.Atoi(endVlanID)
+
+						// Restricting the free range to the requested window
+						if vnID < idMin {
+							vnID = idMin
+						}
+
+						if idMax > 0 && maxVnID > idMax+1 {
+							maxVnID = idMax + 1
+						}
+
+						j := 0
+						for vnID < maxVnID && j < 8 {
+							tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %d\n", vnID))
+							vnIDs = append(vnIDs, strconv.Itoa(vnID))
+							vnID++
+							j++
 						}
 					}
 				}
 			}
+		}
+
+		if len(vnIDs) > 0 || (idMin == 0 && idMax == 0) {
 			return vnIDs, nil
 		}
 	}
 
+	if err == nil && (idMin > 0 || idMax > 0) {
+		window := fmt.Sprintf("between %d and %d", idMin, idMax)
+
+		if idMax == 0 {
+			window = fmt.Sprintf("from %d", idMin)
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free vlan ID %s in vlan domain: %s\n", window, vlmdomainName))
+
+		return []string{}, fmt.Errorf("SOLIDServer - Unable to find a free vlan ID %s in vlan domain: %s\n", window, vlmdomainName)
+	}
+
 	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free vlan ID in vlan domain: %s\n", vlmdomainName))
 
 	return []string{}, err
 }
 
+// Return a CustomizeDiff function planning the provider's default_space as the space of the objects to create without space
+// The space set on a resource always wins, existing objects keep the space read from SOLIDserver
+// When required, the plan fails if neither the resource nor the provider sets the space
+// Otherwise, without default_space, a space left out of the configuration dissociates the object from its space
+func resourcediffdefaultspace(required bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := d.GetRawConfig()
+
+		// Space set in the configuration, even if not known yet
+		if !config.IsNull() && !config.GetAttr("space").IsNull() {
+			return nil
+		}
+
+		defaultSpace := ""
+
+		if meta != nil {
+			defaultSpace = meta.(*SOLIDserver).DefaultSpace
+		}
+
+		if d.Id() != "" {
+			if !required && defaultSpace == "" && !config.IsNull() && d.Get("space").(string) != "" {
+				return d.SetNew("space", "")
+			}
+
+			return nil
+		}
+
+		if d.NewValueKnown("space") && d.Get("space").(string) != "" {
+			return nil
+		}
+
+		if defaultSpace != "" {
+			return d.SetNew("space", defaultSpace)
+		}
+
+		if required {
+			return fmt.Errorf("The space must be set, either on the resource or through the provider's default_space")
+		}
+
+		return nil
+	}
+}
+
 // Return the oid of a space from site_name
 // Or an empty string in case of failure
 func ipsiteidbyname(siteName string, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindIPSpace, siteName); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_name='"+strings.ToLower(siteName)+"'")
+	parameters.Add("WHERE", "site_name='"+whereescape(strings.ToLower(siteName))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/ip_site_list", &parameters)
@@ -542,6 +1156,7 @@ func ipsiteidbyname(siteName string, meta interface{}) (string, error) {
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if siteID, siteIDExist := buf[0]["site_id"].(string); siteIDExist {
+				lookupcachesetstring(meta, cacheKindIPSpace, siteID, siteName)
 				return siteID, nil
 			}
 		}
@@ -552,14 +1167,230 @@ func ipsiteidbyname(siteName string, meta interface{}) (string, error) {
 	return "", err
 }
 
+// Return the oid of a class from its type (ex: ip_subnet) and name
+// Or an empty string in case of failure
+func classidbyname(classType string, className string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindClass, classType, className); cachedExist {
+		return cached, nil
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "class_type='"+whereescape(classType)+"' AND class_name='"+whereescape(className)+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/class_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if classID, classIDExist := buf[0]["class_id"].(string); classIDExist {
+				lookupcachesetstring(meta, cacheKindClass, classID, classType, className)
+				return classID, nil
+			}
+		}
+
+		// Reporting the failures of the lookup apart from missing classes
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return "", fmt.Errorf("SOLIDServer - Unable to look up %s class: %s (%s)\n", classType, className, errMsg)
+				}
+			}
+
+			return "", fmt.Errorf("SOLIDServer - Unable to look up %s class: %s (HTTP %d)\n", classType, className, resp.StatusCode)
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find %s class: %s\n", classType, className))
+
+		return "", fmt.Errorf("SOLIDServer - Unable to find %s class: %s\n", classType, className)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find %s class: %s\n", classType, className))
+
+	return "", err
+}
+
+// Return the number of free, used and total VLANs of a VLAN range
+// Or an error in case of failure
+func vlanrangecounts(vlmrangeID string, meta interface{}) (int, int, int, error) {
+	total, totalErr := countall("rest/vlmvlan_count", "vlmrange_id='"+vlmrangeID+"'", meta)
+	if totalErr != nil {
+		return 0, 0, 0, totalErr
+	}
+
+	free, freeErr := countall("rest/vlmvlan_count", "vlmrange_id='"+vlmrangeID+"' AND type='free'", meta)
+	if freeErr != nil {
+		return 0, 0, 0, freeErr
+	}
+
+	return free, total - free, total, nil
+}
+
+// Return the VLAN ranges of a VLAN domain
+// Or an error in case of failure
+func vlandomainranges(vlmdomainID string, meta interface{}) ([]map[string]interface{}, error) {
+	return listall("rest/vlmrange_list", "vlmdomain_id='"+whereescape(vlmdomainID)+"'", meta)
+}
+
+// Create a VLAN range within a VLAN domain, or edit the bounds of an existing one when rangeID is set
+// Return the oid of the VLAN range or an error in case of failure
+func vlanrangeset(vlmdomainID string, rangeID string, rangeName string, start int, end int, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("vlmdomain_id", vlmdomainID)
+	parameters.Add("vlmrange_name", rangeName)
+	parameters.Add("vlmrange_start_vlan_id", strconv.Itoa(start))
+	parameters.Add("vlmrange_end_vlan_id", strconv.Itoa(end))
+
+	method := "post"
+
+	if rangeID != "" {
+		method = "put"
+		parameters.Add("vlmrange_id", rangeID)
+		parameters.Add("add_flag", "edit_only")
+	} else {
+		parameters.Add("add_flag", "new_only")
+	}
+
+	// Sending the request
+	resp, body, err := s.Request(method, "rest/vlm_range_add", &parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Set VLAN Range: %s (%d-%d) (oid): %s\n", rangeName, start, end, oid))
+			return oid, nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return "", fmt.Errorf("SOLIDServer - Unable to set VLAN Range: %s (%d-%d) (%s)\n", rangeName, start, end, errMsg)
+		}
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to set VLAN Range: %s (%d-%d)\n", rangeName, start, end)
+}
+
+// Return the number of free and used VLANs of a VLAN domain
+// Or an error in case of failure
+func vlandomaincounts(vlmdomainID string, meta interface{}) (int, int, error) {
+	used, usedErr := countall("rest/vlmvlan_count", "vlmdomain_id='"+vlmdomainID+"' AND type!='free'", meta)
+	if usedErr != nil {
+		return 0, 0, usedErr
+	}
+
+	free, freeErr := countall("rest/vlmvlan_count", "vlmdomain_id='"+vlmdomainID+"' AND type='free'", meta)
+	if freeErr != nil {
+		return 0, 0, freeErr
+	}
+
+	return free, used, nil
+}
+
+// Return the number of VLAN IDs within the range of a VLAN domain
+// Or the size of the whole VLAN (1-4094) or VXLAN (1-16777215) ID space if the range is not reported
+func vlandomaincapacity(domain map[string]interface{}, vxlan bool) int {
+	start, startErr := strconv.Atoi(fmt.Sprintf("%v", domain["vlmdomain_start_vlan_id"]))
+	end, endErr := strconv.Atoi(fmt.Sprintf("%v", domain["vlmdomain_end_vlan_id"]))
+
+	if startErr == nil && endErr == nil && end >= start {
+		return end - start + 1
+	}
+
+	if vxlan {
+		return 16777215
+	}
+
+	return 4094
+}
+
+// Return the names of the subnets associated to a VLAN from its vlan domain name and vlan ID
+// Or nil in case of failure
+func vlansubnetsbyid(vlmdomainName string, vlmvlanVlanID int, meta interface{}) ([]string, error) {
+	buf, err := listall("rest/ip_block_subnet_list", "vlmdomain_name='"+whereescape(vlmdomainName)+"' AND vlmvlan_vlan_id='"+strconv.Itoa(vlmvlanVlanID)+"'", meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subnets := []string{}
+
+	for _, subnet := range buf {
+		if subnetName, subnetNameExist := subnet["subnet_name"].(string); subnetNameExist {
+			subnets = append(subnets, subnetName)
+		}
+	}
+
+	return subnets, nil
+}
+
+// Return the name of the vlan range a vlan belongs to from its oid
+// Or an empty string if the vlan doesn't belong to any range, or in case of failure
+func vlanrangenamebyid(vlmvlanID string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("vlmvlan_id", vlmvlanID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/vlmvlan_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			return vlanrangename(buf[0]), nil
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find vlan (oid): %s\n", vlmvlanID))
+
+		return "", fmt.Errorf("SOLIDServer - Unable to find vlan (oid): %s\n", vlmvlanID)
+	}
+
+	return "", err
+}
+
+// Return the name of the vlan range of a vlan from its information
+// Or an empty string if the vlan doesn't belong to any range
+func vlanrangename(vlanInfo map[string]interface{}) string {
+	if rangeName, rangeNameExist := vlanInfo["vlmrange_name"].(string); rangeNameExist && rangeName != "#" {
+		return rangeName
+	}
+
+	return ""
+}
+
 // Return the oid of a vlan domain from vlmdomain_name
 // Or an empty string in case of failure
 func vlandomainidbyname(vlmdomainName string, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindVlanDomain, vlmdomainName); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"'")
+	parameters.Add("WHERE", "vlmdomain_name='"+whereescape(strings.ToLower(vlmdomainName))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/vlmdomain_name", &parameters)
@@ -571,6 +1402,7 @@ func vlandomainidbyname(vlmdomainName string, meta interface{}) (string, error)
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if vlmdomainID, vlmdomainIDExist := buf[0]["vlmdomain_id"].(string); vlmdomainIDExist {
+				lookupcachesetstring(meta, cacheKindVlanDomain, vlmdomainID, vlmdomainName)
 				return vlmdomainID, nil
 			}
 		}
@@ -586,9 +1418,13 @@ func vlandomainidbyname(vlmdomainName string, meta interface{}) (string, error)
 func vlanidbyinfo(vlmdomainName string, vlmvlanvlanID int, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindVlan, vlmdomainName, strconv.Itoa(vlmvlanvlanID)); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "vlmdomain_name='"+vlmdomainName+"' AND vlmvlan_vlan_id='"+strconv.Itoa(vlmvlanvlanID)+"'")
+	parameters.Add("WHERE", "vlmdomain_name='"+whereescape(vlmdomainName)+"' AND vlmvlan_vlan_id='"+strconv.Itoa(vlmvlanvlanID)+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/vlmvlan_list", &parameters)
@@ -600,12 +1436,13 @@ func vlanidbyinfo(vlmdomainName string, vlmvlanvlanID int, meta interface{}) (st
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if vlmvlanID, vlmvlanIDExist := buf[0]["vlmvlan_id"].(string); vlmvlanIDExist {
+				lookupcachesetstring(meta, cacheKindVlan, vlmvlanID, vlmdomainName, strconv.Itoa(vlmvlanvlanID))
 				return vlmvlanID, nil
 			}
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find VLAN ID %d within VLAN Domain\n", vlmvlanvlanID, vlmdomainName))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find VLAN ID %d within VLAN Domain: %s\n", vlmvlanvlanID, vlmdomainName))
 
 	return "", err
 }
@@ -615,10 +1452,14 @@ func vlanidbyinfo(vlmdomainName string, vlmvlanvlanID int, meta interface{}) (st
 func ipsubnetidbyname(siteID string, subnetName string, terminal bool, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindIPSubnet, "id", siteID, subnetName, strconv.FormatBool(terminal)); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 
-	whereClause := "site_id='" + siteID + "' AND " + "subnet_name='" + strings.ToLower(subnetName) + "'"
+	whereClause := "site_id='" + siteID + "' AND " + "subnet_name='" + whereescape(strings.ToLower(subnetName)) + "'"
 
 	if terminal {
 		whereClause += "AND is_terminal='1'"
@@ -638,6 +1479,7 @@ func ipsubnetidbyname(siteID string, subnetName string, terminal bool, meta inte
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if subnetID, subnetIDExist := buf[0]["subnet_id"].(string); subnetIDExist {
+				lookupcachesetstring(meta, cacheKindIPSubnet, subnetID, "id", siteID, subnetName, strconv.FormatBool(terminal))
 				return subnetID, nil
 			}
 		}
@@ -653,9 +1495,13 @@ func ipsubnetidbyname(siteID string, subnetName string, terminal bool, meta inte
 func ippoolidbyname(siteID string, poolName string, subnetName string, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindIPPool, "id", siteID, poolName, subnetName); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool_name='"+strings.ToLower(poolName)+"' AND subnet_name='"+strings.ToLower(subnetName)+"'")
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool_name='"+whereescape(strings.ToLower(poolName))+"' AND subnet_name='"+whereescape(strings.ToLower(subnetName))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/ip_pool_list", &parameters)
@@ -667,6 +1513,7 @@ func ippoolidbyname(siteID string, poolName string, subnetName string, meta inte
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if poolID, poolIDExist := buf[0]["pool_id"].(string); poolIDExist {
+				lookupcachesetstring(meta, cacheKindIPPool, poolID, "id", siteID, poolName, subnetName)
 				return poolID, nil
 			}
 		}
@@ -683,9 +1530,13 @@ func ippoolinfobyname(siteID string, poolName string, subnetName string, meta in
 	res := make(map[string]interface{})
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetmap(meta, cacheKindIPPool, "info", siteID, poolName, subnetName); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool_name='"+strings.ToLower(poolName)+"' AND subnet_name='"+strings.ToLower(subnetName)+"'")
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool_name='"+whereescape(strings.ToLower(poolName))+"' AND subnet_name='"+whereescape(strings.ToLower(subnetName))+"'")
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/ip_pool_list", &parameters)
@@ -717,6 +1568,7 @@ func ippoolinfobyname(siteID string, poolName string, subnetName string, meta in
 					res["end_addr"] = hexiptoip(poolEndAddr)
 				}
 
+				lookupcachesetmap(meta, cacheKindIPPool, res, "info", siteID, poolName, subnetName)
 				return res, nil
 			}
 		}
@@ -727,27 +1579,17 @@ func ippoolinfobyname(siteID string, poolName string, subnetName string, meta in
 	return nil, err
 }
 
-// Return a map of information about a subnet from site_id, subnet_name and is_terminal property
+// Return the class parameters of a subnet from its subnet_id
 // Or nil in case of failure
-func ipsubnetinfobyname(siteID string, subnetName string, terminal bool, meta interface{}) (map[string]interface{}, error) {
-	res := make(map[string]interface{})
+func ipsubnetclassparams(subnetID string, meta interface{}) (url.Values, error) {
 	s := meta.(*SOLIDserver)
 
 	// Building parameters
 	parameters := url.Values{}
-
-	whereClause := "site_id='" + siteID + "' AND " + "subnet_name='" + strings.ToLower(subnetName) + "'"
-
-	if terminal {
-		whereClause += "AND is_terminal='1'"
-	} else {
-		whereClause += "AND is_terminal='0'"
-	}
-
-	parameters.Add("WHERE", whereClause)
+	parameters.Add("subnet_id", subnetID)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_block_subnet_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip_block_subnet_info", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -755,68 +1597,33 @@ func ipsubnetinfobyname(siteID string, subnetName string, terminal bool, meta in
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if subnetID, subnetIDExist := buf[0]["subnet_id"].(string); subnetIDExist {
-				res["id"] = subnetID
-
-				if subnetName, subnetNameExist := buf[0]["subnet_name"].(string); subnetNameExist {
-					res["name"] = subnetName
-				}
-
-				if subnetSize, subnetSizeExist := buf[0]["subnet_size"].(string); subnetSizeExist {
-					res["size"], _ = strconv.Atoi(subnetSize)
-					res["prefix_length"] = sizetoprefixlength(res["size"].(int))
-				}
-
-				if subnetStartAddr, subnetStartAddrExist := buf[0]["start_ip_addr"].(string); subnetStartAddrExist {
-					res["start_hex_addr"] = subnetStartAddr
-					res["start_addr"] = hexiptoip(subnetStartAddr)
-				}
-
-				if subnetEndAddr, subnetEndAddrExist := buf[0]["end_ip_addr"].(string); subnetEndAddrExist {
-					res["end_hex_addr"] = subnetEndAddr
-					res["end_addr"] = hexiptoip(subnetEndAddr)
-				}
-
-				if subnetTerminal, subnetTerminalExist := buf[0]["is_terminal"].(string); subnetTerminalExist {
-					res["terminal"] = subnetTerminal
-				}
-
-				if subnetLvl, subnetLvlExist := buf[0]["subnet_level"].(string); subnetLvlExist {
-					res["level"] = subnetLvl
-				}
-
-				return res, nil
+			if classParameters, classParametersExist := buf[0]["subnet_class_parameters"].(string); classParametersExist {
+				return url.ParseQuery(classParameters)
 			}
 		}
 
-		return nil, fmt.Errorf("SOLIDServer - Unable to find IP subnet: %s\n", subnetName)
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to read class parameters of IP subnet (oid): %s\n", subnetID))
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to read class parameters of IP subnet (oid): %s\n", subnetID)
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP subnet: %s\n", subnetName))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to read class parameters of IP subnet (oid): %s\n", subnetID))
 
 	return nil, err
 }
 
-// Return the oid of a subnet from site_id, subnet_name and is_terminal property
-// Or an empty string in case of failure
-func ip6subnetidbyname(siteID string, subnetName string, terminal bool, meta interface{}) (string, error) {
+// Return a map of information about a subnet from its subnet_id
+// Or nil in case of failure
+func ipsubnetinfobyid(subnetID string, meta interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
 	s := meta.(*SOLIDserver)
 
 	// Building parameters
 	parameters := url.Values{}
-
-	whereClause := "site_id='" + siteID + "' AND " + "subnet6_name='" + strings.ToLower(subnetName) + "'"
-
-	if terminal {
-		whereClause += "AND is_terminal='1'"
-	} else {
-		whereClause += "AND is_terminal='0'"
-	}
-
-	parameters.Add("WHERE", whereClause)
+	parameters.Add("subnet_id", subnetID)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip_block_subnet_info", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -824,106 +1631,149 @@ func ip6subnetidbyname(siteID string, subnetName string, terminal bool, meta int
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if subnetID, subnetIDExist := buf[0]["subnet6_id"].(string); subnetIDExist {
-				return subnetID, nil
+			res["id"] = subnetID
+			res["name"], _ = buf[0]["subnet_name"].(string)
+			res["site_id"], _ = buf[0]["site_id"].(string)
+			res["site_name"], _ = buf[0]["site_name"].(string)
+			res["level"], _ = buf[0]["subnet_level"].(string)
+			res["terminal"], _ = buf[0]["is_terminal"].(string)
+
+			if subnetSize, subnetSizeExist := buf[0]["subnet_size"].(string); subnetSizeExist {
+				res["size"], _ = strconv.Atoi(subnetSize)
+				res["prefix_length"] = sizetoprefixlength(res["size"].(int))
+			}
+
+			if subnetStartAddr, subnetStartAddrExist := buf[0]["start_ip_addr"].(string); subnetStartAddrExist {
+				res["start_hex_addr"] = subnetStartAddr
+				res["start_addr"] = hexiptoip(subnetStartAddr)
+			}
+
+			if subnetEndAddr, subnetEndAddrExist := buf[0]["end_ip_addr"].(string); subnetEndAddrExist {
+				res["end_hex_addr"] = subnetEndAddr
+				res["end_addr"] = hexiptoip(subnetEndAddr)
 			}
+
+			return res, nil
 		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP subnet (oid): %s\n", subnetID))
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to find IP subnet (oid): %s\n", subnetID)
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 subnet: %s\n", subnetName))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP subnet (oid): %s\n", subnetID))
 
-	return "", err
+	return nil, err
 }
 
-// Return the oid of a pool from site_id and pool_name
-// Or an empty string in case of failure
-func ip6poolidbyname(siteID string, poolName string, subnetName string, meta interface{}) (string, error) {
+// Delete a subnet from its subnet_id
+// Return an error in case of failure
+func ipsubnetdelete(subnetID string, meta interface{}) error {
 	s := meta.(*SOLIDserver)
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool6_name='"+strings.ToLower(poolName)+"' AND subnet6_name='"+strings.ToLower(subnetName)+"'")
+	parameters.Add("subnet_id", subnetID)
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_pool6_list", &parameters)
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/ip_subnet_delete", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
 		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if poolID, poolIDExist := buf[0]["pool6_id"].(string); poolIDExist {
-				return poolID, nil
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			lookupcacheinvalidate(meta, cacheKindIPSubnet, cacheKindIPPool)
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to delete IP subnet (oid): %s (%s)\n", subnetID, errMsg)
 			}
 		}
-	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 pool: %s\n", poolName))
+		return fmt.Errorf("SOLIDServer - Unable to delete IP subnet (oid): %s\n", subnetID)
+	}
 
-	return "", err
+	return err
 }
 
-// Return the oid of a pool from site_id and pool_name
-// Or an empty string in case of failure
-func ip6poolinfobyname(siteID string, poolName string, subnetName string, meta interface{}) (map[string]interface{}, error) {
-	res := make(map[string]interface{})
-	s := meta.(*SOLIDserver)
+// Return a map of information about a subnet from a row of the subnet list or info services
+// Or nil if the row does not describe a subnet
+func ipsubnetinfofromrow(row map[string]interface{}) map[string]interface{} {
+	subnetID, subnetIDExist := row["subnet_id"].(string)
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool6_name='"+strings.ToLower(poolName)+"' AND subnet6_name='"+strings.ToLower(subnetName)+"'")
+	if !subnetIDExist {
+		return nil
+	}
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_pool6_list", &parameters)
+	res := map[string]interface{}{"id": subnetID}
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	if subnetName, subnetNameExist := row["subnet_name"].(string); subnetNameExist {
+		res["name"] = subnetName
+	}
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if poolID, poolIDExist := buf[0]["pool6_id"].(string); poolIDExist {
-				res["id"] = poolID
+	if subnetSize, subnetSizeExist := row["subnet_size"].(string); subnetSizeExist {
+		res["size"], _ = strconv.Atoi(subnetSize)
+		res["prefix_length"] = sizetoprefixlength(res["size"].(int))
+	}
 
-				if poolName, poolNameExist := buf[0]["pool6_name"].(string); poolNameExist {
-					res["name"] = poolName
-				}
+	if subnetStartAddr, subnetStartAddrExist := row["start_ip_addr"].(string); subnetStartAddrExist {
+		res["start_hex_addr"] = subnetStartAddr
+		res["start_addr"] = hexiptoip(subnetStartAddr)
+	}
 
-				if poolSize, poolSizeExist := buf[0]["pool6_size"].(string); poolSizeExist {
-					res["size"], _ = strconv.Atoi(poolSize)
-				}
+	if subnetEndAddr, subnetEndAddrExist := row["end_ip_addr"].(string); subnetEndAddrExist {
+		res["end_hex_addr"] = subnetEndAddr
+		res["end_addr"] = hexiptoip(subnetEndAddr)
+	}
 
-				if poolStartAddr, poolStartAddrExist := buf[0]["start_ip6_addr"].(string); poolStartAddrExist {
-					res["start_hex_addr"] = poolStartAddr
-					res["start_addr"] = hexiptoip(poolStartAddr)
-				}
+	if subnetTerminal, subnetTerminalExist := row["is_terminal"].(string); subnetTerminalExist {
+		res["terminal"] = subnetTerminal
+	}
 
-				if poolEndAddr, poolEndAddrExist := buf[0]["end_ip6_addr"].(string); poolEndAddrExist {
-					res["end_hex_addr"] = poolEndAddr
-					res["end_addr"] = hexiptoip(poolEndAddr)
-				}
+	if subnetLvl, subnetLvlExist := row["subnet_level"].(string); subnetLvlExist {
+		res["level"] = subnetLvl
+	}
 
-				return res, nil
-			}
-		}
+	return res
+}
+
+// Return the description (CIDR and oid) of every terminal subnet sharing the same name within a space
+// Or an error in case of failure
+func ipsubnetsbyname(spaceName string, subnetName string, meta interface{}) ([]string, error) {
+	buf, err := listall("rest/ip_block_subnet_list", "site_name='"+whereescape(spaceName)+"' AND subnet_name='"+whereescape(strings.ToLower(subnetName))+"' AND is_terminal='1'", meta)
+
+	if err != nil {
+		return nil, err
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 pool: %s\n", poolName))
+	subnets := []string{}
 
-	return nil, err
+	for _, row := range buf {
+		if info := ipsubnetinfofromrow(row); info != nil {
+			subnets = append(subnets, fmt.Sprintf("%s/%d (oid: %s)", info["start_addr"], info["prefix_length"], info["id"]))
+		}
+	}
+
+	return subnets, nil
 }
 
 // Return a map of information about a subnet from site_id, subnet_name and is_terminal property
 // Or nil in case of failure
-func ip6subnetinfobyname(siteID string, subnetName string, terminal bool, meta interface{}) (map[string]interface{}, error) {
-	res := make(map[string]interface{})
+func ipsubnetinfobyname(siteID string, subnetName string, terminal bool, meta interface{}) (map[string]interface{}, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetmap(meta, cacheKindIPSubnet, "info", siteID, subnetName, strconv.FormatBool(terminal)); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 
-	whereClause := "site_id='" + siteID + "' AND " + "subnet6_name='" + strings.ToLower(subnetName) + "'"
+	whereClause := "site_id='" + siteID + "' AND " + "subnet_name='" + whereescape(strings.ToLower(subnetName)) + "'"
 
 	if terminal {
 		whereClause += "AND is_terminal='1'"
@@ -934,7 +1784,7 @@ func ip6subnetinfobyname(siteID string, subnetName string, terminal bool, meta i
 	parameters.Add("WHERE", whereClause)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip_block_subnet_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -942,58 +1792,44 @@ func ip6subnetinfobyname(siteID string, subnetName string, terminal bool, meta i
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if subnetID, subnetIDExist := buf[0]["subnet6_id"].(string); subnetIDExist {
-				res["id"] = subnetID
-
-				if subnetName, subnetNameExist := buf[0]["subnet6_name"].(string); subnetNameExist {
-					res["name"] = subnetName
-				}
-
-				if subnetPrefixSize, subnetPrefixSizeExist := buf[0]["subnet6_prefix"].(string); subnetPrefixSizeExist {
-					res["prefix_length"], _ = strconv.Atoi(subnetPrefixSize)
-				}
-
-				if subnetStartAddr, subnetStartAddrExist := buf[0]["start_ip6_addr"].(string); subnetStartAddrExist {
-					res["start_hex_addr"] = subnetStartAddr
-					res["start_addr"] = hexiptoip(subnetStartAddr)
-				}
-
-				if subnetEndAddr, subnetEndAddrExist := buf[0]["end_ip6_addr"].(string); subnetEndAddrExist {
-					res["end_hex_addr"] = subnetEndAddr
-					res["end_addr"] = hexiptoip(subnetEndAddr)
-				}
-
-				if subnetTerminal, subnetTerminalExist := buf[0]["is_terminal"].(string); subnetTerminalExist {
-					res["terminal"] = subnetTerminal
-				}
-
-				if subnetLvl, subnetLvlExist := buf[0]["subnet_level"].(string); subnetLvlExist {
-					res["level"] = subnetLvl
-				}
-
+			if res := ipsubnetinfofromrow(buf[0]); res != nil {
+				lookupcachesetmap(meta, cacheKindIPSubnet, res, "info", siteID, subnetName, strconv.FormatBool(terminal))
 				return res, nil
 			}
 		}
 
-		return nil, fmt.Errorf("SOLIDServer - Unable to find IPv6 subnet: %s\n", subnetName)
+		return nil, fmt.Errorf("SOLIDServer - Unable to find IP subnet: %s\n", subnetName)
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 subnet: %s\n", subnetName))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP subnet: %s\n", subnetName))
 
 	return nil, err
 }
 
-// Return the oid of an address from site_id, ip_address
+// Return the oid of a subnet from site_id, subnet_name and is_terminal property
 // Or an empty string in case of failure
-func ipaddressidbyip(siteID string, ipAddress string, meta interface{}) (string, error) {
+func ip6subnetidbyname(siteID string, subnetName string, terminal bool, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindIP6Subnet, "id", siteID, subnetName, strconv.FormatBool(terminal)); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"ip_addr='"+iptohexip(ipAddress)+"'")
+
+	whereClause := "site_id='" + siteID + "' AND " + "subnet6_name='" + whereescape(strings.ToLower(subnetName)) + "'"
+
+	if terminal {
+		whereClause += "AND is_terminal='1'"
+	} else {
+		whereClause += "AND is_terminal='0'"
+	}
+
+	parameters.Add("WHERE", whereClause)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_address_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -1001,28 +1837,33 @@ func ipaddressidbyip(siteID string, ipAddress string, meta interface{}) (string,
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if ipID, ipIDExist := buf[0]["ip_id"].(string); ipIDExist {
-				return ipID, nil
+			if subnetID, subnetIDExist := buf[0]["subnet6_id"].(string); subnetIDExist {
+				lookupcachesetstring(meta, cacheKindIP6Subnet, subnetID, "id", siteID, subnetName, strconv.FormatBool(terminal))
+				return subnetID, nil
 			}
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP address: %s\n", ipAddress))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 subnet: %s\n", subnetName))
 
 	return "", err
 }
 
-// Return the oid of an address from site_id, ip_address
+// Return the oid of a pool from site_id and pool_name
 // Or an empty string in case of failure
-func ip6addressidbyip6(siteID string, ipAddress string, meta interface{}) (string, error) {
+func ip6poolidbyname(siteID string, poolName string, subnetName string, meta interface{}) (string, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindIP6Pool, "id", siteID, poolName, subnetName); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"ip6_addr='"+ip6tohexip6(ipAddress)+"'")
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool6_name='"+whereescape(strings.ToLower(poolName))+"' AND subnet6_name='"+whereescape(strings.ToLower(subnetName))+"'")
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_address6_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip6_pool6_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -1030,29 +1871,34 @@ func ip6addressidbyip6(siteID string, ipAddress string, meta interface{}) (strin
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if ipID, ipIDExist := buf[0]["ip6_id"].(string); ipIDExist {
-				return ipID, nil
+			if poolID, poolIDExist := buf[0]["pool6_id"].(string); poolIDExist {
+				lookupcachesetstring(meta, cacheKindIP6Pool, poolID, "id", siteID, poolName, subnetName)
+				return poolID, nil
 			}
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 address: %s\n", ipAddress))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 pool: %s\n", poolName))
 
 	return "", err
 }
 
-// Return the oid of an address from ip_id, ip_name_type, alias_name
+// Return the oid of a pool from site_id and pool_name
 // Or an empty string in case of failure
-func ipaliasidbyinfo(addressID string, aliasName string, ipNameType string, meta interface{}) (string, error) {
+func ip6poolinfobyname(siteID string, poolName string, subnetName string, meta interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetmap(meta, cacheKindIP6Pool, "info", siteID, poolName, subnetName); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("ip_id", addressID)
-	parameters.Add("WHERE", "ip_name_type='"+ipNameType+"' AND "+"alias_name='"+aliasName+"'")
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool6_name='"+whereescape(strings.ToLower(poolName))+"' AND subnet6_name='"+whereescape(strings.ToLower(subnetName))+"'")
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_alias_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip6_pool6_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -1060,87 +1906,108 @@ func ipaliasidbyinfo(addressID string, aliasName string, ipNameType string, meta
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if ip_name_id, ip_name_id_exist := buf[0]["ip_name_id"].(string); ip_name_id_exist {
-				return ip_name_id, nil
+			if poolID, poolIDExist := buf[0]["pool6_id"].(string); poolIDExist {
+				res["id"] = poolID
+
+				if poolName, poolNameExist := buf[0]["pool6_name"].(string); poolNameExist {
+					res["name"] = poolName
+				}
+
+				if poolSize, poolSizeExist := buf[0]["pool6_size"].(string); poolSizeExist {
+					res["size"], _ = strconv.Atoi(poolSize)
+				}
+
+				if poolStartAddr, poolStartAddrExist := buf[0]["start_ip6_addr"].(string); poolStartAddrExist {
+					res["start_hex_addr"] = poolStartAddr
+					res["start_addr"] = hexiptoip(poolStartAddr)
+				}
+
+				if poolEndAddr, poolEndAddrExist := buf[0]["end_ip6_addr"].(string); poolEndAddrExist {
+					res["end_hex_addr"] = poolEndAddr
+					res["end_addr"] = hexiptoip(poolEndAddr)
+				}
+
+				lookupcachesetmap(meta, cacheKindIP6Pool, res, "info", siteID, poolName, subnetName)
+				return res, nil
 			}
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP alias: %s - %s associated with IP address ID %s\n", aliasName, ipNameType, addressID))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 pool: %s\n", poolName))
 
-	return "", err
+	return nil, err
 }
 
-// Return an available subnet address from site_id, block_id and expected subnet_size
-// Or an empty string in case of failure
-func ipsubnetfindbysize(siteID string, blockID string, requestedIP string, prefixSize int, meta interface{}) ([]string, error) {
-	subnetAddresses := []string{}
-	s := meta.(*SOLIDserver)
+// Return the value of an IPv6 subnet field whose name depends on the SOLIDserver version
+// 8.x appliances use the subnet6_ prefix (ex: subnet6_class_parameters) while 7.x ones use subnet_ (ex: subnet_class_parameters)
+// Or an empty string if none of them is available
+func ip6subnetfield(info map[string]interface{}, field string, meta interface{}) string {
+	names := []string{"subnet6_" + field, "subnet_" + field}
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("site_id", siteID)
-	parameters.Add("prefix", strconv.Itoa(prefixSize))
-	parameters.Add("max_find", "16")
+	if meta.(*SOLIDserver).Version < 800 {
+		names = []string{"subnet_" + field, "subnet6_" + field}
+	}
 
-	// Specifying a suggested subnet IP address
-	if len(requestedIP) > 0 {
-		subnetAddresses = append(subnetAddresses, iptohexip(requestedIP))
-		return subnetAddresses, nil
+	for _, name := range names {
+		if value, valueExist := info[name].(string); valueExist {
+			return value
+		}
 	}
 
-	// Trying to create a subnet under an existing block
-	parameters.Add("block_id", blockID)
+	return ""
+}
 
-	// Sending the creation request
-	resp, body, err := s.Request("get", "rpc/ip_find_free_subnet", &parameters)
+// Return a map of information about an IPv6 subnet from a row of the subnet list or info services
+// Or nil if the row does not describe a subnet
+func ip6subnetinfofromrow(row map[string]interface{}) map[string]interface{} {
+	subnetID, subnetIDExist := row["subnet6_id"].(string)
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	if !subnetIDExist {
+		return nil
+	}
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			subnetAddresses := []string{}
+	res := map[string]interface{}{"id": subnetID}
 
-			for i := 0; i < len(buf); i++ {
-				if hexaddr, hexaddr_exist := buf[i]["start_ip_addr"].(string); hexaddr_exist {
-					tflog.Debug(s.Ctx, fmt.Sprintf("Suggested IP subnet address: %s\n", hexiptoip(hexaddr)))
-					subnetAddresses = append(subnetAddresses, hexaddr)
-				}
-			}
-			return subnetAddresses, nil
-		}
+	if subnetName, subnetNameExist := row["subnet6_name"].(string); subnetNameExist {
+		res["name"] = subnetName
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free IP subnet in space (oid): %s, block (oid): %s, size: %s\n", siteID, blockID, strconv.Itoa(prefixSize)))
+	if subnetPrefixSize, subnetPrefixSizeExist := row["subnet6_prefix"].(string); subnetPrefixSizeExist {
+		res["prefix_length"], _ = strconv.Atoi(subnetPrefixSize)
+	}
 
-	return []string{}, err
+	if subnetStartAddr, subnetStartAddrExist := row["start_ip6_addr"].(string); subnetStartAddrExist {
+		res["start_hex_addr"] = subnetStartAddr
+		res["start_addr"] = hexiptoip(subnetStartAddr)
+	}
+
+	if subnetEndAddr, subnetEndAddrExist := row["end_ip6_addr"].(string); subnetEndAddrExist {
+		res["end_hex_addr"] = subnetEndAddr
+		res["end_addr"] = hexiptoip(subnetEndAddr)
+	}
+
+	if subnetTerminal, subnetTerminalExist := row["is_terminal"].(string); subnetTerminalExist {
+		res["terminal"] = subnetTerminal
+	}
+
+	if subnetLvl, subnetLvlExist := row["subnet_level"].(string); subnetLvlExist {
+		res["level"] = subnetLvl
+	}
+
+	return res
 }
 
-// Return an available subnet address from site_id, block_id and expected subnet_size
-// Or an empty string in case of failure
-func ip6subnetfindbysize(siteID string, blockID string, requestedIP string, prefixSize int, meta interface{}) ([]string, error) {
-	subnetAddresses := []string{}
+// Return a map of information about an IPv6 subnet from its oid
+// Or nil in case of failure
+func ip6subnetinfobyid(subnetID string, meta interface{}) (map[string]interface{}, error) {
 	s := meta.(*SOLIDserver)
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("site_id", siteID)
-	parameters.Add("prefix", strconv.Itoa(prefixSize))
-	parameters.Add("max_find", "16")
-
-	// Specifying a suggested subnet IP address
-	if len(requestedIP) > 0 {
-		subnetAddresses = append(subnetAddresses, ip6tohexip6(requestedIP))
-		return subnetAddresses, nil
-	}
-
-	// Trying to create a subnet under an existing block
-	parameters.Add("block6_id", blockID)
+	parameters.Add("subnet6_id", subnetID)
 
-	// Sending the creation request
-	resp, body, err := s.Request("get", "rpc/ip6_find_free_subnet6", &parameters)
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_info", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -1148,34 +2015,77 @@ func ip6subnetfindbysize(siteID string, blockID string, requestedIP string, pref
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			subnetAddresses := []string{}
-
-			for i := 0; i < len(buf); i++ {
-				if hexaddr, hexaddr_exist := buf[i]["start_ip6_addr"].(string); hexaddr_exist {
-					tflog.Debug(s.Ctx, fmt.Sprintf("Suggested IPv6 subnet address: %s\n", hexip6toip6(hexaddr)))
-					subnetAddresses = append(subnetAddresses, hexaddr)
-				}
+			if res := ip6subnetinfofromrow(buf[0]); res != nil {
+				res["site_id"], _ = buf[0]["site_id"].(string)
+				res["site_name"], _ = buf[0]["site_name"].(string)
+				return res, nil
 			}
-			return subnetAddresses, nil
 		}
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to find IPv6 subnet (oid): %s\n", subnetID)
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free IPv6 subnet in space (oid): %s, block (oid): %s, size: %s\n", siteID, blockID, strconv.Itoa(prefixSize)))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 subnet (oid): %s\n", subnetID))
 
-	return []string{}, err
+	return nil, err
 }
 
-// Return the oid of a Custom DB from name
-// Or an empty string in case of failure
-func cdbnameidbyname(name string, meta interface{}) (string, error) {
+// Return the description (CIDR and oid) of every terminal IPv6 subnet sharing the same name within a space
+// Or an error in case of failure
+func ip6subnetsbyname(spaceName string, subnetName string, meta interface{}) ([]string, error) {
+	buf, err := listall("rest/ip6_block6_subnet6_list", "site_name='"+whereescape(spaceName)+"' AND subnet6_name='"+whereescape(strings.ToLower(subnetName))+"' AND is_terminal='1'", meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subnets := []string{}
+
+	for _, row := range buf {
+		if info := ip6subnetinfofromrow(row); info != nil {
+			subnets = append(subnets, fmt.Sprintf("%s/%d (oid: %s)", hexip6toip6(infostring(row, "start_ip6_addr")), info["prefix_length"], info["id"]))
+		}
+	}
+
+	return subnets, nil
+}
+
+// Return the number of IPv6 subnets whose parent is the IPv6 block/subnet
+// Or an error in case of failure
+func ip6subnetchildcount(subnetID string, meta interface{}) (int, error) {
+	buf, err := listall("rest/ip6_block6_subnet6_list", "parent_subnet6_id='"+subnetID+"'", meta)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(buf), nil
+}
+
+// Return a map of information about a subnet from site_id, subnet_name and is_terminal property
+// Or nil in case of failure
+func ip6subnetinfobyname(siteID string, subnetName string, terminal bool, meta interface{}) (map[string]interface{}, error) {
 	s := meta.(*SOLIDserver)
 
+	if cached, cachedExist := lookupcachegetmap(meta, cacheKindIP6Subnet, "info", siteID, subnetName, strconv.FormatBool(terminal)); cachedExist {
+		return cached, nil
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "name='"+name+"'")
+
+	whereClause := "site_id='" + siteID + "' AND " + "subnet6_name='" + whereescape(strings.ToLower(subnetName)) + "'"
+
+	if terminal {
+		whereClause += "AND is_terminal='1'"
+	} else {
+		whereClause += "AND is_terminal='0'"
+	}
+
+	parameters.Add("WHERE", whereClause)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/custom_db_name_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -1183,143 +2093,1073 @@ func cdbnameidbyname(name string, meta interface{}) (string, error) {
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if cdbnameID, cdbnameIDExist := buf[0]["custom_db_name_id"].(string); cdbnameIDExist {
+			if res := ip6subnetinfofromrow(buf[0]); res != nil {
+				lookupcachesetmap(meta, cacheKindIP6Subnet, res, "info", siteID, subnetName, strconv.FormatBool(terminal))
+				return res, nil
+			}
+		}
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to find IPv6 subnet: %s\n", subnetName)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 subnet: %s\n", subnetName))
+
+	return nil, err
+}
+
+// Return the oid of an address from site_id, ip_address
+// Or an empty string in case of failure
+func ipaddressidbyip(siteID string, ipAddress string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"ip_addr='"+iptohexip(ipAddress)+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_address_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if ipID, ipIDExist := buf[0]["ip_id"].(string); ipIDExist {
+				return ipID, nil
+			}
+		}
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP address: %s\n", ipAddress))
+
+	return "", err
+}
+
+// Return a map of information (id, address) about an address registered with a given name within a subnet
+// Or nil if none is found or in case of failure
+func ipaddressinfobyname(subnetID string, ipName string, meta interface{}) (map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "subnet_id='"+subnetID+"' AND "+"name='"+whereescape(ipName)+"'")
+	parameters.Add("limit", "1")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_used_address_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if ipID, ipIDExist := buf[0]["ip_id"].(string); ipIDExist {
+				ipAddr, _ := buf[0]["ip_addr"].(string)
+
+				return map[string]interface{}{
+					"id":      ipID,
+					"address": hexiptoip(ipAddr),
+				}, nil
+			}
+		}
+
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			return nil, fmt.Errorf("SOLIDServer - Unable to look for IP address: %s\n", ipName)
+		}
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP address: %s\n", ipName))
+
+	return nil, err
+}
+
+// Return the oid of an address from site_id, ip_address
+// Or an empty string in case of failure
+func ip6addressidbyip6(siteID string, ipAddress string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"ip6_addr='"+ip6tohexip6(ipAddress)+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip6_address6_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if ipID, ipIDExist := buf[0]["ip6_id"].(string); ipIDExist {
+				return ipID, nil
+			}
+		}
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 address: %s\n", ipAddress))
+
+	return "", err
+}
+
+// Return the oid of an address from ip_id, ip_name_type, alias_name
+// Or an empty string in case of failure
+func ipaliasidbyinfo(addressID string, aliasName string, ipNameType string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", addressID)
+	parameters.Add("WHERE", "ip_name_type='"+whereescape(ipNameType)+"' AND "+"alias_name='"+whereescape(aliasName)+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_alias_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if ip_name_id, ip_name_id_exist := buf[0]["ip_name_id"].(string); ip_name_id_exist {
+				return ip_name_id, nil
+			}
+		}
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP alias: %s - %s associated with IP address ID %s\n", aliasName, ipNameType, addressID))
+
+	return "", err
+}
+
+// Check if a prefix length complies with the min/max allocation sizes (0 meaning no constraint)
+// maxAllocationSize is the shortest acceptable prefix length, minAllocationSize the longest one
+func ipsubnetallocationsizeallowed(prefixLength int, maxAllocationSize int, minAllocationSize int) bool {
+	if maxAllocationSize > 0 && prefixLength < maxAllocationSize {
+		return false
+	}
+	if minAllocationSize > 0 && prefixLength > minAllocationSize {
+		return false
+	}
+	return true
+}
+
+// Return an available subnet address from site_id, block_id and expected subnet_size
+// The expected subnet_size must comply with the allocation sizes (0 meaning no constraint),
+// whatever the size of the free ranges holding the candidates
+// Or an empty string in case of failure
+func ipsubnetfindbysize(siteID string, blockID string, requestedIP string, prefixSize int, maxAllocationSize int, minAllocationSize int, meta interface{}) ([]string, error) {
+	subnetAddresses := []string{}
+	s := meta.(*SOLIDserver)
+
+	if !ipsubnetallocationsizeallowed(prefixSize, maxAllocationSize, minAllocationSize) {
+		return subnetAddresses, fmt.Errorf("SOLIDServer - IP subnet size /%d is out of the allowed allocation sizes\n", prefixSize)
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("prefix", strconv.Itoa(prefixSize))
+	parameters.Add("max_find", "16")
+
+	// Specifying a suggested subnet IP address
+	if len(requestedIP) > 0 {
+		subnetAddresses = append(subnetAddresses, iptohexip(requestedIP))
+		return subnetAddresses, nil
+	}
+
+	// Trying to create a subnet under an existing block
+	parameters.Add("block_id", blockID)
+
+	// Sending the creation request
+	resp, body, err := s.Request("get", "rpc/ip_find_free_subnet", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			subnetAddresses := []string{}
+
+			for i := 0; i < len(buf); i++ {
+				if hexaddr, hexaddr_exist := buf[i]["start_ip_addr"].(string); hexaddr_exist {
+					tflog.Debug(s.Ctx, fmt.Sprintf("Suggested IP subnet address: %s\n", hexiptoip(hexaddr)))
+					subnetAddresses = append(subnetAddresses, hexaddr)
+				}
+			}
+			return subnetAddresses, nil
+		}
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free IP subnet in space (oid): %s, block (oid): %s, size: %s\n", siteID, blockID, strconv.Itoa(prefixSize)))
+
+	return []string{}, err
+}
+
+// Return an available subnet address from site_id, block_id and expected subnet_size
+// Or an empty string in case of failure
+func ip6subnetfindbysize(siteID string, blockID string, requestedIP string, prefixSize int, meta interface{}) ([]string, error) {
+	subnetAddresses := []string{}
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("prefix", strconv.Itoa(prefixSize))
+	parameters.Add("max_find", "16")
+
+	// Specifying a suggested subnet IP address
+	if len(requestedIP) > 0 {
+		subnetAddresses = append(subnetAddresses, ip6tohexip6(requestedIP))
+		return subnetAddresses, nil
+	}
+
+	// Trying to create a subnet under an existing block
+	parameters.Add("block6_id", blockID)
+
+	// Sending the creation request
+	resp, body, err := s.Request("get", "rpc/ip6_find_free_subnet6", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			subnetAddresses := []string{}
+
+			for i := 0; i < len(buf); i++ {
+				if hexaddr, hexaddr_exist := buf[i]["start_ip6_addr"].(string); hexaddr_exist {
+					tflog.Debug(s.Ctx, fmt.Sprintf("Suggested IPv6 subnet address: %s\n", hexip6toip6(hexaddr)))
+					subnetAddresses = append(subnetAddresses, hexaddr)
+				}
+			}
+			return subnetAddresses, nil
+		}
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free IPv6 subnet in space (oid): %s, block (oid): %s, size: %s\n", siteID, blockID, strconv.Itoa(prefixSize)))
+
+	return []string{}, err
+}
+
+// Return the oid of a Custom DB from name
+// Or an empty string in case of failure
+func cdbnameidbyname(name string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	if cached, cachedExist := lookupcachegetstring(meta, cacheKindCdb, name); cachedExist {
+		return cached, nil
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "name='"+whereescape(name)+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/custom_db_name_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if cdbnameID, cdbnameIDExist := buf[0]["custom_db_name_id"].(string); cdbnameIDExist {
+				lookupcachesetstring(meta, cacheKindCdb, cdbnameID, name)
 				return cdbnameID, nil
 			}
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find Custom DB: %s\n", name))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find Custom DB: %s\n", name))
+
+	return "", err
+}
+
+// Update a DNS SMART member's role list
+// Return false in case of failure
+func dnssmartmembersupdate(smartName string, smartMembersRole string, meta interface{}) bool {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters for retrieving SMART vdns_dns_group_role information
+	parameters := url.Values{}
+	parameters.Add("dns_name", smartName)
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("vdns_dns_group_role", smartMembersRole)
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/dns_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			return true
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update members list of the DNS SMART: %s (%s)\n", smartName, errMsg))
+			}
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update members list of the DNS SMART: %s\n", smartName))
+		}
+	}
+
+	return false
+}
+
+// Get DNS Server status
+// Return an empty string in case of failure the server status otherwise (Y -> OK)
+func dnsserverstatus(serverID string, meta interface{}) string {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters for retrieving information
+	parameters := url.Values{}
+	parameters.Add("dns_id", serverID)
+
+	// Sending the get request
+	resp, body, err := s.Request("get", "rest/dns_server_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if state, stateExist := buf[0]["dns_state"].(string); stateExist {
+				return state
+			}
+			return ""
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server status: %s (%s)\n", serverID, errMsg))
+			}
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server status: %s\n", serverID))
+		}
+	}
+
+	return ""
+}
+
+// Count the objects of a count service (ex: rest/dns_rr_count) matching a WHERE clause
+// Return the number of objects
+// Or -1 and an error in case of failure
+func countall(service string, whereClause string, meta interface{}) (int, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+
+	if whereClause != "" {
+		parameters.Add("WHERE", whereClause)
+	}
+
+	// Sending the read request
+	resp, body, err := s.Request("get", service, &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 204 {
+			return 0, nil
+		}
+
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if total, totalExist := buf[0]["total"].(string); totalExist {
+				if count, countErr := strconv.Atoi(total); countErr == nil {
+					return count, nil
+				}
+			}
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to count objects using: %s (%s)\n", service, errMsg))
+			}
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to count objects using: %s\n", service))
+		}
+
+		return -1, fmt.Errorf("SOLIDServer - Unable to count objects using: %s\n", service)
+	}
+
+	return -1, err
+}
+
+// Decode a part of a composite import ID, reserved characters can be URL-style escaped (ex: %2F for '/', %3A for ':')
+// Parts that are not validly escaped are returned as is
+func importidunescape(part string) string {
+	if unescaped, err := url.PathUnescape(part); err == nil {
+		return unescaped
+	}
+
+	return part
+}
+
+// Encode a part of a composite import ID, escaping the reserved characters (ex: '/' as %2F, ':' as %3A)
+func importidescape(part string) string {
+	return strings.ReplaceAll(url.PathEscape(part), ":", "%3A")
+}
+
+// Split a composite import ID (ex: "server/view" or "server:view") into at most maxParts parts
+// Return nil if the ID is not a composite one (ex: an oid)
+func importidparse(id string, maxParts int) []string {
+	var parts []string
+
+	// ':' takes precedence as it never appears in server, view nor zone names (unlike '/' in classless reverse zones)
+	if strings.Contains(id, ":") {
+		parts = strings.SplitN(id, ":", maxParts)
+	} else if strings.Contains(id, "/") {
+		parts = strings.SplitN(id, "/", maxParts)
+	} else {
+		return nil
+	}
+
+	for i := range parts {
+		parts[i] = importidunescape(parts[i])
+	}
+
+	return parts
+}
+
+// Split a composite pool import ID (space/subnet/pool or space:subnet:pool) into its space, subnet and pool names
+// Unlike importidparse, the subnet name of the '/' form may contain slashes (ex: 10.0.0.0/24) or colons (ex: 2001:db8::/64)
+// Return false if the ID is not a composite one (ex: an oid)
+func poolimportidsplit(id string) (string, string, string, bool) {
+	first := strings.Index(id, "/")
+	last := strings.LastIndex(id, "/")
+
+	if first <= 0 || last-first < 2 || last == len(id)-1 {
+		if !strings.Contains(id, "/") && strings.Count(id, ":") == 2 {
+			parts := importidparse(id, 3)
+			return parts[0], parts[1], parts[2], true
+		}
+
+		return "", "", "", false
+	}
+
+	return importidunescape(id[:first]), importidunescape(id[first+1 : last]), importidunescape(id[last+1:]), true
+}
+
+// Return the oid of the single object matching a WHERE clause for import purposes
+// Or an error listing the candidates (matching the candidatesWhere clause) if none or several objects are matching
+func importidbywhere(objectType string, service string, whereClause string, candidatesWhere string, idField string, nameField string, meta interface{}) (string, error) {
+	buf, err := listall(service, whereClause, meta)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(buf) == 1 {
+		if oid, oidExist := buf[0][idField].(string); oidExist {
+			return oid, nil
+		}
+	}
+
+	if len(buf) > 1 {
+		matches := []string{}
+
+		for _, object := range buf {
+			oid, _ := object[idField].(string)
+			name, _ := object[nameField].(string)
+			matches = append(matches, name+" (oid: "+oid+")")
+		}
+
+		return "", fmt.Errorf("SOLIDServer - Multiple %ss matching the import ID: %s\n", objectType, strings.Join(matches, ", "))
+	}
+
+	candidates, candidatesErr := listall(service, candidatesWhere, meta)
+
+	if candidatesErr != nil {
+		return "", candidatesErr
+	}
+
+	names := []string{}
+
+	for _, object := range candidates {
+		if name, nameExist := object[nameField].(string); nameExist {
+			names = append(names, name)
+		}
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to find %s matching the import ID, candidates: %s\n", objectType, strings.Join(names, ", "))
+}
+
+// Get DNS Server View Support
+// Return an true if the DNS Server has views
+func dnsserverhasviews(serverName string, meta interface{}) bool {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters for retrieving information
+	parameters := url.Values{}
+
+	whereClause := "dns_name='" + whereescape(serverName) + "'"
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the get request
+	resp, body, err := s.Request("get", "rest/dns_view_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 204 {
+			return false
+		}
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			return true
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server views (%s)\n", errMsg))
+			}
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server views\n"))
+		}
+	}
+
+	return true
+}
+
+// Return the longest zone name among zones matching the suffix of a record name
+// Or an empty string if none of the zones is matching
+func dnszonelongestsuffix(rrName string, zones []string) string {
+	name := strings.TrimSuffix(strings.ToLower(rrName), ".")
+	result := ""
+
+	for _, zone := range zones {
+		zoneName := strings.TrimSuffix(strings.ToLower(zone), ".")
+
+		if zoneName == "" || len(zoneName) <= len(result) {
+			continue
+		}
+
+		if name == zoneName || strings.HasSuffix(name, "."+zoneName) {
+			result = zoneName
+		}
+	}
+
+	return result
+}
+
+// Find the zone hosting a record on a DNS server (and view)
+// Return the longest matching zone name, or an empty string if no zone is matching
+// Or an empty string and an error in case of failure
+func dnszonefindbyrrname(serverName string, viewName string, rrName string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	whereClause := "dns_name='" + whereescape(serverName) + "'"
+
+	if viewName != "" {
+		whereClause += " AND dnsview_name='" + whereescape(viewName) + "'"
+	}
+
+	// Listing every zone of the server, the best match may be on any page
+	buf, err := listall("rest/dns_zone_list", whereClause, meta)
+
+	if err != nil {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS zones of server: %s\n", serverName))
+
+		return "", fmt.Errorf("SOLIDServer - Unable to retrieve DNS zones of server: %s\n", serverName)
+	}
+
+	zones := []string{}
+
+	for _, zone := range buf {
+		if zoneName, zoneNameExist := zone["dnszone_name"].(string); zoneNameExist {
+			zones = append(zones, zoneName)
+		}
+	}
+
+	return dnszonelongestsuffix(rrName, zones), nil
+}
+
+// Check whether a DNS zone (including zones pending deletion) is still listed on a server/view
+// Return true if the zone exists, false otherwise
+// Or an error in case of failure
+func dnszoneexists(serverName string, viewName string, zoneName string, meta interface{}) (bool, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	whereClause := "dns_name='" + whereescape(serverName) + "' AND dnszone_name='" + whereescape(zoneName) + "'"
+
+	if viewName != "" && viewName != "#" {
+		whereClause += " AND dnsview_name='" + whereescape(viewName) + "'"
+	}
+
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dns_zone_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 204 {
+			return false, nil
+		}
+
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if _, zoneIDExist := buf[0]["dnszone_id"].(string); zoneIDExist {
+				return true, nil
+			}
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS zone: %s (%s)\n", zoneName, errMsg))
+			}
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS zone: %s\n", zoneName))
+		}
+
+		return false, fmt.Errorf("SOLIDServer - Unable to retrieve DNS zone: %s\n", zoneName)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS zone: %s\n", zoneName))
+
+	return false, err
+}
+
+// Return the target of the first PTR record with the given owner name
+// Or false if none is found, an error in case of failure
+func iplookupptr(ptrName string, meta interface{}) (string, bool, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "rr_full_name='"+whereescape(ptrName)+"' AND rr_type='PTR'")
+	parameters.Add("limit", "1")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dns_rr_list", &parameters)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode == 204 || (resp.StatusCode == 200 && len(buf) == 0) {
+		return "", false, nil
+	}
+
+	if resp.StatusCode == 200 && len(buf) > 0 {
+		if target, targetExist := buf[0]["value1"].(string); targetExist {
+			return target, true, nil
+		}
+	}
+
+	// Log the error
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve PTR record: %s (%s)\n", ptrName, errMsg))
+		}
+	}
+
+	return "", false, fmt.Errorf("SOLIDServer - Unable to retrieve PTR record: %s\n", ptrName)
+}
+
+// Return a description of the A and PTR records of an IP address name pointing at another address
+// (ex: left behind when the address moved to another subnet), they are reported but never deleted
+// The lookup is limited to a DNS server, and to one of its views if set
+// Or an error in case of failure
+func ipaddressstalerecords(name string, address string, serverName string, viewName string, meta interface{}) ([]string, error) {
+	result := []string{}
+	name = strings.TrimSuffix(name, ".")
+
+	if name == "" {
+		return result, nil
+	}
+
+	scope := "dns_name='" + whereescape(serverName) + "'"
+
+	if viewName != "" {
+		scope += " AND dnsview_name='" + whereescape(viewName) + "'"
+	}
+
+	records, err := listall("rest/dns_rr_list", scope+" AND rr_full_name='"+whereescape(name)+"' AND rr_type='A'", meta)
+
+	if err != nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to list the A records of: %s (%s)\n", name, strings.TrimSpace(err.Error()))
+	}
+
+	for _, record := range records {
+		if value := infostring(record, "value1"); value != address {
+			result = append(result, fmt.Sprintf("A %s -> %s (zone: %s, view: %s)", name, value, infostring(record, "dnszone_name"), infostring(record, "dnsview_name")))
+		}
+	}
+
+	records, err = listall("rest/dns_rr_list", scope+" AND rr_type='PTR' AND (value1='"+whereescape(name)+"' OR value1='"+whereescape(name)+".')", meta)
+
+	if err != nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to list the PTR records of: %s (%s)\n", name, strings.TrimSpace(err.Error()))
+	}
+
+	for _, record := range records {
+		if ptrName := strings.TrimSuffix(infostring(record, "rr_full_name"), "."); !strings.EqualFold(ptrName, iptoptr(address)) {
+			result = append(result, fmt.Sprintf("PTR %s -> %s (zone: %s, view: %s)", ptrName, name, infostring(record, "dnszone_name"), infostring(record, "dnsview_name")))
+		}
+	}
+
+	return result, nil
+}
+
+// Return the information of a RR from its server, view, zone, name, type and value
+// Or nil if the RR does not exist, an error in case of failure
+func dnsrrinfo(serverName string, viewName string, zoneName string, rrName string, rrType string, value string, meta interface{}) (map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+
+	if strings.ToUpper(rrType) == "AAAA" {
+		value = shortip6tolongip6(value)
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	whereClause := "dns_name='" + whereescape(serverName) + "' AND dnszone_name='" + whereescape(zoneName) + "' AND " + rrnamewhere(rrName) + " AND rr_type='" + strings.ToUpper(rrType) + "' AND value1='" + whereescape(value) + "'"
+
+	if viewName != "" && viewName != "#" {
+		whereClause += " AND dnsview_name='" + whereescape(viewName) + "'"
+	} else {
+		whereClause += " AND dnsview_name='#'"
+	}
+
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dns_rr_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 204 {
+			return nil, nil
+		}
+
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if _, rrIDExist := buf[0]["rr_id"].(string); rrIDExist {
+				return buf[0], nil
+			}
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve RR: %s (%s)\n", rrName, errMsg))
+			}
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve RR: %s\n", rrName))
+		}
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to retrieve RR: %s\n", rrName)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve RR: %s\n", rrName))
+
+	return nil, err
+}
+
+// Create a RR within a zone
+// Return the ID of the created RR
+// Or an error in case of failure
+func dnsrradd(serverName string, viewName string, zoneName string, rrName string, rrType string, value string, ttl int, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("dns_name", serverName)
+	parameters.Add("dnszone_name", zoneName)
+	parameters.Add("rr_name", rrName)
+	parameters.Add("rr_type", strings.ToUpper(rrType))
+	parameters.Add("value1", value)
+	parameters.Add("rr_ttl", strconv.Itoa(ttl))
+
+	if viewName != "" && viewName != "#" {
+		parameters.Add("dnsview_name", viewName)
+	}
+
+	// Sending the creation request
+	resp, body, err := s.Request("post", "rest/dns_rr_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				return oid, nil
+			}
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to create RR: %s (%s)\n", rrName, errMsg))
+				return "", fmt.Errorf("SOLIDServer - Unable to create RR: %s (%s)\n", rrName, errMsg)
+			}
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to create RR: %s\n", rrName))
+
+		return "", fmt.Errorf("SOLIDServer - Unable to create RR: %s\n", rrName)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to create RR: %s\n", rrName))
 
 	return "", err
 }
 
-// Update a DNS SMART member's role list
-// Return false in case of failure
-func dnssmartmembersupdate(smartName string, smartMembersRole string, meta interface{}) bool {
+// Update the value and TTL of a RR from its ID
+// Return an error in case of failure
+func dnsrrupdate(rrID string, serverName string, viewName string, zoneName string, rrName string, rrType string, value string, ttl int, meta interface{}) error {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters for retrieving SMART vdns_dns_group_role information
+	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("dns_name", smartName)
+	parameters.Add("rr_id", rrID)
 	parameters.Add("add_flag", "edit_only")
-	parameters.Add("vdns_dns_group_role", smartMembersRole)
+	parameters.Add("dns_name", serverName)
+	parameters.Add("dnszone_name", zoneName)
+	parameters.Add("rr_name", rrName)
+	parameters.Add("rr_type", strings.ToUpper(rrType))
+	parameters.Add("value1", value)
+	parameters.Add("rr_ttl", strconv.Itoa(ttl))
+
+	if viewName != "" && viewName != "#" {
+		parameters.Add("dnsview_name", viewName)
+	}
 
 	// Sending the update request
-	resp, body, err := s.Request("put", "rest/dns_add", &parameters)
+	resp, body, err := s.Request("put", "rest/dns_rr_add", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
 		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			return true
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if _, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				return nil
+			}
 		}
 
 		// Log the error
 		if len(buf) > 0 {
 			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update members list of the DNS SMART: %s (%s)\n", smartName, errMsg))
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update RR: %s (%s)\n", rrName, errMsg))
+				return fmt.Errorf("SOLIDServer - Unable to update RR: %s (%s)\n", rrName, errMsg)
 			}
-		} else {
-			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update members list of the DNS SMART: %s\n", smartName))
 		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update RR: %s\n", rrName))
+
+		return fmt.Errorf("SOLIDServer - Unable to update RR: %s\n", rrName)
 	}
 
-	return false
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update RR: %s\n", rrName))
+
+	return err
 }
 
-// Get DNS Server status
-// Return an empty string in case of failure the server status otherwise (Y -> OK)
-func dnsserverstatus(serverID string, meta interface{}) string {
+// Delete a RR from its ID
+// Return an error in case of failure
+func dnsrrdelete(rrID string, meta interface{}) error {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters for retrieving information
+	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("dns_id", serverID)
+	parameters.Add("rr_id", rrID)
 
-	// Sending the get request
-	resp, body, err := s.Request("get", "rest/dns_server_info", &parameters)
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/dns_rr_delete", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
 		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if state, stateExist := buf[0]["dns_state"].(string); stateExist {
-				return state
-			}
-			return ""
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			return nil
 		}
 
 		// Log the error
 		if len(buf) > 0 {
 			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server status: %s (%s)\n", serverID, errMsg))
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to delete RR (oid): %s (%s)\n", rrID, errMsg))
+				return fmt.Errorf("SOLIDServer - Unable to delete RR (oid): %s (%s)\n", rrID, errMsg)
 			}
-		} else {
-			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server status: %s\n", serverID))
 		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to delete RR (oid): %s\n", rrID))
+
+		return fmt.Errorf("SOLIDServer - Unable to delete RR (oid): %s\n", rrID)
 	}
 
-	return ""
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to delete RR (oid): %s\n", rrID))
+
+	return err
 }
 
-// Get DNS Server View Support
-// Return an true if the DNS Server has views
-func dnsserverhasviews(serverName string, meta interface{}) bool {
+// Re-apply the A and AAAA RRs of a DNS zone for SOLIDserver to re-create their PTR RRs (ex: after a change of space)
+// Return the number of re-applied RRs or an error in case of failure
+func dnszoneptrresync(zoneID string, meta interface{}) (int, error) {
+	rrs, err := listall("rest/dns_rr_list", "dnszone_id='"+zoneID+"' AND rr_type IN ('A','AAAA')", meta)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for i, rr := range rrs {
+		rrID, _ := rr["rr_id"].(string)
+		serverName, _ := rr["dns_name"].(string)
+		viewName, _ := rr["dnsview_name"].(string)
+		zoneName, _ := rr["dnszone_name"].(string)
+		rrName, _ := rr["rr_full_name"].(string)
+		rrType, _ := rr["rr_type"].(string)
+		value, _ := rr["value1"].(string)
+		ttl, _ := strconv.Atoi(fmt.Sprintf("%v", rr["ttl"]))
+
+		if err := dnsrrupdate(rrID, serverName, viewName, zoneName, rrName, rrType, value, ttl, meta); err != nil {
+			return i, err
+		}
+	}
+
+	return len(rrs), nil
+}
+
+// Return the number of RRs of a DNS zone by type and the sorted names of its delegations (NS RRs not at the apex)
+// The RRs are aggregated page by page, large zones are never held in memory
+// Or an error in case of failure
+func dnszonestatistics(zoneID string, zoneName string, meta interface{}) (map[string]int, []string, error) {
+	counts := map[string]int{}
+	delegations := map[string]bool{}
+
+	err := listeach("rest/dns_rr_list", "dnszone_id='"+whereescape(zoneID)+"'", meta, func(rr map[string]interface{}) bool {
+		rrType := strings.ToUpper(infostring(rr, "rr_type"))
+		counts[rrType]++
+
+		if rrName := strings.TrimSuffix(rrnameunescape(infostring(rr, "rr_full_name")), "."); rrType == "NS" && !strings.EqualFold(rrName, strings.TrimSuffix(zoneName, ".")) {
+			delegations[strings.ToLower(rrName)] = true
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("SOLIDServer - Unable to list the RRs of DNS zone: %s (%s)\n", zoneName, strings.TrimSpace(err.Error()))
+	}
+
+	names := make([]string, 0, len(delegations))
+
+	for name := range delegations {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return counts, names, nil
+}
+
+// Request a reload of a DNS zone on its server
+// Return an error in case of failure
+func dnszonereload(zoneID string, meta interface{}) error {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters for retrieving information
+	// Building parameters
 	parameters := url.Values{}
+	parameters.Add("dnszone_id", zoneID)
 
-	whereClause := "dns_name='" + serverName + "'"
-	parameters.Add("WHERE", whereClause)
-
-	// Sending the get request
-	resp, body, err := s.Request("get", "rest/dns_view_list", &parameters)
+	// Sending the reload request
+	resp, body, err := s.Request("put", "rest/dns_zone_reload", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
 		// Checking the answer
-		if resp.StatusCode == 204 {
-			return false
-		}
-
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			return true
+		if resp.StatusCode == 200 || resp.StatusCode == 201 || resp.StatusCode == 204 {
+			return nil
 		}
 
 		// Log the error
 		if len(buf) > 0 {
 			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server views (%s)\n", errMsg))
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to reload DNS zone (oid): %s (%s)\n", zoneID, errMsg))
+				return fmt.Errorf("SOLIDServer - Unable to reload DNS zone (oid): %s (%s)\n", zoneID, errMsg)
 			}
-		} else {
-			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server views\n"))
 		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to reload DNS zone (oid): %s\n", zoneID))
+
+		return fmt.Errorf("SOLIDServer - Unable to reload DNS zone (oid): %s\n", zoneID)
 	}
 
-	return true
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to reload DNS zone (oid): %s\n", zoneID))
+
+	return err
 }
 
-// Get number of pending deletion operations on DNS server
-// Return -1 in case of failure
-func dnsserverpendingdeletions(serverID string, meta interface{}) int {
+// Return the information of a DNS zone from its server, optional view and name
+// Or nil if the zone doesn't exist
+// Or an error in case of failure
+func dnszoneinfobyname(serverName string, viewName string, zoneName string, meta interface{}) (map[string]interface{}, error) {
+	whereClause := "dns_name='" + whereescape(serverName) + "' AND dnszone_name='" + whereescape(zoneName) + "'"
+
+	if viewName != "" && viewName != "#" {
+		whereClause += " AND dnsview_name='" + whereescape(viewName) + "'"
+	}
+
+	zones, err := listall("rest/dns_zone_list", whereClause, meta)
+
+	if err != nil || len(zones) == 0 {
+		return nil, err
+	}
+
+	return zones[0], nil
+}
+
+// Return the SOA serial of a DNS zone
+// Or false if the zone doesn't exist
+// Or an error in case of failure
+func dnszoneserial(zoneID string, meta interface{}) (uint32, bool, error) {
 	s := meta.(*SOLIDserver)
-	result := 0
 
-	// Building parameters for retrieving information
+	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "delayed_delete_time='1' AND dns_id='"+serverID+"'")
+	parameters.Add("dnszone_id", zoneID)
 
-	// Sending the get request
-	resp, body, err := s.Request("get", "rest/dns_zone_count", &parameters)
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dns_zone_info", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -1327,56 +3167,245 @@ func dnsserverpendingdeletions(serverID string, meta interface{}) int {
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if total, totalExist := buf[0]["total"].(string); totalExist {
-				inc, _ := strconv.Atoi(total)
-				result += inc
-			} else {
-				return -1
+			if _, errExist := buf[0]["errmsg"].(string); !errExist {
+				serial, _ := strconv.ParseUint(infostring(buf[0], "dnszone_serial"), 10, 32)
+				return uint32(serial), true, nil
 			}
 		}
-		// Log the error
-		if len(buf) > 0 {
-			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server pending operations: %s (%s)\n", serverID, errMsg))
-			}
-		} else {
-			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server pending operations: %s\n", serverID))
+
+		if resp.StatusCode == 204 || resp.StatusCode == 400 || resp.StatusCode == 404 {
+			return 0, false, nil
 		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to read the serial of DNS zone (oid): %s\n", zoneID))
+
+		return 0, false, fmt.Errorf("SOLIDServer - Unable to read the serial of DNS zone (oid): %s\n", zoneID)
 	}
 
-	// Building parameters for retrieving information
-	parameters = url.Values{}
-	parameters.Add("WHERE", "delayed_delete_time='1' AND dns_id='"+serverID+"'")
+	return 0, false, err
+}
 
-	// Sending the get request
-	resp, body, err = s.Request("get", "rest/dns_view_count", &parameters)
+// Return true if a SOA serial is greater than another one, using serial number arithmetic (RFC 1982)
+func dnsserialgreater(serial uint32, than uint32) bool {
+	return int32(serial-than) > 0
+}
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+// Return the sorted logins of the members of a group
+// Or an error in case of failure
+func groupmembers(groupID string, meta interface{}) ([]string, error) {
+	users, err := listall("rest/group_user_list", "grp_id='"+whereescape(groupID)+"'", meta)
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if total, totalExist := buf[0]["total"].(string); totalExist {
-				inc, _ := strconv.Atoi(total)
-				result += inc
-			} else {
-				return -1
-			}
+	if err != nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to list the members of group (oid): %s (%s)\n", groupID, err)
+	}
+
+	logins := make([]string, 0, len(users))
+
+	for _, user := range users {
+		if login := infostring(user, "usr_login"); login != "" {
+			logins = append(logins, login)
 		}
-		// Log the error
-		if len(buf) > 0 {
-			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server pending operations: %s (%s)\n", serverID, errMsg))
+	}
+
+	sort.Strings(logins)
+
+	return logins, nil
+}
+
+// Return the resources a group is granted permissions on, sorted by type then name
+// Or an empty list if this SOLIDserver version doesn't expose them, an error in case of failure
+func grouppermissions(groupID string, meta interface{}) ([]interface{}, error) {
+	if meta.(*SOLIDserver).Version < versionGroupResources {
+		return []interface{}{}, nil
+	}
+
+	resources, err := listall("rest/group_resource_list", "grp_id='"+whereescape(groupID)+"'", meta)
+
+	if err != nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to list the permissions of group (oid): %s (%s)\n", groupID, err)
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if type1, type2 := infostring(resources[i], "resource_type"), infostring(resources[j], "resource_type"); type1 != type2 {
+			return type1 < type2
+		}
+
+		return infostring(resources[i], "resource_name") < infostring(resources[j], "resource_name")
+	})
+
+	permissions := make([]interface{}, 0, len(resources))
+
+	for _, resource := range resources {
+		permissions = append(permissions, map[string]interface{}{
+			"type": infostring(resource, "resource_type"),
+			"name": infostring(resource, "resource_name"),
+		})
+	}
+
+	return permissions, nil
+}
+
+// Compute the Levenshtein distance between two strings
+func levenshtein(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
 			}
-		} else {
-			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server pending operations: %s\n", serverID))
+
+			curr[j] = prev[j] + 1
+
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// Return the closest candidate to a name (case insensitive)
+// Or an empty string if none is close enough (distance above half of the name length)
+func closestmatch(name string, candidates []string) string {
+	result := ""
+	best := len(name)/2 + 1
+
+	for _, candidate := range candidates {
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); distance < best {
+			best = distance
+			result = candidate
 		}
 	}
 
 	return result
 }
 
+// Build a "not found" error message including the closest existing name if any
+func notfounderror(objectType string, name string, location string, candidates []string) error {
+	if suggestion := closestmatch(name, candidates); suggestion != "" {
+		return fmt.Errorf("%s '%s' not found on %s (did you mean '%s'?)", objectType, name, location, suggestion)
+	}
+
+	return fmt.Errorf("%s '%s' not found on %s", objectType, name, location)
+}
+
+// Ensure a DNS server, and a view of this server when specified, exists
+// Return nil if they exist
+// Or an error including the closest existing name in case of failure
+func dnsservervalidate(serverName string, viewName string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	servers, serversErr := listall("rest/dns_server_list", "", meta)
+
+	if serversErr != nil {
+		return serversErr
+	}
+
+	serverNames := []string{}
+	serverFound := false
+
+	for _, server := range servers {
+		if name, nameExist := server["dns_name"].(string); nameExist {
+			serverNames = append(serverNames, name)
+			serverFound = serverFound || strings.EqualFold(name, serverName)
+		}
+	}
+
+	if !serverFound {
+		return notfounderror("DNS server", serverName, s.Host, serverNames)
+	}
+
+	if viewName == "" || viewName == "#" {
+		return nil
+	}
+
+	views, viewsErr := listall("rest/dns_view_list", "dns_name='"+whereescape(serverName)+"'", meta)
+
+	if viewsErr != nil {
+		return viewsErr
+	}
+
+	viewNames := []string{}
+
+	for _, view := range views {
+		if name, nameExist := view["dnsview_name"].(string); nameExist {
+			if strings.EqualFold(name, viewName) {
+				return nil
+			}
+
+			viewNames = append(viewNames, name)
+		}
+	}
+
+	return notfounderror("DNS view", viewName, "DNS server '"+serverName+"'", viewNames)
+}
+
+// Return a CustomizeDiff function validating the DNS server (and view) referenced by a resource
+// The validation is skipped when values are not known yet, or unchanged
+// DNS servers and views created within the same plan under a literal name require disable_plan_validation
+func resourcediffvalidatednsserver(viewKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if meta == nil || meta.(*SOLIDserver).DisablePlanValidation {
+			return nil
+		}
+
+		if !d.NewValueKnown("dnsserver") || (viewKey != "" && !d.NewValueKnown(viewKey)) {
+			return nil
+		}
+
+		if d.Id() != "" && !d.HasChange("dnsserver") && (viewKey == "" || !d.HasChange(viewKey)) {
+			return nil
+		}
+
+		viewName := ""
+
+		if viewKey != "" {
+			viewName = d.Get(viewKey).(string)
+		}
+
+		return dnsservervalidate(d.Get("dnsserver").(string), viewName, meta)
+	}
+}
+
+// Get number of pending deletion operations on DNS server
+// Or 0 and an error in case of failure (ex: DNS server already deleted)
+func dnsserverpendingdeletions(serverID string, meta interface{}) (int, error) {
+	s := meta.(*SOLIDserver)
+	result := 0
+
+	for _, service := range []string{"rest/dns_zone_count", "rest/dns_view_count"} {
+		pending, pendingErr := countall(service, "delayed_delete_time='1' AND dns_id='"+serverID+"'", meta)
+
+		if pendingErr != nil {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to retrieve DNS server pending operations: %s (%s)\n", serverID, pendingErr))
+			return 0, pendingErr
+		}
+
+		result += pending
+	}
+
+	return result, nil
+}
+
 // Set a DNSserver or DNSview param value
 // Return false in case of failure
 func dnsparamset(serverName string, viewID string, paramKey string, paramValue string, meta interface{}) bool {
@@ -1467,8 +3496,9 @@ func dnsparamunset(serverName string, viewID string, paramKey string, meta inter
 }
 
 // Get a DNSserver or DNSview param's value
-// Return an empty string and an error in case of failure
-func dnsparamget(serverName string, viewID string, paramKey string, meta interface{}) (string, error) {
+// Return the value and whether the param is set (the value of a set param can be empty)
+// Or an error in case of failure
+func dnsparamget(serverName string, viewID string, paramKey string, meta interface{}) (string, bool, error) {
 	s := meta.(*SOLIDserver)
 
 	service := "dns_server_param_list"
@@ -1476,35 +3506,82 @@ func dnsparamget(serverName string, viewID string, paramKey string, meta interfa
 		service = "dns_view_param_list"
 	}
 
-	// Building parameters for retrieving information
-	parameters := url.Values{}
+	// Building parameters for retrieving information
+	parameters := url.Values{}
+
+	if viewID == "" {
+		parameters.Add("WHERE", "dns_name='"+whereescape(serverName)+"' AND param_key='"+whereescape(paramKey)+"'")
+	} else {
+		parameters.Add("WHERE", "dns_name='"+whereescape(serverName)+"' AND dnsview_id='"+viewID+"' AND param_key='"+whereescape(paramKey)+"'")
+	}
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/"+service, &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			paramValue, _ := buf[0]["param_value"].(string)
+			return paramValue, true, nil
+		}
+
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			tflog.Debug(s.Ctx, fmt.Sprintf("DNS Param Key not set: %s\n", paramKey))
+			return "", false, nil
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to read DNS Param Key: %s (%s)\n", paramKey, errMsg))
+			}
+		}
+
+		return "", false, fmt.Errorf("SOLIDServer - Unable to read DNS Param Key: %s\n", paramKey)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to read DNS Param Key: %s\n", paramKey))
+
+	return "", false, err
+}
+
+// Get a DNSserver param's value through the lookup cache
+// Return the value and whether the param is set, or an error in case of failure
+func dnsserverparamget(serverName string, paramKey string, meta interface{}) (string, bool, error) {
+	if cached, cachedExist := lookupcachegetmap(meta, cacheKindDNSServerParam, serverName, paramKey); cachedExist {
+		return cached["value"].(string), cached["found"].(bool), nil
+	}
+
+	paramValue, paramFound, paramErr := dnsparamget(serverName, "", paramKey, meta)
+
+	if paramErr == nil {
+		lookupcachesetmap(meta, cacheKindDNSServerParam, map[string]interface{}{"value": paramValue, "found": paramFound}, serverName, paramKey)
+	}
+
+	return paramValue, paramFound, paramErr
+}
 
-	if viewID == "" {
-		parameters.Add("WHERE", "dns_name='"+serverName+"' AND param_key='"+paramKey+"'")
-	} else {
-		parameters.Add("WHERE", "dns_name='"+serverName+"' AND dnsview_id='"+viewID+"' AND param_key='"+paramKey+"'")
-	}
+// List the params set on a DNSserver
+// Return a map of the param values indexed by their key, or an error in case of failure
+func dnsserverparamlist(serverName string, meta interface{}) (map[string]string, error) {
+	buf, err := listall("rest/dns_server_param_list", "dns_name='"+whereescape(serverName)+"'", meta)
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/"+service, &parameters)
+	if err != nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to list DNS server parameters: %s\n", serverName)
+	}
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	params := make(map[string]string, len(buf))
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if paramValue, paramValueExist := buf[0]["param_value"].(string); paramValueExist {
-				return paramValue, nil
-			} else {
-				return "", nil
-			}
+	for _, param := range buf {
+		if paramKey, paramKeyExist := param["param_key"].(string); paramKeyExist && paramKey != "" {
+			params[paramKey], _ = param["param_value"].(string)
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find DNS Param Key: %s\n", paramKey))
-
-	return "", err
+	return params, nil
 }
 
 // Add a DNS server to a SMART with the required role, return the
@@ -1531,13 +3608,10 @@ func dnsaddtosmart(smartName string, serverName string, serverRole string, meta
 
 		// Atomic SMART registration service unavailable attempting to use existing services
 		if resp.StatusCode == 400 || resp.StatusCode == 404 {
-			// Random Delay (in case of concurrent resources creation - until 8.0 and service dns_smart_member_add)
-			//time.Sleep(time.Duration((rand.Intn(600) / 10) * time.Second))
-
 			// Otherwise proceed using the previous method
 			// Building parameters for retrieving SMART vdns_dns_group_role information
 			parameters := url.Values{}
-			parameters.Add("WHERE", "vdns_parent_name='"+smartName+"' AND dns_type!='vdns'")
+			parameters.Add("WHERE", "vdns_parent_name='"+whereescape(smartName)+"' AND dns_type!='vdns'")
 
 			// Sending the read request
 			resp, body, err := s.Request("get", "rest/dns_server_list", &parameters)
@@ -1616,12 +3690,9 @@ func dnsdeletefromsmart(smartName string, serverName string, meta interface{}) b
 
 		// Atomic SMART registration service unavailable attempting to use existing services
 		if resp.StatusCode == 400 || resp.StatusCode == 404 {
-			// Random Delay (in case of concurrent resources creation - until 8.0 and service dns_smart_member_add)
-			//time.Sleep(time.Duration((rand.Intn(600) / 10) * time.Second))
-
 			// Building parameters for retrieving SMART vdns_dns_group_role information
 			parameters := url.Values{}
-			parameters.Add("WHERE", "vdns_parent_name='"+smartName+"' AND dns_type!='vdns'")
+			parameters.Add("WHERE", "vdns_parent_name='"+whereescape(smartName)+"' AND dns_type!='vdns'")
 
 			// Sending the read request
 			resp, body, err := s.Request("get", "rest/dns_server_list", &parameters)
@@ -1676,3 +3747,387 @@ func dnsdeletefromsmart(smartName string, serverName string, meta interface{}) b
 
 	return false
 }
+
+// Register an IP address as excluded from a pool (ip_name and ip_class_name set to 'excluded')
+// Return an error in case of failure
+func ippoolexclusionadd(siteID string, address string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("hostaddr", address)
+	parameters.Add("ip_name", "excluded")
+	parameters.Add("ip_class_name", "excluded")
+
+	// Sending the creation request
+	resp, body, err := s.Request("post", "rest/ip_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Created IP pool exclusion: %s (oid): %s\n", address, oid))
+				return nil
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to exclude IP address: %s (%s)\n", address, errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to exclude IP address: %s\n", address)
+	}
+
+	return err
+}
+
+// Remove an IP address previously excluded from a pool
+// Return an error in case of failure
+func ippoolexclusiondelete(siteID string, address string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	addressID, addressErr := ipaddressidbyip(siteID, address, meta)
+
+	if addressErr != nil {
+		return addressErr
+	}
+
+	// Nothing left to remove
+	if addressID == "" {
+		tflog.Debug(s.Ctx, fmt.Sprintf("IP pool exclusion already removed: %s\n", address))
+		return nil
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", addressID)
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/ip_delete", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Deleted IP pool exclusion: %s\n", address))
+			return nil
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to remove IP address exclusion: %s (%s)\n", address, errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to remove IP address exclusion: %s\n", address)
+	}
+
+	return err
+}
+
+// Return the list of the IP addresses excluded from a pool
+// Or an empty list in case of failure
+func ippoolexclusionlist(poolID string, meta interface{}) ([]string, error) {
+	s := meta.(*SOLIDserver)
+	addresses := []string{}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "pool_id='"+poolID+"' AND ip_class_name='excluded'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_used_address_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			for i := range buf {
+				if hexaddr, hexaddrExist := buf[i]["ip_addr"].(string); hexaddrExist {
+					addresses = append(addresses, hexiptoip(hexaddr))
+				}
+			}
+
+			return addresses, nil
+		}
+
+		return addresses, fmt.Errorf("SOLIDServer - Unable to list IP pool exclusions (oid): %s\n", poolID)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to list IP pool exclusions (oid): %s\n", poolID))
+
+	return addresses, err
+}
+
+// Return the end time (RFC3339) of the most recent DHCP lease of an IP address within a space
+// Or an empty string if the IP address has no DHCP lease
+func ipaddresslastseen(siteID string, hexIP string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+	lastSeen := ""
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "ip_addr='"+hexIP+"' AND site_id='"+siteID+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dhcp_lease_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			for i := range buf {
+				if leaseEndTime, leaseEndTimeExist := buf[i]["lease_end_time"].(string); leaseEndTimeExist {
+					if endTime := timestamptorfc3339(leaseEndTime); endTime > lastSeen {
+						lastSeen = endTime
+					}
+				}
+			}
+
+			return lastSeen, nil
+		}
+
+		return lastSeen, fmt.Errorf("SOLIDServer - Unable to list DHCP leases of IP address: %s\n", hexiptoip(hexIP))
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to list DHCP leases of IP address: %s\n", hexiptoip(hexIP)))
+
+	return lastSeen, err
+}
+
+// Return the oid of a DHCP server from its name
+// Or an empty string in case of failure
+func dhcpserveridbyname(serverName string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "dhcp_name='"+whereescape(strings.ToLower(serverName))+"'")
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dhcp_server_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if serverID, serverIDExist := buf[0]["dhcp_id"].(string); serverIDExist {
+				return serverID, nil
+			}
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find DHCP server: %s\n", serverName))
+
+		return "", fmt.Errorf("SOLIDServer - Unable to find DHCP server: %s\n", serverName)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find DHCP server: %s\n", serverName))
+
+	return "", err
+}
+
+// Return the number of DHCP ranges relying on a DHCP failover channel
+// Or -1 in case of failure
+func dhcpfailoverrangecount(failoverID string, meta interface{}) (int, error) {
+	return countall("rest/dhcp_range_count", "dhcpfailover_id='"+failoverID+"'", meta)
+}
+
+// Return the members of a DHCP SMART
+// Or an error in case of failure
+func dhcpsmartmembers(smartName string, meta interface{}) ([]map[string]interface{}, error) {
+	members, err := listall("rest/dhcp_server_list", "vdhcp_parent_name='"+whereescape(smartName)+"' AND dhcp_type!='vdhcp'", meta)
+
+	if err != nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to retrieve members list of the DHCP SMART: %s (%s)\n", smartName, err)
+	}
+
+	return members, nil
+}
+
+// Update a DHCP SMART member's role list
+// Return false in case of failure
+func dhcpsmartmembersupdate(smartName string, smartMembersRole string, meta interface{}) bool {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dhcp_name", smartName)
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("vdhcp_dhcp_group_role", smartMembersRole)
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/dhcp_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			return true
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update members list of the DHCP SMART: %s (%s)\n", smartName, errMsg))
+			}
+		} else {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Unable to update members list of the DHCP SMART: %s\n", smartName))
+		}
+	}
+
+	return false
+}
+
+// Add a DHCP server to a SMART with the required role
+// Return false in case of failure
+func dhcpaddtosmart(smartName string, serverName string, serverRole string, meta interface{}) bool {
+	s := meta.(*SOLIDserver)
+
+	members, membersErr := dhcpsmartmembers(smartName, meta)
+
+	if membersErr != nil {
+		tflog.Debug(s.Ctx, membersErr.Error())
+		return false
+	}
+
+	// Building vdhcp_dhcp_group_role parameter from the SMART member list
+	membersRole := ""
+
+	for _, smartMember := range members {
+		membersRole += infostring(smartMember, "dhcp_name") + "&" + infostring(smartMember, "dhcp_role") + ";"
+	}
+
+	membersRole += serverName + "&" + serverRole
+
+	return dhcpsmartmembersupdate(smartName, membersRole, meta)
+}
+
+// Remove a DHCP server from a SMART
+// Return false in case of failure
+func dhcpdeletefromsmart(smartName string, serverName string, meta interface{}) bool {
+	s := meta.(*SOLIDserver)
+
+	members, membersErr := dhcpsmartmembers(smartName, meta)
+
+	if membersErr != nil {
+		tflog.Debug(s.Ctx, membersErr.Error())
+		return false
+	}
+
+	// Building vdhcp_dhcp_group_role parameter from the SMART member list
+	membersRole := ""
+
+	for _, smartMember := range members {
+		if !strings.EqualFold(infostring(smartMember, "dhcp_name"), serverName) {
+			membersRole += infostring(smartMember, "dhcp_name") + "&" + infostring(smartMember, "dhcp_role") + ";"
+		}
+	}
+
+	return dhcpsmartmembersupdate(smartName, membersRole, meta)
+}
+
+// Return the information of an application pool from its application name, fqdn and name
+// Or nil if the pool does not exist and an error in case of failure
+func apppoolinfobyname(appName string, appFqdn string, poolName string, meta interface{}) (map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+
+	whereClause := "appapplication_name='" + whereescape(appName) + "' AND appapplication_fqdn='" + whereescape(appFqdn) + "' AND apppool_name='" + whereescape(poolName) + "'"
+
+	buf, err := listall("rest/app_pool_list", whereClause, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buf) == 0 {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find application pool: %s (%s)\n", poolName, appFqdn))
+		return nil, nil
+	}
+
+	return apppoolinfobyid(infostring(buf[0], "apppool_id"), meta)
+}
+
+// Return the information of an application pool from its oid
+// Or an error in case of failure
+func apppoolinfobyid(poolID string, meta interface{}) (map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("apppool_id", poolID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/app_pool_info", &parameters)
+
+	if err == nil {
+		var info [](map[string]interface{})
+		json.Unmarshal([]byte(body), &info)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(info) > 0 {
+			return info[0], nil
+		}
+
+		if len(info) > 0 {
+			if errMsg, errExist := info[0]["errmsg"].(string); errExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Unable to read application pool (oid): %s (%s)\n", poolID, errMsg))
+			}
+		}
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to read application pool (oid): %s\n", poolID)
+	}
+
+	return nil, err
+}
+
+// Return the IP version (ipv4 or ipv6) of an IP address
+// Or an empty string if the address is not valid
+func ipversion(address string) string {
+	addr, err := netip.ParseAddr(address)
+
+	if err != nil {
+		return ""
+	}
+
+	if addr.Is4() {
+		return "ipv4"
+	}
+
+	return "ipv6"
+}
+
+// Return an IP address in CIDR notation (canonical form), either as a host prefix (/32 or /128)
+// or with the given prefix length (ex: the one of its subnet)
+// Or an empty string if the address is not valid
+func ipaddresscidr(address string, prefixLength int) string {
+	addr, err := netip.ParseAddr(address)
+
+	if err != nil {
+		return ""
+	}
+
+	if prefixLength <= 0 || prefixLength > addr.BitLen() {
+		prefixLength = addr.BitLen()
+	}
+
+	return netip.PrefixFrom(addr, prefixLength).String()
+}
@@ -0,0 +1,573 @@
+package solidserver
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"smart-prod01", "smart-dev01", "ns1.example.com"}
+
+	if match := closestmatch("smart-prod1", candidates); match != "smart-prod01" {
+		t.Errorf("expected smart-prod01, got %q", match)
+	}
+
+	if match := closestmatch("completely-different", candidates); match != "" {
+		t.Errorf("expected no suggestion, got %q", match)
+	}
+}
+
+func TestDNSServerValidate(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_server_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"dns_name": "smart-prod01"}})
+	})
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"dnsview_name": "internal"}})
+	})
+
+	if err := dnsservervalidate("smart-prod01", "internal", s); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err := dnsservervalidate("smart-prod1", "", s)
+
+	if err == nil || err.Error() != "DNS server 'smart-prod1' not found on "+s.Host+" (did you mean 'smart-prod01'?)" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := dnsservervalidate("smart-prod01", "internl", s); err == nil || !strings.Contains(err.Error(), "did you mean 'internal'?") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDNSServerValidatePlan(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_server_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	m.handle("/rest/dns_view_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	resources := map[string]struct {
+		resource *schema.Resource
+		config   map[string]interface{}
+	}{
+		"dns_view": {resourcednsview(), map[string]interface{}{"dnsserver": "smart-new01", "name": "internal"}},
+		"dns_zone": {resourcednszone(), map[string]interface{}{"dnsserver": "smart-new01", "dnsview": "internal", "name": "example.com"}},
+		"dns_rr":   {resourcednsrr(), map[string]interface{}{"dnsserver": "smart-new01", "dnsview": "internal", "name": "www.example.com", "type": "A", "value": "10.0.0.1"}},
+		"dns_host": {resourcednshost(), map[string]interface{}{"dnsserver": "smart-new01", "dnsview": "internal", "name": "www.example.com", "address": "10.0.0.1"}},
+	}
+
+	for name, r := range resources {
+		// A missing DNS server fails the plan
+		if _, err := r.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(r.config), s); err == nil || !strings.Contains(err.Error(), "smart-new01") {
+			t.Errorf("%s: expected a missing DNS server error, got: %v", name, err)
+		}
+
+		// A DNS server created within the same plan is not known yet
+		unknown := map[string]interface{}{}
+		for k, v := range r.config {
+			unknown[k] = v
+		}
+		unknown["dnsserver"] = testUnknownValue
+
+		if _, err := r.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(unknown), s); err != nil {
+			t.Errorf("%s: unexpected plan error with an unknown DNS server: %s", name, err)
+		}
+	}
+
+	// The validation can be disabled
+	s.DisablePlanValidation = true
+
+	for name, r := range resources {
+		if _, err := r.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(r.config), s); err != nil {
+			t.Errorf("%s: unexpected plan error without plan validation: %s", name, err)
+		}
+	}
+}
+
+func TestIPSubnetFindBySizeAllocationSize(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// Candidates for a /24 within a /16 and two /24 free ranges (10.1.0.0/16, 10.2.0.0/24, 10.2.1.0/24)
+	m.handle("/rpc/ip_find_free_subnet", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{
+			{"start_ip_addr": "0a010000", "end_ip_addr": "0a01ffff"},
+			{"start_ip_addr": "0a020000", "end_ip_addr": "0a0200ff"},
+			{"start_ip_addr": "0a020100", "end_ip_addr": "0a0201ff"},
+		})
+	})
+
+	cases := []struct {
+		maxAllocationSize int
+		minAllocationSize int
+		expected          int
+	}{
+		{0, 0, 3},
+		{24, 0, 3},
+		{0, 24, 3},
+		{16, 28, 3},
+		{25, 0, 0},
+		{0, 23, 0},
+	}
+
+	for _, c := range cases {
+		subnetAddresses, err := ipsubnetfindbysize("2", "12", "", 24, c.maxAllocationSize, c.minAllocationSize, s)
+
+		if len(subnetAddresses) != c.expected || (err != nil) != (c.expected == 0) {
+			t.Errorf("max: %d, min: %d: expected %d candidate(s), got %v (%v)", c.maxAllocationSize, c.minAllocationSize, c.expected, subnetAddresses, err)
+		}
+	}
+
+	if m.count("/rpc/ip_find_free_subnet") != 4 {
+		t.Errorf("expected disallowed sizes not to be looked up, got %d lookups", m.count("/rpc/ip_find_free_subnet"))
+	}
+}
+func TestPrefixLengthEdgeCases(t *testing.T) {
+	cases := []struct {
+		length  int
+		size    int
+		netmask string
+	}{
+		{0, 1 << 32, "0.0.0.0"},
+		{1, 1 << 31, "128.0.0.0"},
+		{24, 256, "255.255.255.0"},
+		{30, 4, "255.255.255.252"},
+		{31, 2, "255.255.255.254"},
+		{32, 1, "255.255.255.255"},
+	}
+
+	for _, c := range cases {
+		if size := prefixlengthtosize(c.length); size != c.size {
+			t.Errorf("prefixlengthtosize(%d): expected %d, got %d", c.length, c.size, size)
+		}
+
+		if length := sizetoprefixlength(c.size); length != c.length {
+			t.Errorf("sizetoprefixlength(%d): expected %d, got %d", c.size, c.length, length)
+		}
+
+		if netmask := prefixlengthtohexip(c.length); netmask != c.netmask {
+			t.Errorf("prefixlengthtohexip(%d): expected %s, got %s", c.length, c.netmask, netmask)
+		}
+	}
+
+	// IPv6 analogues, the length of a single address prefix being 128
+	cases6 := map[int64]string{
+		0:   "340282366920938463463374607431768211456",
+		1:   "170141183460469231731687303715884105728",
+		64:  "18446744073709551616",
+		126: "4",
+		127: "2",
+		128: "1",
+		129: "-1",
+		-1:  "-1",
+	}
+
+	for length, expected := range cases6 {
+		if size6 := prefix6lengthtosize(length); size6.String() != expected {
+			t.Errorf("prefix6lengthtosize(%d): expected %s, got %s", length, expected, size6)
+		}
+	}
+
+	if prefixlengthtosize(33) != -1 || prefixlengthtohexip(33) != "" {
+		t.Errorf("expected invalid prefix lengths to be refused")
+	}
+
+	// Point-to-point and host subnets have no network nor broadcast address
+	addrCases := []struct {
+		start, end, addr  string
+		length, maxLength int
+		expected          bool
+	}{
+		{"0a000000", "0a0000ff", "0a000000", 24, 32, false},
+		{"0a000000", "0a0000ff", "0a000001", 24, 32, true},
+		{"0a000000", "0a0000ff", "0a0000ff", 24, 32, false},
+		{"0a000000", "0a000003", "0a000001", 30, 32, true},
+		{"0a000000", "0a000003", "0a000003", 30, 32, false},
+		{"0a000000", "0a000001", "0a000000", 31, 32, true},
+		{"0a000000", "0a000001", "0a000001", 31, 32, true},
+		{"0a000000", "0a000001", "0a000002", 31, 32, false},
+		{"0a000005", "0a000005", "0a000005", 32, 32, true},
+		{"0a000005", "0a000005", "", 32, 32, false},
+		{"20010db8000000000000000000000000", "20010db8000000000000000000000001", "20010db8000000000000000000000001", 127, 128, true},
+		{"20010db8000000000000000000000005", "20010db8000000000000000000000005", "20010db8000000000000000000000005", 128, 128, true},
+		{"20010db8000000000000000000000000", "20010db80000000000000000000000ff", "20010db8000000000000000000000000", 120, 128, false},
+	}
+
+	for _, c := range addrCases {
+		if inSubnet := iphexaddrinsubnet(c.start, c.end, c.addr, c.length, c.maxLength); inSubnet != c.expected {
+			t.Errorf("iphexaddrinsubnet(%s, %s, %s, /%d): expected %t", c.start, c.end, c.addr, c.length, c.expected)
+		}
+	}
+}
+
+func TestIPAddressFindFreeInRanges(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// A /31 with its first address in use and a /32 left free
+	m.handle("/rest/ip_address_list", mockWhereList([]map[string]interface{}{
+		{"subnet_id": "12", "type": "free", "free_start_ip_addr": "0a000001", "free_end_ip_addr": "0a000001"},
+		{"subnet_id": "13", "type": "free", "free_start_ip_addr": "ffffffff", "free_end_ip_addr": "ffffffff"},
+		{"subnet_id": "14", "type": "free", "free_start_ip_addr": "0a000010", "free_end_ip_addr": "0a000011"},
+	}))
+
+	for subnetID, expected := range map[string]string{"12": "10.0.0.1", "13": "255.255.255.255", "14": "10.0.0.16,10.0.0.17", "15": ""} {
+		if addresses, err := ipaddressfindfreeinranges(subnetID, s); err != nil || strings.Join(addresses, ",") != expected {
+			t.Errorf("subnet (oid) %s: expected %q, got %v (%v)", subnetID, expected, addresses, err)
+		}
+	}
+}
+
+func TestMacNormalize(t *testing.T) {
+	cases := map[string]string{
+		"00:11:22:aa:bb:cc":   "00:11:22:aa:bb:cc",
+		"00-11-22-aa-bb-cc":   "00:11:22:aa:bb:cc",
+		"00:11:22:AA:BB:CC":   "00:11:22:aa:bb:cc",
+		"00-11-22-AA-BB-CC":   "00:11:22:aa:bb:cc",
+		"EIP:0a000001":        "",
+		"eip:0a000001":        "",
+		"":                    "",
+		" 00:11:22:aa:bb:cc ": "00:11:22:aa:bb:cc",
+	}
+
+	for mac, expected := range cases {
+		if normalized := macnormalize(mac); normalized != expected {
+			t.Errorf("macnormalize(%q): expected %q, got %q", mac, expected, normalized)
+		}
+
+		if stored := resourcestatemac(mac); stored != expected {
+			t.Errorf("resourcestatemac(%q): expected %q, got %q", mac, expected, stored)
+		}
+	}
+
+	if !resourcediffsuppressmac("mac", "00:11:22:aa:bb:cc", "00-11-22-AA-BB-CC", nil) {
+		t.Errorf("expected dash and uppercase MAC addresses to be suppressed")
+	}
+
+	if !resourcediffsuppressmac("mac", "EIP:0a000001", "", nil) {
+		t.Errorf("expected the placeholder MAC address to be suppressed")
+	}
+
+	if resourcediffsuppressmac("mac", "00:11:22:aa:bb:cc", "00:11:22:aa:bb:cd", nil) {
+		t.Errorf("unexpected suppression of different MAC addresses")
+	}
+}
+
+func TestIP6RangeUsage(t *testing.T) {
+	// A /120 worth of addresses with 64 of them in use
+	free, utilization := ip6rangeusage("20010db8000000000000000000000000", "20010db80000000000000000000000ff", 64)
+	if free != 192 || utilization != 25 {
+		t.Errorf("unexpected usage: free=%d utilization=%f", free, utilization)
+	}
+
+	// A /64 worth of addresses overflows the free count
+	free, utilization = ip6rangeusage("20010db8000000000000000000000000", "20010db800000000ffffffffffffffff", 10)
+	if free != math.MaxInt || utilization <= 0 || utilization >= 0.0001 {
+		t.Errorf("unexpected usage: free=%d utilization=%g", free, utilization)
+	}
+
+	// Invalid boundaries
+	if free, utilization = ip6rangeusage("", "20010db80000000000000000000000ff", 10); free != 0 || utilization != 0 {
+		t.Errorf("unexpected usage of an invalid range: free=%d utilization=%f", free, utilization)
+	}
+}
+
+func TestIP6PoolReadUsage(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/ip6_pool6_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"pool6_id":               "12",
+			"pool6_name":             "pool01",
+			"pool6_class_name":       "",
+			"pool6_class_parameters": "",
+			"pool6_start_ip6_addr":   "20010db8000000000000000000000000",
+			"pool6_end_ip6_addr":     "20010db80000000000000000000000ff",
+		}})
+	})
+
+	m.handle("/rest/ip6_address6_count", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "pool6_id='12'" {
+			t.Errorf("unexpected WHERE clause: %s", r.URL.Query().Get("WHERE"))
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"total": "128"}})
+	})
+
+	d := resourceip6pool().TestResourceData()
+	d.SetId("12")
+
+	if diags := resourceip6poolRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("used_count").(int) != 128 || d.Get("free_count").(int) != 128 || d.Get("utilization_percent").(float64) != 50 {
+		t.Errorf("unexpected usage: %v", d.State().Attributes)
+	}
+}
+
+func TestDNSParamGet(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	m.handle("/rest/dns_view_param_list", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Query().Get("WHERE"), "param_key='forward'"):
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"param_key": "forward", "param_value": "first"}})
+		case strings.Contains(r.URL.Query().Get("WHERE"), "param_key='forwarders'"):
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"param_key": "forwarders", "param_value": ""}})
+		case strings.Contains(r.URL.Query().Get("WHERE"), "param_key='allow-new-zones'"):
+			mockReply(w, http.StatusOK, nil)
+		default:
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "invalid WHERE clause"}})
+		}
+	})
+
+	// Set param
+	if value, found, err := dnsparamget("ns01", "4", "forward", s); value != "first" || !found || err != nil {
+		t.Errorf("unexpected set param: %q %v %v", value, found, err)
+	}
+
+	// Set param with an empty value
+	if value, found, err := dnsparamget("ns01", "4", "forwarders", s); value != "" || !found || err != nil {
+		t.Errorf("unexpected empty param: %q %v %v", value, found, err)
+	}
+
+	// Param not set
+	if value, found, err := dnsparamget("ns01", "4", "allow-new-zones", s); value != "" || found || err != nil {
+		t.Errorf("unexpected missing param: %q %v %v", value, found, err)
+	}
+
+	// Lookup error
+	if _, found, err := dnsparamget("ns01", "4", "notify", s); found || err == nil {
+		t.Errorf("expected a lookup error, got: %v %v", found, err)
+	}
+}
+
+func TestClassParamMatch(t *testing.T) {
+	patterns := []string{"audit_*", "eip_sync", "owner?"}
+
+	for key, expected := range map[string]bool{
+		"audit_ts":      true,
+		"audit_":        true,
+		"eip_sync":      true,
+		"eip_sync_flag": false,
+		"owner1":        true,
+		"owner":         false,
+		"gateway":       false,
+	} {
+		if classparammatch(key, patterns) != expected {
+			t.Errorf("classparammatch(%q): expected %v", key, expected)
+		}
+	}
+
+	if classparammatch("audit_ts", nil) {
+		t.Errorf("unexpected match without any pattern")
+	}
+}
+
+func TestIgnoreClassParameters(t *testing.T) {
+	r := resourceipspace()
+	m, s := newMockSOLIDserver(t)
+
+	state := &terraform.InstanceState{
+		ID: "2",
+		Attributes: map[string]string{
+			"id":                        "2",
+			"name":                      "local",
+			"class":                     "",
+			"class_parameters.%":        "2",
+			"class_parameters.owner":    "netops",
+			"class_parameters.audit_ts": "1700000000",
+			"ignore_class_parameters.#": "1",
+			"ignore_class_parameters.0": "audit_*",
+		},
+	}
+
+	config := map[string]interface{}{
+		"name":                    "local",
+		"class_parameters":        map[string]interface{}{"owner": "netops", "audit_ts": "0", "audit_by": "me"},
+		"ignore_class_parameters": []interface{}{"audit_*"},
+	}
+
+	// Changes of the ignored class parameters are not part of the plan
+	diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil, s, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Fatalf("unexpected diff: %v", diff.Attributes)
+	}
+
+	// Other class parameters are still compared
+	config["class_parameters"] = map[string]interface{}{"owner": "secops", "audit_ts": "0"}
+
+	diff, err = schema.InternalMap(r.Schema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil, s, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attr, attrExist := diff.Attributes["class_parameters.owner"]; !attrExist || attr.New != "secops" {
+		t.Fatalf("expected a diff on the owner class parameter, got %v", diff.Attributes)
+	}
+
+	// The ignored class parameters are dropped from the desired class parameters, thus neither sent nor read back
+	m.handle("/rest/ip_site_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{
+			"site_id":               "2",
+			"site_name":             "local",
+			"site_class_name":       "",
+			"site_class_parameters": "owner=netops&audit_ts=1800000000",
+		}})
+	})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("2")
+
+	if classParameters := resourceclassparams(d); len(classParameters) != 1 || classParameters["owner"] != "secops" {
+		t.Errorf("unexpected class parameters to send: %v", classParameters)
+	}
+
+	if diags := resourceipspaceRead(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if classParameters := d.Get("class_parameters").(map[string]interface{}); len(classParameters) != 1 || classParameters["owner"] != "netops" {
+		t.Errorf("unexpected class parameters read: %v", classParameters)
+	}
+}
+
+// Emulate a listing service filtering its rows using the conditions of the WHERE clause
+// A WHERE clause that cannot be fully parsed (ex: unescaped quotes) is refused
+func mockWhereList(rows []map[string]interface{}) http.HandlerFunc {
+	condition := regexp.MustCompile(`(\w+)\s*(?:=|(?i:like))\s*'((?:[^']|'')*)'`)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		where := r.URL.Query().Get("WHERE")
+
+		if leftover := strings.TrimSpace(strings.ReplaceAll(condition.ReplaceAllString(where, ""), "AND", "")); leftover != "" {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "syntax error near: " + leftover}})
+			return
+		}
+
+		matched := []map[string]interface{}{}
+		for _, row := range rows {
+			match := true
+			for _, c := range condition.FindAllStringSubmatch(where, -1) {
+				if !strings.EqualFold(row[c[1]].(string), strings.ReplaceAll(c[2], "''", "'")) {
+					match = false
+				}
+			}
+			if match {
+				matched = append(matched, row)
+			}
+		}
+		mockReply(w, http.StatusOK, matched)
+	}
+}
+
+func TestWhereEscapeLookups(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	names := []string{"10.4.12.0/22 - Prod East", "O'Brien's lab", "R&D", " padded "}
+	subnets, pools, zones, domains, vlans := []map[string]interface{}{}, []map[string]interface{}{}, []map[string]interface{}{}, []map[string]interface{}{}, []map[string]interface{}{}
+
+	for i, name := range names {
+		id := strconv.Itoa(i + 1)
+		subnets = append(subnets, map[string]interface{}{"subnet_id": id, "site_id": "2", "subnet_name": name, "is_terminal": "1"})
+		pools = append(pools, map[string]interface{}{"pool_id": id, "site_id": "2", "pool_name": name, "subnet_name": name})
+		zones = append(zones, map[string]interface{}{"dnszone_id": id, "dns_name": "ns01", "dnszone_name": name})
+		domains = append(domains, map[string]interface{}{"vlmdomain_id": id, "vlmdomain_name": name})
+		vlans = append(vlans, map[string]interface{}{"vlmvlan_id": id, "vlmdomain_name": name, "vlmvlan_vlan_id": "12"})
+	}
+
+	m.handle("/rest/ip_block_subnet_list", mockWhereList(subnets))
+	m.handle("/rest/ip_pool_list", mockWhereList(pools))
+	m.handle("/rest/dns_zone_list", mockWhereList(zones))
+	m.handle("/rest/vlmdomain_name", mockWhereList(domains))
+	m.handle("/rest/vlmvlan_list", mockWhereList(vlans))
+
+	for i, name := range names {
+		id := strconv.Itoa(i + 1)
+
+		if subnetID, err := ipsubnetidbyname("2", name, true, s); err != nil || subnetID != id {
+			t.Errorf("unexpected IP subnet lookup of %q: %s (%v)", name, subnetID, err)
+		}
+
+		if poolID, err := ippoolidbyname("2", name, name, s); err != nil || poolID != id {
+			t.Errorf("unexpected IP pool lookup of %q: %s (%v)", name, poolID, err)
+		}
+
+		if exists, err := dnszoneexists("ns01", "", name, s); err != nil || !exists {
+			t.Errorf("unexpected DNS zone lookup of %q: %t (%v)", name, exists, err)
+		}
+
+		if domainID, err := vlandomainidbyname(name, s); err != nil || domainID != id {
+			t.Errorf("unexpected VLAN domain lookup of %q: %s (%v)", name, domainID, err)
+		}
+
+		if vlanID, err := vlanidbyinfo(name, 12, s); err != nil || vlanID != id {
+			t.Errorf("unexpected VLAN lookup of %q: %s (%v)", name, vlanID, err)
+		}
+	}
+}
+
+func TestCountAll(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	where := ""
+	status := http.StatusOK
+	reply := []map[string]interface{}{{"total": "2001"}}
+
+	m.handle("/rest/dns_zone_count", func(w http.ResponseWriter, r *http.Request) {
+		where = r.URL.Query().Get("WHERE")
+		mockReply(w, status, reply)
+	})
+
+	if count, err := countall("rest/dns_zone_count", "dns_name='"+whereescape("O'Brien")+"'", s); err != nil || count != 2001 {
+		t.Fatalf("unexpected count: %d (%v)", count, err)
+	}
+
+	if where != "dns_name='O''Brien'" {
+		t.Errorf("unexpected filter: %s", where)
+	}
+
+	// No matching object
+	status, reply = http.StatusNoContent, nil
+
+	if count, err := countall("rest/dns_zone_count", "", s); err != nil || count != 0 {
+		t.Errorf("unexpected count: %d (%v)", count, err)
+	}
+
+	if where != "" {
+		t.Errorf("unexpected filter: %s", where)
+	}
+
+	// Malformed or failed answers
+	for _, failure := range []struct {
+		status int
+		reply  []map[string]interface{}
+	}{
+		{http.StatusOK, []map[string]interface{}{{"total": "many"}}},
+		{http.StatusOK, []map[string]interface{}{{}}},
+		{http.StatusBadRequest, []map[string]interface{}{{"errmsg": "invalid WHERE"}}},
+	} {
+		status, reply = failure.status, failure.reply
+
+		if count, err := countall("rest/dns_zone_count", "", s); err == nil || count != -1 {
+			t.Errorf("expected a failure for %d %v, got: %d", failure.status, failure.reply, count)
+		}
+	}
+}
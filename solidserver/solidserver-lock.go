@@ -0,0 +1,206 @@
+package solidserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"math/rand"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Class parameter advertising an allocation in progress within a subnet to other provider instances (best effort)
+// Its value is made of the owner of the lock and its expiry date (unix timestamp) separated by a ';'
+const allocationLockParameter = "tf_allocation_lock"
+
+var (
+	// Lifetime of a lock, allowing to recover from crashed runs, renewed while the lock is held
+	allocationLockTTL = 60 * time.Second
+	// Maximum time spent waiting for a lock
+	allocationLockTimeout = 5 * time.Minute
+	// Delay between two attempts to acquire a lock
+	allocationLockRetryDelay = 2 * time.Second
+
+	// Per subnet mutexes serializing the allocations within the provider process
+	allocationLocks = sync.Map{}
+	// Identity of the provider process within the lock class parameter
+	allocationLockOwner = fmt.Sprintf("%d-%x", os.Getpid(), rand.Int63())
+)
+
+// Update the class parameters of a subnet
+func allocationlocksetparams(subnetID string, classParameters url.Values, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet_id", subnetID)
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("subnet_class_parameters", classParameters.Encode())
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/ip_subnet_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			return nil
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to update class parameters of subnet (oid): %s\n", subnetID)
+	}
+
+	return err
+}
+
+// Return the owner and the expiry date of a lock class parameter value
+func allocationlockparse(value string) (string, time.Time) {
+	owner, expiry, found := strings.Cut(value, ";")
+
+	if !found {
+		return "", time.Time{}
+	}
+
+	timestamp, _ := strconv.ParseInt(expiry, 10, 64)
+
+	return owner, time.Unix(timestamp, 0)
+}
+
+// Acquire the allocation lock of a subnet
+// Allocations are serialized within the provider process using a mutex; across provider instances
+// the lock is a best effort class parameter written then read back, not an atomic operation, so two
+// instances updating the subnet at the same time may both believe they hold it
+// Return the function releasing the lock
+// Or nil in case of failure
+func allocationlock(subnetID string, meta interface{}) (func(), error) {
+	s := meta.(*SOLIDserver)
+
+	mutex, _ := allocationLocks.LoadOrStore(subnetID, &sync.Mutex{})
+	mutex.(*sync.Mutex).Lock()
+
+	deadline := time.Now().Add(allocationLockTimeout)
+
+	for time.Now().Before(deadline) {
+		classParameters, err := ipsubnetclassparams(subnetID, meta)
+
+		if err != nil {
+			mutex.(*sync.Mutex).Unlock()
+			return nil, err
+		}
+
+		owner, expiry := allocationlockparse(classParameters.Get(allocationLockParameter))
+
+		if owner != "" && owner != allocationLockOwner && time.Now().Before(expiry) {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Waiting for allocation lock on subnet (oid): %s held by %s until %s\n", subnetID, owner, expiry.Format(time.RFC3339)))
+			time.Sleep(allocationLockRetryDelay + time.Duration(rand.Intn(1000))*time.Millisecond)
+			continue
+		}
+
+		// Claim the lock, then read it back to detect most concurrent claims (a race remains possible)
+		classParameters.Set(allocationLockParameter, allocationLockOwner+";"+strconv.FormatInt(time.Now().Add(allocationLockTTL).Unix(), 10))
+
+		if err := allocationlocksetparams(subnetID, classParameters, meta); err != nil {
+			mutex.(*sync.Mutex).Unlock()
+			return nil, err
+		}
+
+		classParameters, err = ipsubnetclassparams(subnetID, meta)
+
+		if err != nil {
+			mutex.(*sync.Mutex).Unlock()
+			return nil, err
+		}
+
+		if owner, _ = allocationlockparse(classParameters.Get(allocationLockParameter)); owner == allocationLockOwner {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Acquired allocation lock on subnet (oid): %s\n", subnetID))
+
+			// Renewing the lock until released, allocations may last longer than its lifetime
+			stop := make(chan struct{})
+			stopped := make(chan struct{})
+
+			go func() {
+				defer close(stopped)
+
+				ticker := time.NewTicker(allocationLockTTL / 3)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ticker.C:
+						allocationlockrenew(subnetID, meta)
+					}
+				}
+			}()
+
+			return func() {
+				close(stop)
+				<-stopped
+				allocationunlock(subnetID, meta)
+				mutex.(*sync.Mutex).Unlock()
+			}, nil
+		}
+	}
+
+	mutex.(*sync.Mutex).Unlock()
+
+	return nil, fmt.Errorf("SOLIDServer - Unable to acquire allocation lock on subnet (oid): %s\n", subnetID)
+}
+
+// Extend the expiry date of the cooperative allocation lock of a subnet if still owned by the provider process
+func allocationlockrenew(subnetID string, meta interface{}) {
+	s := meta.(*SOLIDserver)
+
+	classParameters, err := ipsubnetclassparams(subnetID, meta)
+
+	if err != nil {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to renew allocation lock on subnet (oid): %s\n", subnetID))
+		return
+	}
+
+	if owner, _ := allocationlockparse(classParameters.Get(allocationLockParameter)); owner != allocationLockOwner {
+		tflog.Warn(s.Ctx, fmt.Sprintf("Allocation lock on subnet (oid): %s lost, now held by %s\n", subnetID, owner))
+		return
+	}
+
+	classParameters.Set(allocationLockParameter, allocationLockOwner+";"+strconv.FormatInt(time.Now().Add(allocationLockTTL).Unix(), 10))
+
+	if err := allocationlocksetparams(subnetID, classParameters, meta); err != nil {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to renew allocation lock on subnet (oid): %s\n", subnetID))
+		return
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Renewed allocation lock on subnet (oid): %s\n", subnetID))
+}
+
+// Release the cooperative allocation lock of a subnet if still owned by the provider process
+func allocationunlock(subnetID string, meta interface{}) {
+	s := meta.(*SOLIDserver)
+
+	classParameters, err := ipsubnetclassparams(subnetID, meta)
+
+	if err != nil {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to release allocation lock on subnet (oid): %s, it will expire\n", subnetID))
+		return
+	}
+
+	if owner, _ := allocationlockparse(classParameters.Get(allocationLockParameter)); owner != allocationLockOwner {
+		return
+	}
+
+	classParameters.Del(allocationLockParameter)
+
+	if err := allocationlocksetparams(subnetID, classParameters, meta); err != nil {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to release allocation lock on subnet (oid): %s, it will expire\n", subnetID))
+		return
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Released allocation lock on subnet (oid): %s\n", subnetID))
+}
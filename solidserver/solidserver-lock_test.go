@@ -0,0 +1,136 @@
+package solidserver
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Emulate the class parameters of a subnet
+func mockSubnetClassParameters(m *mockSOLIDserver, initial string) *string {
+	mutex := sync.Mutex{}
+	classParameters := initial
+
+	m.handle("/rest/ip_block_subnet_info", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"subnet_class_parameters": classParameters}})
+	})
+
+	m.handle("/rest/ip_subnet_add", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		classParameters = r.URL.Query().Get("subnet_class_parameters")
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "12"}})
+	})
+
+	return &classParameters
+}
+
+func TestAllocationLockSerialized(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	classParameters := mockSubnetClassParameters(m, "owner=netops")
+
+	wg := sync.WaitGroup{}
+	mutex := sync.Mutex{}
+	holders := 0
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			unlock, err := allocationlock("12", s)
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+
+			mutex.Lock()
+			holders++
+			if holders > 1 {
+				t.Errorf("lock held concurrently")
+			}
+			mutex.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			holders--
+			mutex.Unlock()
+
+			unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if *classParameters != "owner=netops" {
+		t.Errorf("lock not released, class parameters: %s", *classParameters)
+	}
+}
+
+func TestAllocationLockExpired(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	expired := url.Values{allocationLockParameter: []string{"crashed;" + strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)}}
+	mockSubnetClassParameters(m, expired.Encode())
+
+	unlock, err := allocationlock("12", s)
+
+	if err != nil {
+		t.Fatalf("expired lock not taken over: %s", err)
+	}
+
+	unlock()
+}
+
+func TestAllocationLockTimeout(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	active := url.Values{allocationLockParameter: []string{"other;" + strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)}}
+	mockSubnetClassParameters(m, active.Encode())
+
+	timeout, delay := allocationLockTimeout, allocationLockRetryDelay
+	allocationLockTimeout, allocationLockRetryDelay = 100*time.Millisecond, 10*time.Millisecond
+	defer func() { allocationLockTimeout, allocationLockRetryDelay = timeout, delay }()
+
+	if _, err := allocationlock("12", s); err == nil {
+		t.Fatalf("lock held by another instance acquired")
+	}
+}
+
+func TestAllocationLockRenewed(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	mockSubnetClassParameters(m, "owner=netops")
+
+	ttl := allocationLockTTL
+	allocationLockTTL = time.Second
+	defer func() { allocationLockTTL = ttl }()
+
+	unlock, err := allocationlock("12", s)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	classParameters, _ := ipsubnetclassparams("12", s)
+	_, initialExpiry := allocationlockparse(classParameters.Get(allocationLockParameter))
+
+	// The lock is still held, and its expiry date pushed back, after its initial lifetime
+	time.Sleep(2500 * time.Millisecond)
+
+	classParameters, err = ipsubnetclassparams("12", s)
+
+	if owner, expiry := allocationlockparse(classParameters.Get(allocationLockParameter)); err != nil || owner != allocationLockOwner || !expiry.After(initialExpiry) {
+		t.Errorf("lock not renewed: %s (%v)", classParameters.Encode(), err)
+	}
+
+	unlock()
+
+	if classParameters, _ = ipsubnetclassparams("12", s); classParameters.Encode() != "owner=netops" {
+		t.Errorf("lock not released, class parameters: %s", classParameters.Encode())
+	}
+}
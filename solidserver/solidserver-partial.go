@@ -0,0 +1,131 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+	"strings"
+)
+
+// Sub-steps (side-effects) of the creation of an object, performed once the object itself is created
+// On failure, the object is rolled back as nothing can reference it yet. If the rollback fails, the object
+// is kept in the state, tainted by Terraform, so the next apply replaces it and converges
+type createSteps struct {
+	objectType string
+	name       string
+	steps      []string
+	completed  map[string]bool
+}
+
+func newcreatesteps(objectType string, name string, steps ...string) *createSteps {
+	return &createSteps{
+		objectType: objectType,
+		name:       name,
+		steps:      steps,
+		completed:  map[string]bool{},
+	}
+}
+
+// Record the completion of a sub-step
+func (c *createSteps) done(step string) {
+	c.completed[step] = true
+}
+
+// Return the completed and the missing sub-steps, in their planned order
+func (c *createSteps) status() ([]string, []string) {
+	completed := []string{}
+	missing := []string{}
+
+	for _, step := range c.steps {
+		if c.completed[step] {
+			completed = append(completed, step)
+		} else {
+			missing = append(missing, step)
+		}
+	}
+
+	return completed, missing
+}
+
+// Return the diagnostics of a failed sub-step, after rolling back the object using rollback
+// The local ID is unset once rolled back, kept otherwise
+func (c *createSteps) fail(ctx context.Context, d *schema.ResourceData, step string, stepErr error, rollback func() error) diag.Diagnostics {
+	rollbackErr := rollback()
+
+	if rollbackErr == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Rolled back %s: %s after a failed %s\n", c.objectType, c.name, step))
+		d.SetId("")
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to roll back %s: %s (%s)\n", c.objectType, c.name, rollbackErr))
+	}
+
+	return c.failed(step, stepErr, rollbackErr)
+}
+
+// Return the diagnostics of a failed sub-step, enumerating the missing side-effects if the rollback failed
+func (c *createSteps) failed(step string, stepErr error, rollbackErr error) diag.Diagnostics {
+	summary := fmt.Sprintf("Unable to create %s: %s, %s failed (%s)", c.objectType, c.name, step, strings.TrimSpace(stepErr.Error()))
+
+	if rollbackErr == nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("The %s was deleted, the next apply creates it again.", c.objectType),
+		}}
+	}
+
+	completed, missing := c.status()
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail: fmt.Sprintf("The %s could not be deleted (%s), it is kept tainted and the next apply replaces it.\nCompleted: %s\nMissing: %s",
+			c.objectType, strings.TrimSpace(rollbackErr.Error()), stepslist(completed), stepslist(missing)),
+	}}
+}
+
+// Return a human readable list of sub-steps
+func stepslist(steps []string) string {
+	if len(steps) == 0 {
+		return "none"
+	}
+
+	return strings.Join(steps, ", ")
+}
+
+// Delete an object by ID, used to roll back the creation of an object
+// Return an error in case of failure
+func objectdelete(objectType string, service string, idParam string, id string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(idParam, id)
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", service, &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode == 200 || resp.StatusCode == 204 {
+		return nil
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return fmt.Errorf("SOLIDServer - Unable to delete %s (oid): %s (%s)\n", objectType, id, errMsg)
+		}
+	}
+
+	return fmt.Errorf("SOLIDServer - Unable to delete %s (oid): %s\n", objectType, id)
+}
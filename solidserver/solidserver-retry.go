@@ -0,0 +1,144 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	// Upper bound of the delay between two polling attempts
+	retryMaxInterval = 32 * time.Second
+	// Error returned when the condition is still not met once the timeout expired
+	errWaitTimeout = errors.New("SOLIDServer - Timeout expired while waiting for the operation to complete")
+)
+
+// Poll fn until it reports completion, fails or the timeout expires
+// The delay between two attempts starts at interval and doubles up to retryMaxInterval, with up to 20% of random jitter
+// A last attempt is always made when the timeout expires
+// Return nil on completion, the error of fn, the error of ctx if cancelled or errWaitTimeout
+func waituntil(ctx context.Context, timeout time.Duration, interval time.Duration, fn func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	delay := interval
+
+	for {
+		done, err := fn()
+
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+
+		if remaining <= 0 {
+			return errWaitTimeout
+		}
+
+		wait := delay
+
+		if delay > 0 {
+			wait += time.Duration(rand.Int63n(int64(delay)/5 + 1))
+		}
+
+		if wait > remaining {
+			wait = remaining
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if delay *= 2; delay > retryMaxInterval {
+			delay = retryMaxInterval
+		}
+	}
+}
+
+// Return the schema of the wait_for_existence block of the data-sources looking objects up by name
+func waitforexistenceschema(objectType string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Retry the lookup of the " + objectType + " until it exists, ex: when created within the same configuration (Default: a single lookup).",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timeout": {
+					Type:         schema.TypeInt,
+					Description:  "The number of seconds to wait for the " + objectType + " (Default: 60).",
+					ValidateFunc: validation.IntAtLeast(1),
+					Optional:     true,
+					Default:      60,
+				},
+				"interval": {
+					Type:         schema.TypeInt,
+					Description:  "The number of seconds between the first two lookups, doubled after each lookup (Default: 5).",
+					ValidateFunc: validation.IntAtLeast(1),
+					Optional:     true,
+					Default:      5,
+				},
+			},
+		},
+	}
+}
+
+// Send the lookup request of a data-source, retrying while no object matches as set by its wait_for_existence block
+// Return the response of the last lookup
+// Or an error stating the time waited and the WHERE clause used once the timeout expired
+func waitforexistence(ctx context.Context, d *schema.ResourceData, service string, parameters *url.Values, meta interface{}) (*http.Response, string, error) {
+	s := meta.(*SOLIDserver)
+	waits := d.Get("wait_for_existence").([]interface{})
+
+	if len(waits) == 0 || waits[0] == nil {
+		return s.Request("get", service, parameters)
+	}
+
+	wait := waits[0].(map[string]interface{})
+	timeout := time.Duration(wait["timeout"].(int)) * time.Second
+	interval := time.Duration(wait["interval"].(int)) * time.Second
+	start := time.Now()
+
+	var resp *http.Response
+	var body string
+
+	err := waituntil(ctx, timeout, interval, func() (bool, error) {
+		var err error
+
+		resp, body, err = s.Request("get", service, parameters)
+
+		if err != nil {
+			return false, err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		return resp.StatusCode == 200 && len(buf) > 0 && buf[0]["errmsg"] == nil, nil
+	})
+
+	if err == errWaitTimeout {
+		return nil, "", fmt.Errorf("SOLIDServer - Unable to find object using: %s after waiting %s (WHERE %s)\n", service, time.Since(start).Round(time.Second), parameters.Get("WHERE"))
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp, body, nil
+}
@@ -0,0 +1,173 @@
+package solidserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWaitUntil(t *testing.T) {
+	// Completion after a few attempts
+	attempts := 0
+	err := waituntil(context.Background(), time.Second, time.Millisecond, func() (bool, error) {
+		attempts++
+		return attempts == 3, nil
+	})
+
+	if err != nil || attempts != 3 {
+		t.Errorf("expected completion after 3 attempts, got %d (%v)", attempts, err)
+	}
+
+	// Errors are reported right away
+	attempts = 0
+	fnErr := errors.New("failure")
+	err = waituntil(context.Background(), time.Second, time.Millisecond, func() (bool, error) {
+		attempts++
+		return false, fnErr
+	})
+
+	if err != fnErr || attempts != 1 {
+		t.Errorf("expected the error of the first attempt, got %d attempt(s) (%v)", attempts, err)
+	}
+}
+
+func TestWaitUntilBackoff(t *testing.T) {
+	defer func(max time.Duration) { retryMaxInterval = max }(retryMaxInterval)
+	retryMaxInterval = 40 * time.Millisecond
+
+	attempts := []time.Time{}
+	err := waituntil(context.Background(), 200*time.Millisecond, 10*time.Millisecond, func() (bool, error) {
+		attempts = append(attempts, time.Now())
+		return false, nil
+	})
+
+	if err != errWaitTimeout {
+		t.Fatalf("expected a timeout, got: %v", err)
+	}
+
+	// The delay doubles from the interval up to retryMaxInterval, a last attempt being made once the timeout expires
+	for i, expected := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond} {
+		if i+1 >= len(attempts) {
+			t.Fatalf("expected more than %d attempts, got %d", i+1, len(attempts))
+		}
+		if delay := attempts[i+1].Sub(attempts[i]); delay < expected {
+			t.Errorf("expected a delay of at least %s before attempt %d, got %s", expected, i+2, delay)
+		}
+	}
+
+	if elapsed := attempts[len(attempts)-1].Sub(attempts[0]); elapsed < 200*time.Millisecond {
+		t.Errorf("expected a last attempt once the timeout expired, got one after %s", elapsed)
+	}
+}
+
+func TestWaitUntilContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := waituntil(ctx, time.Hour, time.Hour, func() (bool, error) {
+		attempts++
+		return false, nil
+	})
+
+	if err != context.Canceled || attempts != 1 {
+		t.Errorf("expected the cancellation to interrupt the wait after a single attempt, got %d (%v)", attempts, err)
+	}
+
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the cancellation to interrupt the wait right away, got %s", time.Since(start))
+	}
+}
+
+func TestDNSViewDeleteRetry(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	defer func(delay time.Duration) { dnsViewDeleteRetryDelay = delay }(dnsViewDeleteRetryDelay)
+	dnsViewDeleteRetryDelay = time.Millisecond
+
+	// The view remains undeletable while its zones are pending deletion
+	m.handle("/rest/dns_view_delete", func(w http.ResponseWriter, r *http.Request) {
+		if m.count("/rest/dns_view_delete") < 3 {
+			mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errno": "1", "errmsg": "View not empty"}})
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"ret_oid": "7"}})
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcednsview().Schema, map[string]interface{}{
+		"dnsserver": "ns.example.com",
+		"name":      "internal",
+	})
+	d.SetId("7")
+
+	if diags := resourcednsviewDelete(context.Background(), d, s); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if m.count("/rest/dns_view_delete") != 3 || d.Id() != "" {
+		t.Errorf("expected the view to be deleted after 3 attempts, got %d attempt(s)", m.count("/rest/dns_view_delete"))
+	}
+}
+
+func TestDataSourceWaitForExistence(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// The space is only listed from the third lookup
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("WHERE") != "site_name='space01'" || m.count("/rest/ip_site_list") < 3 {
+			mockReply(w, http.StatusNoContent, nil)
+			return
+		}
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "space01", "site_class_name": "", "site_class_parameters": ""}})
+	})
+
+	// A single lookup by default
+	d := schema.TestResourceDataRaw(t, dataSourceipspace().Schema, map[string]interface{}{"name": "space01"})
+
+	if diags := dataSourceipspaceRead(context.Background(), d, s); !diags.HasError() || m.count("/rest/ip_site_list") != 1 {
+		t.Errorf("expected a single failed lookup, got %d lookup(s) (%v)", m.count("/rest/ip_site_list"), diags)
+	}
+
+	// Retrying until the space exists
+	d = schema.TestResourceDataRaw(t, dataSourceipspace().Schema, map[string]interface{}{
+		"name":               "space01",
+		"wait_for_existence": []interface{}{map[string]interface{}{"timeout": 10, "interval": 1}},
+	})
+
+	if diags := dataSourceipspaceRead(context.Background(), d, s); diags.HasError() || d.Id() != "2" || m.count("/rest/ip_site_list") != 3 {
+		t.Errorf("expected the space to be found by the second retried lookup, got %d lookup(s) (%v)", m.count("/rest/ip_site_list"), diags)
+	}
+
+	// The timeout error states the time waited and the WHERE clause
+	d = schema.TestResourceDataRaw(t, dataSourceipspace().Schema, map[string]interface{}{
+		"name":               "space02",
+		"wait_for_existence": []interface{}{map[string]interface{}{"timeout": 1, "interval": 1}},
+	})
+
+	if diags := dataSourceipspaceRead(context.Background(), d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, "after waiting 1s (WHERE site_name='space02')") {
+		t.Errorf("expected a timeout error, got: %v", diags)
+	}
+
+	// Cancellation interrupts the wait
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d = schema.TestResourceDataRaw(t, dataSourcevlandomain().Schema, map[string]interface{}{
+		"name":               "domain01",
+		"wait_for_existence": []interface{}{map[string]interface{}{"timeout": 3600}},
+	})
+
+	if diags := dataSourcevlandomainRead(ctx, d, s); !diags.HasError() || !strings.Contains(diags[0].Summary, context.Canceled.Error()) {
+		t.Errorf("expected a cancellation error, got: %v", diags)
+	}
+}
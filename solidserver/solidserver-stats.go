@@ -0,0 +1,159 @@
+package solidserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Per endpoint API usage statistics
+type EndpointStats struct {
+	Calls      int64 `json:"calls"`
+	DurationMs int64 `json:"duration_ms"`
+	Retries    int64 `json:"retries"`
+	Errors     int64 `json:"errors"`
+}
+
+// API usage statistics of a provider instance, appended as JSON lines to a file
+// Only the endpoints (ex: rest/ip_site_list) are recorded, never object names nor credentials
+type RequestStats struct {
+	mutex     sync.Mutex
+	file      string
+	endpoints map[string]*EndpointStats
+}
+
+// JSON line appended to the statistics file
+type requestStatsLine struct {
+	Timestamp       string                    `json:"timestamp"`
+	TotalCalls      int64                     `json:"total_calls"`
+	TotalDurationMs int64                     `json:"total_duration_ms"`
+	TotalRetries    int64                     `json:"total_retries"`
+	TotalErrors     int64                     `json:"total_errors"`
+	Endpoints       map[string]*EndpointStats `json:"endpoints"`
+}
+
+// Statistics of the provider instances to flush when the provider process exits
+var requestStatsInstances = struct {
+	mutex sync.Mutex
+	stats []*RequestStats
+}{}
+
+// Return a new statistics recorder flushed into file
+// Or nil if no file is specified
+func NewRequestStats(file string) *RequestStats {
+	if file == "" {
+		return nil
+	}
+
+	stats := &RequestStats{
+		file:      file,
+		endpoints: make(map[string]*EndpointStats),
+	}
+
+	requestStatsInstances.mutex.Lock()
+	requestStatsInstances.stats = append(requestStatsInstances.stats, stats)
+	requestStatsInstances.mutex.Unlock()
+
+	return stats
+}
+
+// Return the statistics of an endpoint, the mutex must be held
+func (r *RequestStats) endpoint(service string) *EndpointStats {
+	e, eExist := r.endpoints[service]
+
+	if !eExist {
+		e = &EndpointStats{}
+		r.endpoints[service] = e
+	}
+
+	return e
+}
+
+// Record an API call
+func (r *RequestStats) Record(service string, duration time.Duration, retries int, failed bool) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	e := r.endpoint(service)
+	e.Calls++
+	e.DurationMs += duration.Milliseconds()
+	e.Retries += int64(retries)
+
+	if failed {
+		e.Errors++
+	}
+}
+
+// Record a retry of an API call
+func (r *RequestStats) Retry(service string) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.endpoint(service).Retries++
+}
+
+// Append the recorded statistics to the file as a JSON line and reset them
+// Nothing is written if no API call was recorded
+func (r *RequestStats) Flush() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.endpoints) == 0 {
+		return nil
+	}
+
+	line := requestStatsLine{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Endpoints: r.endpoints,
+	}
+
+	for _, e := range r.endpoints {
+		line.TotalCalls += e.Calls
+		line.TotalDurationMs += e.DurationMs
+		line.TotalRetries += e.Retries
+		line.TotalErrors += e.Errors
+	}
+
+	buf, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(r.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("SOLIDServer - Unable to open statistics file: %s (%s)\n", r.file, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(buf, '\n')); err != nil {
+		return fmt.Errorf("SOLIDServer - Unable to write statistics file: %s (%s)\n", r.file, err)
+	}
+
+	r.endpoints = make(map[string]*EndpointStats)
+
+	return nil
+}
+
+// Flush the statistics of all the provider instances
+func FlushStats() {
+	requestStatsInstances.mutex.Lock()
+	defer requestStatsInstances.mutex.Unlock()
+
+	for _, stats := range requestStatsInstances.stats {
+		stats.Flush()
+	}
+}
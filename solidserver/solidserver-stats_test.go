@@ -0,0 +1,80 @@
+package solidserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRequestStatsFlush(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+	file := filepath.Join(t.TempDir(), "stats.jsonl")
+	s.Stats = NewRequestStats(file)
+
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2", "site_name": "secret-space"}})
+	})
+
+	parameters := url.Values{}
+	parameters.Add("WHERE", "site_name='secret-space'")
+
+	for i := 0; i < 3; i++ {
+		s.Request("get", "rest/ip_site_list", &parameters)
+	}
+	s.Request("get", "rest/ip_subnet_list", &parameters)
+
+	if err := s.Stats.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Nothing recorded since the last flush, nothing written
+	if err := s.Stats.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected a single JSON line, got %d", len(lines))
+	}
+
+	if strings.Contains(lines[0], "secret-space") || strings.Contains(lines[0], s.Password) {
+		t.Errorf("statistics must not contain object names nor credentials: %s", lines[0])
+	}
+
+	var line map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &line); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+
+	for _, key := range []string{"timestamp", "total_calls", "total_duration_ms", "total_retries", "total_errors", "endpoints"} {
+		if _, keyExist := line[key]; !keyExist {
+			t.Errorf("missing key %q in %s", key, lines[0])
+		}
+	}
+
+	if line["total_calls"].(float64) != 4 || line["total_errors"].(float64) != 1 {
+		t.Errorf("unexpected totals: %s", lines[0])
+	}
+
+	endpoints := line["endpoints"].(map[string]interface{})
+	siteList, siteListExist := endpoints["rest/ip_site_list"].(map[string]interface{})
+
+	if !siteListExist || siteList["calls"].(float64) != 3 || siteList["errors"].(float64) != 0 {
+		t.Errorf("unexpected endpoint statistics: %v", endpoints)
+	}
+
+	for _, key := range []string{"calls", "duration_ms", "retries", "errors"} {
+		if _, keyExist := siteList[key]; !keyExist {
+			t.Errorf("missing endpoint key %q", key)
+		}
+	}
+}
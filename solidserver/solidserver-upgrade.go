@@ -0,0 +1,126 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+)
+
+// Version of the state of the resources whose ID is re-resolved by stateupgraderoid
+const stateVersionOID = 1
+
+// Return true if a resource ID has the format of a SOLIDserver oid
+func stateidisoid(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Return a string attribute of a raw state, or an empty string if not set
+func statestring(rawState map[string]interface{}, key string) string {
+	if value, valueExist := rawState[key].(string); valueExist {
+		return value
+	}
+
+	return ""
+}
+
+// Retrieve an object using an info service (ex: rest/ip_address_info) and its oid
+// Return nil if it does not exist
+// Or an error in case of failure (ex: permission denied, server error)
+func stateobjectinfo(service string, idParam string, id string, meta interface{}) (map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(idParam, id)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", service, &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	if resp.StatusCode == 200 && len(buf) > 0 {
+		if _, errExist := buf[0]["errmsg"].(string); !errExist {
+			return buf[0], nil
+		}
+	}
+
+	if resp.StatusCode == 204 || objectmissing(resp.StatusCode, buf) {
+		return nil, nil
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return nil, fmt.Errorf("SOLIDServer - Unable to retrieve object (oid): %s (%s)\n", id, errMsg)
+		}
+	}
+
+	return nil, fmt.Errorf("SOLIDServer - Unable to retrieve object (oid): %s (HTTP %d)\n", id, resp.StatusCode)
+}
+
+// Return a state upgrader rewriting the ID of the resources created by older provider versions
+// The ID is kept when it is the oid of an existing object matching the natural key stored in the state (ex: space and address),
+// otherwise the object is looked up using this natural key (lookup returns an empty oid when not found) and its oid is stored.
+// Unresolved IDs are kept as is, the next Read reports the object as missing.
+func stateupgraderoid(objectType string, r *schema.Resource, service string, idParam string, lookup func(rawState map[string]interface{}, meta interface{}) (string, error), match func(rawState map[string]interface{}, info map[string]interface{}) bool) schema.StateUpgrader {
+	return schema.StateUpgrader{
+		Version: stateVersionOID - 1,
+		// Only the format of the ID changed, the schema did not
+		Type: r.CoreConfigSchema().ImpliedType(),
+		Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			id := statestring(rawState, "id")
+
+			if meta == nil {
+				return rawState, nil
+			}
+
+			if stateidisoid(id) {
+				info, infoErr := stateobjectinfo(service, idParam, id, meta)
+
+				if infoErr != nil {
+					return nil, fmt.Errorf("Unable to upgrade the state of %s (oid): %s (%s)\n", objectType, id, infoErr)
+				}
+
+				// The oid may have been reused by another object
+				if info != nil && match(rawState, info) {
+					return rawState, nil
+				}
+			}
+
+			oid, oidErr := lookup(rawState, meta)
+
+			if oidErr != nil {
+				return nil, fmt.Errorf("Unable to upgrade the state of %s (oid): %s (%s)\n", objectType, id, oidErr)
+			}
+
+			if oid == "" {
+				tflog.Warn(ctx, fmt.Sprintf("Unable to migrate the ID of %s: %s, no matching object found\n", objectType, id))
+				return rawState, nil
+			}
+
+			if oid != id {
+				tflog.Info(ctx, fmt.Sprintf("Migrated the ID of %s: %s to oid %s\n", objectType, id, oid))
+				rawState["id"] = oid
+			}
+
+			return rawState, nil
+		},
+	}
+}
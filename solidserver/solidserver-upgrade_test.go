@@ -0,0 +1,173 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestStateIDIsOID(t *testing.T) {
+	for id, expected := range map[string]bool{
+		"42":                     true,
+		"":                       false,
+		"10.0.0.0/24":            false,
+		"ns01/www.example.com/A": false,
+		"0x2a":                   false,
+	} {
+		if stateidisoid(id) != expected {
+			t.Errorf("unexpected oid detection of %q", id)
+		}
+	}
+}
+
+func TestStateUpgradeOID(t *testing.T) {
+	m, s := newMockSOLIDserver(t)
+
+	// Objects known by SOLIDserver
+	m.handle("/rest/dns_rr_info", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("rr_id") {
+		case "55":
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"rr_id": "55", "dns_name": "ns01", "rr_full_name": "www.lab.example.com.", "rr_type": "A"}})
+		case "56":
+			// The oid of a deleted RR reused by another one
+			mockReply(w, http.StatusOK, []map[string]interface{}{{"rr_id": "56", "dns_name": "ns01", "rr_full_name": "mail.lab.example.com", "rr_type": "A"}})
+		case "57":
+			mockReply(w, http.StatusForbidden, []map[string]interface{}{{"errmsg": "Permission denied"}})
+		default:
+			mockReply(w, http.StatusNoContent, nil)
+		}
+	})
+	m.handle("/rest/ip_address_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusBadRequest, []map[string]interface{}{{"errmsg": "object not found"}})
+	})
+	m.handle("/rest/ip_block_subnet_info", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusNoContent, nil)
+	})
+
+	// Natural key lookups
+	m.handle("/rest/dns_zone_list", mockWhereList([]map[string]interface{}{
+		{"dns_name": "ns01", "dnszone_name": "example.com"},
+		{"dns_name": "ns01", "dnszone_name": "lab.example.com"},
+	}))
+	m.handle("/rest/dns_rr_list", mockWhereList([]map[string]interface{}{
+		{"rr_id": "55", "dns_name": "ns01", "dnsview_name": "#", "dnszone_name": "lab.example.com", "rr_full_name": "www.lab.example.com", "rr_type": "A", "value1": "10.0.0.1"},
+	}))
+	m.handle("/rest/ip_site_list", func(w http.ResponseWriter, r *http.Request) {
+		mockReply(w, http.StatusOK, []map[string]interface{}{{"site_id": "2"}})
+	})
+	m.handle("/rest/ip_address_list", mockWhereList([]map[string]interface{}{
+		{"ip_id": "77", "site_id": "2", "ip_addr": "0a000005"},
+	}))
+	m.handle("/rest/ip_block_subnet_list", mockWhereList([]map[string]interface{}{
+		{"subnet_id": "11", "site_name": "space01", "start_ip_addr": "0a000000", "subnet_size": "256", "is_terminal": "0"},
+		{"subnet_id": "12", "site_name": "space01", "start_ip_addr": "0a000000", "subnet_size": "256", "is_terminal": "1"},
+	}))
+
+	// States stored by older provider versions
+	testCases := []struct {
+		name     string
+		upgrader func() (map[string]interface{}, error)
+		expected string
+	}{
+		{
+			name: "dns_rr without zone",
+			upgrader: func() (map[string]interface{}, error) {
+				return resourcednsrr().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+					"id": "ns01:www.lab.example.com:A", "dnsserver": "ns01", "dnsview": "", "name": "www.lab.example.com", "type": "A", "value": "10.0.0.1", "ttl": float64(3600),
+				}, s)
+			},
+			expected: "55",
+		},
+		{
+			name: "dns_rr identified by its oid",
+			upgrader: func() (map[string]interface{}, error) {
+				return resourcednsrr().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+					"id": "55", "dnsserver": "ns01", "name": "www.lab.example.com", "type": "A", "value": "10.0.0.1",
+				}, s)
+			},
+			expected: "55",
+		},
+		{
+			name: "dns_rr identified by a reused oid",
+			upgrader: func() (map[string]interface{}, error) {
+				return resourcednsrr().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+					"id": "56", "dnsserver": "ns01", "dnszone": "lab.example.com", "name": "www.lab.example.com", "type": "A", "value": "10.0.0.1",
+				}, s)
+			},
+			expected: "55",
+		},
+		{
+			name: "ip_address with a stale numeric ID",
+			upgrader: func() (map[string]interface{}, error) {
+				return resourceipaddress().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+					"id": "1234", "space": "space01", "subnet": "subnet01", "address": "10.0.0.5", "name": "host01",
+				}, s)
+			},
+			expected: "77",
+		},
+		{
+			name: "ip_address unknown",
+			upgrader: func() (map[string]interface{}, error) {
+				return resourceipaddress().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+					"id": "1234", "space": "space01", "address": "10.0.0.6",
+				}, s)
+			},
+			expected: "1234",
+		},
+		{
+			name: "ip_subnet identified by its prefix",
+			upgrader: func() (map[string]interface{}, error) {
+				return resourceipsubnet().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+					"id": "10.0.0.0/24", "space": "space01", "block": "block01", "prefix": "10.0.0.0/24", "prefix_size": float64(24), "terminal": true,
+				}, s)
+			},
+			expected: "12",
+		},
+		{
+			name: "ip_subnet block identified by its prefix",
+			upgrader: func() (map[string]interface{}, error) {
+				return resourceipsubnet().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+					"id": "10.0.0.0/24", "space": "space01", "prefix": "10.0.0.0/24", "terminal": false,
+				}, s)
+			},
+			expected: "11",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := tc.upgrader()
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if state["id"] != tc.expected {
+				t.Errorf("expected ID %s, got %v", tc.expected, state["id"])
+			}
+		})
+	}
+
+	// Oids of existing objects are not looked up again
+	if m.count("/rest/dns_rr_list") != 2 {
+		t.Errorf("expected two RR lookups, got %d", m.count("/rest/dns_rr_list"))
+	}
+
+	// Failures other than a missing object are reported
+	if _, err := resourcednsrr().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+		"id": "57", "dnsserver": "ns01", "name": "www.lab.example.com", "type": "A", "value": "10.0.0.1",
+	}, s); err == nil {
+		t.Errorf("expected an error when the RR can't be retrieved")
+	}
+
+	// States are kept as is before the provider is configured
+	state, err := resourceipaddress().StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{"id": "10.0.0.5"}, nil)
+
+	if err != nil || state["id"] != "10.0.0.5" {
+		t.Errorf("unexpected upgrade without provider: %v (%v)", state, err)
+	}
+
+	if err := Provider().InternalValidate(); err != nil {
+		t.Errorf("invalid provider: %s", err)
+	}
+}
@@ -0,0 +1,136 @@
+//go:build all || sweep
+// +build all sweep
+
+// to sweep the objects leaked by the acceptance tests: -tags sweep -sweep=all
+
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// Objects types swept along with the columns describing them
+// The dependencies are swept first: addresses before pools before subnets before spaces, RRs before zones before views
+var testSweeperTypes = []struct {
+	resourceName  string
+	listService   string
+	idName        string
+	nameName      string
+	levelName     string
+	deleteService string
+	dependencies  []string
+}{
+	{"solidserver_ip_address", "rest/ip_address_list", "ip_id", "name", "", "rest/ip_delete", nil},
+	{"solidserver_ip6_address", "rest/ip6_address6_list", "ip6_id", "ip6_name", "", "rest/ip6_address6_delete", nil},
+	{"solidserver_device", "rest/hostdev_list", "hostdev_id", "hostdev_name", "", "rest/hostdev_delete", []string{"solidserver_ip_address", "solidserver_ip6_address"}},
+	{"solidserver_ip_pool", "rest/ip_pool_list", "pool_id", "pool_name", "", "rest/ip_pool_delete", []string{"solidserver_ip_address"}},
+	{"solidserver_ip6_pool", "rest/ip6_pool6_list", "pool6_id", "pool6_name", "", "rest/ip6_pool6_delete", []string{"solidserver_ip6_address"}},
+	{"solidserver_ip_subnet", "rest/ip_block_subnet_list", "subnet_id", "subnet_name", "subnet_level", "rest/ip_subnet_delete", []string{"solidserver_ip_pool", "solidserver_ip_address"}},
+	{"solidserver_ip6_subnet", "rest/ip6_block6_subnet6_list", "subnet6_id", "subnet6_name", "subnet_level", "rest/ip6_subnet6_delete", []string{"solidserver_ip6_pool", "solidserver_ip6_address"}},
+	{"solidserver_vlan", "rest/vlmvlan_list", "vlmvlan_id", "vlmvlan_name", "", "rest/vlm_vlan_delete", []string{"solidserver_ip_subnet", "solidserver_ip6_subnet"}},
+	{"solidserver_vlan_range", "rest/vlmrange_list", "vlmrange_id", "vlmrange_name", "", "rest/vlm_range_delete", []string{"solidserver_vlan"}},
+	{"solidserver_vlan_domain", "rest/vlmdomain_list", "vlmdomain_id", "vlmdomain_name", "", "rest/vlm_domain_delete", []string{"solidserver_vlan_range", "solidserver_vlan"}},
+	{"solidserver_ip_space", "rest/ip_site_list", "site_id", "site_name", "", "rest/ip_site_delete", []string{"solidserver_ip_subnet", "solidserver_ip6_subnet"}},
+	{"solidserver_dns_rr", "rest/dns_rr_list", "rr_id", "rr_full_name", "", "rest/dns_rr_delete", nil},
+	{"solidserver_dns_zone", "rest/dns_zone_list", "dnszone_id", "dnszone_name", "", "rest/dns_zone_delete", []string{"solidserver_dns_rr"}},
+	{"solidserver_dns_view", "rest/dns_view_list", "dnsview_id", "dnsview_name", "", "rest/dns_view_delete", []string{"solidserver_dns_zone"}},
+	{"solidserver_dns_server", "rest/dns_server_list", "dns_id", "dns_name", "", "rest/dns_delete", []string{"solidserver_dns_view", "solidserver_dns_zone"}},
+	{"solidserver_app_node", "rest/app_node_list", "appnode_id", "appnode_name", "", "rest/app_node_delete", nil},
+	{"solidserver_app_pool", "rest/app_pool_list", "apppool_id", "apppool_name", "", "rest/app_pool_delete", []string{"solidserver_app_node"}},
+	{"solidserver_app_application", "rest/app_application_list", "appapplication_id", "appapplication_name", "", "rest/app_application_delete", []string{"solidserver_app_pool"}},
+	{"solidserver_user", "rest/user_admin_list", "usr_id", "usr_login", "", "rest/user_delete", nil},
+	{"solidserver_usergroup", "rest/group_admin_list", "grp_id", "grp_name", "", "rest/group_delete", []string{"solidserver_user"}},
+	{"solidserver_cdb", "rest/custom_db_name_list", "custom_db_name_id", "name", "", "rest/custom_db_name_delete", nil},
+	{"solidserver_dhcp_failover", "rest/dhcp_failover_list", "dhcpfailover_id", "dhcpfailover_name", "", "rest/dhcp_failover_delete", nil},
+	{"solidserver_class", "rest/class_list", "class_id", "class_name", "", "rest/class_delete", []string{"solidserver_ip_space", "solidserver_dns_server", "solidserver_vlan_domain", "solidserver_device", "solidserver_app_application", "solidserver_user", "solidserver_cdb"}},
+}
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	for _, sweeperType := range testSweeperTypes {
+		sweeperType := sweeperType
+
+		resource.AddTestSweepers(sweeperType.resourceName, &resource.Sweeper{
+			Name:         sweeperType.resourceName,
+			Dependencies: sweeperType.dependencies,
+			F: func(region string) error {
+				s, err := testSweeperClient()
+				if err != nil {
+					return err
+				}
+
+				return testSweep(s, sweeperType.listService, sweeperType.idName, sweeperType.nameName, sweeperType.levelName, sweeperType.deleteService)
+			},
+		})
+	}
+}
+
+// Return a SOLIDserver client configured from the environment used by the acceptance tests
+func testSweeperClient() (*SOLIDserver, error) {
+	sslVerify, sslVerifyErr := strconv.ParseBool(os.Getenv("SOLIDServer_SSLVERIFY"))
+	if sslVerifyErr != nil {
+		sslVerify = true
+	}
+
+	s, diags := NewSOLIDserver(context.Background(), os.Getenv("SOLIDServer_HOST"), false, os.Getenv("SOLIDServer_USERNAME"), os.Getenv("SOLIDServer_PASSWORD"), "", "", sslVerify, "", 10, "", "", "", nil, true, true, "", "", conflictModeDisabled, "", 443, "")
+
+	if diags.HasError() {
+		return nil, fmt.Errorf("Unable to connect to SOLIDserver: %s", diags[0].Summary)
+	}
+
+	return s, nil
+}
+
+// Delete the objects whose names contain testAccPrefix, the deepest ones first if levelName is set
+func testSweep(s *SOLIDserver, listService string, idName string, nameName string, levelName string, deleteService string) error {
+	objects, err := listall(listService, nameName+" LIKE '%"+testAccPrefix+"-%'", s)
+
+	if err != nil {
+		return err
+	}
+
+	// Nested objects (ex: subnets within blocks) must be deleted before their parents
+	if levelName != "" {
+		sort.SliceStable(objects, func(i, j int) bool {
+			li, _ := strconv.Atoi(fmt.Sprintf("%v", objects[i][levelName]))
+			lj, _ := strconv.Atoi(fmt.Sprintf("%v", objects[j][levelName]))
+			return li > lj
+		})
+	}
+
+	for _, object := range objects {
+		oid, _ := object[idName].(string)
+		name, _ := object[nameName].(string)
+
+		if oid == "" {
+			continue
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add(idName, oid)
+
+		// Sending the deletion request
+		resp, _, err := s.Request("delete", deleteService, &parameters)
+
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			return fmt.Errorf("Unable to sweep %s (oid): %s using %s", name, oid, deleteService)
+		}
+	}
+
+	return nil
+}
@@ -2,8 +2,17 @@ package solidserver
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 )
 
+// Prefix of the names of the objects created by the acceptance tests, used by the sweepers to find leaked objects
+const testAccPrefix = "tf-acc-test"
+
+// Return a random name prefixed by testAccPrefix, safe to use across parallel and repeated acceptance test runs
+func testAccName(kind string) string {
+	return fmt.Sprintf("%s-%s-%s", testAccPrefix, kind, acctest.RandString(8))
+}
+
 func Config_CreateSpace(spacename string) string {
 	return fmt.Sprintf(`
     resource "solidserver_ip_space" "space" {